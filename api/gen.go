@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/oapi-codegen/runtime"
 )
@@ -20,38 +21,462 @@ const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for AlertmanagerAlertStatus.
+const (
+	AlertStatusFiring   AlertmanagerAlertStatus = "firing"
+	AlertStatusResolved AlertmanagerAlertStatus = "resolved"
+)
+
+// Defines values for AuditEventAction.
+const (
+	AuditActionAdminDisabled         AuditEventAction = "admin_disabled"
+	AuditActionChangeApplied         AuditEventAction = "change_applied"
+	AuditActionChangeApproved        AuditEventAction = "change_approved"
+	AuditActionChangeCancelled       AuditEventAction = "change_cancelled"
+	AuditActionChangeRequested       AuditEventAction = "change_requested"
+	AuditActionConfirmed             AuditEventAction = "confirmed"
+	AuditActionPostponed             AuditEventAction = "postponed"
+	AuditActionReleased              AuditEventAction = "released"
+	AuditActionVerificationCancelled AuditEventAction = "verification_cancelled"
+	AuditActionVerificationStarted   AuditEventAction = "verification_started"
+)
+
+// Defines values for AuthMode.
+const (
+	AuthModeHeader AuthMode = "header"
+	AuthModeLocal  AuthMode = "local"
+	AuthModeNone   AuthMode = "none"
+	AuthModeOIDC   AuthMode = "oidc"
+)
+
+// Defines values for ChainActionAction.
+const (
+	ChainActionArm     ChainActionAction = "arm"
+	ChainActionTrigger ChainActionAction = "trigger"
+)
+
+// Defines values for CheckInMethod.
+const (
+	CheckInMethodAPI          CheckInMethod = "api"
+	CheckInMethodAlertmanager CheckInMethod = "alertmanager"
+	CheckInMethodCLI          CheckInMethod = "cli"
+	CheckInMethodEmail        CheckInMethod = "email"
+	CheckInMethodMQTT         CheckInMethod = "mqtt"
+	CheckInMethodPush         CheckInMethod = "push"
+	CheckInMethodToken        CheckInMethod = "token"
+	CheckInMethodUI           CheckInMethod = "ui"
+)
+
+// Defines values for DeliveryKind.
+const (
+	DeliveryKindAction  DeliveryKind = "action"
+	DeliveryKindEvent   DeliveryKind = "event"
+	DeliveryKindWebhook DeliveryKind = "webhook"
+)
+
+// Defines values for EventType.
+const (
+	EventTypeCheckedIn    EventType = "switch.checked_in"
+	EventTypeCreated      EventType = "switch.created"
+	EventTypeDeleted      EventType = "switch.deleted"
+	EventTypeDisabled     EventType = "switch.disabled"
+	EventTypeFailed       EventType = "switch.failed"
+	EventTypePaused       EventType = "switch.paused"
+	EventTypeReminderSent EventType = "switch.reminder_sent"
+	EventTypeResumed      EventType = "switch.resumed"
+	EventTypeTriggered    EventType = "switch.triggered"
+	EventTypeUpdated      EventType = "switch.updated"
+)
+
 // Defines values for HealthStatus.
 const (
 	HealthStatusFailed HealthStatus = "failed"
 	HealthStatusOk     HealthStatus = "ok"
 )
 
+// Defines values for PauseRequestResumePolicy.
+const (
+	PauseRequestResumePolicyPreserve PauseRequestResumePolicy = "preserve"
+	PauseRequestResumePolicyReset    PauseRequestResumePolicy = "reset"
+)
+
+// Defines values for PendingChangeAction.
+const (
+	PendingChangeActionDelete  PendingChangeAction = "delete"
+	PendingChangeActionDisable PendingChangeAction = "disable"
+	PendingChangeActionPause   PendingChangeAction = "pause"
+	PendingChangeActionUpdate  PendingChangeAction = "update"
+)
+
+// Defines values for PendingChangeStatus.
+const (
+	PendingChangeStatusApplied   PendingChangeStatus = "applied"
+	PendingChangeStatusCancelled PendingChangeStatus = "cancelled"
+	PendingChangeStatusPending   PendingChangeStatus = "pending"
+)
+
+// Defines values for Role.
+const (
+	RoleAdmin Role = "admin"
+	RoleUser  Role = "user"
+)
+
+// Defines values for SwitchResumePolicy.
+const (
+	SwitchResumePolicyPreserve SwitchResumePolicy = "preserve"
+	SwitchResumePolicyReset    SwitchResumePolicy = "reset"
+)
+
 // Defines values for SwitchStatus.
 const (
 	SwitchStatusActive    SwitchStatus = "active"
 	SwitchStatusDisabled  SwitchStatus = "disabled"
 	SwitchStatusFailed    SwitchStatus = "failed"
+	SwitchStatusMissing   SwitchStatus = "missing"
+	SwitchStatusPaused    SwitchStatus = "paused"
 	SwitchStatusTriggered SwitchStatus = "triggered"
+	SwitchStatusVerifying SwitchStatus = "verifying"
+	SwitchStatusWaiting   SwitchStatus = "waiting"
+)
+
+// Defines values for TokenScope.
+const (
+	TokenScopeAdmin   TokenScope = "admin"
+	TokenScopeCheckIn TokenScope = "checkin"
+	TokenScopeRead    TokenScope = "read"
+	TokenScopeWrite   TokenScope = "write"
+)
+
+// Defines values for WebhookMethod.
+const (
+	WebhookMethodPatch WebhookMethod = "PATCH"
+	WebhookMethodPost  WebhookMethod = "POST"
+	WebhookMethodPut   WebhookMethod = "PUT"
 )
 
+// APIToken Personal access token that authenticates scripts and CI as the user who created it
+type APIToken struct {
+	CreatedAt *int64 `json:"createdAt,omitempty"`
+
+	// ExpiresAt Unix time after which the token no longer works. Tokens without one don't expire
+	ExpiresAt *int64 `json:"expiresAt,omitempty"`
+	Id        *int   `json:"id,omitempty"`
+
+	// LastUsedAt Unix time the token last authenticated a request
+	LastUsedAt *int64 `json:"lastUsedAt,omitempty"`
+
+	// Name What the token is used for
+	Name string `json:"name" validate:"required,min=1,max=100"`
+
+	// Scopes What the token can do. write includes read and checkin, and admin includes everything, such as managing tokens
+	Scopes []TokenScope `json:"scopes" validate:"required,min=1,unique,dive,oneof=read checkin write admin"`
+
+	// Token The token to send as a bearer token. Only returned when the token is created
+	Token *string `json:"token,omitempty"`
+}
+
+// AdminDisable defines model for AdminDisable.
+type AdminDisable struct {
+	// Reason Why the switch was disabled, recorded in its audit trail
+	Reason *string `json:"reason,omitempty" validate:"omitempty,max=500"`
+}
+
+// AdminStats defines model for AdminStats.
+type AdminStats struct {
+	// CheckInsLastDay Number of check-ins in the last 24 hours
+	CheckInsLastDay   int `json:"checkInsLastDay"`
+	EncryptedSwitches int `json:"encryptedSwitches"`
+	Switches          int `json:"switches"`
+
+	// SwitchesByStatus Number of switches in each status
+	SwitchesByStatus map[string]int `json:"switchesByStatus"`
+
+	// Users Number of users who own a switch
+	Users int `json:"users"`
+}
+
+// AdminSwitch Metadata of a switch as seen by admins. Switch content is never included
+type AdminSwitch struct {
+	CheckInInterval string `json:"checkInInterval"`
+	Encrypted       bool   `json:"encrypted"`
+	Id              int    `json:"id"`
+	LastCheckInAt   *int64 `json:"lastCheckInAt,omitempty"`
+	Protected       *bool  `json:"protected,omitempty"`
+
+	// Status Current switch status
+	Status       SwitchStatus `json:"status"`
+	TriggerAt    *int64       `json:"triggerAt,omitempty"`
+	TriggerCount *int         `json:"triggerCount,omitempty"`
+	UserId       string       `json:"userId"`
+}
+
+// AdminUser A user as seen by admins
+type AdminUser struct {
+	// DefaultQuota Whether the user has the server's default quota rather than one set by an admin
+	DefaultQuota bool `json:"defaultQuota"`
+
+	// LocalAccount Whether the user signs in with a local account
+	LocalAccount *bool `json:"localAccount,omitempty"`
+
+	// MaxSwitches Maximum number of switches the user can have. 0 means unlimited
+	MaxSwitches int `json:"maxSwitches"`
+
+	// Role What a user is allowed to do. Admins can manage every user's switches and quotas
+	Role Role `json:"role"`
+
+	// SwitchCount Number of switches the user owns
+	SwitchCount int    `json:"switchCount"`
+	UserId      string `json:"userId"`
+}
+
+// AlertmanagerAlert defines model for AlertmanagerAlert.
+type AlertmanagerAlert struct {
+	Annotations *map[string]string      `json:"annotations,omitempty"`
+	EndsAt      *time.Time              `json:"endsAt,omitempty"`
+	Labels      map[string]string       `json:"labels"`
+	StartsAt    *time.Time              `json:"startsAt,omitempty"`
+	Status      AlertmanagerAlertStatus `json:"status"`
+}
+
+// AlertmanagerAlertStatus defines model for AlertmanagerAlert.Status.
+type AlertmanagerAlertStatus string
+
+// AlertmanagerResult defines model for AlertmanagerResult.
+type AlertmanagerResult struct {
+	// CheckedIn Whether a matching firing alert checked in to the switch
+	CheckedIn bool `json:"checkedIn"`
+}
+
+// AlertmanagerWebhook Notification sent by an Alertmanager webhook receiver. Fields this server doesn't use are ignored
+type AlertmanagerWebhook struct {
+	Alerts   []AlertmanagerAlert `json:"alerts"`
+	Receiver *string             `json:"receiver,omitempty"`
+
+	// Status Whether any alert of the group is firing
+	Status  *string `json:"status,omitempty"`
+	Version *string `json:"version,omitempty"`
+}
+
+// AuditEvent A single event in a switch's audit trail
+type AuditEvent struct {
+	// Action What happened
+	Action AuditEventAction `json:"action"`
+
+	// Actor Who caused the event, e.g. system, a user ID or contact:<name>
+	Actor string `json:"actor"`
+
+	// CreatedAt Unix time of the event
+	CreatedAt int64 `json:"createdAt"`
+
+	// Detail Additional context, e.g. how long the release was postponed
+	Detail *string `json:"detail,omitempty"`
+	Id     *int    `json:"id,omitempty"`
+
+	// SwitchId ID of the switch the event is about
+	SwitchId int `json:"switchId"`
+}
+
+// AuditEventAction What happened
+type AuditEventAction string
+
 // AuthConfig Authentication configuration returned to the UI for OIDC discovery
 type AuthConfig struct {
-	// Audience OIDC client ID / audience
+	// Audience OIDC client ID / audience of the first issuer
 	Audience *string `json:"audience,omitempty"`
 
 	// Enabled Whether authentication is enabled
 	Enabled bool `json:"enabled"`
 
-	// IssuerUrl OIDC issuer URL
+	// IssuerUrl OIDC issuer URL of the first issuer
 	IssuerUrl *string `json:"issuerUrl,omitempty"`
+
+	// Issuers OIDC providers users can sign in with
+	Issuers *[]AuthIssuer `json:"issuers,omitempty"`
+
+	// Mode How users sign in: not at all, with an OIDC provider, with local accounts, or through a trusted reverse proxy
+	Mode AuthMode `json:"mode"`
+}
+
+// AuthIssuer An OIDC provider users can sign in with
+type AuthIssuer struct {
+	// ClientId OIDC client ID the UI signs in with
+	ClientId string `json:"clientId"`
+
+	// IssuerUrl OIDC issuer URL
+	IssuerUrl string `json:"issuerUrl"`
+
+	// Name Name of the provider. User IDs from named providers are prefixed with it. Empty for the provider set with --auth-issuer-url
+	Name string `json:"name"`
+}
+
+// AuthMode How users sign in: not at all, with an OIDC provider, with local accounts, or through a trusted reverse proxy
+type AuthMode string
+
+// BulkCheckInResponse Summary of a check-in to several switches
+type BulkCheckInResponse struct {
+	// CheckedIn Number of switches checked in
+	CheckedIn int `json:"checkedIn"`
+
+	// Failed Number of switches that could not be checked in
+	Failed  int                 `json:"failed"`
+	Results []BulkCheckInResult `json:"results"`
+}
+
+// BulkCheckInResult defines model for BulkCheckInResult.
+type BulkCheckInResult struct {
+	// Error Why the switch could not be checked in
+	Error *string `json:"error,omitempty"`
+
+	// Id Switch ID
+	Id int `json:"id"`
+
+	// Success Whether the switch was checked in
+	Success bool `json:"success"`
+
+	// TriggerAt New trigger time of the switch
+	TriggerAt *int64 `json:"triggerAt,omitempty"`
+}
+
+// ChainAction What a switch does to another switch when it triggers
+type ChainAction struct {
+	// Action Arm starts a fresh countdown on a switch that isn't counting down, trigger expires it immediately as if its countdown ran out
+	Action ChainActionAction `json:"action" validate:"required,oneof=arm trigger"`
+
+	// SwitchId ID of the switch to act on
+	SwitchId int `json:"switchId" validate:"required,min=1"`
+}
+
+// ChainActionAction Arm starts a fresh countdown on a switch that isn't counting down, trigger expires it immediately as if its countdown ran out
+type ChainActionAction string
+
+// CheckIn A single check-in recorded for a switch
+type CheckIn struct {
+	// CheckedInAt Unix time of the check-in
+	CheckedInAt int64 `json:"checkedInAt"`
+	Id          *int  `json:"id,omitempty"`
+
+	// IpAddress IP address the check-in came from
+	IpAddress *string `json:"ipAddress,omitempty"`
+
+	// Method How the check-in was made
+	Method CheckInMethod `json:"method"`
+
+	// SwitchId ID of the switch that was checked in
+	SwitchId int `json:"switchId"`
+
+	// UserAgent User agent of the client that checked in
+	UserAgent *string `json:"userAgent,omitempty"`
+}
+
+// CheckInMethod How the check-in was made
+type CheckInMethod string
+
+// CheckInRequest Optional details sent when checking in
+type CheckInRequest struct {
+	// Code Check-in code. Using the switch's duress code returns the same response as a normal check-in
+	Code *string `json:"code,omitempty"`
+
+	// Passkey Credential returned by navigator.credentials.create() or get(), JSON encoded with binary fields as base64url like PublicKeyCredential.toJSON() does
+	Passkey *PasskeyCredential `json:"passkey,omitempty"`
+}
+
+// ContactVerification What a trusted contact sees when verifying an expired switch
+type ContactVerification struct {
+	// ContactName Name of the trusted contact the token was issued to
+	ContactName string `json:"contactName"`
+
+	// MaxPostpone Longest the release can be postponed
+	MaxPostpone *string `json:"maxPostpone,omitempty"`
+
+	// Owner User ID of the switch owner
+	Owner string `json:"owner"`
+
+	// ReleaseAt Unix time at which the switch is released unless postponed
+	ReleaseAt int64 `json:"releaseAt"`
+
+	// Status Current switch status
+	Status string `json:"status"`
+}
+
+// Delivery The result of an action or webhook run when a switch triggered, or of sending an event to a subscription
+type Delivery struct {
+	// Attempts How many times the webhook was attempted
+	Attempts *int `json:"attempts,omitempty"`
+
+	// CreatedAt Unix time the action finished
+	CreatedAt int64 `json:"createdAt"`
+
+	// Duration How long the action or webhook ran, in milliseconds
+	Duration *int64 `json:"duration,omitempty"`
+
+	// Error Why the action or webhook failed, e.g. a timeout
+	Error *string `json:"error,omitempty"`
+
+	// ExitCode Exit code of the action's command
+	ExitCode *int `json:"exitCode,omitempty"`
+	Id       *int `json:"id,omitempty"`
+
+	// Kind What was run
+	Kind DeliveryKind `json:"kind"`
+
+	// Output Combined standard output and error of the command, truncated to 4 KiB
+	Output *string `json:"output,omitempty"`
+
+	// StatusCode HTTP status code of the webhook's last attempt
+	StatusCode *int `json:"statusCode,omitempty"`
+
+	// SubscriptionId ID of the event subscription the event was sent to
+	SubscriptionId *int `json:"subscriptionId,omitempty"`
+
+	// Success Whether the action completed successfully
+	Success bool `json:"success"`
+
+	// SwitchId ID of the switch that triggered, or that the event is about
+	SwitchId int `json:"switchId"`
+
+	// Target Name of the action, the webhook URL without its query string, or the event type
+	Target string `json:"target"`
 }
 
+// DeliveryKind What was run
+type DeliveryKind string
+
 // Error Includes http status code and reason for error
 type Error struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 }
 
+// Event A switch lifecycle event, sent to event subscriptions and the live event stream
+type Event struct {
+	// CreatedAt Unix time the event happened
+	CreatedAt int64 `json:"createdAt"`
+
+	// Id Sequence number of the event, increasing across events and server restarts
+	Id int64 `json:"id"`
+
+	// Labels Labels of the switch
+	Labels *[]string `json:"labels,omitempty"`
+
+	// Status Status of the switch after the event
+	Status *string `json:"status,omitempty"`
+
+	// SwitchId ID of the switch
+	SwitchId int `json:"switchId"`
+
+	// TriggerAt Unix time the switch expires after the event
+	TriggerAt *int64 `json:"triggerAt,omitempty"`
+
+	// Type What happened to a switch
+	Type EventType `json:"type"`
+
+	// UserId Owner of the switch
+	UserId string `json:"userId"`
+}
+
+// EventType What happened to a switch
+type EventType string
+
 // Health Status of health check which checks for database r/w access
 type Health struct {
 	Status HealthStatus `json:"status"`
@@ -60,6 +485,114 @@ type Health struct {
 // HealthStatus defines model for Health.Status.
 type HealthStatus string
 
+// LoginRequest Credentials of a local user account
+type LoginRequest struct {
+	Password string `json:"password" validate:"required,max=256"`
+
+	// TotpCode Code from an authenticator app, required when two-factor authentication is enabled
+	TotpCode *string `json:"totpCode,omitempty"`
+	Username string  `json:"username" validate:"required,max=100"`
+}
+
+// Passkey A passkey registered to a local user account
+type Passkey struct {
+	// CreatedAt Unix timestamp of when the passkey was registered
+	CreatedAt int64 `json:"createdAt"`
+	Id        int   `json:"id"`
+
+	// LastUsedAt Unix timestamp of when the passkey last signed in or confirmed a check-in
+	LastUsedAt *int64 `json:"lastUsedAt,omitempty"`
+	Name       string `json:"name"`
+}
+
+// PasskeyConfirmation Assertion confirming a change with one of the user's passkeys
+type PasskeyConfirmation struct {
+	// Passkey Credential returned by navigator.credentials.create() or get(), JSON encoded with binary fields as base64url like PublicKeyCredential.toJSON() does
+	Passkey *PasskeyCredential `json:"passkey,omitempty"`
+}
+
+// PasskeyCredential Credential returned by navigator.credentials.create() or get(), JSON encoded with binary fields as base64url like PublicKeyCredential.toJSON() does
+type PasskeyCredential map[string]interface{}
+
+// PasskeyOptions Options for navigator.credentials.create() or get() under a publicKey key, with binary fields encoded as base64url
+type PasskeyOptions map[string]interface{}
+
+// PasskeyRegistration defines model for PasskeyRegistration.
+type PasskeyRegistration struct {
+	// Credential Credential returned by navigator.credentials.create() or get(), JSON encoded with binary fields as base64url like PublicKeyCredential.toJSON() does
+	Credential PasskeyCredential `json:"credential"`
+
+	// Name Name to recognize the passkey by
+	Name string `json:"name" validate:"required,max=100"`
+
+	// Passkey Credential returned by navigator.credentials.create() or get(), JSON encoded with binary fields as base64url like PublicKeyCredential.toJSON() does
+	Passkey *PasskeyCredential `json:"passkey,omitempty"`
+}
+
+// PasswordChange defines model for PasswordChange.
+type PasswordChange struct {
+	CurrentPassword string `json:"currentPassword" validate:"required,max=256"`
+
+	// NewPassword At least 8 characters
+	NewPassword string `json:"newPassword" validate:"required,min=8,max=256"`
+}
+
+// PauseRequest How long to pause a switch. Exactly one of until or duration must be set
+type PauseRequest struct {
+	// Duration How long to pause the switch for
+	Duration *string `json:"duration,omitempty"`
+
+	// ResumePolicy Whether to keep the time remaining when the pause started or re-arm with a fresh check-in interval on resume
+	ResumePolicy *PauseRequestResumePolicy `json:"resumePolicy,omitempty"`
+
+	// Until Unix time at which the switch is resumed
+	Until *int64 `json:"until,omitempty"`
+}
+
+// PauseRequestResumePolicy Whether to keep the time remaining when the pause started or re-arm with a fresh check-in interval on resume
+type PauseRequestResumePolicy string
+
+// PendingChange A change to a protected switch that waits for approval or a delay before it applies
+type PendingChange struct {
+	// Action What the change does
+	Action PendingChangeAction `json:"action"`
+
+	// ApplyAt Unix time the change applies if it isn't cancelled. Unset when it needs approval
+	ApplyAt *int64 `json:"applyAt,omitempty"`
+
+	// CreatedAt Unix time the change was requested
+	CreatedAt int64 `json:"createdAt"`
+	Id        *int  `json:"id,omitempty"`
+
+	// RequestedBy User who requested the change
+	RequestedBy string `json:"requestedBy"`
+
+	// ResolvedAt Unix time the change was applied or cancelled
+	ResolvedAt *int64 `json:"resolvedAt,omitempty"`
+
+	// ResolvedBy User who approved or cancelled the change
+	ResolvedBy *string             `json:"resolvedBy,omitempty"`
+	Status     PendingChangeStatus `json:"status"`
+
+	// Summary Human readable description of the change
+	Summary string `json:"summary"`
+
+	// SwitchId ID of the switch the change applies to
+	SwitchId int `json:"switchId"`
+}
+
+// PendingChangeAction What the change does
+type PendingChangeAction string
+
+// PendingChangeStatus defines model for PendingChange.Status.
+type PendingChangeStatus string
+
+// PostponeRequest defines model for PostponeRequest.
+type PostponeRequest struct {
+	// Duration How long to postpone the release from now, e.g. 24h
+	Duration string `json:"duration"`
+}
+
 // PushSubscription Details to send push notifications. Secret fields that aren't available to be read via the API
 type PushSubscription struct {
 	Endpoint *string `json:"endpoint,omitempty"`
@@ -69,14 +602,66 @@ type PushSubscription struct {
 	} `json:"keys,omitempty"`
 }
 
+// QuotaUpdate defines model for QuotaUpdate.
+type QuotaUpdate struct {
+	// MaxSwitches Maximum number of switches the user can have. 0 means unlimited
+	MaxSwitches int `json:"maxSwitches" validate:"min=0"`
+}
+
+// Role What a user is allowed to do. Admins can manage every user's switches and quotas
+type Role string
+
+// RoleUpdate defines model for RoleUpdate.
+type RoleUpdate struct {
+	// Role What a user is allowed to do. Admins can manage every user's switches and quotas
+	Role Role `json:"role"`
+}
+
+// Session A signed in session of a local user account
+type Session struct {
+	// ExpiresAt Unix timestamp of when the session ends
+	ExpiresAt int64 `json:"expiresAt"`
+
+	// Token Session token sent as a bearer token. The UI uses the session cookie instead
+	Token    string `json:"token"`
+	Username string `json:"username"`
+}
+
 // Switch defines model for Switch.
 type Switch struct {
+	// Actions Names of actions from the server configuration to run on the host when the switch triggers. Only admins and the users an action lists can add it
+	Actions *[]string `json:"actions,omitempty" validate:"omitempty,max=10,unique,dive,min=1"`
+
+	// AlertMatchers Labels a firing Alertmanager alert must have to check in to the switch through its check-in token. Defaults to alertname Watchdog
+	AlertMatchers *map[string]string `json:"alertMatchers,omitempty" validate:"omitempty,max=20,dive,keys,min=1,endkeys"`
+
+	// Approvers Users who can approve pending changes to a protected switch. Without approvers, changes apply after the change delay
+	Approvers *[]string `json:"approvers,omitempty" validate:"omitempty,max=10,unique,dive,min=1"`
+
+	// ChangeDelay How long a pending change to a protected switch waits before it applies when there are no approvers
+	ChangeDelay *string `json:"changeDelay,omitempty"`
+
 	// CheckInInterval Timer countdown until a switch is triggered
 	CheckInInterval string `json:"checkInInterval" validate:"required"`
 
+	// CheckInToken Secret that checks in to the switch without logging in, such as from an MQTT button. Send an empty string to remove it
+	CheckInToken *string `json:"checkInToken,omitempty" validate:"omitempty,eq=|min=16,max=256"`
+
+	// CheckInTokenEnabled Whether a check-in token is configured
+	CheckInTokenEnabled *bool `json:"checkInTokenEnabled,omitempty"`
+
 	// DeleteAfterTriggered Whether to delete the switch after triggering
 	DeleteAfterTriggered *bool `json:"deleteAfterTriggered,omitempty"`
 
+	// DuressCode Alternate check-in code. Checking in with it looks like a normal check-in but silently triggers the switch. Send an empty string to remove it
+	DuressCode *string `json:"duressCode,omitempty" validate:"omitempty,eq=|min=4"`
+
+	// DuressEnabled Whether a duress code is configured
+	DuressEnabled *bool `json:"duressEnabled,omitempty"`
+
+	// DuressNotifiers Notification channels alerted when the duress code is used. Defaults to the switch notifiers
+	DuressNotifiers *[]string `json:"duressNotifiers,omitempty"`
+
 	// Encrypted Where or not to encrypt switch data. Data will no longer be readable via the API
 	Encrypted *bool `json:"encrypted,omitempty"`
 
@@ -84,15 +669,51 @@ type Switch struct {
 	FailureReason *string `json:"failureReason,omitempty"`
 
 	// Id Autogenerated switch ID when switch is created
-	Id      *int   `json:"id,omitempty"`
-	Message string `json:"message" validate:"required,min=1"`
+	Id *int `json:"id,omitempty"`
+
+	// Labels Labels used to group switches, e.g. for checking in to several at once
+	Labels *[]string `json:"labels,omitempty" validate:"omitempty,max=20,dive,min=1,max=64"`
+
+	// LastCheckInAt Unix time of the most recent check-in
+	LastCheckInAt *int64 `json:"lastCheckInAt,omitempty"`
+
+	// MaxPostpone Longest a trusted contact can postpone the release at a time
+	MaxPostpone *string `json:"maxPostpone,omitempty"`
+
+	// MaxTriggers With rearm, how many times in a row the switch can trigger without a check-in before it stops re-arming. Unlimited when unset
+	MaxTriggers *int `json:"maxTriggers,omitempty" validate:"omitempty,min=1"`
+
+	// Members Users who must check in for the switch to stay armed. When set, the switch triggers at the end of an interval in which fewer than quorum members checked in
+	Members *[]SwitchMember `json:"members,omitempty" validate:"omitempty,max=50,unique=UserId,dive"`
+	Message string          `json:"message" validate:"required,min=1"`
 
 	// Notifiers List of notification channels powered by shoutrrr
 	Notifiers []string `json:"notifiers" validate:"required,min=1"`
 
+	// OnTrigger Switches of the same owner that are armed or triggered when this switch triggers
+	OnTrigger *[]ChainAction `json:"onTrigger,omitempty" validate:"omitempty,max=10,unique=SwitchId,dive"`
+
+	// Passkey Credential returned by navigator.credentials.create() or get(), JSON encoded with binary fields as base64url like PublicKeyCredential.toJSON() does
+	Passkey *PasskeyCredential `json:"passkey,omitempty"`
+
+	// PausedAt Unix time at which the switch was paused
+	PausedAt *int64 `json:"pausedAt,omitempty"`
+
+	// PausedUntil Unix time at which a paused switch is automatically resumed
+	PausedUntil *int64 `json:"pausedUntil,omitempty"`
+
+	// Protected Whether disabling, deleting, lengthening the interval or removing notifiers requires approval or a delay
+	Protected *bool `json:"protected,omitempty"`
+
 	// PushSubscription Optional PWA push subscription for background alerts
 	PushSubscription *PushSubscription `json:"pushSubscription,omitempty"`
 
+	// Quorum Number of members who must check in each interval. Defaults to every member
+	Quorum *int `json:"quorum,omitempty" validate:"omitempty,min=1"`
+
+	// Rearm Whether the switch starts a fresh interval after it triggers instead of staying triggered
+	Rearm *bool `json:"rearm,omitempty"`
+
 	// ReminderEnabled If push notifications have been configured
 	ReminderEnabled *bool `json:"reminderEnabled,omitempty"`
 
@@ -102,64 +723,326 @@ type Switch struct {
 	// ReminderThreshold How long before expiration to send a push notification
 	ReminderThreshold *string `json:"reminderThreshold,omitempty"`
 
-	// Status Current switch status
-	Status *SwitchStatus `json:"status,omitempty"`
+	// RepeatInterval How often a triggered switch that isn't re-armed sends still missing alerts until someone checks in
+	RepeatInterval *string `json:"repeatInterval,omitempty"`
+
+	// RequirePasskey Whether checking in requires signing a challenge with a passkey, so a stolen token alone can't check in. Check-ins that can't present one, like check-in tokens and checking in to all switches, are rejected. Turning it off has to be confirmed with one of the owner's passkeys. Only available in local auth mode
+	RequirePasskey *bool `json:"requirePasskey,omitempty"`
+
+	// Requires IDs of switches of the same owner that must also have expired before this switch fires. Until then an expired switch is waiting
+	Requires *[]int `json:"requires,omitempty" validate:"omitempty,max=10,unique,dive,min=1"`
+
+	// ResumePolicy How a paused switch is re-armed when the pause ends
+	ResumePolicy *SwitchResumePolicy `json:"resumePolicy,omitempty"`
 
-	// TriggerAt Time to trigger in Unix time format to trigger switch
+	// Status Current switch status. A switch with trusted contacts is verifying between expiring and being released, a triggered switch with a repeat interval is missing until someone checks in, and an expired switch is waiting until the switches it requires have expired. Updating a switch can only disable it; its status otherwise changes by checking in, pausing and resuming
+	Status *SwitchStatus `json:"status,omitempty" validate:"omitempty,oneof=active disabled failed missing paused triggered verifying waiting"`
+
+	// TriggerAt Time to trigger in Unix time format to trigger switch. While verifying, the time the switch is released, and while missing, the time of the next still missing alert
 	TriggerAt *int64 `json:"triggerAt,omitempty"`
 
+	// TriggerCount How many times the switch has triggered since the last check-in
+	TriggerCount *int `json:"triggerCount,omitempty"`
+
+	// TrustedContacts People asked to verify an expired switch before it is released. They can postpone or confirm the release
+	TrustedContacts *[]TrustedContact `json:"trustedContacts,omitempty" validate:"omitempty,max=10,unique=Name,dive"`
+
 	// UserId User ID of the switch owner
 	UserId *string `json:"userId,omitempty"`
+
+	// VerificationWindow How long trusted contacts have to respond before an expired switch is released
+	VerificationWindow *string `json:"verificationWindow,omitempty"`
+
+	// Webhooks Signed HTTP requests sent when the switch triggers
+	Webhooks *[]Webhook `json:"webhooks,omitempty" validate:"omitempty,max=10,unique=Url,dive"`
 }
 
-// SwitchStatus Current switch status
+// SwitchResumePolicy How a paused switch is re-armed when the pause ends
+type SwitchResumePolicy string
+
+// SwitchStatus Current switch status. A switch with trusted contacts is verifying between expiring and being released, a triggered switch with a repeat interval is missing until someone checks in, and an expired switch is waiting until the switches it requires have expired. Updating a switch can only disable it; its status otherwise changes by checking in, pausing and resuming
 type SwitchStatus string
 
-// GetSwitchParams defines parameters for GetSwitch.
-type GetSwitchParams struct {
-	// Limit Limit the number of switches returned (default is 100)
-	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
-}
+// SwitchMember A user who checks in to a quorum switch
+type SwitchMember struct {
+	// CheckedIn Whether the member has checked in during the current interval
+	CheckedIn *bool `json:"checkedIn,omitempty"`
 
-// PostSwitchJSONRequestBody defines body for PostSwitch for application/json ContentType.
-type PostSwitchJSONRequestBody = Switch
+	// LastCheckInAt Unix time of the member's most recent check-in
+	LastCheckInAt *int64 `json:"lastCheckInAt,omitempty"`
 
-// PutSwitchIdJSONRequestBody defines body for PutSwitchId for application/json ContentType.
-type PutSwitchIdJSONRequestBody = Switch
+	// Notifier Notification channel powered by shoutrrr used to remind the member to check in
+	Notifier *string `json:"notifier,omitempty"`
 
-// RequestEditorFn  is the function signature for the RequestEditor callback function
-type RequestEditorFn func(ctx context.Context, req *http.Request) error
+	// UserId User ID of the member, matched against the authenticated user
+	UserId string `json:"userId" validate:"required,min=1"`
+}
 
-// Doer performs HTTP requests.
-//
-// The standard http.Client implements this interface.
-type HttpRequestDoer interface {
-	Do(req *http.Request) (*http.Response, error)
+// TOTPCode defines model for TOTPCode.
+type TOTPCode struct {
+	Code string `json:"code" validate:"required,max=10"`
 }
 
-// Client which conforms to the OpenAPI3 specification for this service.
-type Client struct {
-	// The endpoint of the server conforming to this interface, with scheme,
-	// https://api.deepmap.com for example. This can contain a path relative
-	// to the server, such as https://api.deepmap.com/dev-test, and all the
-	// paths in the swagger spec will be appended to the server.
-	Server string
+// TOTPSetup TOTP secret to add to an authenticator app
+type TOTPSetup struct {
+	// Secret Base32 secret for manual entry
+	Secret string `json:"secret"`
 
-	// Doer for performing requests, typically a *http.Client with any
-	// customized settings, such as certificate chains.
-	Client HttpRequestDoer
+	// Url otpauth URL, usually shown as a QR code
+	Url string `json:"url"`
+}
 
-	// A list of callbacks for modifying requests which are generated before sending over
-	// the network.
-	RequestEditors []RequestEditorFn
+// TokenScope defines model for TokenScope.
+type TokenScope string
+
+// TrustedContact A person asked to verify an expired switch before it is released
+type TrustedContact struct {
+	// Name Name used to identify the contact in the audit trail
+	Name string `json:"name" validate:"required,min=1,max=64"`
+
+	// Notifier Notification channel powered by shoutrrr the verification link is sent to. Omit on update to keep the current one
+	Notifier *string `json:"notifier,omitempty"`
 }
 
-// ClientOption allows setting custom parameters during construction
-type ClientOption func(*Client) error
+// User A local user account
+type User struct {
+	// CreatedAt Unix timestamp of when the account was created
+	CreatedAt int64 `json:"createdAt"`
 
-// Creates a new Client, with reasonable defaults
-func NewClient(server string, opts ...ClientOption) (*Client, error) {
-	// create a client with sane default values
+	// TotpEnabled Whether signing in requires a TOTP code
+	TotpEnabled bool   `json:"totpEnabled"`
+	Username    string `json:"username"`
+}
+
+// Webhook An HTTP request sent when a switch triggers, signed with HMAC-SHA256 so the receiver can verify it
+type Webhook struct {
+	// Body Go template rendered to the JSON request body. Switch fields are available as .Event, .SwitchID, .UserID, .Message, .Labels, .CheckInInterval, .LastCheckInAt and .TriggeredAt, and the json function quotes a value. Defaults to a JSON document with every field
+	Body *string `json:"body,omitempty" validate:"omitempty,max=8192"`
+
+	// ClientCertificate PEM encoded client certificate presented for mutual TLS. Omit on update to keep the current one
+	ClientCertificate *string `json:"clientCertificate,omitempty"`
+
+	// ClientKey PEM encoded private key of the client certificate. Omit on update to keep the current one
+	ClientKey *string `json:"clientKey,omitempty"`
+
+	// Headers Extra request headers. Omit on update to keep the current ones
+	Headers *map[string]string `json:"headers,omitempty" validate:"omitempty,max=20"`
+
+	// MaxAttempts How many times the request is attempted before giving up, defaults to 3
+	MaxAttempts *int `json:"maxAttempts,omitempty" validate:"omitempty,min=1,max=10"`
+
+	// Method HTTP method, defaults to POST
+	Method *WebhookMethod `json:"method,omitempty" validate:"omitempty,oneof=POST PUT PATCH"`
+
+	// MutualTls Whether the webhook presents a client certificate
+	MutualTls *bool `json:"mutualTls,omitempty"`
+
+	// RetryBackoff Wait before the first retry, doubling for each later one up to a minute. Defaults to 2s
+	RetryBackoff *string `json:"retryBackoff,omitempty"`
+
+	// Secret Key used to sign requests. Required for new webhooks, omit on update to keep the current one
+	Secret *string `json:"secret,omitempty" validate:"omitempty,eq=|min=16,max=256"`
+
+	// Signed Whether the webhook has a signing secret
+	Signed *bool `json:"signed,omitempty"`
+
+	// Url URL the request is sent to
+	Url string `json:"url" validate:"required,http_url,max=2048"`
+}
+
+// WebhookMethod HTTP method, defaults to POST
+type WebhookMethod string
+
+// WebhookSubscription A webhook that receives lifecycle events of your switches as signed JSON requests
+type WebhookSubscription struct {
+	// CreatedAt Unix time the subscription was created
+	CreatedAt *int64 `json:"createdAt,omitempty"`
+
+	// Enabled Whether events are sent, defaults to true
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Events Event types to send. Every event is sent when empty
+	Events *[]EventType `json:"events,omitempty" validate:"omitempty,unique,dive,oneof=switch.created switch.updated switch.checked_in switch.reminder_sent switch.paused switch.resumed switch.triggered switch.failed switch.disabled switch.deleted"`
+	Id     *int         `json:"id,omitempty"`
+
+	// MaxAttempts How many times an event is attempted before giving up, defaults to 3
+	MaxAttempts *int `json:"maxAttempts,omitempty" validate:"omitempty,min=1,max=10"`
+
+	// RetryBackoff Wait before the first retry, doubling for each later one up to a minute. Defaults to 2s
+	RetryBackoff *string `json:"retryBackoff,omitempty"`
+
+	// Secret Key used to sign requests. Required for new subscriptions, omit on update to keep the current one
+	Secret *string `json:"secret,omitempty" validate:"omitempty,eq=|min=16,max=256"`
+
+	// Url URL events are sent to
+	Url string `json:"url" validate:"required,http_url,max=2048"`
+}
+
+// GetAdminSwitchesParams defines parameters for GetAdminSwitches.
+type GetAdminSwitchesParams struct {
+	// UserId Only list the switches of this user
+	UserId *string `form:"userId,omitempty" json:"userId,omitempty"`
+
+	// Limit Maximum number of switches to return, newest first (default is 50)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of switches to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// PostCheckinParams defines parameters for PostCheckin.
+type PostCheckinParams struct {
+	// Label Only check in to switches with this label
+	Label *string `form:"label,omitempty" json:"label,omitempty"`
+}
+
+// GetEventsParams defines parameters for GetEvents.
+type GetEventsParams struct {
+	// LastEventID ID of the last event received. Recent events after it are sent before live ones
+	LastEventID *int64 `json:"Last-Event-ID,omitempty"`
+}
+
+// GetSwitchParams defines parameters for GetSwitch.
+type GetSwitchParams struct {
+	// Limit Limit the number of switches returned (default is 100)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetSwitchIdAuditParams defines parameters for GetSwitchIdAudit.
+type GetSwitchIdAuditParams struct {
+	// Limit Maximum number of events to return, newest first (default is 50)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of events to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// GetSwitchIdCheckinsParams defines parameters for GetSwitchIdCheckins.
+type GetSwitchIdCheckinsParams struct {
+	// Limit Maximum number of check-ins to return, newest first (default is 50)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of check-ins to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// GetSwitchIdDeliveriesParams defines parameters for GetSwitchIdDeliveries.
+type GetSwitchIdDeliveriesParams struct {
+	// Limit Maximum number of deliveries to return, newest first (default is 50)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of deliveries to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// GetWebhooksIdDeliveriesParams defines parameters for GetWebhooksIdDeliveries.
+type GetWebhooksIdDeliveriesParams struct {
+	// Limit Maximum number of deliveries to return, newest first (default is 50)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of deliveries to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// PostAdminSwitchesIdDisableJSONRequestBody defines body for PostAdminSwitchesIdDisable for application/json ContentType.
+type PostAdminSwitchesIdDisableJSONRequestBody = AdminDisable
+
+// PutAdminUsersUserIdQuotaJSONRequestBody defines body for PutAdminUsersUserIdQuota for application/json ContentType.
+type PutAdminUsersUserIdQuotaJSONRequestBody = QuotaUpdate
+
+// PutAdminUsersUserIdRoleJSONRequestBody defines body for PutAdminUsersUserIdRole for application/json ContentType.
+type PutAdminUsersUserIdRoleJSONRequestBody = RoleUpdate
+
+// PostAuthLoginJSONRequestBody defines body for PostAuthLogin for application/json ContentType.
+type PostAuthLoginJSONRequestBody = LoginRequest
+
+// PostAuthLoginPasskeyJSONRequestBody defines body for PostAuthLoginPasskey for application/json ContentType.
+type PostAuthLoginPasskeyJSONRequestBody = PasskeyCredential
+
+// PostAuthPasskeysJSONRequestBody defines body for PostAuthPasskeys for application/json ContentType.
+type PostAuthPasskeysJSONRequestBody = PasskeyRegistration
+
+// DeleteAuthPasskeysPasskeyIdJSONRequestBody defines body for DeleteAuthPasskeysPasskeyId for application/json ContentType.
+type DeleteAuthPasskeysPasskeyIdJSONRequestBody = PasskeyConfirmation
+
+// PostAuthPasswordJSONRequestBody defines body for PostAuthPassword for application/json ContentType.
+type PostAuthPasswordJSONRequestBody = PasswordChange
+
+// PostAuthTotpDisableJSONRequestBody defines body for PostAuthTotpDisable for application/json ContentType.
+type PostAuthTotpDisableJSONRequestBody = TOTPCode
+
+// PostAuthTotpEnableJSONRequestBody defines body for PostAuthTotpEnable for application/json ContentType.
+type PostAuthTotpEnableJSONRequestBody = TOTPCode
+
+// PostCheckinJSONRequestBody defines body for PostCheckin for application/json ContentType.
+type PostCheckinJSONRequestBody = CheckInRequest
+
+// PostContactTokenPostponeJSONRequestBody defines body for PostContactTokenPostpone for application/json ContentType.
+type PostContactTokenPostponeJSONRequestBody = PostponeRequest
+
+// PostIntegrationsAlertmanagerTokenJSONRequestBody defines body for PostIntegrationsAlertmanagerToken for application/json ContentType.
+type PostIntegrationsAlertmanagerTokenJSONRequestBody = AlertmanagerWebhook
+
+// PostSwitchJSONRequestBody defines body for PostSwitch for application/json ContentType.
+type PostSwitchJSONRequestBody = Switch
+
+// PostSwitchPauseJSONRequestBody defines body for PostSwitchPause for application/json ContentType.
+type PostSwitchPauseJSONRequestBody = PauseRequest
+
+// PutSwitchIdJSONRequestBody defines body for PutSwitchId for application/json ContentType.
+type PutSwitchIdJSONRequestBody = Switch
+
+// PostSwitchIdPauseJSONRequestBody defines body for PostSwitchIdPause for application/json ContentType.
+type PostSwitchIdPauseJSONRequestBody = PauseRequest
+
+// PostSwitchIdResetJSONRequestBody defines body for PostSwitchIdReset for application/json ContentType.
+type PostSwitchIdResetJSONRequestBody = CheckInRequest
+
+// PostTokensJSONRequestBody defines body for PostTokens for application/json ContentType.
+type PostTokensJSONRequestBody = APIToken
+
+// PutTokensIdJSONRequestBody defines body for PutTokensId for application/json ContentType.
+type PutTokensIdJSONRequestBody = APIToken
+
+// PostWebhooksJSONRequestBody defines body for PostWebhooks for application/json ContentType.
+type PostWebhooksJSONRequestBody = WebhookSubscription
+
+// PutWebhooksIdJSONRequestBody defines body for PutWebhooksId for application/json ContentType.
+type PutWebhooksIdJSONRequestBody = WebhookSubscription
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
 	client := Client{
 		Server: server,
 	}
@@ -200,12 +1083,128 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// GetAdminStats request
+	GetAdminStats(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminSwitches request
+	GetAdminSwitches(ctx context.Context, params *GetAdminSwitchesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAdminSwitchesIdDisableWithBody request with any body
+	PostAdminSwitchesIdDisableWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAdminSwitchesIdDisable(ctx context.Context, id int, body PostAdminSwitchesIdDisableJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAdminUsers request
+	GetAdminUsers(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteAdminUsersUserIdQuota request
+	DeleteAdminUsersUserIdQuota(ctx context.Context, userId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutAdminUsersUserIdQuotaWithBody request with any body
+	PutAdminUsersUserIdQuotaWithBody(ctx context.Context, userId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutAdminUsersUserIdQuota(ctx context.Context, userId string, body PutAdminUsersUserIdQuotaJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutAdminUsersUserIdRoleWithBody request with any body
+	PutAdminUsersUserIdRoleWithBody(ctx context.Context, userId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutAdminUsersUserIdRole(ctx context.Context, userId string, body PutAdminUsersUserIdRoleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetAuthConfig request
 	GetAuthConfig(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PostAuthLoginWithBody request with any body
+	PostAuthLoginWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAuthLogin(ctx context.Context, body PostAuthLoginJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthLoginPasskeyWithBody request with any body
+	PostAuthLoginPasskeyWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAuthLoginPasskey(ctx context.Context, body PostAuthLoginPasskeyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthLoginPasskeyChallenge request
+	PostAuthLoginPasskeyChallenge(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthLogout request
+	PostAuthLogout(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAuthMe request
+	GetAuthMe(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAuthPasskeys request
+	GetAuthPasskeys(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthPasskeysWithBody request with any body
+	PostAuthPasskeysWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAuthPasskeys(ctx context.Context, body PostAuthPasskeysJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthPasskeysChallenge request
+	PostAuthPasskeysChallenge(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthPasskeysConfirmChallenge request
+	PostAuthPasskeysConfirmChallenge(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteAuthPasskeysPasskeyIdWithBody request with any body
+	DeleteAuthPasskeysPasskeyIdWithBody(ctx context.Context, passkeyId int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	DeleteAuthPasskeysPasskeyId(ctx context.Context, passkeyId int, body DeleteAuthPasskeysPasskeyIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthPasswordWithBody request with any body
+	PostAuthPasswordWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAuthPassword(ctx context.Context, body PostAuthPasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthTotp request
+	PostAuthTotp(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthTotpDisableWithBody request with any body
+	PostAuthTotpDisableWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAuthTotpDisable(ctx context.Context, body PostAuthTotpDisableJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthTotpEnableWithBody request with any body
+	PostAuthTotpEnableWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAuthTotpEnable(ctx context.Context, body PostAuthTotpEnableJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetChanges request
+	GetChanges(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostChangesIdApprove request
+	PostChangesIdApprove(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostChangesIdCancel request
+	PostChangesIdCancel(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostCheckinWithBody request with any body
+	PostCheckinWithBody(ctx context.Context, params *PostCheckinParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostCheckin(ctx context.Context, params *PostCheckinParams, body PostCheckinJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetContactToken request
+	GetContactToken(ctx context.Context, token string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostContactTokenConfirm request
+	PostContactTokenConfirm(ctx context.Context, token string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostContactTokenPostponeWithBody request with any body
+	PostContactTokenPostponeWithBody(ctx context.Context, token string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostContactTokenPostpone(ctx context.Context, token string, body PostContactTokenPostponeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetEvents request
+	GetEvents(ctx context.Context, params *GetEventsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetHealth request
 	GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PostIntegrationsAlertmanagerTokenWithBody request with any body
+	PostIntegrationsAlertmanagerTokenWithBody(ctx context.Context, token string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostIntegrationsAlertmanagerToken(ctx context.Context, token string, body PostIntegrationsAlertmanagerTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetSwitch request
 	GetSwitch(ctx context.Context, params *GetSwitchParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -214,6 +1213,14 @@ type ClientInterface interface {
 
 	PostSwitch(ctx context.Context, body PostSwitchJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PostSwitchPauseWithBody request with any body
+	PostSwitchPauseWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostSwitchPause(ctx context.Context, body PostSwitchPauseJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostSwitchResume request
+	PostSwitchResume(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// DeleteSwitchId request
 	DeleteSwitchId(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -225,18 +1232,81 @@ type ClientInterface interface {
 
 	PutSwitchId(ctx context.Context, id int, body PutSwitchIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetSwitchIdAudit request
+	GetSwitchIdAudit(ctx context.Context, id int, params *GetSwitchIdAuditParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSwitchIdCheckins request
+	GetSwitchIdCheckins(ctx context.Context, id int, params *GetSwitchIdCheckinsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSwitchIdDeliveries request
+	GetSwitchIdDeliveries(ctx context.Context, id int, params *GetSwitchIdDeliveriesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostSwitchIdDisable request
 	PostSwitchIdDisable(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	// PostSwitchIdReset request
-	PostSwitchIdReset(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error)
+	// PostSwitchIdPauseWithBody request with any body
+	PostSwitchIdPauseWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostSwitchIdPause(ctx context.Context, id int, body PostSwitchIdPauseJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostSwitchIdResetWithBody request with any body
+	PostSwitchIdResetWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostSwitchIdReset(ctx context.Context, id int, body PostSwitchIdResetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostSwitchIdResetChallenge request
+	PostSwitchIdResetChallenge(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostSwitchIdResume request
+	PostSwitchIdResume(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTokens request
+	GetTokens(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostTokensWithBody request with any body
+	PostTokensWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostTokens(ctx context.Context, body PostTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteTokensId request
+	DeleteTokensId(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTokensId request
+	GetTokensId(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutTokensIdWithBody request with any body
+	PutTokensIdWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutTokensId(ctx context.Context, id int, body PutTokensIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetVapid request
 	GetVapid(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetWebhooks request
+	GetWebhooks(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostWebhooksWithBody request with any body
+	PostWebhooksWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostWebhooks(ctx context.Context, body PostWebhooksJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteWebhooksId request
+	DeleteWebhooksId(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetWebhooksId request
+	GetWebhooksId(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutWebhooksIdWithBody request with any body
+	PutWebhooksIdWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutWebhooksId(ctx context.Context, id int, body PutWebhooksIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetWebhooksIdDeliveries request
+	GetWebhooksIdDeliveries(ctx context.Context, id int, params *GetWebhooksIdDeliveriesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
-func (c *Client) GetAuthConfig(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetAuthConfigRequest(c.Server)
+func (c *Client) GetAdminStats(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminStatsRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -247,8 +1317,8 @@ func (c *Client) GetAuthConfig(ctx context.Context, reqEditors ...RequestEditorF
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetHealthRequest(c.Server)
+func (c *Client) GetAdminSwitches(ctx context.Context, params *GetAdminSwitchesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminSwitchesRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -259,8 +1329,8 @@ func (c *Client) GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetSwitch(ctx context.Context, params *GetSwitchParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetSwitchRequest(c.Server, params)
+func (c *Client) PostAdminSwitchesIdDisableWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminSwitchesIdDisableRequestWithBody(c.Server, id, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -271,8 +1341,8 @@ func (c *Client) GetSwitch(ctx context.Context, params *GetSwitchParams, reqEdit
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostSwitchWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSwitchRequestWithBody(c.Server, contentType, body)
+func (c *Client) PostAdminSwitchesIdDisable(ctx context.Context, id int, body PostAdminSwitchesIdDisableJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAdminSwitchesIdDisableRequest(c.Server, id, body)
 	if err != nil {
 		return nil, err
 	}
@@ -283,8 +1353,8 @@ func (c *Client) PostSwitchWithBody(ctx context.Context, contentType string, bod
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostSwitch(ctx context.Context, body PostSwitchJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSwitchRequest(c.Server, body)
+func (c *Client) GetAdminUsers(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminUsersRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -295,8 +1365,8 @@ func (c *Client) PostSwitch(ctx context.Context, body PostSwitchJSONRequestBody,
 	return c.Client.Do(req)
 }
 
-func (c *Client) DeleteSwitchId(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewDeleteSwitchIdRequest(c.Server, id)
+func (c *Client) DeleteAdminUsersUserIdQuota(ctx context.Context, userId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteAdminUsersUserIdQuotaRequest(c.Server, userId)
 	if err != nil {
 		return nil, err
 	}
@@ -307,8 +1377,8 @@ func (c *Client) DeleteSwitchId(ctx context.Context, id int, reqEditors ...Reque
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetSwitchId(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetSwitchIdRequest(c.Server, id)
+func (c *Client) PutAdminUsersUserIdQuotaWithBody(ctx context.Context, userId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutAdminUsersUserIdQuotaRequestWithBody(c.Server, userId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -319,8 +1389,8 @@ func (c *Client) GetSwitchId(ctx context.Context, id int, reqEditors ...RequestE
 	return c.Client.Do(req)
 }
 
-func (c *Client) PutSwitchIdWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPutSwitchIdRequestWithBody(c.Server, id, contentType, body)
+func (c *Client) PutAdminUsersUserIdQuota(ctx context.Context, userId string, body PutAdminUsersUserIdQuotaJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutAdminUsersUserIdQuotaRequest(c.Server, userId, body)
 	if err != nil {
 		return nil, err
 	}
@@ -331,8 +1401,8 @@ func (c *Client) PutSwitchIdWithBody(ctx context.Context, id int, contentType st
 	return c.Client.Do(req)
 }
 
-func (c *Client) PutSwitchId(ctx context.Context, id int, body PutSwitchIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPutSwitchIdRequest(c.Server, id, body)
+func (c *Client) PutAdminUsersUserIdRoleWithBody(ctx context.Context, userId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutAdminUsersUserIdRoleRequestWithBody(c.Server, userId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -343,8 +1413,8 @@ func (c *Client) PutSwitchId(ctx context.Context, id int, body PutSwitchIdJSONRe
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostSwitchIdDisable(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSwitchIdDisableRequest(c.Server, id)
+func (c *Client) PutAdminUsersUserIdRole(ctx context.Context, userId string, body PutAdminUsersUserIdRoleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutAdminUsersUserIdRoleRequest(c.Server, userId, body)
 	if err != nil {
 		return nil, err
 	}
@@ -355,8 +1425,8 @@ func (c *Client) PostSwitchIdDisable(ctx context.Context, id int, reqEditors ...
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostSwitchIdReset(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSwitchIdResetRequest(c.Server, id)
+func (c *Client) GetAuthConfig(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAuthConfigRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -367,8 +1437,8 @@ func (c *Client) PostSwitchIdReset(ctx context.Context, id int, reqEditors ...Re
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetVapid(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetVapidRequest(c.Server)
+func (c *Client) PostAuthLoginWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthLoginRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -379,863 +1449,7902 @@ func (c *Client) GetVapid(ctx context.Context, reqEditors ...RequestEditorFn) (*
 	return c.Client.Do(req)
 }
 
-// NewGetAuthConfigRequest generates requests for GetAuthConfig
-func NewGetAuthConfigRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) PostAuthLogin(ctx context.Context, body PostAuthLoginJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthLoginRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/auth/config")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PostAuthLoginPasskeyWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthLoginPasskeyRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewGetHealthRequest generates requests for GetHealth
-func NewGetHealthRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) PostAuthLoginPasskey(ctx context.Context, body PostAuthLoginPasskeyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthLoginPasskeyRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/health")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PostAuthLoginPasskeyChallenge(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthLoginPasskeyChallengeRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewGetSwitchRequest generates requests for GetSwitch
-func NewGetSwitchRequest(server string, params *GetSwitchParams) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) PostAuthLogout(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthLogoutRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/switch")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) GetAuthMe(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAuthMeRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-
-	if params != nil {
-		queryValues := queryURL.Query()
-
-		if params.Limit != nil {
-
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
-			}
-
-		}
-
-		queryURL.RawQuery = queryValues.Encode()
-	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewPostSwitchRequest calls the generic PostSwitch builder with application/json body
-func NewPostSwitchRequest(server string, body PostSwitchJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) GetAuthPasskeys(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAuthPasskeysRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPostSwitchRequestWithBody(server, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewPostSwitchRequestWithBody generates requests for PostSwitch with any type of body
-func NewPostSwitchRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) PostAuthPasskeysWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthPasskeysRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/switch")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PostAuthPasskeys(ctx context.Context, body PostAuthPasskeysJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthPasskeysRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewDeleteSwitchIdRequest generates requests for DeleteSwitchId
-func NewDeleteSwitchIdRequest(server string, id int) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+func (c *Client) PostAuthPasskeysChallenge(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthPasskeysChallengeRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) PostAuthPasskeysConfirmChallenge(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthPasskeysConfirmChallengeRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/switch/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) DeleteAuthPasskeysPasskeyIdWithBody(ctx context.Context, passkeyId int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteAuthPasskeysPasskeyIdRequestWithBody(c.Server, passkeyId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewGetSwitchIdRequest generates requests for GetSwitchId
-func NewGetSwitchIdRequest(server string, id int) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+func (c *Client) DeleteAuthPasskeysPasskeyId(ctx context.Context, passkeyId int, body DeleteAuthPasskeysPasskeyIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteAuthPasskeysPasskeyIdRequest(c.Server, passkeyId, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) PostAuthPasswordWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthPasswordRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/switch/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PostAuthPassword(ctx context.Context, body PostAuthPasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthPasswordRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewPutSwitchIdRequest calls the generic PutSwitchId builder with application/json body
-func NewPutSwitchIdRequest(server string, id int, body PutSwitchIdJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) PostAuthTotp(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthTotpRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPutSwitchIdRequestWithBody(server, id, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewPutSwitchIdRequestWithBody generates requests for PutSwitchId with any type of body
-func NewPutSwitchIdRequestWithBody(server string, id int, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+func (c *Client) PostAuthTotpDisableWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthTotpDisableRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) PostAuthTotpDisable(ctx context.Context, body PostAuthTotpDisableJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthTotpDisableRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/switch/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PostAuthTotpEnableWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthTotpEnableRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("PUT", queryURL.String(), body)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewPostSwitchIdDisableRequest generates requests for PostSwitchIdDisable
-func NewPostSwitchIdDisableRequest(server string, id int) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+func (c *Client) PostAuthTotpEnable(ctx context.Context, body PostAuthTotpEnableJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthTotpEnableRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) GetChanges(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetChangesRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/switch/%s/disable", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PostChangesIdApprove(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostChangesIdApproveRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewPostSwitchIdResetRequest generates requests for PostSwitchIdReset
-func NewPostSwitchIdResetRequest(server string, id int) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+func (c *Client) PostChangesIdCancel(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostChangesIdCancelRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) PostCheckinWithBody(ctx context.Context, params *PostCheckinParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostCheckinRequestWithBody(c.Server, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/switch/%s/reset", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PostCheckin(ctx context.Context, params *PostCheckinParams, body PostCheckinJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostCheckinRequest(c.Server, params, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewGetVapidRequest generates requests for GetVapid
-func NewGetVapidRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) GetContactToken(ctx context.Context, token string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetContactTokenRequest(c.Server, token)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/vapid")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PostContactTokenConfirm(ctx context.Context, token string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostContactTokenConfirmRequest(c.Server, token)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+func (c *Client) PostContactTokenPostponeWithBody(ctx context.Context, token string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostContactTokenPostponeRequestWithBody(c.Server, token, contentType, body)
+	if err != nil {
+		return nil, err
 	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
-	return nil
-}
-
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
+	return c.Client.Do(req)
 }
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+func (c *Client) PostContactTokenPostpone(ctx context.Context, token string, body PostContactTokenPostponeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostContactTokenPostponeRequest(c.Server, token, body)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+func (c *Client) GetEvents(ctx context.Context, params *GetEventsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEventsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
 }
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// GetAuthConfigWithResponse request
-	GetAuthConfigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAuthConfigResponse, error)
-
-	// GetHealthWithResponse request
-	GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error)
-
-	// GetSwitchWithResponse request
-	GetSwitchWithResponse(ctx context.Context, params *GetSwitchParams, reqEditors ...RequestEditorFn) (*GetSwitchResponse, error)
-
-	// PostSwitchWithBodyWithResponse request with any body
-	PostSwitchWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSwitchResponse, error)
-
-	PostSwitchWithResponse(ctx context.Context, body PostSwitchJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSwitchResponse, error)
-
-	// DeleteSwitchIdWithResponse request
-	DeleteSwitchIdWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*DeleteSwitchIdResponse, error)
-
-	// GetSwitchIdWithResponse request
-	GetSwitchIdWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*GetSwitchIdResponse, error)
-
-	// PutSwitchIdWithBodyWithResponse request with any body
-	PutSwitchIdWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutSwitchIdResponse, error)
-
-	PutSwitchIdWithResponse(ctx context.Context, id int, body PutSwitchIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutSwitchIdResponse, error)
-
-	// PostSwitchIdDisableWithResponse request
-	PostSwitchIdDisableWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*PostSwitchIdDisableResponse, error)
-
-	// PostSwitchIdResetWithResponse request
-	PostSwitchIdResetWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*PostSwitchIdResetResponse, error)
-
-	// GetVapidWithResponse request
-	GetVapidWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetVapidResponse, error)
+func (c *Client) GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHealthRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type GetAuthConfigResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *AuthConfig
+func (c *Client) PostIntegrationsAlertmanagerTokenWithBody(ctx context.Context, token string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostIntegrationsAlertmanagerTokenRequestWithBody(c.Server, token, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// Status returns HTTPResponse.Status
-func (r GetAuthConfigResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+func (c *Client) PostIntegrationsAlertmanagerToken(ctx context.Context, token string, body PostIntegrationsAlertmanagerTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostIntegrationsAlertmanagerTokenRequest(c.Server, token, body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetAuthConfigResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *Client) GetSwitch(ctx context.Context, params *GetSwitchParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSwitchRequest(c.Server, params)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type GetHealthResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *Health
-	JSON503      *Health
+func (c *Client) PostSwitchWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSwitchRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// Status returns HTTPResponse.Status
-func (r GetHealthResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+func (c *Client) PostSwitch(ctx context.Context, body PostSwitchJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSwitchRequest(c.Server, body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetHealthResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *Client) PostSwitchPauseWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSwitchPauseRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type GetSwitchResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *[]Switch
-	JSON401      *Error
-	JSON500      *Error
+func (c *Client) PostSwitchPause(ctx context.Context, body PostSwitchPauseJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSwitchPauseRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// Status returns HTTPResponse.Status
-func (r GetSwitchResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+func (c *Client) PostSwitchResume(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSwitchResumeRequest(c.Server)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetSwitchResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *Client) DeleteSwitchId(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteSwitchIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type PostSwitchResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON201      *Switch
-	JSON400      *Error
-	JSON401      *Error
-	JSON500      *Error
+func (c *Client) GetSwitchId(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSwitchIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// Status returns HTTPResponse.Status
-func (r PostSwitchResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+func (c *Client) PutSwitchIdWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutSwitchIdRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostSwitchResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *Client) PutSwitchId(ctx context.Context, id int, body PutSwitchIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutSwitchIdRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
-
-type DeleteSwitchIdResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON401      *Error
-	JSON404      *Error
-	JSON500      *Error
-}
-
-// Status returns HTTPResponse.Status
-func (r DeleteSwitchIdResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	return c.Client.Do(req)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r DeleteSwitchIdResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *Client) GetSwitchIdAudit(ctx context.Context, id int, params *GetSwitchIdAuditParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSwitchIdAuditRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
-
-type GetSwitchIdResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *Switch
-	JSON401      *Error
-	JSON404      *Error
-	JSON500      *Error
-}
-
-// Status returns HTTPResponse.Status
-func (r GetSwitchIdResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	return c.Client.Do(req)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetSwitchIdResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *Client) GetSwitchIdCheckins(ctx context.Context, id int, params *GetSwitchIdCheckinsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSwitchIdCheckinsRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
-
-type PutSwitchIdResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *Switch
-	JSON400      *Error
-	JSON401      *Error
-	JSON404      *Error
-	JSON500      *Error
-}
-
-// Status returns HTTPResponse.Status
-func (r PutSwitchIdResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	return c.Client.Do(req)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PutSwitchIdResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *Client) GetSwitchIdDeliveries(ctx context.Context, id int, params *GetSwitchIdDeliveriesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSwitchIdDeliveriesRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
-
-type PostSwitchIdDisableResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *Switch
-	JSON401      *Error
-	JSON404      *Error
-	JSON500      *Error
-}
-
-// Status returns HTTPResponse.Status
-func (r PostSwitchIdDisableResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	return c.Client.Do(req)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostSwitchIdDisableResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *Client) PostSwitchIdDisable(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSwitchIdDisableRequest(c.Server, id)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
-
-type PostSwitchIdResetResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *Switch
-	JSON401      *Error
-	JSON404      *Error
-	JSON500      *Error
-}
-
-// Status returns HTTPResponse.Status
-func (r PostSwitchIdResetResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	return c.Client.Do(req)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostSwitchIdResetResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *Client) PostSwitchIdPauseWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSwitchIdPauseRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
-
-type GetVapidResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON401      *Error
-}
-
-// Status returns HTTPResponse.Status
-func (r GetVapidResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	return c.Client.Do(req)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetVapidResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *Client) PostSwitchIdPause(ctx context.Context, id int, body PostSwitchIdPauseJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSwitchIdPauseRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// GetAuthConfigWithResponse request returning *GetAuthConfigResponse
-func (c *ClientWithResponses) GetAuthConfigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAuthConfigResponse, error) {
-	rsp, err := c.GetAuthConfig(ctx, reqEditors...)
+func (c *Client) PostSwitchIdResetWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSwitchIdResetRequestWithBody(c.Server, id, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetAuthConfigResponse(rsp)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// GetHealthWithResponse request returning *GetHealthResponse
-func (c *ClientWithResponses) GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error) {
-	rsp, err := c.GetHealth(ctx, reqEditors...)
+func (c *Client) PostSwitchIdReset(ctx context.Context, id int, body PostSwitchIdResetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSwitchIdResetRequest(c.Server, id, body)
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetHealthResponse(rsp)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// GetSwitchWithResponse request returning *GetSwitchResponse
-func (c *ClientWithResponses) GetSwitchWithResponse(ctx context.Context, params *GetSwitchParams, reqEditors ...RequestEditorFn) (*GetSwitchResponse, error) {
-	rsp, err := c.GetSwitch(ctx, params, reqEditors...)
+func (c *Client) PostSwitchIdResetChallenge(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSwitchIdResetChallengeRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetSwitchResponse(rsp)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// PostSwitchWithBodyWithResponse request with arbitrary body returning *PostSwitchResponse
-func (c *ClientWithResponses) PostSwitchWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSwitchResponse, error) {
-	rsp, err := c.PostSwitchWithBody(ctx, contentType, body, reqEditors...)
+func (c *Client) PostSwitchIdResume(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSwitchIdResumeRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostSwitchResponse(rsp)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-func (c *ClientWithResponses) PostSwitchWithResponse(ctx context.Context, body PostSwitchJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSwitchResponse, error) {
-	rsp, err := c.PostSwitch(ctx, body, reqEditors...)
+func (c *Client) GetTokens(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTokensRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostSwitchResponse(rsp)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// DeleteSwitchIdWithResponse request returning *DeleteSwitchIdResponse
-func (c *ClientWithResponses) DeleteSwitchIdWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*DeleteSwitchIdResponse, error) {
-	rsp, err := c.DeleteSwitchId(ctx, id, reqEditors...)
+func (c *Client) PostTokensWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTokensRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-	return ParseDeleteSwitchIdResponse(rsp)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// GetSwitchIdWithResponse request returning *GetSwitchIdResponse
-func (c *ClientWithResponses) GetSwitchIdWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*GetSwitchIdResponse, error) {
-	rsp, err := c.GetSwitchId(ctx, id, reqEditors...)
+func (c *Client) PostTokens(ctx context.Context, body PostTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTokensRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetSwitchIdResponse(rsp)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// PutSwitchIdWithBodyWithResponse request with arbitrary body returning *PutSwitchIdResponse
-func (c *ClientWithResponses) PutSwitchIdWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutSwitchIdResponse, error) {
-	rsp, err := c.PutSwitchIdWithBody(ctx, id, contentType, body, reqEditors...)
+func (c *Client) DeleteTokensId(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteTokensIdRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
-	return ParsePutSwitchIdResponse(rsp)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-func (c *ClientWithResponses) PutSwitchIdWithResponse(ctx context.Context, id int, body PutSwitchIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutSwitchIdResponse, error) {
-	rsp, err := c.PutSwitchId(ctx, id, body, reqEditors...)
+func (c *Client) GetTokensId(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTokensIdRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
-	return ParsePutSwitchIdResponse(rsp)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// PostSwitchIdDisableWithResponse request returning *PostSwitchIdDisableResponse
-func (c *ClientWithResponses) PostSwitchIdDisableWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*PostSwitchIdDisableResponse, error) {
-	rsp, err := c.PostSwitchIdDisable(ctx, id, reqEditors...)
+func (c *Client) PutTokensIdWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutTokensIdRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutTokensId(ctx context.Context, id int, body PutTokensIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutTokensIdRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetVapid(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetVapidRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetWebhooks(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetWebhooksRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostWebhooksWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostWebhooksRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostWebhooks(ctx context.Context, body PostWebhooksJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostWebhooksRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteWebhooksId(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteWebhooksIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetWebhooksId(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetWebhooksIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutWebhooksIdWithBody(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutWebhooksIdRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutWebhooksId(ctx context.Context, id int, body PutWebhooksIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutWebhooksIdRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetWebhooksIdDeliveries(ctx context.Context, id int, params *GetWebhooksIdDeliveriesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetWebhooksIdDeliveriesRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetAdminStatsRequest generates requests for GetAdminStats
+func NewGetAdminStatsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/stats")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAdminSwitchesRequest generates requests for GetAdminSwitches
+func NewGetAdminSwitchesRequest(server string, params *GetAdminSwitchesParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/switches")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.UserId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "userId", runtime.ParamLocationQuery, *params.UserId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAdminSwitchesIdDisableRequest calls the generic PostAdminSwitchesIdDisable builder with application/json body
+func NewPostAdminSwitchesIdDisableRequest(server string, id int, body PostAdminSwitchesIdDisableJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAdminSwitchesIdDisableRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPostAdminSwitchesIdDisableRequestWithBody generates requests for PostAdminSwitchesIdDisable with any type of body
+func NewPostAdminSwitchesIdDisableRequestWithBody(server string, id int, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/switches/%s/disable", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetAdminUsersRequest generates requests for GetAdminUsers
+func NewGetAdminUsersRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteAdminUsersUserIdQuotaRequest generates requests for DeleteAdminUsersUserIdQuota
+func NewDeleteAdminUsersUserIdQuotaRequest(server string, userId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users/%s/quota", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutAdminUsersUserIdQuotaRequest calls the generic PutAdminUsersUserIdQuota builder with application/json body
+func NewPutAdminUsersUserIdQuotaRequest(server string, userId string, body PutAdminUsersUserIdQuotaJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutAdminUsersUserIdQuotaRequestWithBody(server, userId, "application/json", bodyReader)
+}
+
+// NewPutAdminUsersUserIdQuotaRequestWithBody generates requests for PutAdminUsersUserIdQuota with any type of body
+func NewPutAdminUsersUserIdQuotaRequestWithBody(server string, userId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users/%s/quota", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPutAdminUsersUserIdRoleRequest calls the generic PutAdminUsersUserIdRole builder with application/json body
+func NewPutAdminUsersUserIdRoleRequest(server string, userId string, body PutAdminUsersUserIdRoleJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutAdminUsersUserIdRoleRequestWithBody(server, userId, "application/json", bodyReader)
+}
+
+// NewPutAdminUsersUserIdRoleRequestWithBody generates requests for PutAdminUsersUserIdRole with any type of body
+func NewPutAdminUsersUserIdRoleRequestWithBody(server string, userId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users/%s/role", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetAuthConfigRequest generates requests for GetAuthConfig
+func NewGetAuthConfigRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/config")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAuthLoginRequest calls the generic PostAuthLogin builder with application/json body
+func NewPostAuthLoginRequest(server string, body PostAuthLoginJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAuthLoginRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAuthLoginRequestWithBody generates requests for PostAuthLogin with any type of body
+func NewPostAuthLoginRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/login")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostAuthLoginPasskeyRequest calls the generic PostAuthLoginPasskey builder with application/json body
+func NewPostAuthLoginPasskeyRequest(server string, body PostAuthLoginPasskeyJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAuthLoginPasskeyRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAuthLoginPasskeyRequestWithBody generates requests for PostAuthLoginPasskey with any type of body
+func NewPostAuthLoginPasskeyRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/login/passkey")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostAuthLoginPasskeyChallengeRequest generates requests for PostAuthLoginPasskeyChallenge
+func NewPostAuthLoginPasskeyChallengeRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/login/passkey/challenge")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAuthLogoutRequest generates requests for PostAuthLogout
+func NewPostAuthLogoutRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/logout")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAuthMeRequest generates requests for GetAuthMe
+func NewGetAuthMeRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/me")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAuthPasskeysRequest generates requests for GetAuthPasskeys
+func NewGetAuthPasskeysRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/passkeys")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAuthPasskeysRequest calls the generic PostAuthPasskeys builder with application/json body
+func NewPostAuthPasskeysRequest(server string, body PostAuthPasskeysJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAuthPasskeysRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAuthPasskeysRequestWithBody generates requests for PostAuthPasskeys with any type of body
+func NewPostAuthPasskeysRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/passkeys")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostAuthPasskeysChallengeRequest generates requests for PostAuthPasskeysChallenge
+func NewPostAuthPasskeysChallengeRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/passkeys/challenge")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAuthPasskeysConfirmChallengeRequest generates requests for PostAuthPasskeysConfirmChallenge
+func NewPostAuthPasskeysConfirmChallengeRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/passkeys/confirm/challenge")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteAuthPasskeysPasskeyIdRequest calls the generic DeleteAuthPasskeysPasskeyId builder with application/json body
+func NewDeleteAuthPasskeysPasskeyIdRequest(server string, passkeyId int, body DeleteAuthPasskeysPasskeyIdJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewDeleteAuthPasskeysPasskeyIdRequestWithBody(server, passkeyId, "application/json", bodyReader)
+}
+
+// NewDeleteAuthPasskeysPasskeyIdRequestWithBody generates requests for DeleteAuthPasskeysPasskeyId with any type of body
+func NewDeleteAuthPasskeysPasskeyIdRequestWithBody(server string, passkeyId int, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "passkeyId", runtime.ParamLocationPath, passkeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/passkeys/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostAuthPasswordRequest calls the generic PostAuthPassword builder with application/json body
+func NewPostAuthPasswordRequest(server string, body PostAuthPasswordJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAuthPasswordRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAuthPasswordRequestWithBody generates requests for PostAuthPassword with any type of body
+func NewPostAuthPasswordRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/password")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostAuthTotpRequest generates requests for PostAuthTotp
+func NewPostAuthTotpRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/totp")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAuthTotpDisableRequest calls the generic PostAuthTotpDisable builder with application/json body
+func NewPostAuthTotpDisableRequest(server string, body PostAuthTotpDisableJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAuthTotpDisableRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAuthTotpDisableRequestWithBody generates requests for PostAuthTotpDisable with any type of body
+func NewPostAuthTotpDisableRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/totp/disable")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostAuthTotpEnableRequest calls the generic PostAuthTotpEnable builder with application/json body
+func NewPostAuthTotpEnableRequest(server string, body PostAuthTotpEnableJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAuthTotpEnableRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAuthTotpEnableRequestWithBody generates requests for PostAuthTotpEnable with any type of body
+func NewPostAuthTotpEnableRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/totp/enable")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetChangesRequest generates requests for GetChanges
+func NewGetChangesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/changes")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostChangesIdApproveRequest generates requests for PostChangesIdApprove
+func NewPostChangesIdApproveRequest(server string, id int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/changes/%s/approve", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostChangesIdCancelRequest generates requests for PostChangesIdCancel
+func NewPostChangesIdCancelRequest(server string, id int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/changes/%s/cancel", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostCheckinRequest calls the generic PostCheckin builder with application/json body
+func NewPostCheckinRequest(server string, params *PostCheckinParams, body PostCheckinJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostCheckinRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPostCheckinRequestWithBody generates requests for PostCheckin with any type of body
+func NewPostCheckinRequestWithBody(server string, params *PostCheckinParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/checkin")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Label != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "label", runtime.ParamLocationQuery, *params.Label); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetContactTokenRequest generates requests for GetContactToken
+func NewGetContactTokenRequest(server string, token string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationPath, token)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/contact/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostContactTokenConfirmRequest generates requests for PostContactTokenConfirm
+func NewPostContactTokenConfirmRequest(server string, token string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationPath, token)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/contact/%s/confirm", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostContactTokenPostponeRequest calls the generic PostContactTokenPostpone builder with application/json body
+func NewPostContactTokenPostponeRequest(server string, token string, body PostContactTokenPostponeJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostContactTokenPostponeRequestWithBody(server, token, "application/json", bodyReader)
+}
+
+// NewPostContactTokenPostponeRequestWithBody generates requests for PostContactTokenPostpone with any type of body
+func NewPostContactTokenPostponeRequestWithBody(server string, token string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationPath, token)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/contact/%s/postpone", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetEventsRequest generates requests for GetEvents
+func NewGetEventsRequest(server string, params *GetEventsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/events")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.LastEventID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Last-Event-ID", runtime.ParamLocationHeader, *params.LastEventID)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Last-Event-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetHealthRequest generates requests for GetHealth
+func NewGetHealthRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/health")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostIntegrationsAlertmanagerTokenRequest calls the generic PostIntegrationsAlertmanagerToken builder with application/json body
+func NewPostIntegrationsAlertmanagerTokenRequest(server string, token string, body PostIntegrationsAlertmanagerTokenJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostIntegrationsAlertmanagerTokenRequestWithBody(server, token, "application/json", bodyReader)
+}
+
+// NewPostIntegrationsAlertmanagerTokenRequestWithBody generates requests for PostIntegrationsAlertmanagerToken with any type of body
+func NewPostIntegrationsAlertmanagerTokenRequestWithBody(server string, token string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationPath, token)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/integrations/alertmanager/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetSwitchRequest generates requests for GetSwitch
+func NewGetSwitchRequest(server string, params *GetSwitchParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/switch")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostSwitchRequest calls the generic PostSwitch builder with application/json body
+func NewPostSwitchRequest(server string, body PostSwitchJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostSwitchRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostSwitchRequestWithBody generates requests for PostSwitch with any type of body
+func NewPostSwitchRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/switch")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostSwitchPauseRequest calls the generic PostSwitchPause builder with application/json body
+func NewPostSwitchPauseRequest(server string, body PostSwitchPauseJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostSwitchPauseRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostSwitchPauseRequestWithBody generates requests for PostSwitchPause with any type of body
+func NewPostSwitchPauseRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/switch/pause")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostSwitchResumeRequest generates requests for PostSwitchResume
+func NewPostSwitchResumeRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/switch/resume")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteSwitchIdRequest generates requests for DeleteSwitchId
+func NewDeleteSwitchIdRequest(server string, id int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/switch/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSwitchIdRequest generates requests for GetSwitchId
+func NewGetSwitchIdRequest(server string, id int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/switch/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutSwitchIdRequest calls the generic PutSwitchId builder with application/json body
+func NewPutSwitchIdRequest(server string, id int, body PutSwitchIdJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutSwitchIdRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPutSwitchIdRequestWithBody generates requests for PutSwitchId with any type of body
+func NewPutSwitchIdRequestWithBody(server string, id int, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/switch/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetSwitchIdAuditRequest generates requests for GetSwitchIdAudit
+func NewGetSwitchIdAuditRequest(server string, id int, params *GetSwitchIdAuditParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/switch/%s/audit", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSwitchIdCheckinsRequest generates requests for GetSwitchIdCheckins
+func NewGetSwitchIdCheckinsRequest(server string, id int, params *GetSwitchIdCheckinsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/switch/%s/checkins", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSwitchIdDeliveriesRequest generates requests for GetSwitchIdDeliveries
+func NewGetSwitchIdDeliveriesRequest(server string, id int, params *GetSwitchIdDeliveriesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/switch/%s/deliveries", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostSwitchIdDisableRequest generates requests for PostSwitchIdDisable
+func NewPostSwitchIdDisableRequest(server string, id int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/switch/%s/disable", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostSwitchIdPauseRequest calls the generic PostSwitchIdPause builder with application/json body
+func NewPostSwitchIdPauseRequest(server string, id int, body PostSwitchIdPauseJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostSwitchIdPauseRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPostSwitchIdPauseRequestWithBody generates requests for PostSwitchIdPause with any type of body
+func NewPostSwitchIdPauseRequestWithBody(server string, id int, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/switch/%s/pause", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostSwitchIdResetRequest calls the generic PostSwitchIdReset builder with application/json body
+func NewPostSwitchIdResetRequest(server string, id int, body PostSwitchIdResetJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostSwitchIdResetRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPostSwitchIdResetRequestWithBody generates requests for PostSwitchIdReset with any type of body
+func NewPostSwitchIdResetRequestWithBody(server string, id int, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/switch/%s/reset", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostSwitchIdResetChallengeRequest generates requests for PostSwitchIdResetChallenge
+func NewPostSwitchIdResetChallengeRequest(server string, id int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/switch/%s/reset/challenge", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostSwitchIdResumeRequest generates requests for PostSwitchIdResume
+func NewPostSwitchIdResumeRequest(server string, id int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/switch/%s/resume", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTokensRequest generates requests for GetTokens
+func NewGetTokensRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tokens")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostTokensRequest calls the generic PostTokens builder with application/json body
+func NewPostTokensRequest(server string, body PostTokensJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostTokensRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostTokensRequestWithBody generates requests for PostTokens with any type of body
+func NewPostTokensRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tokens")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteTokensIdRequest generates requests for DeleteTokensId
+func NewDeleteTokensIdRequest(server string, id int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tokens/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTokensIdRequest generates requests for GetTokensId
+func NewGetTokensIdRequest(server string, id int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tokens/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutTokensIdRequest calls the generic PutTokensId builder with application/json body
+func NewPutTokensIdRequest(server string, id int, body PutTokensIdJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutTokensIdRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPutTokensIdRequestWithBody generates requests for PutTokensId with any type of body
+func NewPutTokensIdRequestWithBody(server string, id int, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tokens/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetVapidRequest generates requests for GetVapid
+func NewGetVapidRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/vapid")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetWebhooksRequest generates requests for GetWebhooks
+func NewGetWebhooksRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostWebhooksRequest calls the generic PostWebhooks builder with application/json body
+func NewPostWebhooksRequest(server string, body PostWebhooksJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostWebhooksRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostWebhooksRequestWithBody generates requests for PostWebhooks with any type of body
+func NewPostWebhooksRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteWebhooksIdRequest generates requests for DeleteWebhooksId
+func NewDeleteWebhooksIdRequest(server string, id int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetWebhooksIdRequest generates requests for GetWebhooksId
+func NewGetWebhooksIdRequest(server string, id int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutWebhooksIdRequest calls the generic PutWebhooksId builder with application/json body
+func NewPutWebhooksIdRequest(server string, id int, body PutWebhooksIdJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutWebhooksIdRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPutWebhooksIdRequestWithBody generates requests for PutWebhooksId with any type of body
+func NewPutWebhooksIdRequestWithBody(server string, id int, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetWebhooksIdDeliveriesRequest generates requests for GetWebhooksIdDeliveries
+func NewGetWebhooksIdDeliveriesRequest(server string, id int, params *GetWebhooksIdDeliveriesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks/%s/deliveries", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetAdminStatsWithResponse request
+	GetAdminStatsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminStatsResponse, error)
+
+	// GetAdminSwitchesWithResponse request
+	GetAdminSwitchesWithResponse(ctx context.Context, params *GetAdminSwitchesParams, reqEditors ...RequestEditorFn) (*GetAdminSwitchesResponse, error)
+
+	// PostAdminSwitchesIdDisableWithBodyWithResponse request with any body
+	PostAdminSwitchesIdDisableWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAdminSwitchesIdDisableResponse, error)
+
+	PostAdminSwitchesIdDisableWithResponse(ctx context.Context, id int, body PostAdminSwitchesIdDisableJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAdminSwitchesIdDisableResponse, error)
+
+	// GetAdminUsersWithResponse request
+	GetAdminUsersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminUsersResponse, error)
+
+	// DeleteAdminUsersUserIdQuotaWithResponse request
+	DeleteAdminUsersUserIdQuotaWithResponse(ctx context.Context, userId string, reqEditors ...RequestEditorFn) (*DeleteAdminUsersUserIdQuotaResponse, error)
+
+	// PutAdminUsersUserIdQuotaWithBodyWithResponse request with any body
+	PutAdminUsersUserIdQuotaWithBodyWithResponse(ctx context.Context, userId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutAdminUsersUserIdQuotaResponse, error)
+
+	PutAdminUsersUserIdQuotaWithResponse(ctx context.Context, userId string, body PutAdminUsersUserIdQuotaJSONRequestBody, reqEditors ...RequestEditorFn) (*PutAdminUsersUserIdQuotaResponse, error)
+
+	// PutAdminUsersUserIdRoleWithBodyWithResponse request with any body
+	PutAdminUsersUserIdRoleWithBodyWithResponse(ctx context.Context, userId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutAdminUsersUserIdRoleResponse, error)
+
+	PutAdminUsersUserIdRoleWithResponse(ctx context.Context, userId string, body PutAdminUsersUserIdRoleJSONRequestBody, reqEditors ...RequestEditorFn) (*PutAdminUsersUserIdRoleResponse, error)
+
+	// GetAuthConfigWithResponse request
+	GetAuthConfigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAuthConfigResponse, error)
+
+	// PostAuthLoginWithBodyWithResponse request with any body
+	PostAuthLoginWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthLoginResponse, error)
+
+	PostAuthLoginWithResponse(ctx context.Context, body PostAuthLoginJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthLoginResponse, error)
+
+	// PostAuthLoginPasskeyWithBodyWithResponse request with any body
+	PostAuthLoginPasskeyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthLoginPasskeyResponse, error)
+
+	PostAuthLoginPasskeyWithResponse(ctx context.Context, body PostAuthLoginPasskeyJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthLoginPasskeyResponse, error)
+
+	// PostAuthLoginPasskeyChallengeWithResponse request
+	PostAuthLoginPasskeyChallengeWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAuthLoginPasskeyChallengeResponse, error)
+
+	// PostAuthLogoutWithResponse request
+	PostAuthLogoutWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAuthLogoutResponse, error)
+
+	// GetAuthMeWithResponse request
+	GetAuthMeWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAuthMeResponse, error)
+
+	// GetAuthPasskeysWithResponse request
+	GetAuthPasskeysWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAuthPasskeysResponse, error)
+
+	// PostAuthPasskeysWithBodyWithResponse request with any body
+	PostAuthPasskeysWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthPasskeysResponse, error)
+
+	PostAuthPasskeysWithResponse(ctx context.Context, body PostAuthPasskeysJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthPasskeysResponse, error)
+
+	// PostAuthPasskeysChallengeWithResponse request
+	PostAuthPasskeysChallengeWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAuthPasskeysChallengeResponse, error)
+
+	// PostAuthPasskeysConfirmChallengeWithResponse request
+	PostAuthPasskeysConfirmChallengeWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAuthPasskeysConfirmChallengeResponse, error)
+
+	// DeleteAuthPasskeysPasskeyIdWithBodyWithResponse request with any body
+	DeleteAuthPasskeysPasskeyIdWithBodyWithResponse(ctx context.Context, passkeyId int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DeleteAuthPasskeysPasskeyIdResponse, error)
+
+	DeleteAuthPasskeysPasskeyIdWithResponse(ctx context.Context, passkeyId int, body DeleteAuthPasskeysPasskeyIdJSONRequestBody, reqEditors ...RequestEditorFn) (*DeleteAuthPasskeysPasskeyIdResponse, error)
+
+	// PostAuthPasswordWithBodyWithResponse request with any body
+	PostAuthPasswordWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthPasswordResponse, error)
+
+	PostAuthPasswordWithResponse(ctx context.Context, body PostAuthPasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthPasswordResponse, error)
+
+	// PostAuthTotpWithResponse request
+	PostAuthTotpWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAuthTotpResponse, error)
+
+	// PostAuthTotpDisableWithBodyWithResponse request with any body
+	PostAuthTotpDisableWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthTotpDisableResponse, error)
+
+	PostAuthTotpDisableWithResponse(ctx context.Context, body PostAuthTotpDisableJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthTotpDisableResponse, error)
+
+	// PostAuthTotpEnableWithBodyWithResponse request with any body
+	PostAuthTotpEnableWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthTotpEnableResponse, error)
+
+	PostAuthTotpEnableWithResponse(ctx context.Context, body PostAuthTotpEnableJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthTotpEnableResponse, error)
+
+	// GetChangesWithResponse request
+	GetChangesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetChangesResponse, error)
+
+	// PostChangesIdApproveWithResponse request
+	PostChangesIdApproveWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*PostChangesIdApproveResponse, error)
+
+	// PostChangesIdCancelWithResponse request
+	PostChangesIdCancelWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*PostChangesIdCancelResponse, error)
+
+	// PostCheckinWithBodyWithResponse request with any body
+	PostCheckinWithBodyWithResponse(ctx context.Context, params *PostCheckinParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostCheckinResponse, error)
+
+	PostCheckinWithResponse(ctx context.Context, params *PostCheckinParams, body PostCheckinJSONRequestBody, reqEditors ...RequestEditorFn) (*PostCheckinResponse, error)
+
+	// GetContactTokenWithResponse request
+	GetContactTokenWithResponse(ctx context.Context, token string, reqEditors ...RequestEditorFn) (*GetContactTokenResponse, error)
+
+	// PostContactTokenConfirmWithResponse request
+	PostContactTokenConfirmWithResponse(ctx context.Context, token string, reqEditors ...RequestEditorFn) (*PostContactTokenConfirmResponse, error)
+
+	// PostContactTokenPostponeWithBodyWithResponse request with any body
+	PostContactTokenPostponeWithBodyWithResponse(ctx context.Context, token string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostContactTokenPostponeResponse, error)
+
+	PostContactTokenPostponeWithResponse(ctx context.Context, token string, body PostContactTokenPostponeJSONRequestBody, reqEditors ...RequestEditorFn) (*PostContactTokenPostponeResponse, error)
+
+	// GetEventsWithResponse request
+	GetEventsWithResponse(ctx context.Context, params *GetEventsParams, reqEditors ...RequestEditorFn) (*GetEventsResponse, error)
+
+	// GetHealthWithResponse request
+	GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error)
+
+	// PostIntegrationsAlertmanagerTokenWithBodyWithResponse request with any body
+	PostIntegrationsAlertmanagerTokenWithBodyWithResponse(ctx context.Context, token string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostIntegrationsAlertmanagerTokenResponse, error)
+
+	PostIntegrationsAlertmanagerTokenWithResponse(ctx context.Context, token string, body PostIntegrationsAlertmanagerTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*PostIntegrationsAlertmanagerTokenResponse, error)
+
+	// GetSwitchWithResponse request
+	GetSwitchWithResponse(ctx context.Context, params *GetSwitchParams, reqEditors ...RequestEditorFn) (*GetSwitchResponse, error)
+
+	// PostSwitchWithBodyWithResponse request with any body
+	PostSwitchWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSwitchResponse, error)
+
+	PostSwitchWithResponse(ctx context.Context, body PostSwitchJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSwitchResponse, error)
+
+	// PostSwitchPauseWithBodyWithResponse request with any body
+	PostSwitchPauseWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSwitchPauseResponse, error)
+
+	PostSwitchPauseWithResponse(ctx context.Context, body PostSwitchPauseJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSwitchPauseResponse, error)
+
+	// PostSwitchResumeWithResponse request
+	PostSwitchResumeWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostSwitchResumeResponse, error)
+
+	// DeleteSwitchIdWithResponse request
+	DeleteSwitchIdWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*DeleteSwitchIdResponse, error)
+
+	// GetSwitchIdWithResponse request
+	GetSwitchIdWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*GetSwitchIdResponse, error)
+
+	// PutSwitchIdWithBodyWithResponse request with any body
+	PutSwitchIdWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutSwitchIdResponse, error)
+
+	PutSwitchIdWithResponse(ctx context.Context, id int, body PutSwitchIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutSwitchIdResponse, error)
+
+	// GetSwitchIdAuditWithResponse request
+	GetSwitchIdAuditWithResponse(ctx context.Context, id int, params *GetSwitchIdAuditParams, reqEditors ...RequestEditorFn) (*GetSwitchIdAuditResponse, error)
+
+	// GetSwitchIdCheckinsWithResponse request
+	GetSwitchIdCheckinsWithResponse(ctx context.Context, id int, params *GetSwitchIdCheckinsParams, reqEditors ...RequestEditorFn) (*GetSwitchIdCheckinsResponse, error)
+
+	// GetSwitchIdDeliveriesWithResponse request
+	GetSwitchIdDeliveriesWithResponse(ctx context.Context, id int, params *GetSwitchIdDeliveriesParams, reqEditors ...RequestEditorFn) (*GetSwitchIdDeliveriesResponse, error)
+
+	// PostSwitchIdDisableWithResponse request
+	PostSwitchIdDisableWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*PostSwitchIdDisableResponse, error)
+
+	// PostSwitchIdPauseWithBodyWithResponse request with any body
+	PostSwitchIdPauseWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSwitchIdPauseResponse, error)
+
+	PostSwitchIdPauseWithResponse(ctx context.Context, id int, body PostSwitchIdPauseJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSwitchIdPauseResponse, error)
+
+	// PostSwitchIdResetWithBodyWithResponse request with any body
+	PostSwitchIdResetWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSwitchIdResetResponse, error)
+
+	PostSwitchIdResetWithResponse(ctx context.Context, id int, body PostSwitchIdResetJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSwitchIdResetResponse, error)
+
+	// PostSwitchIdResetChallengeWithResponse request
+	PostSwitchIdResetChallengeWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*PostSwitchIdResetChallengeResponse, error)
+
+	// PostSwitchIdResumeWithResponse request
+	PostSwitchIdResumeWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*PostSwitchIdResumeResponse, error)
+
+	// GetTokensWithResponse request
+	GetTokensWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetTokensResponse, error)
+
+	// PostTokensWithBodyWithResponse request with any body
+	PostTokensWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTokensResponse, error)
+
+	PostTokensWithResponse(ctx context.Context, body PostTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTokensResponse, error)
+
+	// DeleteTokensIdWithResponse request
+	DeleteTokensIdWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*DeleteTokensIdResponse, error)
+
+	// GetTokensIdWithResponse request
+	GetTokensIdWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*GetTokensIdResponse, error)
+
+	// PutTokensIdWithBodyWithResponse request with any body
+	PutTokensIdWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTokensIdResponse, error)
+
+	PutTokensIdWithResponse(ctx context.Context, id int, body PutTokensIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTokensIdResponse, error)
+
+	// GetVapidWithResponse request
+	GetVapidWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetVapidResponse, error)
+
+	// GetWebhooksWithResponse request
+	GetWebhooksWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetWebhooksResponse, error)
+
+	// PostWebhooksWithBodyWithResponse request with any body
+	PostWebhooksWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostWebhooksResponse, error)
+
+	PostWebhooksWithResponse(ctx context.Context, body PostWebhooksJSONRequestBody, reqEditors ...RequestEditorFn) (*PostWebhooksResponse, error)
+
+	// DeleteWebhooksIdWithResponse request
+	DeleteWebhooksIdWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*DeleteWebhooksIdResponse, error)
+
+	// GetWebhooksIdWithResponse request
+	GetWebhooksIdWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*GetWebhooksIdResponse, error)
+
+	// PutWebhooksIdWithBodyWithResponse request with any body
+	PutWebhooksIdWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutWebhooksIdResponse, error)
+
+	PutWebhooksIdWithResponse(ctx context.Context, id int, body PutWebhooksIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutWebhooksIdResponse, error)
+
+	// GetWebhooksIdDeliveriesWithResponse request
+	GetWebhooksIdDeliveriesWithResponse(ctx context.Context, id int, params *GetWebhooksIdDeliveriesParams, reqEditors ...RequestEditorFn) (*GetWebhooksIdDeliveriesResponse, error)
+}
+
+type GetAdminStatsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AdminStats
+	JSON401      *Error
+	JSON403      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminStatsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminStatsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminSwitchesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]AdminSwitch
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminSwitchesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminSwitchesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAdminSwitchesIdDisableResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AdminSwitch
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAdminSwitchesIdDisableResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAdminSwitchesIdDisableResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAdminUsersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]AdminUser
+	JSON401      *Error
+	JSON403      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminUsersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminUsersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteAdminUsersUserIdQuotaResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AdminUser
+	JSON401      *Error
+	JSON403      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteAdminUsersUserIdQuotaResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteAdminUsersUserIdQuotaResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutAdminUsersUserIdQuotaResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AdminUser
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PutAdminUsersUserIdQuotaResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutAdminUsersUserIdQuotaResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutAdminUsersUserIdRoleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AdminUser
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PutAdminUsersUserIdRoleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutAdminUsersUserIdRoleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAuthConfigResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AuthConfig
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAuthConfigResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAuthConfigResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthLoginResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Session
+	JSON400      *Error
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthLoginResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthLoginResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthLoginPasskeyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Session
+	JSON400      *Error
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthLoginPasskeyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthLoginPasskeyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthLoginPasskeyChallengeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PasskeyOptions
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthLoginPasskeyChallengeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthLoginPasskeyChallengeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthLogoutResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthLogoutResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthLogoutResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAuthMeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *User
+	JSON401      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAuthMeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAuthMeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAuthPasskeysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Passkey
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAuthPasskeysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAuthPasskeysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthPasskeysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Passkey
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthPasskeysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthPasskeysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthPasskeysChallengeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PasskeyOptions
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthPasskeysChallengeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthPasskeysChallengeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthPasskeysConfirmChallengeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PasskeyOptions
+	JSON400      *Error
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthPasskeysConfirmChallengeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthPasskeysConfirmChallengeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteAuthPasskeysPasskeyIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteAuthPasskeysPasskeyIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteAuthPasskeysPasskeyIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthPasswordResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthPasswordResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthPasswordResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthTotpResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TOTPSetup
+	JSON401      *Error
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthTotpResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthTotpResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthTotpDisableResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthTotpDisableResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthTotpDisableResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthTotpEnableResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthTotpEnableResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthTotpEnableResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetChangesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]PendingChange
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetChangesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetChangesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostChangesIdApproveResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PendingChange
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostChangesIdApproveResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostChangesIdApproveResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostChangesIdCancelResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PendingChange
+	JSON401      *Error
+	JSON404      *Error
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostChangesIdCancelResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostChangesIdCancelResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostCheckinResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BulkCheckInResponse
+	JSON400      *Error
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostCheckinResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostCheckinResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetContactTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ContactVerification
+	JSON404      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetContactTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetContactTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostContactTokenConfirmResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ContactVerification
+	JSON404      *Error
+	JSON409      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostContactTokenConfirmResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostContactTokenConfirmResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostContactTokenPostponeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ContactVerification
+	JSON400      *Error
+	JSON404      *Error
+	JSON409      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostContactTokenPostponeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostContactTokenPostponeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetEventsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEventsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEventsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetHealthResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Health
+	JSON503      *Health
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHealthResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHealthResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostIntegrationsAlertmanagerTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AlertmanagerResult
+	JSON400      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostIntegrationsAlertmanagerTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostIntegrationsAlertmanagerTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSwitchResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Switch
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSwitchResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSwitchResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostSwitchResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Switch
+	JSON400      *Error
+	JSON401      *Error
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostSwitchResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostSwitchResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostSwitchPauseResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Switch
+	JSON400      *Error
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostSwitchPauseResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostSwitchPauseResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostSwitchResumeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Switch
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostSwitchResumeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostSwitchResumeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteSwitchIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *PendingChange
+	JSON401      *Error
+	JSON404      *Error
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteSwitchIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteSwitchIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSwitchIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Switch
+	JSON401      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSwitchIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSwitchIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutSwitchIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Switch
+	JSON202      *PendingChange
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PutSwitchIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutSwitchIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSwitchIdAuditResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]AuditEvent
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSwitchIdAuditResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSwitchIdAuditResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSwitchIdCheckinsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]CheckIn
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSwitchIdCheckinsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSwitchIdCheckinsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSwitchIdDeliveriesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Delivery
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSwitchIdDeliveriesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSwitchIdDeliveriesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostSwitchIdDisableResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Switch
+	JSON202      *PendingChange
+	JSON401      *Error
+	JSON404      *Error
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostSwitchIdDisableResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostSwitchIdDisableResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostSwitchIdPauseResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Switch
+	JSON202      *PendingChange
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostSwitchIdPauseResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostSwitchIdPauseResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostSwitchIdResetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Switch
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostSwitchIdResetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostSwitchIdResetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostSwitchIdResetChallengeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PasskeyOptions
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostSwitchIdResetChallengeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostSwitchIdResetChallengeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostSwitchIdResumeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Switch
+	JSON401      *Error
+	JSON404      *Error
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostSwitchIdResumeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostSwitchIdResumeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTokensResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]APIToken
+	JSON401      *Error
+	JSON403      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTokensResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTokensResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostTokensResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *APIToken
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostTokensResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostTokensResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteTokensIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteTokensIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteTokensIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTokensIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *APIToken
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTokensIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTokensIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutTokensIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *APIToken
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PutTokensIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutTokensIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetVapidResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetVapidResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetVapidResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetWebhooksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]WebhookSubscription
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetWebhooksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetWebhooksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostWebhooksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *WebhookSubscription
+	JSON400      *Error
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostWebhooksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostWebhooksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteWebhooksIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteWebhooksIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteWebhooksIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetWebhooksIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WebhookSubscription
+	JSON401      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetWebhooksIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetWebhooksIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutWebhooksIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WebhookSubscription
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PutWebhooksIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutWebhooksIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetWebhooksIdDeliveriesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Delivery
+	JSON400      *Error
+	JSON401      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetWebhooksIdDeliveriesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetWebhooksIdDeliveriesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetAdminStatsWithResponse request returning *GetAdminStatsResponse
+func (c *ClientWithResponses) GetAdminStatsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminStatsResponse, error) {
+	rsp, err := c.GetAdminStats(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminStatsResponse(rsp)
+}
+
+// GetAdminSwitchesWithResponse request returning *GetAdminSwitchesResponse
+func (c *ClientWithResponses) GetAdminSwitchesWithResponse(ctx context.Context, params *GetAdminSwitchesParams, reqEditors ...RequestEditorFn) (*GetAdminSwitchesResponse, error) {
+	rsp, err := c.GetAdminSwitches(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminSwitchesResponse(rsp)
+}
+
+// PostAdminSwitchesIdDisableWithBodyWithResponse request with arbitrary body returning *PostAdminSwitchesIdDisableResponse
+func (c *ClientWithResponses) PostAdminSwitchesIdDisableWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAdminSwitchesIdDisableResponse, error) {
+	rsp, err := c.PostAdminSwitchesIdDisableWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminSwitchesIdDisableResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAdminSwitchesIdDisableWithResponse(ctx context.Context, id int, body PostAdminSwitchesIdDisableJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAdminSwitchesIdDisableResponse, error) {
+	rsp, err := c.PostAdminSwitchesIdDisable(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAdminSwitchesIdDisableResponse(rsp)
+}
+
+// GetAdminUsersWithResponse request returning *GetAdminUsersResponse
+func (c *ClientWithResponses) GetAdminUsersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAdminUsersResponse, error) {
+	rsp, err := c.GetAdminUsers(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminUsersResponse(rsp)
+}
+
+// DeleteAdminUsersUserIdQuotaWithResponse request returning *DeleteAdminUsersUserIdQuotaResponse
+func (c *ClientWithResponses) DeleteAdminUsersUserIdQuotaWithResponse(ctx context.Context, userId string, reqEditors ...RequestEditorFn) (*DeleteAdminUsersUserIdQuotaResponse, error) {
+	rsp, err := c.DeleteAdminUsersUserIdQuota(ctx, userId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteAdminUsersUserIdQuotaResponse(rsp)
+}
+
+// PutAdminUsersUserIdQuotaWithBodyWithResponse request with arbitrary body returning *PutAdminUsersUserIdQuotaResponse
+func (c *ClientWithResponses) PutAdminUsersUserIdQuotaWithBodyWithResponse(ctx context.Context, userId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutAdminUsersUserIdQuotaResponse, error) {
+	rsp, err := c.PutAdminUsersUserIdQuotaWithBody(ctx, userId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutAdminUsersUserIdQuotaResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutAdminUsersUserIdQuotaWithResponse(ctx context.Context, userId string, body PutAdminUsersUserIdQuotaJSONRequestBody, reqEditors ...RequestEditorFn) (*PutAdminUsersUserIdQuotaResponse, error) {
+	rsp, err := c.PutAdminUsersUserIdQuota(ctx, userId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutAdminUsersUserIdQuotaResponse(rsp)
+}
+
+// PutAdminUsersUserIdRoleWithBodyWithResponse request with arbitrary body returning *PutAdminUsersUserIdRoleResponse
+func (c *ClientWithResponses) PutAdminUsersUserIdRoleWithBodyWithResponse(ctx context.Context, userId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutAdminUsersUserIdRoleResponse, error) {
+	rsp, err := c.PutAdminUsersUserIdRoleWithBody(ctx, userId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutAdminUsersUserIdRoleResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutAdminUsersUserIdRoleWithResponse(ctx context.Context, userId string, body PutAdminUsersUserIdRoleJSONRequestBody, reqEditors ...RequestEditorFn) (*PutAdminUsersUserIdRoleResponse, error) {
+	rsp, err := c.PutAdminUsersUserIdRole(ctx, userId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutAdminUsersUserIdRoleResponse(rsp)
+}
+
+// GetAuthConfigWithResponse request returning *GetAuthConfigResponse
+func (c *ClientWithResponses) GetAuthConfigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAuthConfigResponse, error) {
+	rsp, err := c.GetAuthConfig(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAuthConfigResponse(rsp)
+}
+
+// PostAuthLoginWithBodyWithResponse request with arbitrary body returning *PostAuthLoginResponse
+func (c *ClientWithResponses) PostAuthLoginWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthLoginResponse, error) {
+	rsp, err := c.PostAuthLoginWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthLoginResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAuthLoginWithResponse(ctx context.Context, body PostAuthLoginJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthLoginResponse, error) {
+	rsp, err := c.PostAuthLogin(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthLoginResponse(rsp)
+}
+
+// PostAuthLoginPasskeyWithBodyWithResponse request with arbitrary body returning *PostAuthLoginPasskeyResponse
+func (c *ClientWithResponses) PostAuthLoginPasskeyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthLoginPasskeyResponse, error) {
+	rsp, err := c.PostAuthLoginPasskeyWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthLoginPasskeyResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAuthLoginPasskeyWithResponse(ctx context.Context, body PostAuthLoginPasskeyJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthLoginPasskeyResponse, error) {
+	rsp, err := c.PostAuthLoginPasskey(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthLoginPasskeyResponse(rsp)
+}
+
+// PostAuthLoginPasskeyChallengeWithResponse request returning *PostAuthLoginPasskeyChallengeResponse
+func (c *ClientWithResponses) PostAuthLoginPasskeyChallengeWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAuthLoginPasskeyChallengeResponse, error) {
+	rsp, err := c.PostAuthLoginPasskeyChallenge(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthLoginPasskeyChallengeResponse(rsp)
+}
+
+// PostAuthLogoutWithResponse request returning *PostAuthLogoutResponse
+func (c *ClientWithResponses) PostAuthLogoutWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAuthLogoutResponse, error) {
+	rsp, err := c.PostAuthLogout(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthLogoutResponse(rsp)
+}
+
+// GetAuthMeWithResponse request returning *GetAuthMeResponse
+func (c *ClientWithResponses) GetAuthMeWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAuthMeResponse, error) {
+	rsp, err := c.GetAuthMe(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAuthMeResponse(rsp)
+}
+
+// GetAuthPasskeysWithResponse request returning *GetAuthPasskeysResponse
+func (c *ClientWithResponses) GetAuthPasskeysWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAuthPasskeysResponse, error) {
+	rsp, err := c.GetAuthPasskeys(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAuthPasskeysResponse(rsp)
+}
+
+// PostAuthPasskeysWithBodyWithResponse request with arbitrary body returning *PostAuthPasskeysResponse
+func (c *ClientWithResponses) PostAuthPasskeysWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthPasskeysResponse, error) {
+	rsp, err := c.PostAuthPasskeysWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthPasskeysResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAuthPasskeysWithResponse(ctx context.Context, body PostAuthPasskeysJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthPasskeysResponse, error) {
+	rsp, err := c.PostAuthPasskeys(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthPasskeysResponse(rsp)
+}
+
+// PostAuthPasskeysChallengeWithResponse request returning *PostAuthPasskeysChallengeResponse
+func (c *ClientWithResponses) PostAuthPasskeysChallengeWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAuthPasskeysChallengeResponse, error) {
+	rsp, err := c.PostAuthPasskeysChallenge(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthPasskeysChallengeResponse(rsp)
+}
+
+// PostAuthPasskeysConfirmChallengeWithResponse request returning *PostAuthPasskeysConfirmChallengeResponse
+func (c *ClientWithResponses) PostAuthPasskeysConfirmChallengeWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAuthPasskeysConfirmChallengeResponse, error) {
+	rsp, err := c.PostAuthPasskeysConfirmChallenge(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthPasskeysConfirmChallengeResponse(rsp)
+}
+
+// DeleteAuthPasskeysPasskeyIdWithBodyWithResponse request with arbitrary body returning *DeleteAuthPasskeysPasskeyIdResponse
+func (c *ClientWithResponses) DeleteAuthPasskeysPasskeyIdWithBodyWithResponse(ctx context.Context, passkeyId int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DeleteAuthPasskeysPasskeyIdResponse, error) {
+	rsp, err := c.DeleteAuthPasskeysPasskeyIdWithBody(ctx, passkeyId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteAuthPasskeysPasskeyIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) DeleteAuthPasskeysPasskeyIdWithResponse(ctx context.Context, passkeyId int, body DeleteAuthPasskeysPasskeyIdJSONRequestBody, reqEditors ...RequestEditorFn) (*DeleteAuthPasskeysPasskeyIdResponse, error) {
+	rsp, err := c.DeleteAuthPasskeysPasskeyId(ctx, passkeyId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteAuthPasskeysPasskeyIdResponse(rsp)
+}
+
+// PostAuthPasswordWithBodyWithResponse request with arbitrary body returning *PostAuthPasswordResponse
+func (c *ClientWithResponses) PostAuthPasswordWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthPasswordResponse, error) {
+	rsp, err := c.PostAuthPasswordWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthPasswordResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAuthPasswordWithResponse(ctx context.Context, body PostAuthPasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthPasswordResponse, error) {
+	rsp, err := c.PostAuthPassword(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthPasswordResponse(rsp)
+}
+
+// PostAuthTotpWithResponse request returning *PostAuthTotpResponse
+func (c *ClientWithResponses) PostAuthTotpWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAuthTotpResponse, error) {
+	rsp, err := c.PostAuthTotp(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthTotpResponse(rsp)
+}
+
+// PostAuthTotpDisableWithBodyWithResponse request with arbitrary body returning *PostAuthTotpDisableResponse
+func (c *ClientWithResponses) PostAuthTotpDisableWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthTotpDisableResponse, error) {
+	rsp, err := c.PostAuthTotpDisableWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthTotpDisableResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAuthTotpDisableWithResponse(ctx context.Context, body PostAuthTotpDisableJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthTotpDisableResponse, error) {
+	rsp, err := c.PostAuthTotpDisable(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthTotpDisableResponse(rsp)
+}
+
+// PostAuthTotpEnableWithBodyWithResponse request with arbitrary body returning *PostAuthTotpEnableResponse
+func (c *ClientWithResponses) PostAuthTotpEnableWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthTotpEnableResponse, error) {
+	rsp, err := c.PostAuthTotpEnableWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthTotpEnableResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAuthTotpEnableWithResponse(ctx context.Context, body PostAuthTotpEnableJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthTotpEnableResponse, error) {
+	rsp, err := c.PostAuthTotpEnable(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthTotpEnableResponse(rsp)
+}
+
+// GetChangesWithResponse request returning *GetChangesResponse
+func (c *ClientWithResponses) GetChangesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetChangesResponse, error) {
+	rsp, err := c.GetChanges(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetChangesResponse(rsp)
+}
+
+// PostChangesIdApproveWithResponse request returning *PostChangesIdApproveResponse
+func (c *ClientWithResponses) PostChangesIdApproveWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*PostChangesIdApproveResponse, error) {
+	rsp, err := c.PostChangesIdApprove(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostChangesIdApproveResponse(rsp)
+}
+
+// PostChangesIdCancelWithResponse request returning *PostChangesIdCancelResponse
+func (c *ClientWithResponses) PostChangesIdCancelWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*PostChangesIdCancelResponse, error) {
+	rsp, err := c.PostChangesIdCancel(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostChangesIdCancelResponse(rsp)
+}
+
+// PostCheckinWithBodyWithResponse request with arbitrary body returning *PostCheckinResponse
+func (c *ClientWithResponses) PostCheckinWithBodyWithResponse(ctx context.Context, params *PostCheckinParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostCheckinResponse, error) {
+	rsp, err := c.PostCheckinWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostCheckinResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostCheckinWithResponse(ctx context.Context, params *PostCheckinParams, body PostCheckinJSONRequestBody, reqEditors ...RequestEditorFn) (*PostCheckinResponse, error) {
+	rsp, err := c.PostCheckin(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostCheckinResponse(rsp)
+}
+
+// GetContactTokenWithResponse request returning *GetContactTokenResponse
+func (c *ClientWithResponses) GetContactTokenWithResponse(ctx context.Context, token string, reqEditors ...RequestEditorFn) (*GetContactTokenResponse, error) {
+	rsp, err := c.GetContactToken(ctx, token, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetContactTokenResponse(rsp)
+}
+
+// PostContactTokenConfirmWithResponse request returning *PostContactTokenConfirmResponse
+func (c *ClientWithResponses) PostContactTokenConfirmWithResponse(ctx context.Context, token string, reqEditors ...RequestEditorFn) (*PostContactTokenConfirmResponse, error) {
+	rsp, err := c.PostContactTokenConfirm(ctx, token, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostContactTokenConfirmResponse(rsp)
+}
+
+// PostContactTokenPostponeWithBodyWithResponse request with arbitrary body returning *PostContactTokenPostponeResponse
+func (c *ClientWithResponses) PostContactTokenPostponeWithBodyWithResponse(ctx context.Context, token string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostContactTokenPostponeResponse, error) {
+	rsp, err := c.PostContactTokenPostponeWithBody(ctx, token, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostContactTokenPostponeResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostContactTokenPostponeWithResponse(ctx context.Context, token string, body PostContactTokenPostponeJSONRequestBody, reqEditors ...RequestEditorFn) (*PostContactTokenPostponeResponse, error) {
+	rsp, err := c.PostContactTokenPostpone(ctx, token, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostContactTokenPostponeResponse(rsp)
+}
+
+// GetEventsWithResponse request returning *GetEventsResponse
+func (c *ClientWithResponses) GetEventsWithResponse(ctx context.Context, params *GetEventsParams, reqEditors ...RequestEditorFn) (*GetEventsResponse, error) {
+	rsp, err := c.GetEvents(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEventsResponse(rsp)
+}
+
+// GetHealthWithResponse request returning *GetHealthResponse
+func (c *ClientWithResponses) GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error) {
+	rsp, err := c.GetHealth(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHealthResponse(rsp)
+}
+
+// PostIntegrationsAlertmanagerTokenWithBodyWithResponse request with arbitrary body returning *PostIntegrationsAlertmanagerTokenResponse
+func (c *ClientWithResponses) PostIntegrationsAlertmanagerTokenWithBodyWithResponse(ctx context.Context, token string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostIntegrationsAlertmanagerTokenResponse, error) {
+	rsp, err := c.PostIntegrationsAlertmanagerTokenWithBody(ctx, token, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostIntegrationsAlertmanagerTokenResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostIntegrationsAlertmanagerTokenWithResponse(ctx context.Context, token string, body PostIntegrationsAlertmanagerTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*PostIntegrationsAlertmanagerTokenResponse, error) {
+	rsp, err := c.PostIntegrationsAlertmanagerToken(ctx, token, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostIntegrationsAlertmanagerTokenResponse(rsp)
+}
+
+// GetSwitchWithResponse request returning *GetSwitchResponse
+func (c *ClientWithResponses) GetSwitchWithResponse(ctx context.Context, params *GetSwitchParams, reqEditors ...RequestEditorFn) (*GetSwitchResponse, error) {
+	rsp, err := c.GetSwitch(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSwitchResponse(rsp)
+}
+
+// PostSwitchWithBodyWithResponse request with arbitrary body returning *PostSwitchResponse
+func (c *ClientWithResponses) PostSwitchWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSwitchResponse, error) {
+	rsp, err := c.PostSwitchWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSwitchResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostSwitchWithResponse(ctx context.Context, body PostSwitchJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSwitchResponse, error) {
+	rsp, err := c.PostSwitch(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSwitchResponse(rsp)
+}
+
+// PostSwitchPauseWithBodyWithResponse request with arbitrary body returning *PostSwitchPauseResponse
+func (c *ClientWithResponses) PostSwitchPauseWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSwitchPauseResponse, error) {
+	rsp, err := c.PostSwitchPauseWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSwitchPauseResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostSwitchPauseWithResponse(ctx context.Context, body PostSwitchPauseJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSwitchPauseResponse, error) {
+	rsp, err := c.PostSwitchPause(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSwitchPauseResponse(rsp)
+}
+
+// PostSwitchResumeWithResponse request returning *PostSwitchResumeResponse
+func (c *ClientWithResponses) PostSwitchResumeWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostSwitchResumeResponse, error) {
+	rsp, err := c.PostSwitchResume(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSwitchResumeResponse(rsp)
+}
+
+// DeleteSwitchIdWithResponse request returning *DeleteSwitchIdResponse
+func (c *ClientWithResponses) DeleteSwitchIdWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*DeleteSwitchIdResponse, error) {
+	rsp, err := c.DeleteSwitchId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteSwitchIdResponse(rsp)
+}
+
+// GetSwitchIdWithResponse request returning *GetSwitchIdResponse
+func (c *ClientWithResponses) GetSwitchIdWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*GetSwitchIdResponse, error) {
+	rsp, err := c.GetSwitchId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSwitchIdResponse(rsp)
+}
+
+// PutSwitchIdWithBodyWithResponse request with arbitrary body returning *PutSwitchIdResponse
+func (c *ClientWithResponses) PutSwitchIdWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutSwitchIdResponse, error) {
+	rsp, err := c.PutSwitchIdWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutSwitchIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutSwitchIdWithResponse(ctx context.Context, id int, body PutSwitchIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutSwitchIdResponse, error) {
+	rsp, err := c.PutSwitchId(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutSwitchIdResponse(rsp)
+}
+
+// GetSwitchIdAuditWithResponse request returning *GetSwitchIdAuditResponse
+func (c *ClientWithResponses) GetSwitchIdAuditWithResponse(ctx context.Context, id int, params *GetSwitchIdAuditParams, reqEditors ...RequestEditorFn) (*GetSwitchIdAuditResponse, error) {
+	rsp, err := c.GetSwitchIdAudit(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSwitchIdAuditResponse(rsp)
+}
+
+// GetSwitchIdCheckinsWithResponse request returning *GetSwitchIdCheckinsResponse
+func (c *ClientWithResponses) GetSwitchIdCheckinsWithResponse(ctx context.Context, id int, params *GetSwitchIdCheckinsParams, reqEditors ...RequestEditorFn) (*GetSwitchIdCheckinsResponse, error) {
+	rsp, err := c.GetSwitchIdCheckins(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSwitchIdCheckinsResponse(rsp)
+}
+
+// GetSwitchIdDeliveriesWithResponse request returning *GetSwitchIdDeliveriesResponse
+func (c *ClientWithResponses) GetSwitchIdDeliveriesWithResponse(ctx context.Context, id int, params *GetSwitchIdDeliveriesParams, reqEditors ...RequestEditorFn) (*GetSwitchIdDeliveriesResponse, error) {
+	rsp, err := c.GetSwitchIdDeliveries(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSwitchIdDeliveriesResponse(rsp)
+}
+
+// PostSwitchIdDisableWithResponse request returning *PostSwitchIdDisableResponse
+func (c *ClientWithResponses) PostSwitchIdDisableWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*PostSwitchIdDisableResponse, error) {
+	rsp, err := c.PostSwitchIdDisable(ctx, id, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
 	return ParsePostSwitchIdDisableResponse(rsp)
 }
 
-// PostSwitchIdResetWithResponse request returning *PostSwitchIdResetResponse
-func (c *ClientWithResponses) PostSwitchIdResetWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*PostSwitchIdResetResponse, error) {
-	rsp, err := c.PostSwitchIdReset(ctx, id, reqEditors...)
+// PostSwitchIdPauseWithBodyWithResponse request with arbitrary body returning *PostSwitchIdPauseResponse
+func (c *ClientWithResponses) PostSwitchIdPauseWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSwitchIdPauseResponse, error) {
+	rsp, err := c.PostSwitchIdPauseWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSwitchIdPauseResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostSwitchIdPauseWithResponse(ctx context.Context, id int, body PostSwitchIdPauseJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSwitchIdPauseResponse, error) {
+	rsp, err := c.PostSwitchIdPause(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSwitchIdPauseResponse(rsp)
+}
+
+// PostSwitchIdResetWithBodyWithResponse request with arbitrary body returning *PostSwitchIdResetResponse
+func (c *ClientWithResponses) PostSwitchIdResetWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSwitchIdResetResponse, error) {
+	rsp, err := c.PostSwitchIdResetWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSwitchIdResetResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostSwitchIdResetWithResponse(ctx context.Context, id int, body PostSwitchIdResetJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSwitchIdResetResponse, error) {
+	rsp, err := c.PostSwitchIdReset(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSwitchIdResetResponse(rsp)
+}
+
+// PostSwitchIdResetChallengeWithResponse request returning *PostSwitchIdResetChallengeResponse
+func (c *ClientWithResponses) PostSwitchIdResetChallengeWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*PostSwitchIdResetChallengeResponse, error) {
+	rsp, err := c.PostSwitchIdResetChallenge(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSwitchIdResetChallengeResponse(rsp)
+}
+
+// PostSwitchIdResumeWithResponse request returning *PostSwitchIdResumeResponse
+func (c *ClientWithResponses) PostSwitchIdResumeWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*PostSwitchIdResumeResponse, error) {
+	rsp, err := c.PostSwitchIdResume(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSwitchIdResumeResponse(rsp)
+}
+
+// GetTokensWithResponse request returning *GetTokensResponse
+func (c *ClientWithResponses) GetTokensWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetTokensResponse, error) {
+	rsp, err := c.GetTokens(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTokensResponse(rsp)
+}
+
+// PostTokensWithBodyWithResponse request with arbitrary body returning *PostTokensResponse
+func (c *ClientWithResponses) PostTokensWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTokensResponse, error) {
+	rsp, err := c.PostTokensWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTokensResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostTokensWithResponse(ctx context.Context, body PostTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTokensResponse, error) {
+	rsp, err := c.PostTokens(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTokensResponse(rsp)
+}
+
+// DeleteTokensIdWithResponse request returning *DeleteTokensIdResponse
+func (c *ClientWithResponses) DeleteTokensIdWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*DeleteTokensIdResponse, error) {
+	rsp, err := c.DeleteTokensId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteTokensIdResponse(rsp)
+}
+
+// GetTokensIdWithResponse request returning *GetTokensIdResponse
+func (c *ClientWithResponses) GetTokensIdWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*GetTokensIdResponse, error) {
+	rsp, err := c.GetTokensId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTokensIdResponse(rsp)
+}
+
+// PutTokensIdWithBodyWithResponse request with arbitrary body returning *PutTokensIdResponse
+func (c *ClientWithResponses) PutTokensIdWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTokensIdResponse, error) {
+	rsp, err := c.PutTokensIdWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutTokensIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutTokensIdWithResponse(ctx context.Context, id int, body PutTokensIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTokensIdResponse, error) {
+	rsp, err := c.PutTokensId(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutTokensIdResponse(rsp)
+}
+
+// GetVapidWithResponse request returning *GetVapidResponse
+func (c *ClientWithResponses) GetVapidWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetVapidResponse, error) {
+	rsp, err := c.GetVapid(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetVapidResponse(rsp)
+}
+
+// GetWebhooksWithResponse request returning *GetWebhooksResponse
+func (c *ClientWithResponses) GetWebhooksWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetWebhooksResponse, error) {
+	rsp, err := c.GetWebhooks(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetWebhooksResponse(rsp)
+}
+
+// PostWebhooksWithBodyWithResponse request with arbitrary body returning *PostWebhooksResponse
+func (c *ClientWithResponses) PostWebhooksWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostWebhooksResponse, error) {
+	rsp, err := c.PostWebhooksWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostWebhooksResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostWebhooksWithResponse(ctx context.Context, body PostWebhooksJSONRequestBody, reqEditors ...RequestEditorFn) (*PostWebhooksResponse, error) {
+	rsp, err := c.PostWebhooks(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostWebhooksResponse(rsp)
+}
+
+// DeleteWebhooksIdWithResponse request returning *DeleteWebhooksIdResponse
+func (c *ClientWithResponses) DeleteWebhooksIdWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*DeleteWebhooksIdResponse, error) {
+	rsp, err := c.DeleteWebhooksId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteWebhooksIdResponse(rsp)
+}
+
+// GetWebhooksIdWithResponse request returning *GetWebhooksIdResponse
+func (c *ClientWithResponses) GetWebhooksIdWithResponse(ctx context.Context, id int, reqEditors ...RequestEditorFn) (*GetWebhooksIdResponse, error) {
+	rsp, err := c.GetWebhooksId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetWebhooksIdResponse(rsp)
+}
+
+// PutWebhooksIdWithBodyWithResponse request with arbitrary body returning *PutWebhooksIdResponse
+func (c *ClientWithResponses) PutWebhooksIdWithBodyWithResponse(ctx context.Context, id int, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutWebhooksIdResponse, error) {
+	rsp, err := c.PutWebhooksIdWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutWebhooksIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutWebhooksIdWithResponse(ctx context.Context, id int, body PutWebhooksIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutWebhooksIdResponse, error) {
+	rsp, err := c.PutWebhooksId(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutWebhooksIdResponse(rsp)
+}
+
+// GetWebhooksIdDeliveriesWithResponse request returning *GetWebhooksIdDeliveriesResponse
+func (c *ClientWithResponses) GetWebhooksIdDeliveriesWithResponse(ctx context.Context, id int, params *GetWebhooksIdDeliveriesParams, reqEditors ...RequestEditorFn) (*GetWebhooksIdDeliveriesResponse, error) {
+	rsp, err := c.GetWebhooksIdDeliveries(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetWebhooksIdDeliveriesResponse(rsp)
+}
+
+// ParseGetAdminStatsResponse parses an HTTP response from a GetAdminStatsWithResponse call
+func ParseGetAdminStatsResponse(rsp *http.Response) (*GetAdminStatsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminStatsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AdminStats
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminSwitchesResponse parses an HTTP response from a GetAdminSwitchesWithResponse call
+func ParseGetAdminSwitchesResponse(rsp *http.Response) (*GetAdminSwitchesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminSwitchesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []AdminSwitch
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAdminSwitchesIdDisableResponse parses an HTTP response from a PostAdminSwitchesIdDisableWithResponse call
+func ParsePostAdminSwitchesIdDisableResponse(rsp *http.Response) (*PostAdminSwitchesIdDisableResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAdminSwitchesIdDisableResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AdminSwitch
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAdminUsersResponse parses an HTTP response from a GetAdminUsersWithResponse call
+func ParseGetAdminUsersResponse(rsp *http.Response) (*GetAdminUsersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminUsersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []AdminUser
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteAdminUsersUserIdQuotaResponse parses an HTTP response from a DeleteAdminUsersUserIdQuotaWithResponse call
+func ParseDeleteAdminUsersUserIdQuotaResponse(rsp *http.Response) (*DeleteAdminUsersUserIdQuotaResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteAdminUsersUserIdQuotaResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AdminUser
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutAdminUsersUserIdQuotaResponse parses an HTTP response from a PutAdminUsersUserIdQuotaWithResponse call
+func ParsePutAdminUsersUserIdQuotaResponse(rsp *http.Response) (*PutAdminUsersUserIdQuotaResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutAdminUsersUserIdQuotaResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AdminUser
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutAdminUsersUserIdRoleResponse parses an HTTP response from a PutAdminUsersUserIdRoleWithResponse call
+func ParsePutAdminUsersUserIdRoleResponse(rsp *http.Response) (*PutAdminUsersUserIdRoleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutAdminUsersUserIdRoleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AdminUser
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAuthConfigResponse parses an HTTP response from a GetAuthConfigWithResponse call
+func ParseGetAuthConfigResponse(rsp *http.Response) (*GetAuthConfigResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAuthConfigResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AuthConfig
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthLoginResponse parses an HTTP response from a PostAuthLoginWithResponse call
+func ParsePostAuthLoginResponse(rsp *http.Response) (*PostAuthLoginResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthLoginResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Session
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthLoginPasskeyResponse parses an HTTP response from a PostAuthLoginPasskeyWithResponse call
+func ParsePostAuthLoginPasskeyResponse(rsp *http.Response) (*PostAuthLoginPasskeyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthLoginPasskeyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Session
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthLoginPasskeyChallengeResponse parses an HTTP response from a PostAuthLoginPasskeyChallengeWithResponse call
+func ParsePostAuthLoginPasskeyChallengeResponse(rsp *http.Response) (*PostAuthLoginPasskeyChallengeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthLoginPasskeyChallengeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PasskeyOptions
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthLogoutResponse parses an HTTP response from a PostAuthLogoutWithResponse call
+func ParsePostAuthLogoutResponse(rsp *http.Response) (*PostAuthLogoutResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthLogoutResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAuthMeResponse parses an HTTP response from a GetAuthMeWithResponse call
+func ParseGetAuthMeResponse(rsp *http.Response) (*GetAuthMeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAuthMeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest User
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAuthPasskeysResponse parses an HTTP response from a GetAuthPasskeysWithResponse call
+func ParseGetAuthPasskeysResponse(rsp *http.Response) (*GetAuthPasskeysResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAuthPasskeysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Passkey
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthPasskeysResponse parses an HTTP response from a PostAuthPasskeysWithResponse call
+func ParsePostAuthPasskeysResponse(rsp *http.Response) (*PostAuthPasskeysResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthPasskeysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Passkey
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthPasskeysChallengeResponse parses an HTTP response from a PostAuthPasskeysChallengeWithResponse call
+func ParsePostAuthPasskeysChallengeResponse(rsp *http.Response) (*PostAuthPasskeysChallengeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthPasskeysChallengeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PasskeyOptions
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthPasskeysConfirmChallengeResponse parses an HTTP response from a PostAuthPasskeysConfirmChallengeWithResponse call
+func ParsePostAuthPasskeysConfirmChallengeResponse(rsp *http.Response) (*PostAuthPasskeysConfirmChallengeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthPasskeysConfirmChallengeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PasskeyOptions
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteAuthPasskeysPasskeyIdResponse parses an HTTP response from a DeleteAuthPasskeysPasskeyIdWithResponse call
+func ParseDeleteAuthPasskeysPasskeyIdResponse(rsp *http.Response) (*DeleteAuthPasskeysPasskeyIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteAuthPasskeysPasskeyIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthPasswordResponse parses an HTTP response from a PostAuthPasswordWithResponse call
+func ParsePostAuthPasswordResponse(rsp *http.Response) (*PostAuthPasswordResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthPasswordResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthTotpResponse parses an HTTP response from a PostAuthTotpWithResponse call
+func ParsePostAuthTotpResponse(rsp *http.Response) (*PostAuthTotpResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthTotpResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TOTPSetup
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthTotpDisableResponse parses an HTTP response from a PostAuthTotpDisableWithResponse call
+func ParsePostAuthTotpDisableResponse(rsp *http.Response) (*PostAuthTotpDisableResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthTotpDisableResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthTotpEnableResponse parses an HTTP response from a PostAuthTotpEnableWithResponse call
+func ParsePostAuthTotpEnableResponse(rsp *http.Response) (*PostAuthTotpEnableResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthTotpEnableResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetChangesResponse parses an HTTP response from a GetChangesWithResponse call
+func ParseGetChangesResponse(rsp *http.Response) (*GetChangesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetChangesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []PendingChange
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostChangesIdApproveResponse parses an HTTP response from a PostChangesIdApproveWithResponse call
+func ParsePostChangesIdApproveResponse(rsp *http.Response) (*PostChangesIdApproveResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostChangesIdApproveResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PendingChange
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostChangesIdCancelResponse parses an HTTP response from a PostChangesIdCancelWithResponse call
+func ParsePostChangesIdCancelResponse(rsp *http.Response) (*PostChangesIdCancelResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostChangesIdCancelResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PendingChange
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostCheckinResponse parses an HTTP response from a PostCheckinWithResponse call
+func ParsePostCheckinResponse(rsp *http.Response) (*PostCheckinResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostCheckinResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BulkCheckInResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetContactTokenResponse parses an HTTP response from a GetContactTokenWithResponse call
+func ParseGetContactTokenResponse(rsp *http.Response) (*GetContactTokenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetContactTokenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ContactVerification
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostContactTokenConfirmResponse parses an HTTP response from a PostContactTokenConfirmWithResponse call
+func ParsePostContactTokenConfirmResponse(rsp *http.Response) (*PostContactTokenConfirmResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostContactTokenConfirmResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ContactVerification
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostContactTokenPostponeResponse parses an HTTP response from a PostContactTokenPostponeWithResponse call
+func ParsePostContactTokenPostponeResponse(rsp *http.Response) (*PostContactTokenPostponeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostContactTokenPostponeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ContactVerification
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetEventsResponse parses an HTTP response from a GetEventsWithResponse call
+func ParseGetEventsResponse(rsp *http.Response) (*GetEventsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEventsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetHealthResponse parses an HTTP response from a GetHealthWithResponse call
+func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHealthResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Health
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Health
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostIntegrationsAlertmanagerTokenResponse parses an HTTP response from a PostIntegrationsAlertmanagerTokenWithResponse call
+func ParsePostIntegrationsAlertmanagerTokenResponse(rsp *http.Response) (*PostIntegrationsAlertmanagerTokenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostIntegrationsAlertmanagerTokenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AlertmanagerResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSwitchResponse parses an HTTP response from a GetSwitchWithResponse call
+func ParseGetSwitchResponse(rsp *http.Response) (*GetSwitchResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSwitchResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Switch
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostSwitchResponse parses an HTTP response from a PostSwitchWithResponse call
+func ParsePostSwitchResponse(rsp *http.Response) (*PostSwitchResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostSwitchResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Switch
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostSwitchPauseResponse parses an HTTP response from a PostSwitchPauseWithResponse call
+func ParsePostSwitchPauseResponse(rsp *http.Response) (*PostSwitchPauseResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostSwitchPauseResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Switch
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostSwitchResumeResponse parses an HTTP response from a PostSwitchResumeWithResponse call
+func ParsePostSwitchResumeResponse(rsp *http.Response) (*PostSwitchResumeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostSwitchResumeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Switch
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteSwitchIdResponse parses an HTTP response from a DeleteSwitchIdWithResponse call
+func ParseDeleteSwitchIdResponse(rsp *http.Response) (*DeleteSwitchIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteSwitchIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest PendingChange
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSwitchIdResponse parses an HTTP response from a GetSwitchIdWithResponse call
+func ParseGetSwitchIdResponse(rsp *http.Response) (*GetSwitchIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSwitchIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Switch
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutSwitchIdResponse parses an HTTP response from a PutSwitchIdWithResponse call
+func ParsePutSwitchIdResponse(rsp *http.Response) (*PutSwitchIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutSwitchIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Switch
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest PendingChange
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSwitchIdAuditResponse parses an HTTP response from a GetSwitchIdAuditWithResponse call
+func ParseGetSwitchIdAuditResponse(rsp *http.Response) (*GetSwitchIdAuditResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSwitchIdAuditResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []AuditEvent
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSwitchIdCheckinsResponse parses an HTTP response from a GetSwitchIdCheckinsWithResponse call
+func ParseGetSwitchIdCheckinsResponse(rsp *http.Response) (*GetSwitchIdCheckinsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSwitchIdCheckinsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []CheckIn
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSwitchIdDeliveriesResponse parses an HTTP response from a GetSwitchIdDeliveriesWithResponse call
+func ParseGetSwitchIdDeliveriesResponse(rsp *http.Response) (*GetSwitchIdDeliveriesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSwitchIdDeliveriesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Delivery
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostSwitchIdDisableResponse parses an HTTP response from a PostSwitchIdDisableWithResponse call
+func ParsePostSwitchIdDisableResponse(rsp *http.Response) (*PostSwitchIdDisableResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostSwitchIdResetResponse(rsp)
+
+	response := &PostSwitchIdDisableResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Switch
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest PendingChange
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
 }
 
-// GetVapidWithResponse request returning *GetVapidResponse
-func (c *ClientWithResponses) GetVapidWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetVapidResponse, error) {
-	rsp, err := c.GetVapid(ctx, reqEditors...)
+// ParsePostSwitchIdPauseResponse parses an HTTP response from a PostSwitchIdPauseWithResponse call
+func ParsePostSwitchIdPauseResponse(rsp *http.Response) (*PostSwitchIdPauseResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetVapidResponse(rsp)
+
+	response := &PostSwitchIdPauseResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Switch
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest PendingChange
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
 }
 
-// ParseGetAuthConfigResponse parses an HTTP response from a GetAuthConfigWithResponse call
-func ParseGetAuthConfigResponse(rsp *http.Response) (*GetAuthConfigResponse, error) {
+// ParsePostSwitchIdResetResponse parses an HTTP response from a PostSwitchIdResetWithResponse call
+func ParsePostSwitchIdResetResponse(rsp *http.Response) (*PostSwitchIdResetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostSwitchIdResetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Switch
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostSwitchIdResetChallengeResponse parses an HTTP response from a PostSwitchIdResetChallengeWithResponse call
+func ParsePostSwitchIdResetChallengeResponse(rsp *http.Response) (*PostSwitchIdResetChallengeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostSwitchIdResetChallengeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PasskeyOptions
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostSwitchIdResumeResponse parses an HTTP response from a PostSwitchIdResumeWithResponse call
+func ParsePostSwitchIdResumeResponse(rsp *http.Response) (*PostSwitchIdResumeResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetAuthConfigResponse{
+	response := &PostSwitchIdResumeResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest AuthConfig
+		var dest Switch
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
 	}
 
 	return response, nil
 }
 
-// ParseGetHealthResponse parses an HTTP response from a GetHealthWithResponse call
-func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
+// ParseGetTokensResponse parses an HTTP response from a GetTokensWithResponse call
+func ParseGetTokensResponse(rsp *http.Response) (*GetTokensResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetHealthResponse{
+	response := &GetTokensResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Health
+		var dest []APIToken
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
-		var dest Health
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON503 = &dest
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParseGetSwitchResponse parses an HTTP response from a GetSwitchWithResponse call
-func ParseGetSwitchResponse(rsp *http.Response) (*GetSwitchResponse, error) {
+// ParsePostTokensResponse parses an HTTP response from a PostTokensWithResponse call
+func ParsePostTokensResponse(rsp *http.Response) (*PostTokensResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetSwitchResponse{
+	response := &PostTokensResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []Switch
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest APIToken
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON200 = &dest
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Error
@@ -1244,6 +9353,13 @@ func ParseGetSwitchResponse(rsp *http.Response) (*GetSwitchResponse, error) {
 		}
 		response.JSON401 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1256,40 +9372,40 @@ func ParseGetSwitchResponse(rsp *http.Response) (*GetSwitchResponse, error) {
 	return response, nil
 }
 
-// ParsePostSwitchResponse parses an HTTP response from a PostSwitchWithResponse call
-func ParsePostSwitchResponse(rsp *http.Response) (*PostSwitchResponse, error) {
+// ParseDeleteTokensIdResponse parses an HTTP response from a DeleteTokensIdWithResponse call
+func ParseDeleteTokensIdResponse(rsp *http.Response) (*DeleteTokensIdResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PostSwitchResponse{
+	response := &DeleteTokensIdResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
-		var dest Switch
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON201 = &dest
+		response.JSON401 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON400 = &dest
+		response.JSON403 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON401 = &dest
+		response.JSON404 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest Error
@@ -1303,20 +9419,27 @@ func ParsePostSwitchResponse(rsp *http.Response) (*PostSwitchResponse, error) {
 	return response, nil
 }
 
-// ParseDeleteSwitchIdResponse parses an HTTP response from a DeleteSwitchIdWithResponse call
-func ParseDeleteSwitchIdResponse(rsp *http.Response) (*DeleteSwitchIdResponse, error) {
+// ParseGetTokensIdResponse parses an HTTP response from a GetTokensIdWithResponse call
+func ParseGetTokensIdResponse(rsp *http.Response) (*GetTokensIdResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &DeleteSwitchIdResponse{
+	response := &GetTokensIdResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest APIToken
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1324,46 +9447,53 @@ func ParseDeleteSwitchIdResponse(rsp *http.Response) (*DeleteSwitchIdResponse, e
 		}
 		response.JSON401 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON404 = &dest
+		response.JSON403 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON500 = &dest
+		response.JSON404 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParseGetSwitchIdResponse parses an HTTP response from a GetSwitchIdWithResponse call
-func ParseGetSwitchIdResponse(rsp *http.Response) (*GetSwitchIdResponse, error) {
+// ParsePutTokensIdResponse parses an HTTP response from a PutTokensIdWithResponse call
+func ParsePutTokensIdResponse(rsp *http.Response) (*PutTokensIdResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetSwitchIdResponse{
+	response := &PutTokensIdResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Switch
+		var dest APIToken
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1371,6 +9501,13 @@ func ParseGetSwitchIdResponse(rsp *http.Response) (*GetSwitchIdResponse, error)
 		}
 		response.JSON401 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1378,39 +9515,98 @@ func ParseGetSwitchIdResponse(rsp *http.Response) (*GetSwitchIdResponse, error)
 		}
 		response.JSON404 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+	}
+
+	return response, nil
+}
+
+// ParseGetVapidResponse parses an HTTP response from a GetVapidWithResponse call
+func ParseGetVapidResponse(rsp *http.Response) (*GetVapidResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetVapidResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON500 = &dest
+		response.JSON401 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParsePutSwitchIdResponse parses an HTTP response from a PutSwitchIdWithResponse call
-func ParsePutSwitchIdResponse(rsp *http.Response) (*PutSwitchIdResponse, error) {
+// ParseGetWebhooksResponse parses an HTTP response from a GetWebhooksWithResponse call
+func ParseGetWebhooksResponse(rsp *http.Response) (*GetWebhooksResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PutSwitchIdResponse{
+	response := &GetWebhooksResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Switch
+		var dest []WebhookSubscription
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostWebhooksResponse parses an HTTP response from a PostWebhooksWithResponse call
+func ParsePostWebhooksResponse(rsp *http.Response) (*PostWebhooksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostWebhooksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest WebhookSubscription
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1425,6 +9621,39 @@ func ParsePutSwitchIdResponse(rsp *http.Response) (*PutSwitchIdResponse, error)
 		}
 		response.JSON401 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteWebhooksIdResponse parses an HTTP response from a DeleteWebhooksIdWithResponse call
+func ParseDeleteWebhooksIdResponse(rsp *http.Response) (*DeleteWebhooksIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteWebhooksIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1444,22 +9673,22 @@ func ParsePutSwitchIdResponse(rsp *http.Response) (*PutSwitchIdResponse, error)
 	return response, nil
 }
 
-// ParsePostSwitchIdDisableResponse parses an HTTP response from a PostSwitchIdDisableWithResponse call
-func ParsePostSwitchIdDisableResponse(rsp *http.Response) (*PostSwitchIdDisableResponse, error) {
+// ParseGetWebhooksIdResponse parses an HTTP response from a GetWebhooksIdWithResponse call
+func ParseGetWebhooksIdResponse(rsp *http.Response) (*GetWebhooksIdResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PostSwitchIdDisableResponse{
+	response := &GetWebhooksIdResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Switch
+		var dest WebhookSubscription
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1491,27 +9720,34 @@ func ParsePostSwitchIdDisableResponse(rsp *http.Response) (*PostSwitchIdDisableR
 	return response, nil
 }
 
-// ParsePostSwitchIdResetResponse parses an HTTP response from a PostSwitchIdResetWithResponse call
-func ParsePostSwitchIdResetResponse(rsp *http.Response) (*PostSwitchIdResetResponse, error) {
+// ParsePutWebhooksIdResponse parses an HTTP response from a PutWebhooksIdWithResponse call
+func ParsePutWebhooksIdResponse(rsp *http.Response) (*PutWebhooksIdResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PostSwitchIdResetResponse{
+	response := &PutWebhooksIdResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Switch
+		var dest WebhookSubscription
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1538,20 +9774,34 @@ func ParsePostSwitchIdResetResponse(rsp *http.Response) (*PostSwitchIdResetRespo
 	return response, nil
 }
 
-// ParseGetVapidResponse parses an HTTP response from a GetVapidWithResponse call
-func ParseGetVapidResponse(rsp *http.Response) (*GetVapidResponse, error) {
+// ParseGetWebhooksIdDeliveriesResponse parses an HTTP response from a GetWebhooksIdDeliveriesWithResponse call
+func ParseGetWebhooksIdDeliveriesResponse(rsp *http.Response) (*GetWebhooksIdDeliveriesResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetVapidResponse{
+	response := &GetWebhooksIdDeliveriesResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Delivery
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1559,6 +9809,20 @@ func ParseGetVapidResponse(rsp *http.Response) (*GetVapidResponse, error) {
 		}
 		response.JSON401 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
 	}
 
 	return response, nil
@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/database"
+	"github.com/circa10a/dead-mans-switch/internal/server/secrets"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// minPasswordLength matches the length the API requires when users change their own password.
+const minPasswordLength = 8
+
+var adminCmd = &cobra.Command{
+	Use:   "admin",
+	Short: "Administer the server's database directly",
+	Long: `Administer the server's database directly.
+
+These commands open the database in --data-dir instead of calling the API, so they
+run on the server's host and work before any user can sign in.`,
+}
+
+var adminUserCmd = &cobra.Command{
+	Use:   "user",
+	Short: "Manage local user accounts used with --auth-mode local and user roles",
+}
+
+var adminUserCreateCmd = &cobra.Command{
+	Use:   "create [username]",
+	Short: "Create a local user account",
+	Long: `Create a local user account.
+
+The password is read from --password, or from stdin when it isn't set. Stdin is read
+without echoing when it is a terminal.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		password, err := readPassword(cmd)
+		if err != nil {
+			return err
+		}
+
+		hash, err := secrets.HashCode(password)
+		if err != nil {
+			return err
+		}
+
+		store, err := openAdminStore(cmd)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = store.Close() }()
+
+		err = store.CreateUser(database.User{
+			Username:     args[0],
+			PasswordHash: hash,
+			CreatedAt:    time.Now().Unix(),
+		})
+		if err != nil {
+			return err
+		}
+
+		printAdminResult(cmd, fmt.Sprintf("Created user %s", args[0]))
+		return nil
+	},
+}
+
+var adminUserResetPasswordCmd = &cobra.Command{
+	Use:   "reset-password [username]",
+	Short: "Set a new password for a local user account and sign out its sessions",
+	Long: `Set a new password for a local user account, sign out its sessions and revoke its API tokens.
+
+The password is read from --password, or from stdin when it isn't set. Use --disable-totp
+to also turn off two-factor authentication for a user who lost their authenticator.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := openAdminStore(cmd)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = store.Close() }()
+
+		user, err := store.GetUser(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to find user %s: %w", args[0], err)
+		}
+
+		password, err := readPassword(cmd)
+		if err != nil {
+			return err
+		}
+
+		user.PasswordHash, err = secrets.HashCode(password)
+		if err != nil {
+			return err
+		}
+
+		disableTOTP, _ := cmd.Flags().GetBool("disable-totp")
+		if disableTOTP {
+			user.TOTPEnabled = false
+			user.TOTPSecret = ""
+		}
+
+		err = store.UpdateUser(user)
+		if err != nil {
+			return err
+		}
+
+		err = store.DeleteUserSessions(user.Username, "")
+		if err != nil {
+			return err
+		}
+
+		err = store.DeleteUserAPITokens(user.Username)
+		if err != nil {
+			return err
+		}
+
+		printAdminResult(cmd, fmt.Sprintf("Reset the password of user %s", user.Username))
+		return nil
+	},
+}
+
+var adminUserSetRoleCmd = &cobra.Command{
+	Use:   "set-role [user-id] [admin|user]",
+	Short: "Assign a role to a user",
+	Long: `Assign a role to a user.
+
+Admins can list every user's switches, disable them and manage quotas through the
+/api/v1/admin endpoints. This assigns the first admin, after which admins can assign
+roles through the API. The user ID is the one switches are owned by, such as a local
+username or the OIDC subject, and doesn't need an account yet.`,
+	Args:      cobra.ExactArgs(2),
+	ValidArgs: []string{string(api.RoleAdmin), string(api.RoleUser)},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		role := api.Role(args[1])
+		if role != api.RoleAdmin && role != api.RoleUser {
+			return fmt.Errorf("invalid role %q. Valid roles are: %s, %s", args[1], api.RoleAdmin, api.RoleUser)
+		}
+
+		store, err := openAdminStore(cmd)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = store.Close() }()
+
+		err = store.SetUserRole(args[0], role)
+		if err != nil {
+			return err
+		}
+
+		printAdminResult(cmd, fmt.Sprintf("Assigned the %s role to user %s", role, args[0]))
+		return nil
+	},
+}
+
+// openAdminStore opens the database in the data directory given by --data-dir.
+func openAdminStore(cmd *cobra.Command) (database.Store, error) {
+	dataDir, _ := cmd.Flags().GetString("data-dir")
+
+	store, err := database.NewSQLiteStore(dataDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	err = store.Init()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database tables: %w", err)
+	}
+
+	return store, nil
+}
+
+// readPassword returns the password given by --password, or reads one from stdin.
+func readPassword(cmd *cobra.Command) (string, error) {
+	password, _ := cmd.Flags().GetString("password")
+
+	if password == "" {
+		var err error
+		password, err = promptPassword(cmd.InOrStdin(), cmd.ErrOrStderr())
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if len(password) < minPasswordLength {
+		return "", fmt.Errorf("password must be at least %d characters", minPasswordLength)
+	}
+
+	return password, nil
+}
+
+// promptPassword reads a password from in, hiding it when in is a terminal.
+func promptPassword(in io.Reader, out io.Writer) (string, error) {
+	if f, ok := in.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		_, _ = fmt.Fprint(out, "Password: ")
+		b, err := term.ReadPassword(int(f.Fd()))
+		_, _ = fmt.Fprintln(out)
+		if err != nil {
+			return "", fmt.Errorf("failed to read password: %w", err)
+		}
+		return string(b), nil
+	}
+
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// printAdminResult reports a completed admin command.
+func printAdminResult(cmd *cobra.Command, msg string) {
+	if useColor {
+		_, _ = color.New(color.FgGreen).Fprintln(cmd.OutOrStdout(), msg)
+	} else {
+		cmd.Println(msg)
+	}
+}
+
+func init() {
+	adminCmd.PersistentFlags().StringP("data-dir", "s", "./data", "Data directory of the server's database and keys")
+	adminCmd.PersistentFlags().BoolVar(&useColor, "color", true, "Enable colorized output")
+
+	adminUserCreateCmd.Flags().String("password", "", "Password of the user (read from stdin when not set)")
+	adminUserResetPasswordCmd.Flags().String("password", "", "New password of the user (read from stdin when not set)")
+	adminUserResetPasswordCmd.Flags().Bool("disable-totp", false, "Also disable two-factor authentication")
+
+	adminUserCmd.AddCommand(adminUserCreateCmd, adminUserResetPasswordCmd, adminUserSetRoleCmd)
+	adminCmd.AddCommand(adminUserCmd)
+	rootCmd.AddCommand(adminCmd)
+}
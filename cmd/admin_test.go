@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/database"
+	"github.com/circa10a/dead-mans-switch/internal/server/secrets"
+)
+
+func Test_AdminUserCreateCommand(t *testing.T) {
+	t.Cleanup(func() { resetFlags(adminUserCreateCmd) })
+	dataDir := t.TempDir()
+
+	out, err := executeCommand("admin", "user", "create", "alice", "--password", "correct-horse", "--data-dir", dataDir, "--color=false")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Created user alice") {
+		t.Errorf("expected a confirmation, got %q", out)
+	}
+
+	store, err := database.NewSQLiteStore(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+
+	user, err := store.GetUser("alice")
+	if err != nil {
+		t.Fatalf("expected the user to be stored: %v", err)
+	}
+	if !secrets.VerifyCode("correct-horse", user.PasswordHash) {
+		t.Error("expected the password to be hashed into the account")
+	}
+
+	_, err = executeCommand("admin", "user", "create", "alice", "--password", "correct-horse", "--data-dir", dataDir, "--color=false")
+	if err == nil {
+		t.Error("expected an error creating a user that already exists")
+	}
+
+	_, err = executeCommand("admin", "user", "create", "bob", "--password", "short", "--data-dir", dataDir, "--color=false")
+	if err == nil {
+		t.Error("expected an error for a short password")
+	}
+}
+
+func Test_AdminUserResetPasswordCommand(t *testing.T) {
+	t.Cleanup(func() {
+		resetFlags(adminUserCreateCmd)
+		resetFlags(adminUserResetPasswordCmd)
+	})
+	dataDir := t.TempDir()
+
+	_, err := executeCommand("admin", "user", "create", "alice", "--password", "correct-horse", "--data-dir", dataDir, "--color=false")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	store, err := database.NewSQLiteStore(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+
+	user, _ := store.GetUser("alice")
+	user.TOTPSecret = "JBSWY3DPEHPK3PXP"
+	user.TOTPEnabled = true
+	_ = store.UpdateUser(user)
+	_ = store.CreateSession(database.Session{TokenHash: "hash", Username: "alice", ExpiresAt: 1 << 40})
+	createdAt := time.Now().Unix()
+	_, err = store.CreateAPIToken("alice", "token-hash", api.APIToken{Name: "ci", Scopes: []api.TokenScope{api.TokenScopeRead}, CreatedAt: &createdAt})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The new password is read from stdin when --password isn't set
+	rootCmd.SetIn(strings.NewReader("battery-staple\n"))
+	t.Cleanup(func() { rootCmd.SetIn(nil) })
+
+	out, err := executeCommand("admin", "user", "reset-password", "alice", "--disable-totp", "--data-dir", dataDir, "--color=false")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Reset the password of user alice") {
+		t.Errorf("expected a confirmation, got %q", out)
+	}
+
+	user, _ = store.GetUser("alice")
+	if !secrets.VerifyCode("battery-staple", user.PasswordHash) {
+		t.Error("expected the new password")
+	}
+	if user.TOTPEnabled || user.TOTPSecret != "" {
+		t.Error("expected two-factor authentication to be disabled")
+	}
+
+	_, err = store.GetSession("hash", 0)
+	if err == nil {
+		t.Error("expected the user's sessions to be signed out")
+	}
+
+	_, _, err = store.GetAPITokenByHash("token-hash")
+	if err == nil {
+		t.Error("expected the user's API tokens to be revoked")
+	}
+
+	_, err = executeCommand("admin", "user", "reset-password", "nobody", "--password", "battery-staple", "--data-dir", dataDir, "--color=false")
+	if err == nil {
+		t.Error("expected an error for an unknown user")
+	}
+}
+
+func Test_AdminUserSetRoleCommand(t *testing.T) {
+	dataDir := t.TempDir()
+
+	out, err := executeCommand("admin", "user", "set-role", "alice@example.com", "admin", "--data-dir", dataDir, "--color=false")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Assigned the admin role to user alice@example.com") {
+		t.Errorf("expected a confirmation, got %q", out)
+	}
+
+	store, err := database.NewSQLiteStore(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+
+	settings, err := store.GetUserSettings("alice@example.com")
+	if err != nil || settings.Role != api.RoleAdmin {
+		t.Errorf("expected the admin role to be stored, got %+v (%v)", settings, err)
+	}
+
+	_, err = executeCommand("admin", "user", "set-role", "alice@example.com", "owner", "--data-dir", dataDir, "--color=false")
+	if err == nil {
+		t.Error("expected an error for an unknown role")
+	}
+}
@@ -28,6 +28,30 @@ type tokenCache struct {
 	ExpiresAt    string `json:"expires_at,omitempty"`
 	RefreshToken string `json:"refresh_token,omitempty"`
 	TokenType    string `json:"token_type"`
+	// TokenURL, ClientID and ClientSecret are where and as whom the refresh token is redeemed.
+	TokenURL     string `json:"token_url,omitempty"`
+	ClientID     string `json:"client_id,omitempty"`
+	ClientSecret string `json:"client_secret,omitempty"`
+}
+
+// oidcDiscovery holds the endpoints of an OIDC discovery document used to sign in.
+type oidcDiscovery struct {
+	AuthorizationEndpoint       string `json:"authorization_endpoint"`
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+	TokenEndpoint               string `json:"token_endpoint"`
+}
+
+// oidcLogin holds how to sign in with an OIDC provider.
+type oidcLogin struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	Username     string
+	Password     string
+	Device       bool
+	Browser      bool
+	Scope        string
+	RedirectPort int
 }
 
 // tokenResponse is the raw OAuth2 token endpoint response.
@@ -132,36 +156,34 @@ func withBearerToken(token string) api.ClientOption {
 	})
 }
 
-// discoverTokenEndpoint fetches the OIDC discovery document and returns the token_endpoint.
-func discoverTokenEndpoint(issuerURL string) (string, error) {
+// discoverOIDC fetches the OIDC discovery document of an issuer.
+func discoverOIDC(issuerURL string) (*oidcDiscovery, error) {
 	discoveryURL := strings.TrimRight(issuerURL, "/") + "/.well-known/openid-configuration"
 
 	httpClient := &http.Client{Timeout: 10 * time.Second}
 
 	resp, err := httpClient.Get(discoveryURL)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch OIDC discovery document: %w", err)
+		return nil, fmt.Errorf("failed to fetch OIDC discovery document: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("OIDC discovery returned HTTP %d", resp.StatusCode)
+		return nil, fmt.Errorf("OIDC discovery returned HTTP %d", resp.StatusCode)
 	}
 
-	var discovery struct {
-		TokenEndpoint string `json:"token_endpoint"`
-	}
+	var discovery oidcDiscovery
 
 	err = json.NewDecoder(resp.Body).Decode(&discovery)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse OIDC discovery document: %w", err)
+		return nil, fmt.Errorf("failed to parse OIDC discovery document: %w", err)
 	}
 
 	if discovery.TokenEndpoint == "" {
-		return "", fmt.Errorf("OIDC discovery document missing token_endpoint")
+		return nil, fmt.Errorf("OIDC discovery document missing token_endpoint")
 	}
 
-	return discovery.TokenEndpoint, nil
+	return &discovery, nil
 }
 
 // fetchTokenPassword performs the OAuth2 Resource Owner Password Credentials grant.
@@ -207,7 +229,9 @@ func doTokenRequest(tokenURL string, data url.Values) (*tokenResponse, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("token request failed (HTTP %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+		tokenErr := &tokenError{StatusCode: resp.StatusCode, body: strings.TrimSpace(string(body))}
+		_ = json.Unmarshal(body, tokenErr)
+		return nil, tokenErr
 	}
 
 	var tok tokenResponse
@@ -223,6 +247,90 @@ func doTokenRequest(tokenURL string, data url.Values) (*tokenResponse, error) {
 	return &tok, nil
 }
 
+// loginOIDC fetches a token from the OIDC provider with the device, browser, password or client credentials
+// flow.
+func loginOIDC(ctx context.Context, out io.Writer, login oidcLogin) (*tokenCache, error) {
+	// Discover the endpoints from the OIDC configuration
+	discovery, err := discoverOIDC(login.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("OIDC discovery failed: %w", err)
+	}
+
+	var tok *tokenResponse
+
+	switch {
+	case login.Device:
+		tok, err = loginDevice(ctx, out, discovery, login.ClientID, login.Scope)
+	case login.Browser:
+		tok, err = loginBrowser(ctx, out, discovery, login.ClientID, login.Scope, login.RedirectPort)
+	case login.ClientSecret != "":
+		tok, err = fetchTokenClientCredentials(discovery.TokenEndpoint, login.ClientID, login.ClientSecret)
+	case login.Username != "" && login.Password != "":
+		tok, err = fetchTokenPassword(discovery.TokenEndpoint, login.ClientID, login.Username, login.Password)
+	default:
+		return nil, fmt.Errorf("provide --device or --browser, --username and --password, or --client-secret")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newTokenCache(tok, discovery.TokenEndpoint, login.ClientID, login.ClientSecret), nil
+}
+
+// newTokenCache returns the cached form of a token response, remembering where to refresh it.
+func newTokenCache(tok *tokenResponse, tokenURL, clientID, clientSecret string) *tokenCache {
+	cache := &tokenCache{
+		AccessToken:  tok.AccessToken,
+		TokenType:    tok.TokenType,
+		RefreshToken: tok.RefreshToken,
+	}
+
+	if tok.RefreshToken != "" {
+		cache.TokenURL = tokenURL
+		cache.ClientID = clientID
+		cache.ClientSecret = clientSecret
+	}
+
+	if tok.ExpiresIn > 0 {
+		cache.ExpiresAt = time.Now().Add(time.Duration(tok.ExpiresIn) * time.Second).UTC().Format(time.RFC3339)
+	}
+
+	return cache
+}
+
+// loginLocal starts a session with a local user account of a server running in local auth mode.
+func loginLocal(baseURL, username, password, totpCode string) (*tokenCache, error) {
+	if username == "" || password == "" {
+		return nil, fmt.Errorf("provide --username and --password")
+	}
+
+	localClient, err := api.NewClientWithResponses(baseURL, api.WithHTTPClient(&http.Client{Timeout: 10 * time.Second}))
+	if err != nil {
+		return nil, err
+	}
+
+	body := api.LoginRequest{Username: username, Password: password}
+	if totpCode != "" {
+		body.TotpCode = &totpCode
+	}
+
+	resp, err := localClient.PostAuthLoginWithResponse(context.Background(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign in: %w", err)
+	}
+
+	if resp.JSON200 == nil {
+		return nil, fmt.Errorf("sign in failed (HTTP %d): %s", resp.StatusCode(), strings.TrimSpace(string(resp.Body)))
+	}
+
+	return &tokenCache{
+		AccessToken: resp.JSON200.Token,
+		TokenType:   "Bearer",
+		ExpiresAt:   time.Unix(resp.JSON200.ExpiresAt, 0).UTC().Format(time.RFC3339),
+	}, nil
+}
+
 var authCmd = &cobra.Command{
 	Use:   "auth",
 	Short: "Manage authentication credentials",
@@ -230,11 +338,21 @@ var authCmd = &cobra.Command{
 
 var authLoginCmd = &cobra.Command{
 	Use:   "login",
-	Short: "Authenticate with an OIDC provider and cache the token locally",
+	Short: "Authenticate with an OIDC provider or a local account and cache the token locally",
 	Long: `Authenticate using OAuth2 and cache the resulting token in
 ~/.dead-mans-switch/credentials.json.
 
-Two grant types are supported:
+Four flows are supported:
+
+  Device authorization (--device), approving the sign in from a browser on
+  any device, such as on a headless machine:
+    dead-mans-switch auth login \
+      --issuer-url URL --client-id ID --device
+
+  Authorization code with PKCE (--browser), opening the browser and
+  receiving the result on a local redirect:
+    dead-mans-switch auth login \
+      --issuer-url URL --client-id ID --browser
 
   Password grant (--username + --password):
     dead-mans-switch auth login \
@@ -246,46 +364,60 @@ Two grant types are supported:
       --issuer-url URL --client-id ID \
       --client-secret SECRET
 
+Servers running with --auth-mode local are signed in to with a local account
+instead, adding --totp-code when two-factor authentication is enabled:
+    dead-mans-switch auth login \
+      --url http://localhost:8080/api/v1 \
+      --username USER --password PASS
+
 Subsequent CLI commands (e.g. "switch create") will automatically use the
-cached token for API requests.`,
+cached token for API requests, refreshing it when it expires if the provider
+issued a refresh token.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		issuerURL, _ := cmd.Flags().GetString("issuer-url")
 		clientID, _ := cmd.Flags().GetString("client-id")
 		username, _ := cmd.Flags().GetString("username")
 		password, _ := cmd.Flags().GetString("password")
 		clientSecret, _ := cmd.Flags().GetString("client-secret")
+		localURL, _ := cmd.Flags().GetString("url")
+		totpCode, _ := cmd.Flags().GetString("totp-code")
+		device, _ := cmd.Flags().GetBool("device")
+		browser, _ := cmd.Flags().GetBool("browser")
+		scope, _ := cmd.Flags().GetString("scope")
+		redirectPort, _ := cmd.Flags().GetInt("redirect-port")
 
-		// Discover the token endpoint from the OIDC configuration
-		tokenURL, err := discoverTokenEndpoint(issuerURL)
-		if err != nil {
-			return fmt.Errorf("OIDC discovery failed: %w", err)
-		}
-
-		var tok *tokenResponse
+		var cache *tokenCache
+		var err error
 
 		switch {
-		case clientSecret != "":
-			tok, err = fetchTokenClientCredentials(tokenURL, clientID, clientSecret)
-		case username != "" && password != "":
-			tok, err = fetchTokenPassword(tokenURL, clientID, username, password)
+		case issuerURL != "":
+			if clientID == "" {
+				return fmt.Errorf("--client-id is required with --issuer-url")
+			}
+			if device && browser {
+				return fmt.Errorf("--device and --browser can't be used together")
+			}
+			cache, err = loginOIDC(cmd.Context(), cmd.OutOrStdout(), oidcLogin{
+				IssuerURL:    issuerURL,
+				ClientID:     clientID,
+				ClientSecret: clientSecret,
+				Username:     username,
+				Password:     password,
+				Device:       device,
+				Browser:      browser,
+				Scope:        scope,
+				RedirectPort: redirectPort,
+			})
+		case localURL != "":
+			cache, err = loginLocal(localURL, username, password, totpCode)
 		default:
-			return fmt.Errorf("provide either --username and --password, or --client-secret")
+			return fmt.Errorf("provide --issuer-url to sign in with an OIDC provider, or --url to sign in with a local account")
 		}
 
 		if err != nil {
 			return err
 		}
 
-		cache := &tokenCache{
-			AccessToken:  tok.AccessToken,
-			TokenType:    tok.TokenType,
-			RefreshToken: tok.RefreshToken,
-		}
-
-		if tok.ExpiresIn > 0 {
-			cache.ExpiresAt = time.Now().Add(time.Duration(tok.ExpiresIn) * time.Second).UTC().Format(time.RFC3339)
-		}
-
 		err = saveToken(cache)
 		if err != nil {
 			return err
@@ -363,11 +495,14 @@ func init() {
 	authLoginCmd.Flags().String("issuer-url", "", "OIDC issuer URL (e.g. http://localhost:9000/application/o/dead-mans-switch/)")
 	authLoginCmd.Flags().String("client-id", "", "OAuth2 client ID")
 	authLoginCmd.Flags().String("client-secret", "", "OAuth2 client secret (for client_credentials grant)")
-	authLoginCmd.Flags().String("username", "", "Username (for password grant)")
-	authLoginCmd.Flags().String("password", "", "Password (for password grant)")
-
-	_ = authLoginCmd.MarkFlagRequired("issuer-url")
-	_ = authLoginCmd.MarkFlagRequired("client-id")
+	authLoginCmd.Flags().String("username", "", "Username (for password grant or a local account)")
+	authLoginCmd.Flags().String("password", "", "Password (for password grant or a local account)")
+	authLoginCmd.Flags().String("url", "", "API base URL of a server using local accounts (e.g. http://localhost:8080/api/v1)")
+	authLoginCmd.Flags().Bool("device", false, "Sign in with the device authorization flow, approving it in a browser on any device")
+	authLoginCmd.Flags().Bool("browser", false, "Sign in through the browser with the authorization code flow and PKCE")
+	authLoginCmd.Flags().String("scope", "openid offline_access", "Scopes requested by --device and --browser (offline_access asks for a refresh token)")
+	authLoginCmd.Flags().Int("redirect-port", 0, "Local port the browser is redirected to with --browser (defaults to a random port)")
+	authLoginCmd.Flags().String("totp-code", "", "Code from an authenticator app (for a local account with two-factor authentication)")
 
 	authCmd.AddCommand(authLoginCmd, authLogoutCmd, authStatusCmd)
 	rootCmd.AddCommand(authCmd)
@@ -2,12 +2,16 @@ package cmd
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
 )
 
 func TestAuthLogin_Success(t *testing.T) {
@@ -398,7 +402,7 @@ func TestInitClient_WithCachedToken(t *testing.T) {
 	defer server.Close()
 
 	apiURL = server.URL
-	err = initClient()
+	err = initClient(io.Discard)
 	if err != nil {
 		t.Fatalf("failed to init client: %v", err)
 	}
@@ -478,3 +482,84 @@ func TestFetchToken_ServerError(t *testing.T) {
 		t.Errorf("expected error to mention status code, got %q", err.Error())
 	}
 }
+
+func TestAuthLogin_LocalAccount(t *testing.T) {
+	// Earlier tests leave --issuer-url set, which takes precedence over --url
+	resetFlags(authLoginCmd)
+	t.Cleanup(func() { resetFlags(authLoginCmd) })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/auth/login" || r.Method != http.MethodPost {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var body api.LoginRequest
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		if body.Username != "alice" || body.Password != "correct-horse" {
+			t.Errorf("unexpected credentials %+v", body)
+		}
+		if body.TotpCode == nil || *body.TotpCode != "123456" {
+			t.Errorf("expected the TOTP code, got %v", body.TotpCode)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(api.Session{
+			Token:     "dmss_session-token",
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+			Username:  "alice",
+		})
+	}))
+	defer server.Close()
+
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	output, err := executeCommand(
+		"auth", "login",
+		"--url", server.URL,
+		"--username", "alice",
+		"--password", "correct-horse",
+		"--totp-code", "123456",
+		"--color=false",
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "Login successful") {
+		t.Errorf("expected success message, got %q", output)
+	}
+
+	tok, err := loadToken()
+	if err != nil || tok == nil {
+		t.Fatalf("expected a cached token: %v", err)
+	}
+	if tok.AccessToken != "dmss_session-token" {
+		t.Errorf("expected the session token, got %q", tok.AccessToken)
+	}
+}
+
+func TestAuthLogin_LocalAccountRejected(t *testing.T) {
+	resetFlags(authLoginCmd)
+	t.Cleanup(func() { resetFlags(authLoginCmd) })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"code":401,"message":"Invalid username, password or TOTP code"}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("HOME", t.TempDir())
+
+	_, err := executeCommand(
+		"auth", "login",
+		"--url", server.URL,
+		"--username", "alice",
+		"--password", "wrong",
+		"--color=false",
+	)
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected the rejected sign in, got %v", err)
+	}
+}
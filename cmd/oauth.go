@@ -0,0 +1,307 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"time"
+)
+
+const (
+	// defaultDeviceInterval is how often the token endpoint is polled when the provider doesn't say.
+	defaultDeviceInterval = 5 * time.Second
+	// browserLoginTimeout is how long the browser flow waits for the provider to redirect back.
+	browserLoginTimeout = 5 * time.Minute
+	// refreshMargin refreshes tokens this long before they expire, so they don't expire in flight.
+	refreshMargin = 30 * time.Second
+)
+
+// tokenError is an error response of the token endpoint.
+type tokenError struct {
+	StatusCode  int
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+	body        string
+}
+
+func (e *tokenError) Error() string {
+	return fmt.Sprintf("token request failed (HTTP %d): %s", e.StatusCode, e.body)
+}
+
+// deviceAuthorization is the device authorization endpoint's response (RFC 8628).
+type deviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval,omitempty"`
+}
+
+// openBrowser opens a URL in the user's browser.
+var openBrowser = func(target string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", target).Start()
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", target).Start()
+	default:
+		return exec.Command("xdg-open", target).Start()
+	}
+}
+
+// loginDevice signs in with the device authorization flow: the user approves the sign in on any device with
+// a browser while the CLI polls the token endpoint.
+func loginDevice(ctx context.Context, out io.Writer, discovery *oidcDiscovery, clientID, scope string) (*tokenResponse, error) {
+	if discovery.DeviceAuthorizationEndpoint == "" {
+		return nil, errors.New("the OIDC provider doesn't support the device authorization flow")
+	}
+
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+
+	resp, err := httpClient.PostForm(discovery.DeviceAuthorizationEndpoint, url.Values{
+		"client_id": {clientID},
+		"scope":     {scope},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to request device authorization: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("device authorization failed (HTTP %d): %s", resp.StatusCode, string(body))
+	}
+
+	var device deviceAuthorization
+	err = json.NewDecoder(resp.Body).Decode(&device)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse device authorization: %w", err)
+	}
+
+	if device.DeviceCode == "" || device.VerificationURI == "" {
+		return nil, errors.New("device authorization response missing device_code or verification_uri")
+	}
+
+	_, _ = fmt.Fprintf(out, "To sign in, open %s and enter the code %s\n", device.VerificationURI, device.UserCode)
+	if device.VerificationURIComplete != "" {
+		_, _ = fmt.Fprintf(out, "Or open %s\n", device.VerificationURIComplete)
+	}
+
+	interval := defaultDeviceInterval
+	if device.Interval > 0 {
+		interval = time.Duration(device.Interval) * time.Second
+	}
+
+	expiresIn := time.Duration(device.ExpiresIn) * time.Second
+	if expiresIn <= 0 {
+		expiresIn = browserLoginTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, expiresIn)
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, errors.New("the device code expired before the sign in was approved")
+		case <-time.After(interval):
+		}
+
+		tok, err := doTokenRequest(discovery.TokenEndpoint, url.Values{
+			"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+			"device_code": {device.DeviceCode},
+			"client_id":   {clientID},
+		})
+
+		var tokenErr *tokenError
+		if errors.As(err, &tokenErr) {
+			switch tokenErr.Code {
+			case "authorization_pending":
+				continue
+			case "slow_down":
+				interval += 5 * time.Second
+				continue
+			case "access_denied":
+				return nil, errors.New("the sign in was denied")
+			case "expired_token":
+				return nil, errors.New("the device code expired before the sign in was approved")
+			}
+		}
+
+		return tok, err
+	}
+}
+
+// loginBrowser signs in with the authorization code flow and PKCE, receiving the code on a loopback
+// redirect listener (RFC 8252).
+func loginBrowser(ctx context.Context, out io.Writer, discovery *oidcDiscovery, clientID, scope string, port int) (*tokenResponse, error) {
+	if discovery.AuthorizationEndpoint == "" {
+		return nil, errors.New("OIDC discovery document missing authorization_endpoint")
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for the browser redirect: %w", err)
+	}
+	redirectURI := fmt.Sprintf("http://%s/callback", listener.Addr().String())
+
+	verifier, err := randomURLString(32)
+	if err != nil {
+		return nil, err
+	}
+	state, err := randomURLString(16)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge := sha256.Sum256([]byte(verifier))
+
+	authURL, err := url.Parse(discovery.AuthorizationEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid authorization_endpoint: %w", err)
+	}
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", clientID)
+	query.Set("redirect_uri", redirectURI)
+	query.Set("scope", scope)
+	query.Set("state", state)
+	query.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	type callbackResult struct {
+		code string
+		err  error
+	}
+	results := make(chan callbackResult, 1)
+
+	server := &http.Server{
+		ReadHeaderTimeout: 5 * time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/callback" {
+				http.NotFound(w, r)
+				return
+			}
+
+			params := r.URL.Query()
+
+			var result callbackResult
+			switch {
+			case params.Get("state") != state:
+				result.err = errors.New("the browser redirect has the wrong state, possibly from another sign in")
+			case params.Get("error") != "":
+				result.err = fmt.Errorf("the OIDC provider refused the sign in: %s %s", params.Get("error"), params.Get("error_description"))
+			case params.Get("code") == "":
+				result.err = errors.New("the browser redirect is missing the authorization code")
+			default:
+				result.code = params.Get("code")
+			}
+
+			if result.err != nil {
+				http.Error(w, "Sign in failed, return to the terminal for details.", http.StatusBadRequest)
+			} else {
+				_, _ = io.WriteString(w, "Signed in to Dead Man's Switch, you can close this window.")
+			}
+
+			select {
+			case results <- result:
+			default:
+			}
+		}),
+	}
+
+	go func() { _ = server.Serve(listener) }()
+	defer func() { _ = server.Close() }()
+
+	_, _ = fmt.Fprintf(out, "Opening your browser to sign in. If it doesn't open, visit:\n%s\n", authURL.String())
+	err = openBrowser(authURL.String())
+	if err != nil {
+		_, _ = fmt.Fprintf(out, "Failed to open the browser: %v\n", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, browserLoginTimeout)
+	defer cancel()
+
+	var result callbackResult
+	select {
+	case result = <-results:
+	case <-ctx.Done():
+		return nil, errors.New("timed out waiting for the browser sign in")
+	}
+
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	return doTokenRequest(discovery.TokenEndpoint, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {result.code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {clientID},
+		"code_verifier": {verifier},
+	})
+}
+
+// refreshCachedToken exchanges the cached refresh token for a new access token once the cached one expires.
+// The cached token is returned as is when it can't be refreshed, and the failure is reported to errOut.
+func refreshCachedToken(errOut io.Writer, tok *tokenCache) *tokenCache {
+	if tok.RefreshToken == "" || tok.TokenURL == "" || tok.ExpiresAt == "" {
+		return tok
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, tok.ExpiresAt)
+	if err != nil || time.Until(expiresAt) > refreshMargin {
+		return tok
+	}
+
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {tok.RefreshToken},
+		"client_id":     {tok.ClientID},
+	}
+	// Confidential clients authenticate to redeem their refresh tokens too
+	if tok.ClientSecret != "" {
+		form.Set("client_secret", tok.ClientSecret)
+	}
+
+	resp, err := doTokenRequest(tok.TokenURL, form)
+	if err != nil {
+		_, _ = fmt.Fprintf(errOut, "Failed to refresh the cached token, run \"dead-mans-switch auth login\" again: %v\n", err)
+		return tok
+	}
+
+	refreshed := newTokenCache(resp, tok.TokenURL, tok.ClientID, tok.ClientSecret)
+	// Providers that don't rotate refresh tokens leave them out of the response
+	if refreshed.RefreshToken == "" {
+		refreshed.RefreshToken = tok.RefreshToken
+	}
+
+	err = saveToken(refreshed)
+	if err != nil {
+		_, _ = fmt.Fprintf(errOut, "Failed to cache the refreshed token: %v\n", err)
+	}
+
+	return refreshed
+}
+
+// randomURLString returns a random URL safe string of n bytes of entropy.
+func randomURLString(n int) (string, error) {
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate random value: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,333 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeIdP is an OIDC provider supporting the device, authorization code and refresh token grants.
+type fakeIdP struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	devicePolls   int
+	deviceResult  string
+	challenge     string
+	redirectURI   string
+	wrongState    bool
+	clientSecret  string
+	refreshTokens map[string]bool
+}
+
+func newFakeIdP(t *testing.T) *fakeIdP {
+	t.Helper()
+
+	idp := &fakeIdP{refreshTokens: map[string]bool{"refresh-1": true}}
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"authorization_endpoint":        idp.URL + "/authorize",
+			"device_authorization_endpoint": idp.URL + "/device",
+			"token_endpoint":                idp.URL + "/token",
+		})
+	})
+
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("client_id") != "cli" || r.FormValue("scope") != "openid offline_access" {
+			t.Errorf("unexpected device authorization request %v", r.Form)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(deviceAuthorization{
+			DeviceCode:              "device-code",
+			UserCode:                "WDJB-MJHT",
+			VerificationURI:         idp.URL + "/activate",
+			VerificationURIComplete: idp.URL + "/activate?user_code=WDJB-MJHT",
+			ExpiresIn:               60,
+			Interval:                1,
+		})
+	})
+
+	// The user signs in right away and the provider redirects back to the CLI
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("response_type") != "code" || query.Get("code_challenge_method") != "S256" {
+			t.Errorf("unexpected authorization request %v", query)
+		}
+
+		idp.mu.Lock()
+		idp.challenge = query.Get("code_challenge")
+		idp.redirectURI = query.Get("redirect_uri")
+		state := query.Get("state")
+		if idp.wrongState {
+			state = "forged"
+		}
+		idp.mu.Unlock()
+
+		http.Redirect(w, r, query.Get("redirect_uri")+"?"+url.Values{"code": {"auth-code"}, "state": {state}}.Encode(), http.StatusFound)
+	})
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		idp.mu.Lock()
+		defer idp.mu.Unlock()
+
+		tokenError := func(code string) {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": code})
+		}
+
+		switch r.FormValue("grant_type") {
+		case "urn:ietf:params:oauth:grant-type:device_code":
+			idp.devicePolls++
+			if r.FormValue("device_code") != "device-code" {
+				tokenError("invalid_grant")
+				return
+			}
+			// The user approves the sign in after the first poll
+			if idp.devicePolls == 1 {
+				tokenError("authorization_pending")
+				return
+			}
+			if idp.deviceResult != "" {
+				tokenError(idp.deviceResult)
+				return
+			}
+		case "authorization_code":
+			verifier := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+			if r.FormValue("code") != "auth-code" || r.FormValue("redirect_uri") != idp.redirectURI ||
+				base64.RawURLEncoding.EncodeToString(verifier[:]) != idp.challenge {
+				tokenError("invalid_grant")
+				return
+			}
+		case "refresh_token":
+			if !idp.refreshTokens[r.FormValue("refresh_token")] || r.FormValue("client_id") != "cli" ||
+				r.FormValue("client_secret") != idp.clientSecret {
+				tokenError("invalid_grant")
+				return
+			}
+			// Refresh tokens are rotated
+			delete(idp.refreshTokens, r.FormValue("refresh_token"))
+			idp.refreshTokens["refresh-2"] = true
+			_ = json.NewEncoder(w).Encode(tokenResponse{AccessToken: "refreshed-token", ExpiresIn: 3600, RefreshToken: "refresh-2", TokenType: "Bearer"})
+			return
+		default:
+			tokenError("unsupported_grant_type")
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(tokenResponse{AccessToken: "access-token", ExpiresIn: 3600, RefreshToken: "refresh-1", TokenType: "Bearer"})
+	})
+
+	idp.Server = httptest.NewServer(mux)
+	t.Cleanup(idp.Close)
+
+	return idp
+}
+
+func TestAuthLogin_Device(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Cleanup(func() { resetFlags(authLoginCmd) })
+
+	idp := newFakeIdP(t)
+
+	output, err := executeCommand("auth", "login", "--issuer-url", idp.URL, "--client-id", "cli", "--device", "--color=false")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "enter the code WDJB-MJHT") || !strings.Contains(output, "Login successful") {
+		t.Errorf("expected the user code and success message, got %q", output)
+	}
+
+	tok, err := loadToken()
+	if err != nil || tok == nil {
+		t.Fatalf("expected a cached token, got %v", err)
+	}
+	if tok.AccessToken != "access-token" || tok.RefreshToken != "refresh-1" || tok.TokenURL != idp.URL+"/token" || tok.ClientID != "cli" {
+		t.Errorf("unexpected cached token %+v", tok)
+	}
+}
+
+func TestAuthLogin_DeviceDenied(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Cleanup(func() { resetFlags(authLoginCmd) })
+
+	idp := newFakeIdP(t)
+	idp.deviceResult = "access_denied"
+
+	_, err := executeCommand("auth", "login", "--issuer-url", idp.URL, "--client-id", "cli", "--device", "--color=false")
+	if err == nil || !strings.Contains(err.Error(), "denied") {
+		t.Errorf("expected the denied sign in to be reported, got %v", err)
+	}
+}
+
+func TestAuthLogin_Browser(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Cleanup(func() { resetFlags(authLoginCmd) })
+
+	// The browser follows the provider's redirect back to the CLI's listener
+	origOpenBrowser := openBrowser
+	t.Cleanup(func() { openBrowser = origOpenBrowser })
+	openBrowser = func(target string) error {
+		go func() {
+			resp, err := http.Get(target)
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+		}()
+		return nil
+	}
+
+	idp := newFakeIdP(t)
+
+	output, err := executeCommand("auth", "login", "--issuer-url", idp.URL, "--client-id", "cli", "--browser", "--color=false")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, idp.URL+"/authorize?") {
+		t.Errorf("expected the authorization URL to be printed, got %q", output)
+	}
+	if !strings.HasPrefix(idp.redirectURI, "http://127.0.0.1:") {
+		t.Errorf("expected a loopback redirect, got %q", idp.redirectURI)
+	}
+
+	tok, _ := loadToken()
+	if tok == nil || tok.AccessToken != "access-token" || tok.RefreshToken != "refresh-1" {
+		t.Errorf("unexpected cached token %+v", tok)
+	}
+
+	t.Run("redirects from another sign in are rejected", func(t *testing.T) {
+		idp.wrongState = true
+
+		_, err := executeCommand("auth", "login", "--issuer-url", idp.URL, "--client-id", "cli", "--browser", "--color=false")
+		if err == nil || !strings.Contains(err.Error(), "wrong state") {
+			t.Errorf("expected the forged state to be rejected, got %v", err)
+		}
+	})
+}
+
+func TestInitClient_RefreshesExpiredToken(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	idp := newFakeIdP(t)
+
+	var receivedAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedAuth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	apiURL = server.URL
+
+	var errOut bytes.Buffer
+
+	request := func() {
+		errOut.Reset()
+		err := initClient(&errOut)
+		if err != nil {
+			t.Fatalf("failed to init client: %v", err)
+		}
+		_, err = client.GetSwitchWithResponse(t.Context(), nil)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+	}
+
+	expired := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+
+	err := saveToken(&tokenCache{
+		AccessToken:  "expired-token",
+		ExpiresAt:    expired,
+		RefreshToken: "refresh-1",
+		TokenType:    "Bearer",
+		TokenURL:     idp.URL + "/token",
+		ClientID:     "cli",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request()
+	if receivedAuth != "Bearer refreshed-token" {
+		t.Errorf("expected the refreshed token to be sent, got %q", receivedAuth)
+	}
+
+	tok, _ := loadToken()
+	if tok.AccessToken != "refreshed-token" || tok.RefreshToken != "refresh-2" || tok.TokenURL != idp.URL+"/token" {
+		t.Errorf("expected the refreshed token to be cached, got %+v", tok)
+	}
+
+	t.Run("tokens that aren't expiring aren't refreshed", func(t *testing.T) {
+		request()
+		if receivedAuth != "Bearer refreshed-token" {
+			t.Errorf("expected the cached token to be sent, got %q", receivedAuth)
+		}
+
+		tok, _ := loadToken()
+		if tok.RefreshToken != "refresh-2" {
+			t.Errorf("expected the refresh token to be kept, got %q", tok.RefreshToken)
+		}
+	})
+
+	t.Run("the cached token is sent when refreshing fails", func(t *testing.T) {
+		err := saveToken(&tokenCache{
+			AccessToken:  "expired-token",
+			ExpiresAt:    expired,
+			RefreshToken: "revoked",
+			TokenType:    "Bearer",
+			TokenURL:     idp.URL + "/token",
+			ClientID:     "cli",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		request()
+		if receivedAuth != "Bearer expired-token" {
+			t.Errorf("expected the cached token to be sent, got %q", receivedAuth)
+		}
+		if !strings.Contains(errOut.String(), "Failed to refresh the cached token") {
+			t.Errorf("expected the failure to be reported on the command's stderr, got %q", errOut.String())
+		}
+	})
+
+	t.Run("confidential clients send their secret", func(t *testing.T) {
+		idp.mu.Lock()
+		idp.clientSecret = "secret"
+		idp.refreshTokens["refresh-3"] = true
+		idp.mu.Unlock()
+
+		err := saveToken(&tokenCache{
+			AccessToken:  "expired-token",
+			ExpiresAt:    expired,
+			RefreshToken: "refresh-3",
+			TokenType:    "Bearer",
+			TokenURL:     idp.URL + "/token",
+			ClientID:     "cli",
+			ClientSecret: "secret",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		request()
+		if receivedAuth != "Bearer refreshed-token" {
+			t.Errorf("expected the refreshed token to be sent, got %q, stderr %q", receivedAuth, errOut.String())
+		}
+
+		tok, _ := loadToken()
+		if tok.ClientSecret != "secret" {
+			t.Errorf("expected the client secret to be kept for the next refresh, got %q", tok.ClientSecret)
+		}
+	})
+}
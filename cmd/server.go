@@ -11,7 +11,12 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/circa10a/dead-mans-switch/api"
 	"github.com/circa10a/dead-mans-switch/internal/server"
+	"github.com/circa10a/dead-mans-switch/internal/server/hooks"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+	"github.com/circa10a/dead-mans-switch/internal/server/mqtt"
+	"github.com/circa10a/dead-mans-switch/internal/server/smtp"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -19,23 +24,49 @@ import (
 
 // Constants for Viper keys and Flag names
 const (
-	authEnabledKey        = "auth-enabled"
-	authIssuerURLKey      = "auth-issuer-url"
-	authAudienceKey       = "auth-audience"
-	autoTLSKey            = "auto-tls"
-	contactEmailKey       = "contact-email"
-	demoModeKey           = "demo-mode"
-	demoPResetIntervalKey = "demo-reset-interval"
-	domainsKey            = "domains"
-	logFormatKey          = "log-format"
-	logLevelKey           = "log-level"
-	metricsKey            = "metrics"
-	portKey               = "port"
-	dataDirKey            = "data-dir"
-	tlsCertificateKey     = "tls-certificate"
-	tlsKeyKey             = "tls-key"
-	workerBatchSizeKey    = "worker-batch-size"
-	workerIntervalKey     = "worker-interval"
+	actionsKey             = "actions"
+	authAdminRolesKey      = "auth-admin-roles"
+	authEnabledKey         = "auth-enabled"
+	authGroupsHeaderKey    = "auth-groups-header"
+	authIssuerURLKey       = "auth-issuer-url"
+	authIssuersKey         = "auth-issuers"
+	authAudienceKey        = "auth-audience"
+	authClientCAKey        = "auth-client-ca"
+	authClientCertRulesKey = "auth-client-cert-rules"
+	authJWKSRefreshKey     = "auth-jwks-refresh-interval"
+	authModeKey            = "auth-mode"
+	authRolesClaimKey      = "auth-roles-claim"
+	authTrustedProxiesKey  = "auth-trusted-proxies"
+	authUserHeaderKey      = "auth-user-header"
+	autoTLSKey             = "auto-tls"
+	contactEmailKey        = "contact-email"
+	demoModeKey            = "demo-mode"
+	demoPResetIntervalKey  = "demo-reset-interval"
+	domainsKey             = "domains"
+	externalURLKey         = "external-url"
+	logFormatKey           = "log-format"
+	logLevelKey            = "log-level"
+	maxPauseDurationKey    = "max-pause-duration"
+	maxSwitchesKey         = "max-switches-per-user"
+	metricsKey             = "metrics"
+	mqttBrokerKey          = "mqtt-broker"
+	mqttCACertificateKey   = "mqtt-ca-certificate"
+	mqttClientCertKey      = "mqtt-client-certificate"
+	mqttClientIDKey        = "mqtt-client-id"
+	mqttClientKeyKey       = "mqtt-client-key"
+	mqttPasswordKey        = "mqtt-password"
+	mqttTopicPrefixKey     = "mqtt-topic-prefix"
+	mqttUsernameKey        = "mqtt-username"
+	portKey                = "port"
+	sessionDurationKey     = "session-duration"
+	smtpAllowedSendersKey  = "smtp-allowed-senders"
+	smtpDomainKey          = "smtp-domain"
+	smtpListenKey          = "smtp-listen"
+	dataDirKey             = "data-dir"
+	tlsCertificateKey      = "tls-certificate"
+	tlsKeyKey              = "tls-key"
+	workerBatchSizeKey     = "worker-batch-size"
+	workerIntervalKey      = "worker-interval"
 )
 
 // serverCmd represents the server command
@@ -43,26 +74,75 @@ var serverCmd = &cobra.Command{
 	Use:   "server",
 	Short: fmt.Sprintf("Start the %s server", project),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		actions, err := loadActions()
+		if err != nil {
+			return err
+		}
+
+		issuers, err := loadIssuers()
+		if err != nil {
+			return err
+		}
+
+		certRules, err := loadCertRules()
+		if err != nil {
+			return err
+		}
+
+		mqttConfig := mqtt.Config{
+			BrokerURL:         viper.GetString(mqttBrokerKey),
+			CACertificate:     viper.GetString(mqttCACertificateKey),
+			ClientCertificate: viper.GetString(mqttClientCertKey),
+			ClientID:          viper.GetString(mqttClientIDKey),
+			ClientKey:         viper.GetString(mqttClientKeyKey),
+			Password:          viper.GetString(mqttPasswordKey),
+			TopicPrefix:       viper.GetString(mqttTopicPrefixKey),
+			Username:          viper.GetString(mqttUsernameKey),
+		}
+
+		smtpConfig := smtp.Config{
+			AllowedSenders: viper.GetStringSlice(smtpAllowedSendersKey),
+			Domain:         viper.GetString(smtpDomainKey),
+			Listen:         viper.GetString(smtpListenKey),
+		}
+
 		// Build server configuration using the constants
 		cfg := &server.Config{
-			AuthEnabled:       viper.GetBool(authEnabledKey),
-			AuthIssuerURL:     viper.GetString(authIssuerURLKey),
-			AuthAudience:      viper.GetString(authAudienceKey),
-			AutoTLS:           viper.GetBool(autoTLSKey),
-			ContactEmail:      viper.GetString(contactEmailKey),
-			DemoMode:          viper.GetBool(demoModeKey),
-			DemoResetInterval: viper.GetDuration(demoPResetIntervalKey),
-			Domains:           viper.GetStringSlice(domainsKey),
-			LogFormat:         viper.GetString(logFormatKey),
-			LogLevel:          viper.GetString(logLevelKey),
-			Metrics:           viper.GetBool(metricsKey),
-			Port:              viper.GetInt(portKey),
-			DataDir:           viper.GetString(dataDirKey),
-			TLSCert:           viper.GetString(tlsCertificateKey),
-			TLSKey:            viper.GetString(tlsKeyKey),
-			Validation:        true,
-			WorkerBatchSize:   viper.GetInt(workerBatchSizeKey),
-			WorkerInterval:    viper.GetDuration(workerIntervalKey),
+			Actions:                 actions,
+			AuthAdminRoles:          viper.GetStringSlice(authAdminRolesKey),
+			AuthEnabled:             viper.GetBool(authEnabledKey),
+			AuthGroupsHeader:        viper.GetString(authGroupsHeaderKey),
+			AuthIssuerURL:           viper.GetString(authIssuerURLKey),
+			AuthAudience:            viper.GetString(authAudienceKey),
+			AuthClientCA:            viper.GetString(authClientCAKey),
+			AuthClientCertRules:     certRules,
+			AuthIssuers:             issuers,
+			AuthJWKSRefreshInterval: viper.GetDuration(authJWKSRefreshKey),
+			AuthMode:                api.AuthMode(viper.GetString(authModeKey)),
+			AuthRolesClaim:          viper.GetString(authRolesClaimKey),
+			AuthTrustedProxies:      viper.GetStringSlice(authTrustedProxiesKey),
+			AuthUserHeader:          viper.GetString(authUserHeaderKey),
+			AutoTLS:                 viper.GetBool(autoTLSKey),
+			ContactEmail:            viper.GetString(contactEmailKey),
+			DemoMode:                viper.GetBool(demoModeKey),
+			DemoResetInterval:       viper.GetDuration(demoPResetIntervalKey),
+			Domains:                 viper.GetStringSlice(domainsKey),
+			ExternalURL:             viper.GetString(externalURLKey),
+			LogFormat:               viper.GetString(logFormatKey),
+			LogLevel:                viper.GetString(logLevelKey),
+			MaxPauseDuration:        viper.GetDuration(maxPauseDurationKey),
+			MaxSwitchesPerUser:      viper.GetInt(maxSwitchesKey),
+			Metrics:                 viper.GetBool(metricsKey),
+			MQTT:                    mqttConfig,
+			Port:                    viper.GetInt(portKey),
+			SessionDuration:         viper.GetDuration(sessionDurationKey),
+			SMTP:                    smtpConfig,
+			DataDir:                 viper.GetString(dataDirKey),
+			TLSCert:                 viper.GetString(tlsCertificateKey),
+			TLSKey:                  viper.GetString(tlsKeyKey),
+			Validation:              true,
+			WorkerBatchSize:         viper.GetInt(workerBatchSizeKey),
+			WorkerInterval:          viper.GetDuration(workerIntervalKey),
 		}
 
 		server, err := server.New(cfg)
@@ -87,22 +167,83 @@ var serverCmd = &cobra.Command{
 	},
 }
 
+// loadActions reads the actions switches can run from the config file. They can't be set with flags or
+// through the API so only the server's operator decides which commands run.
+func loadActions() (map[string]hooks.Action, error) {
+	actions := map[string]hooks.Action{}
+
+	err := viper.UnmarshalKey(actionsKey, &actions)
+	if err != nil {
+		return nil, fmt.Errorf("invalid actions configuration: %w", err)
+	}
+
+	return actions, nil
+}
+
+// loadIssuers reads the OIDC providers trusted besides --auth-issuer-url from the config file. Each needs its
+// own audiences and claim mapping, which doesn't fit in flags.
+func loadIssuers() ([]middleware.Issuer, error) {
+	issuers := []middleware.Issuer{}
+
+	err := viper.UnmarshalKey(authIssuersKey, &issuers)
+	if err != nil {
+		return nil, fmt.Errorf("invalid auth issuers configuration: %w", err)
+	}
+
+	return issuers, nil
+}
+
+// loadCertRules reads the rules mapping client certificates to users from the config file.
+func loadCertRules() ([]middleware.CertRule, error) {
+	rules := []middleware.CertRule{}
+
+	err := viper.UnmarshalKey(authClientCertRulesKey, &rules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid auth client cert rules configuration: %w", err)
+	}
+
+	return rules, nil
+}
+
 func init() {
 	rootCmd.AddCommand(serverCmd)
 
 	serverFlags := []flagDef{
+		{Name: authAdminRolesKey, Type: "stringArray", Default: []string{}, Usage: "Roles or groups in the --auth-roles-claim of a token, or in the --auth-groups-header of a proxy, that make its user an admin.", ViperKey: authAdminRolesKey},
+		{Name: authClientCAKey, Type: "string", Default: "", Usage: "PEM bundle of CAs to verify client certificates against. Clients with a verified certificate sign in as the user mapped by auth-client-cert-rules in the config file, or its subject common name.", ViperKey: authClientCAKey},
 		{Name: authEnabledKey, Type: "bool", Default: false, Usage: "Enable JWT authentication via Authentik.", ViperKey: authEnabledKey},
+		{Name: authGroupsHeaderKey, Type: "string", Default: "", Usage: "Header a trusted proxy lists the user's groups in, separated by commas or pipes, checked against --auth-admin-roles. Used with --auth-mode=header.", ViperKey: authGroupsHeaderKey},
 		{Name: authIssuerURLKey, Type: "string", Default: "", Usage: "Identity provider OAuth2 issuer URL.", ViperKey: authIssuerURLKey},
 		{Name: authAudienceKey, Type: "string", Default: "", Usage: "Expected JWT audience claim.", ViperKey: authAudienceKey},
+		{Name: authJWKSRefreshKey, Type: "duration", Default: 1 * time.Hour, Usage: "How often to fetch the identity provider's signing keys again. Tokens signed by an unknown key also fetch them, at most every 30 seconds.", ViperKey: authJWKSRefreshKey},
+		{Name: authModeKey, Type: "string", Default: "", Usage: "How users sign in. Supported values are 'none', 'oidc', 'local' for user accounts created with the admin command and 'header' to trust the user named by a reverse proxy. Defaults to 'oidc' when --auth-enabled is set.", ViperKey: authModeKey},
+		{Name: authRolesClaimKey, Type: "string", Default: "groups", Usage: "JWT claim holding the roles or groups of a user, checked against --auth-admin-roles.", ViperKey: authRolesClaimKey},
+		{Name: authTrustedProxiesKey, Type: "stringArray", Default: []string{}, Usage: "CIDRs or IP addresses of reverse proxies whose identity headers are trusted with --auth-mode=header.", ViperKey: authTrustedProxiesKey},
+		{Name: authUserHeaderKey, Type: "string", Default: "Remote-User", Usage: "Header a trusted proxy names the signed in user in, such as Tailscale-User-Login or X-authentik-username. Used with --auth-mode=header.", ViperKey: authUserHeaderKey},
 		{Name: autoTLSKey, Shorthand: "a", Type: "bool", Default: false, Usage: "Enable automatic TLS via Let's Encrypt. Requires port 80/443 open to the internet for domain validation.", ViperKey: autoTLSKey},
 		{Name: contactEmailKey, Shorthand: "", Type: "string", Default: "user@dead-mans-switch.com", Usage: "Email used for TLS cert registration + push notification point of contact (not required).", ViperKey: contactEmailKey},
 		{Name: demoModeKey, Shorthand: "", Type: "bool", Default: false, Usage: "Enable demo mode which creates sample switches on startup and resets the database periodically.", ViperKey: demoModeKey},
 		{Name: demoPResetIntervalKey, Shorthand: "", Type: "duration", Default: 1 * time.Hour, Usage: "How often to reset the database with fresh sample switches when in demo mode.", ViperKey: demoPResetIntervalKey},
 		{Name: domainsKey, Shorthand: "d", Type: "stringArray", Default: []string{}, Usage: "Domains to issue certificate for. Must be used with --auto-tls.", ViperKey: domainsKey},
+		{Name: externalURLKey, Shorthand: "", Type: "string", Default: "", Usage: "Public URL of the server used in links sent to trusted contacts. Defaults to the first domain or localhost.", ViperKey: externalURLKey},
 		{Name: logFormatKey, Shorthand: "f", Type: "string", Default: "text", Usage: "Server logging format. Supported values are 'text' and 'json'.", ViperKey: logFormatKey},
 		{Name: logLevelKey, Shorthand: "l", Type: "string", Default: "info", Usage: "Server logging level.", ViperKey: logLevelKey},
+		{Name: maxPauseDurationKey, Shorthand: "", Type: "duration", Default: 30 * 24 * time.Hour, Usage: "Maximum length of time a switch can be paused.", ViperKey: maxPauseDurationKey},
+		{Name: maxSwitchesKey, Shorthand: "", Type: "int", Default: 0, Usage: "How many switches a user can have unless an admin sets their quota. 0 means no limit.", ViperKey: maxSwitchesKey},
 		{Name: metricsKey, Shorthand: "m", Type: "bool", Default: false, Usage: "Enable Prometheus metrics instrumentation.", ViperKey: metricsKey},
+		{Name: mqttBrokerKey, Shorthand: "", Type: "string", Default: "", Usage: "MQTT broker URL such as mqtt://localhost:1883 or mqtts://broker:8883. Enables publishing switch events and check-ins over MQTT.", ViperKey: mqttBrokerKey},
+		{Name: mqttCACertificateKey, Shorthand: "", Type: "string", Default: "", Usage: "Path to a CA certificate used to verify the MQTT broker.", ViperKey: mqttCACertificateKey},
+		{Name: mqttClientCertKey, Shorthand: "", Type: "string", Default: "", Usage: "Path to a client certificate for mutual TLS with the MQTT broker.", ViperKey: mqttClientCertKey},
+		{Name: mqttClientIDKey, Shorthand: "", Type: "string", Default: "dead-mans-switch", Usage: "Client ID used to connect to the MQTT broker.", ViperKey: mqttClientIDKey},
+		{Name: mqttClientKeyKey, Shorthand: "", Type: "string", Default: "", Usage: "Path to the key of the MQTT client certificate.", ViperKey: mqttClientKeyKey},
+		{Name: mqttPasswordKey, Shorthand: "", Type: "string", Default: "", Usage: "Password used to connect to the MQTT broker.", ViperKey: mqttPasswordKey},
+		{Name: mqttTopicPrefixKey, Shorthand: "", Type: "string", Default: "dead-mans-switch", Usage: "Prefix of the MQTT topics switch events are published to and check-ins are received on.", ViperKey: mqttTopicPrefixKey},
+		{Name: mqttUsernameKey, Shorthand: "", Type: "string", Default: "", Usage: "Username used to connect to the MQTT broker.", ViperKey: mqttUsernameKey},
 		{Name: portKey, Shorthand: "p", Type: "int", Default: 8080, Usage: "Port to listen on. Cannot be used in conjunction with --auto-tls since that will require listening on 80 and 443.", ViperKey: portKey},
+		{Name: sessionDurationKey, Shorthand: "", Type: "duration", Default: 7 * 24 * time.Hour, Usage: "How long a session of a local user account lasts before signing in again.", ViperKey: sessionDurationKey},
+		{Name: smtpAllowedSendersKey, Shorthand: "", Type: "stringArray", Default: []string{}, Usage: "Addresses, or @domain suffixes, allowed to check in by email. Any sender is allowed if not set.", ViperKey: smtpAllowedSendersKey},
+		{Name: smtpDomainKey, Shorthand: "", Type: "string", Default: "", Usage: "Domain of the email check-in addresses. Defaults to the host of --external-url.", ViperKey: smtpDomainKey},
+		{Name: smtpListenKey, Shorthand: "", Type: "string", Default: "", Usage: "Address to accept email check-ins on such as :2525. Enables checking in by replying to reminder emails.", ViperKey: smtpListenKey},
 		{Name: dataDirKey, Shorthand: "s", Type: "string", Default: "./data", Usage: "Data directory for database and keys", ViperKey: dataDirKey},
 		{Name: tlsCertificateKey, Shorthand: "", Type: "string", Default: "", Usage: "Path to custom TLS certificate. Cannot be used with --auto-tls.", ViperKey: tlsCertificateKey},
 		{Name: tlsKeyKey, Shorthand: "", Type: "string", Default: "", Usage: "Path to custom TLS key. Cannot be used with --auto-tls.", ViperKey: tlsKeyKey},
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 	"syscall"
 	"testing"
@@ -112,3 +113,79 @@ func TestServerSignalExit(t *testing.T) {
 		t.Fatal("Server command did not exit after SIGTERM")
 	}
 }
+
+func TestLoadActions(t *testing.T) {
+	setupViper()
+	t.Cleanup(viper.Reset)
+
+	config := filepath.Join(t.TempDir(), "dead-mans-switch.yaml")
+	err := os.WriteFile(config, []byte(`
+actions:
+  wipe-secrets:
+    command: /usr/local/bin/wipe
+    args: ["--force", "/srv/secrets"]
+    timeout: 2m
+  shutdown:
+    command: /sbin/poweroff
+`), 0o600)
+	if err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	viper.SetConfigFile(config)
+	err = viper.ReadInConfig()
+	if err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+
+	actions, err := loadActions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wipe := actions["wipe-secrets"]
+	if wipe.Command != "/usr/local/bin/wipe" || len(wipe.Args) != 2 || wipe.Args[1] != "/srv/secrets" || wipe.Timeout != 2*time.Minute {
+		t.Errorf("unexpected wipe-secrets action %+v", wipe)
+	}
+	if actions["shutdown"].Command != "/sbin/poweroff" || actions["shutdown"].Timeout != 0 {
+		t.Errorf("unexpected shutdown action %+v", actions["shutdown"])
+	}
+}
+
+func TestLoadIssuers(t *testing.T) {
+	setupViper()
+	t.Cleanup(viper.Reset)
+
+	config := filepath.Join(t.TempDir(), "dead-mans-switch.yaml")
+	err := os.WriteFile(config, []byte(`
+auth-issuers:
+  - name: family
+    url: https://family.example.com/realms/home
+    audiences: [dms-ui, dms-cli]
+    client-id: dms-ui
+    user-claim: email
+`), 0o600)
+	if err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	viper.SetConfigFile(config)
+	err = viper.ReadInConfig()
+	if err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+
+	issuers, err := loadIssuers()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(issuers) != 1 {
+		t.Fatalf("expected 1 issuer, got %d", len(issuers))
+	}
+
+	family := issuers[0]
+	if family.Name != "family" || family.URL != "https://family.example.com/realms/home" || len(family.Audiences) != 2 || family.ClientID != "dms-ui" || family.UserClaim != "email" {
+		t.Errorf("unexpected family issuer %+v", family)
+	}
+}
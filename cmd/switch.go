@@ -2,12 +2,18 @@ package cmd
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/handlers"
 	"github.com/fatih/color"
 	"github.com/hokaccha/go-prettyjson"
 	"github.com/spf13/cobra"
@@ -15,31 +21,65 @@ import (
 )
 
 var (
-	apiURL       string
-	outputFormat string
-	useColor     bool
-	client       *api.ClientWithResponses
+	apiURL         string
+	clientCertFile string
+	clientKeyFile  string
+	outputFormat   string
+	useColor       bool
+	client         *api.ClientWithResponses
 )
 
-func initClient() error {
+func initClient(errOut io.Writer) error {
 	var err error
 
-	httpClient := &http.Client{
+	client, err = newClient(errOut, &http.Client{
 		Timeout: 5 * time.Second,
+	})
+	return err
+}
+
+// newClient returns an API client that sends requests through the given HTTP client. Problems refreshing
+// the cached token are reported to errOut.
+func newClient(errOut io.Writer, httpClient *http.Client) (*api.ClientWithResponses, error) {
+	// Servers verifying client certificates sign devices in with them instead of a token
+	useClientCert := clientCertFile != "" || clientKeyFile != ""
+	if useClientCert {
+		cert, err := tls.LoadX509KeyPair(clientCertFile, clientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.Certificates = []tls.Certificate{cert}
+		httpClient.Transport = transport
 	}
 
 	opts := []api.ClientOption{
 		api.WithHTTPClient(httpClient),
+		// Identify as the CLI so check-ins are recorded with the right method
+		api.WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
+			req.Header.Set(handlers.ClientHeader, string(api.CheckInMethodCLI))
+			return nil
+		}),
+	}
+
+	// Attach an API token from the environment, or the cached bearer token if available, refreshed once it expires.
+	// Certificates are only checked without a token, so none is sent with a client certificate.
+	if useClientCert {
+		return api.NewClientWithResponses(apiURL, opts...)
 	}
 
-	// Attach cached bearer token if available
-	tok, loadErr := loadToken()
-	if loadErr == nil && tok != nil && tok.AccessToken != "" {
+	if apiToken := os.Getenv(apiTokenEnvVar); apiToken != "" {
+		opts = append(opts, withBearerToken(apiToken))
+	} else if tok, loadErr := loadToken(); loadErr == nil && tok != nil && tok.AccessToken != "" {
+		tok = refreshCachedToken(errOut, tok)
 		opts = append(opts, withBearerToken(tok.AccessToken))
 	}
 
-	client, err = api.NewClientWithResponses(apiURL, opts...)
-	return err
+	return api.NewClientWithResponses(apiURL, opts...)
 }
 
 // formatOutput handles conversion and writing to the command's designated output
@@ -125,7 +165,7 @@ var switchCmd = &cobra.Command{
 	Use:   "switch",
 	Short: "Manage dead man switches",
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		return initClient()
+		return initClient(cmd.ErrOrStderr())
 	},
 }
 
@@ -178,6 +218,42 @@ var createSwitchCmd = &cobra.Command{
 			DeleteAfterTriggered: &deleteAfter,
 		}
 
+		if cmd.Flags().Changed("labels") {
+			labels, _ := cmd.Flags().GetStringArray("labels")
+			body.Labels = &labels
+		}
+
+		if cmd.Flags().Changed("checkin-token") {
+			token, _ := cmd.Flags().GetString("checkin-token")
+			body.CheckInToken = &token
+		}
+
+		if cmd.Flags().Changed("alert-matchers") {
+			matchers, _ := cmd.Flags().GetStringToString("alert-matchers")
+			body.AlertMatchers = &matchers
+		}
+
+		setDuressFlags(cmd, &body)
+		setQuorumFlags(cmd, &body)
+		setContactFlags(cmd, &body)
+		setProtectionFlags(cmd, &body)
+		setRecurringFlags(cmd, &body)
+
+		if cmd.Flags().Changed("actions") {
+			actions, _ := cmd.Flags().GetStringArray("actions")
+			body.Actions = &actions
+		}
+
+		err := setChainFlags(cmd, &body)
+		if err != nil {
+			return err
+		}
+
+		err = setWebhookFlags(cmd, &body)
+		if err != nil {
+			return err
+		}
+
 		resp, err := client.PostSwitchWithResponse(context.Background(), body)
 		if err != nil {
 			return err
@@ -220,6 +296,7 @@ var updateSwitchCmd = &cobra.Command{
 			Notifiers:            existing.JSON200.Notifiers,
 			DeleteAfterTriggered: existing.JSON200.DeleteAfterTriggered,
 			Encrypted:            existing.JSON200.Encrypted,
+			Labels:               existing.JSON200.Labels,
 		}
 
 		if cmd.Flags().Changed("message") {
@@ -234,11 +311,55 @@ var updateSwitchCmd = &cobra.Command{
 		if cmd.Flags().Changed("delete-after-triggered") {
 			body.DeleteAfterTriggered = &deleteAfter
 		}
+		if cmd.Flags().Changed("labels") {
+			labels, _ := cmd.Flags().GetStringArray("labels")
+			body.Labels = &labels
+		}
+
+		if cmd.Flags().Changed("checkin-token") {
+			token, _ := cmd.Flags().GetString("checkin-token")
+			body.CheckInToken = &token
+		}
+
+		if cmd.Flags().Changed("alert-matchers") {
+			matchers, _ := cmd.Flags().GetStringToString("alert-matchers")
+			body.AlertMatchers = &matchers
+		}
+
+		setDuressFlags(cmd, &body)
+
+		// The quorum is validated against the members, so send the current ones when only the quorum changes
+		if cmd.Flags().Changed("quorum") && !cmd.Flags().Changed("members") {
+			body.Members = existing.JSON200.Members
+		}
+		setQuorumFlags(cmd, &body)
+		setContactFlags(cmd, &body)
+		setProtectionFlags(cmd, &body)
+		setRecurringFlags(cmd, &body)
+
+		if cmd.Flags().Changed("actions") {
+			actions, _ := cmd.Flags().GetStringArray("actions")
+			body.Actions = &actions
+		}
+
+		err = setChainFlags(cmd, &body)
+		if err != nil {
+			return err
+		}
+
+		err = setWebhookFlags(cmd, &body)
+		if err != nil {
+			return err
+		}
 
 		resp, err := client.PutSwitchIdWithResponse(ctx, id, body)
 		if err != nil {
 			return err
 		}
+		if resp.JSON202 != nil {
+			dumpResponse(cmd, resp.StatusCode(), resp.Body, resp.JSON202)
+			return nil
+		}
 		dumpResponse(cmd, resp.StatusCode(), resp.Body, resp.JSON200)
 		return nil
 	},
@@ -258,6 +379,10 @@ var deleteSwitchCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		if resp.JSON202 != nil {
+			dumpResponse(cmd, resp.StatusCode(), resp.Body, resp.JSON202)
+			return nil
+		}
 		dumpResponse(cmd, resp.StatusCode(), resp.Body, nil)
 		return nil
 	},
@@ -265,7 +390,109 @@ var deleteSwitchCmd = &cobra.Command{
 
 var resetSwitchCmd = &cobra.Command{
 	Use:   "reset [id]",
-	Short: "Reset a dead man switch timer",
+	Short: "Reset a dead man switch timer, or all of your active switches with --all or --label",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, _ := cmd.Flags().GetBool("all")
+		label, _ := cmd.Flags().GetString("label")
+		bulk := all || label != ""
+
+		if bulk == (len(args) > 0) {
+			return fmt.Errorf("provide either a switch ID, --all or --label")
+		}
+
+		body := api.CheckInRequest{}
+		if cmd.Flags().Changed("code") {
+			code, _ := cmd.Flags().GetString("code")
+			body.Code = &code
+		}
+
+		ctx := context.Background()
+
+		if bulk {
+			params := &api.PostCheckinParams{}
+			if label != "" {
+				params.Label = &label
+			}
+
+			resp, err := client.PostCheckinWithResponse(ctx, params, body)
+			if err != nil {
+				return err
+			}
+			dumpResponse(cmd, resp.StatusCode(), resp.Body, resp.JSON200)
+			return nil
+		}
+
+		var id int
+		_, err := fmt.Sscanf(args[0], "%d", &id)
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.PostSwitchIdResetWithResponse(ctx, id, body)
+		if err != nil {
+			return err
+		}
+		dumpResponse(cmd, resp.StatusCode(), resp.Body, resp.JSON200)
+		return nil
+	},
+}
+
+var checkInsSwitchCmd = &cobra.Command{
+	Use:   "checkins [id]",
+	Short: "Show the check-in history of a dead man switch",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var id int
+		_, err := fmt.Sscanf(args[0], "%d", &id)
+		if err != nil {
+			return err
+		}
+
+		limit, _ := cmd.Flags().GetInt("limit")
+		offset, _ := cmd.Flags().GetInt("offset")
+
+		resp, err := client.GetSwitchIdCheckinsWithResponse(context.Background(), id, &api.GetSwitchIdCheckinsParams{
+			Limit:  &limit,
+			Offset: &offset,
+		})
+		if err != nil {
+			return err
+		}
+		dumpResponse(cmd, resp.StatusCode(), resp.Body, resp.JSON200)
+		return nil
+	},
+}
+
+var auditSwitchCmd = &cobra.Command{
+	Use:   "audit [id]",
+	Short: "Show the audit trail of a dead man switch",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var id int
+		_, err := fmt.Sscanf(args[0], "%d", &id)
+		if err != nil {
+			return err
+		}
+
+		limit, _ := cmd.Flags().GetInt("limit")
+		offset, _ := cmd.Flags().GetInt("offset")
+
+		resp, err := client.GetSwitchIdAuditWithResponse(context.Background(), id, &api.GetSwitchIdAuditParams{
+			Limit:  &limit,
+			Offset: &offset,
+		})
+		if err != nil {
+			return err
+		}
+		dumpResponse(cmd, resp.StatusCode(), resp.Body, resp.JSON200)
+		return nil
+	},
+}
+
+var deliveriesSwitchCmd = &cobra.Command{
+	Use:   "deliveries [id]",
+	Short: "Show the results of the actions and webhooks run when a dead man switch triggered",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		var id int
@@ -274,7 +501,13 @@ var resetSwitchCmd = &cobra.Command{
 			return err
 		}
 
-		resp, err := client.PostSwitchIdResetWithResponse(context.Background(), id)
+		limit, _ := cmd.Flags().GetInt("limit")
+		offset, _ := cmd.Flags().GetInt("offset")
+
+		resp, err := client.GetSwitchIdDeliveriesWithResponse(context.Background(), id, &api.GetSwitchIdDeliveriesParams{
+			Limit:  &limit,
+			Offset: &offset,
+		})
 		if err != nil {
 			return err
 		}
@@ -298,13 +531,323 @@ var disableSwitchCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		if resp.JSON202 != nil {
+			dumpResponse(cmd, resp.StatusCode(), resp.Body, resp.JSON202)
+			return nil
+		}
+		dumpResponse(cmd, resp.StatusCode(), resp.Body, resp.JSON200)
+		return nil
+	},
+}
+
+var changesCmd = &cobra.Command{
+	Use:   "changes",
+	Short: "List pending changes to protected switches that you requested or can approve",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resp, err := client.GetChangesWithResponse(context.Background())
+		if err != nil {
+			return err
+		}
+		dumpResponse(cmd, resp.StatusCode(), resp.Body, resp.JSON200)
+		return nil
+	},
+}
+
+var approveChangeCmd = &cobra.Command{
+	Use:   "approve [change-id]",
+	Short: "Approve and apply a pending change to a protected switch",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var id int
+		_, err := fmt.Sscanf(args[0], "%d", &id)
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.PostChangesIdApproveWithResponse(context.Background(), id)
+		if err != nil {
+			return err
+		}
 		dumpResponse(cmd, resp.StatusCode(), resp.Body, resp.JSON200)
 		return nil
 	},
 }
 
+var cancelChangeCmd = &cobra.Command{
+	Use:   "cancel-change [change-id]",
+	Short: "Cancel a pending change to a protected switch",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var id int
+		_, err := fmt.Sscanf(args[0], "%d", &id)
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.PostChangesIdCancelWithResponse(context.Background(), id)
+		if err != nil {
+			return err
+		}
+		dumpResponse(cmd, resp.StatusCode(), resp.Body, resp.JSON200)
+		return nil
+	},
+}
+
+var pauseSwitchCmd = &cobra.Command{
+	Use:   "pause [id]",
+	Short: "Pause a dead man switch, or all of your switches with --all",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, _ := cmd.Flags().GetBool("all")
+		until, _ := cmd.Flags().GetString("until")
+		duration, _ := cmd.Flags().GetDuration("duration")
+		policy, _ := cmd.Flags().GetString("policy")
+
+		if all == (len(args) > 0) {
+			return fmt.Errorf("provide either a switch ID or --all")
+		}
+
+		body := api.PauseRequest{}
+
+		switch {
+		case until != "" && cmd.Flags().Changed("duration"):
+			return fmt.Errorf("--until and --duration cannot be used together")
+		case until != "":
+			t, err := time.Parse(time.RFC3339, until)
+			if err != nil {
+				return fmt.Errorf("invalid --until value, expected RFC3339 (e.g. 2025-01-02T15:04:05Z): %w", err)
+			}
+			u := t.Unix()
+			body.Until = &u
+		case cmd.Flags().Changed("duration"):
+			d := duration.String()
+			body.Duration = &d
+		default:
+			return fmt.Errorf("provide either --until or --duration")
+		}
+
+		if cmd.Flags().Changed("policy") {
+			p := api.PauseRequestResumePolicy(policy)
+			body.ResumePolicy = &p
+		}
+
+		ctx := context.Background()
+
+		if all {
+			resp, err := client.PostSwitchPauseWithResponse(ctx, body)
+			if err != nil {
+				return err
+			}
+			dumpResponse(cmd, resp.StatusCode(), resp.Body, resp.JSON200)
+			return nil
+		}
+
+		var id int
+		_, err := fmt.Sscanf(args[0], "%d", &id)
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.PostSwitchIdPauseWithResponse(ctx, id, body)
+		if err != nil {
+			return err
+		}
+		if resp.JSON202 != nil {
+			dumpResponse(cmd, resp.StatusCode(), resp.Body, resp.JSON202)
+			return nil
+		}
+		dumpResponse(cmd, resp.StatusCode(), resp.Body, resp.JSON200)
+		return nil
+	},
+}
+
+var resumeSwitchCmd = &cobra.Command{
+	Use:   "resume [id]",
+	Short: "Resume a paused dead man switch, or all of your paused switches with --all",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, _ := cmd.Flags().GetBool("all")
+
+		if all == (len(args) > 0) {
+			return fmt.Errorf("provide either a switch ID or --all")
+		}
+
+		ctx := context.Background()
+
+		if all {
+			resp, err := client.PostSwitchResumeWithResponse(ctx)
+			if err != nil {
+				return err
+			}
+			dumpResponse(cmd, resp.StatusCode(), resp.Body, resp.JSON200)
+			return nil
+		}
+
+		var id int
+		_, err := fmt.Sscanf(args[0], "%d", &id)
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.PostSwitchIdResumeWithResponse(ctx, id)
+		if err != nil {
+			return err
+		}
+		dumpResponse(cmd, resp.StatusCode(), resp.Body, resp.JSON200)
+		return nil
+	},
+}
+
+// setDuressFlags copies the duress flags onto a switch request body when they are set.
+func setDuressFlags(cmd *cobra.Command, body *api.Switch) {
+	if cmd.Flags().Changed("duress-code") {
+		code, _ := cmd.Flags().GetString("duress-code")
+		body.DuressCode = &code
+	}
+	if cmd.Flags().Changed("duress-notifiers") {
+		notifiers, _ := cmd.Flags().GetStringArray("duress-notifiers")
+		body.DuressNotifiers = &notifiers
+	}
+}
+
+// setQuorumFlags copies the quorum flags onto a switch request body when they are set.
+// Members are given as a user ID, optionally followed by =<notifier URL> for reminders.
+func setQuorumFlags(cmd *cobra.Command, body *api.Switch) {
+	if cmd.Flags().Changed("members") {
+		values, _ := cmd.Flags().GetStringArray("members")
+		members := make([]api.SwitchMember, 0, len(values))
+		for _, value := range values {
+			userID, notifier, found := strings.Cut(value, "=")
+			member := api.SwitchMember{UserId: userID}
+			if found {
+				member.Notifier = &notifier
+			}
+			members = append(members, member)
+		}
+		body.Members = &members
+	}
+	if cmd.Flags().Changed("quorum") {
+		quorum, _ := cmd.Flags().GetInt("quorum")
+		body.Quorum = &quorum
+	}
+}
+
+// setContactFlags copies the trusted contact flags onto a switch request body when they are set.
+// Contacts are given as <name>=<notifier URL>, or just <name> to keep a contact's current notifier on update.
+func setContactFlags(cmd *cobra.Command, body *api.Switch) {
+	if cmd.Flags().Changed("trusted-contacts") {
+		values, _ := cmd.Flags().GetStringArray("trusted-contacts")
+		contacts := make([]api.TrustedContact, 0, len(values))
+		for _, value := range values {
+			name, notifier, found := strings.Cut(value, "=")
+			contact := api.TrustedContact{Name: name}
+			if found {
+				contact.Notifier = &notifier
+			}
+			contacts = append(contacts, contact)
+		}
+		body.TrustedContacts = &contacts
+	}
+	if cmd.Flags().Changed("verification-window") {
+		window, _ := cmd.Flags().GetDuration("verification-window")
+		verificationWindow := window.String()
+		body.VerificationWindow = &verificationWindow
+	}
+	if cmd.Flags().Changed("max-postpone") {
+		postpone, _ := cmd.Flags().GetDuration("max-postpone")
+		maxPostpone := postpone.String()
+		body.MaxPostpone = &maxPostpone
+	}
+}
+
+// setProtectionFlags copies the protection flags onto a switch request body when they are set.
+func setProtectionFlags(cmd *cobra.Command, body *api.Switch) {
+	if cmd.Flags().Changed("protected") {
+		protected, _ := cmd.Flags().GetBool("protected")
+		body.Protected = &protected
+	}
+	if cmd.Flags().Changed("approvers") {
+		approvers, _ := cmd.Flags().GetStringArray("approvers")
+		body.Approvers = &approvers
+	}
+	if cmd.Flags().Changed("change-delay") {
+		delay, _ := cmd.Flags().GetDuration("change-delay")
+		changeDelay := delay.String()
+		body.ChangeDelay = &changeDelay
+	}
+}
+
+// setRecurringFlags copies the re-arm and repeat flags onto a switch request body when they are set.
+func setRecurringFlags(cmd *cobra.Command, body *api.Switch) {
+	if cmd.Flags().Changed("rearm") {
+		rearm, _ := cmd.Flags().GetBool("rearm")
+		body.Rearm = &rearm
+	}
+	if cmd.Flags().Changed("max-triggers") {
+		maxTriggers, _ := cmd.Flags().GetInt("max-triggers")
+		body.MaxTriggers = &maxTriggers
+	}
+	if cmd.Flags().Changed("repeat-interval") {
+		interval, _ := cmd.Flags().GetDuration("repeat-interval")
+		repeatInterval := interval.String()
+		body.RepeatInterval = &repeatInterval
+	}
+}
+
+// setChainFlags copies the chain action and requirement flags onto a switch request body when they are set.
+func setChainFlags(cmd *cobra.Command, body *api.Switch) error {
+	if cmd.Flags().Changed("on-trigger") {
+		values, _ := cmd.Flags().GetStringArray("on-trigger")
+		actions := make([]api.ChainAction, 0, len(values))
+		for _, value := range values {
+			target, action, found := strings.Cut(value, "=")
+			if !found {
+				return fmt.Errorf("invalid chain action %q, expected <switch-id>=arm or <switch-id>=trigger", value)
+			}
+
+			switchID, err := strconv.Atoi(target)
+			if err != nil {
+				return fmt.Errorf("invalid switch id in chain action %q: %w", value, err)
+			}
+
+			actions = append(actions, api.ChainAction{SwitchId: switchID, Action: api.ChainActionAction(action)})
+		}
+		body.OnTrigger = &actions
+	}
+	if cmd.Flags().Changed("requires") {
+		requires, _ := cmd.Flags().GetIntSlice("requires")
+		body.Requires = &requires
+	}
+
+	return nil
+}
+
+// setWebhookFlags reads the webhooks file onto a switch request body when it is set.
+func setWebhookFlags(cmd *cobra.Command, body *api.Switch) error {
+	if !cmd.Flags().Changed("webhooks-file") {
+		return nil
+	}
+
+	path, _ := cmd.Flags().GetString("webhooks-file")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	webhooks := []api.Webhook{}
+	err = json.Unmarshal(data, &webhooks)
+	if err != nil {
+		return fmt.Errorf("invalid webhooks file %q: %w", path, err)
+	}
+	body.Webhooks = &webhooks
+
+	return nil
+}
+
 func init() {
 	switchCmd.PersistentFlags().StringVarP(&apiURL, "url", "u", "http://localhost:8080/api/v1", "API base URL")
+	switchCmd.PersistentFlags().StringVar(&clientCertFile, "client-cert", "", "PEM client certificate for servers that sign in with client certificates")
+	switchCmd.PersistentFlags().StringVar(&clientKeyFile, "client-key", "", "PEM key of the client certificate")
 	switchCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "json", "Output format (json, yaml)")
 	switchCmd.PersistentFlags().BoolVar(&useColor, "color", true, "Enable colorized output")
 
@@ -313,12 +856,52 @@ func init() {
 		c.Flags().DurationP("interval", "i", time.Hour*24, "Check-in interval (e.g. 1h, 30m)")
 		c.Flags().StringArrayP("notifiers", "n", []string{}, "Notifier URLs")
 		c.Flags().BoolP("delete-after-triggered", "d", false, "Delete switch after notification is triggered")
+		c.Flags().StringArrayP("labels", "l", []string{}, "Labels used to group switches")
+		c.Flags().String("checkin-token", "", "Secret that checks in to the switch without logging in, such as over MQTT (empty string removes it)")
+		c.Flags().StringToString("alert-matchers", map[string]string{}, "Labels a firing Alertmanager alert needs to check in with the check-in token, as name=value (defaults to alertname=Watchdog)")
+		c.Flags().String("duress-code", "", "Alternate check-in code that silently triggers the switch (empty string removes it)")
+		c.Flags().StringArray("duress-notifiers", []string{}, "Notifier URLs alerted when the duress code is used (defaults to the switch notifiers)")
+		c.Flags().StringArray("members", []string{}, "Users who must check in, as <user-id> or <user-id>=<reminder notifier URL>")
+		c.Flags().Int("quorum", 0, "Number of members who must check in each interval (defaults to every member)")
+		c.Flags().StringArray("trusted-contacts", []string{}, "Contacts asked to verify the switch before it is released, as <name>=<notifier URL>")
+		c.Flags().Duration("verification-window", 0, "How long trusted contacts have to respond before release (defaults to 24h)")
+		c.Flags().Duration("max-postpone", 0, "Longest a trusted contact can postpone the release (defaults to 72h)")
+		c.Flags().Bool("protected", false, "Hold back disabling, deleting or weakening the switch until approved or delayed")
+		c.Flags().StringArray("approvers", []string{}, "Users who can approve changes to a protected switch")
+		c.Flags().Duration("change-delay", 0, "How long changes to a protected switch without approvers wait before applying (defaults to 72h)")
+		c.Flags().Bool("rearm", false, "Start a fresh interval after the switch triggers instead of staying triggered")
+		c.Flags().Int("max-triggers", 0, "With --rearm, stop re-arming after this many triggers in a row without a check-in")
+		c.Flags().Duration("repeat-interval", 0, "Send still missing alerts this often after the switch triggers until someone checks in")
+		c.Flags().StringArray("on-trigger", []string{}, "Switches to act on when the switch triggers, as <switch-id>=arm or <switch-id>=trigger")
+		c.Flags().IntSlice("requires", []int{}, "IDs of switches that must also have expired before the switch triggers")
+		c.Flags().StringArray("actions", []string{}, "Names of actions from the server configuration to run on the host when the switch triggers")
+		c.Flags().String("webhooks-file", "", "JSON file with the signed webhooks to send when the switch triggers")
 	}
 
+	resetSwitchCmd.Flags().String("code", "", "Check-in code")
+	resetSwitchCmd.Flags().Bool("all", false, "Reset all of your active switches")
+	resetSwitchCmd.Flags().String("label", "", "Reset all of your active switches with this label")
+
+	checkInsSwitchCmd.Flags().Int("limit", 50, "Maximum number of check-ins to show")
+	checkInsSwitchCmd.Flags().Int("offset", 0, "Number of check-ins to skip")
+
+	auditSwitchCmd.Flags().Int("limit", 50, "Maximum number of audit events to show")
+	auditSwitchCmd.Flags().Int("offset", 0, "Number of audit events to skip")
+
+	deliveriesSwitchCmd.Flags().Int("limit", 50, "Maximum number of deliveries to show")
+	deliveriesSwitchCmd.Flags().Int("offset", 0, "Number of deliveries to skip")
+
 	createSwitchCmd.Flags().BoolP("encrypt", "e", false, "Encrypt notifiers/message")
 	_ = createSwitchCmd.MarkFlagRequired("message")
 	_ = createSwitchCmd.MarkFlagRequired("notifiers")
 
-	switchCmd.AddCommand(getSwitchesCmd, createSwitchCmd, updateSwitchCmd, deleteSwitchCmd, resetSwitchCmd, disableSwitchCmd)
+	pauseSwitchCmd.Flags().Bool("all", false, "Pause all of your active switches")
+	pauseSwitchCmd.Flags().String("until", "", "Resume the switch at this time (RFC3339, e.g. 2025-01-02T15:04:05Z)")
+	pauseSwitchCmd.Flags().Duration("duration", 0, "Pause the switch for this long (e.g. 72h)")
+	pauseSwitchCmd.Flags().String("policy", "preserve", "How to re-arm on resume: 'preserve' keeps the remaining time, 'reset' starts a fresh interval")
+
+	resumeSwitchCmd.Flags().Bool("all", false, "Resume all of your paused switches")
+
+	switchCmd.AddCommand(getSwitchesCmd, createSwitchCmd, updateSwitchCmd, deleteSwitchCmd, resetSwitchCmd, checkInsSwitchCmd, auditSwitchCmd, deliveriesSwitchCmd, disableSwitchCmd, pauseSwitchCmd, resumeSwitchCmd, changesCmd, approveChangeCmd, cancelChangeCmd)
 	rootCmd.AddCommand(switchCmd)
 }
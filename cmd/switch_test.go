@@ -2,13 +2,26 @@ package cmd
 
 import (
 	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 // executeCommand is a helper to run cobra commands and capture output
@@ -22,6 +35,18 @@ func executeCommand(args ...string) (string, error) {
 	return buf.String(), err
 }
 
+// resetFlags restores a command's flags to their defaults so values don't leak between tests
+func resetFlags(cmd *cobra.Command) {
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			_ = sv.Replace([]string{})
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	})
+}
+
 func Test_CreateCommand(t *testing.T) {
 	// Setup a mock server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -63,6 +88,273 @@ func Test_CreateCommand(t *testing.T) {
 	}
 }
 
+func Test_CreateCommand_Members(t *testing.T) {
+	t.Cleanup(func() { resetFlags(createSwitchCmd) })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body api.Switch
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		if body.Members == nil || len(*body.Members) != 2 {
+			t.Fatalf("expected 2 members, got %v", body.Members)
+		}
+		alice, bob := (*body.Members)[0], (*body.Members)[1]
+		if alice.UserId != "alice" || alice.Notifier == nil || *alice.Notifier != "ntfy://ntfy.sh/alice" {
+			t.Errorf("unexpected member %+v", alice)
+		}
+		if bob.UserId != "bob" || bob.Notifier != nil {
+			t.Errorf("unexpected member %+v", bob)
+		}
+		if body.Quorum == nil || *body.Quorum != 1 {
+			t.Errorf("expected quorum 1, got %v", body.Quorum)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer server.Close()
+
+	_, err := executeCommand("switch", "create", "-m", "team", "-n", "logger://",
+		"--members", "alice=ntfy://ntfy.sh/alice", "--members", "bob", "--quorum", "1",
+		"--url", server.URL, "--color=false")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func Test_CreateCommand_TrustedContacts(t *testing.T) {
+	t.Cleanup(func() { resetFlags(createSwitchCmd) })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body api.Switch
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		if body.TrustedContacts == nil || len(*body.TrustedContacts) != 1 {
+			t.Fatalf("expected 1 trusted contact, got %v", body.TrustedContacts)
+		}
+		sam := (*body.TrustedContacts)[0]
+		if sam.Name != "sam" || sam.Notifier == nil || *sam.Notifier != "ntfy://ntfy.sh/sam" {
+			t.Errorf("unexpected trusted contact %+v", sam)
+		}
+		if body.VerificationWindow == nil || *body.VerificationWindow != "12h0m0s" {
+			t.Errorf("expected verification window 12h0m0s, got %v", body.VerificationWindow)
+		}
+		if body.MaxPostpone == nil || *body.MaxPostpone != "48h0m0s" {
+			t.Errorf("expected max postpone 48h0m0s, got %v", body.MaxPostpone)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer server.Close()
+
+	_, err := executeCommand("switch", "create", "-m", "verified", "-n", "logger://",
+		"--trusted-contacts", "sam=ntfy://ntfy.sh/sam", "--verification-window", "12h", "--max-postpone", "48h",
+		"--url", server.URL, "--color=false")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func Test_CreateCommand_Recurring(t *testing.T) {
+	t.Cleanup(func() { resetFlags(createSwitchCmd) })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body api.Switch
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		if body.Rearm == nil || !*body.Rearm {
+			t.Errorf("expected rearm to be set, got %v", body.Rearm)
+		}
+		if body.MaxTriggers == nil || *body.MaxTriggers != 3 {
+			t.Errorf("expected max triggers 3, got %v", body.MaxTriggers)
+		}
+		if body.RepeatInterval == nil || *body.RepeatInterval != "6h0m0s" {
+			t.Errorf("expected repeat interval 6h0m0s, got %v", body.RepeatInterval)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer server.Close()
+
+	_, err := executeCommand("switch", "create", "-m", "heartbeat", "-n", "logger://",
+		"--rearm", "--max-triggers", "3", "--repeat-interval", "6h",
+		"--url", server.URL, "--color=false")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func Test_CreateCommand_CheckInToken(t *testing.T) {
+	t.Cleanup(func() { resetFlags(createSwitchCmd) })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body api.Switch
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		if body.CheckInToken == nil || *body.CheckInToken != "a-long-random-check-in-token" {
+			t.Errorf("expected the check-in token to be sent, got %v", body.CheckInToken)
+		}
+		if body.AlertMatchers == nil || (*body.AlertMatchers)["alertname"] != "Watchdog" || (*body.AlertMatchers)["cluster"] != "production" {
+			t.Errorf("expected the alert matchers to be sent, got %v", body.AlertMatchers)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer server.Close()
+
+	_, err := executeCommand("switch", "create", "-m", "button", "-n", "logger://",
+		"--checkin-token", "a-long-random-check-in-token",
+		"--alert-matchers", "alertname=Watchdog,cluster=production",
+		"--url", server.URL, "--color=false")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func Test_CreateCommand_Chained(t *testing.T) {
+	t.Cleanup(func() { resetFlags(createSwitchCmd) })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body api.Switch
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		if body.OnTrigger == nil || len(*body.OnTrigger) != 2 {
+			t.Fatalf("expected 2 chain actions, got %v", body.OnTrigger)
+		}
+		if (*body.OnTrigger)[0] != (api.ChainAction{SwitchId: 2, Action: api.ChainActionArm}) || (*body.OnTrigger)[1] != (api.ChainAction{SwitchId: 3, Action: api.ChainActionTrigger}) {
+			t.Errorf("unexpected chain actions %+v", *body.OnTrigger)
+		}
+		if body.Requires == nil || len(*body.Requires) != 2 || (*body.Requires)[0] != 4 || (*body.Requires)[1] != 5 {
+			t.Errorf("expected required switches [4 5], got %v", body.Requires)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer server.Close()
+
+	_, err := executeCommand("switch", "create", "-m", "chained", "-n", "logger://",
+		"--on-trigger", "2=arm", "--on-trigger", "3=trigger", "--requires", "4,5",
+		"--url", server.URL, "--color=false")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	resetFlags(createSwitchCmd)
+
+	_, err = executeCommand("switch", "create", "-m", "chained", "-n", "logger://",
+		"--on-trigger", "two=arm", "--url", server.URL, "--color=false")
+	if err == nil {
+		t.Error("expected an error for an invalid chain action")
+	}
+}
+
+func Test_CreateCommand_Webhooks(t *testing.T) {
+	t.Cleanup(func() { resetFlags(createSwitchCmd) })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body api.Switch
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		if body.Webhooks == nil || len(*body.Webhooks) != 1 {
+			t.Fatalf("expected 1 webhook, got %v", body.Webhooks)
+		}
+		hook := (*body.Webhooks)[0]
+		if hook.Url != "https://example.com/hook" || hook.Secret == nil || *hook.Secret != "0123456789abcdef" {
+			t.Errorf("unexpected webhook %+v", hook)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "webhooks.json")
+	err := os.WriteFile(path, []byte(`[{"url": "https://example.com/hook", "secret": "0123456789abcdef"}]`), 0o600)
+	if err != nil {
+		t.Fatalf("failed to write webhooks file: %v", err)
+	}
+
+	_, err = executeCommand("switch", "create", "-m", "webhooks", "-n", "logger://",
+		"--webhooks-file", path, "--url", server.URL, "--color=false")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	resetFlags(createSwitchCmd)
+
+	_, err = executeCommand("switch", "create", "-m", "webhooks", "-n", "logger://",
+		"--webhooks-file", filepath.Join(t.TempDir(), "missing.json"), "--url", server.URL, "--color=false")
+	if err == nil {
+		t.Error("expected an error for a missing webhooks file")
+	}
+}
+
+func Test_DisableCommand_Protected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/switch/1/disable" {
+			t.Errorf("expected path %q, got %q", "/switch/1/disable", r.URL.Path)
+		}
+
+		id := 3
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(api.PendingChange{
+			Id:       &id,
+			SwitchId: 1,
+			Action:   api.PendingChangeActionDisable,
+			Status:   api.PendingChangeStatusPending,
+			Summary:  "Disable the switch",
+		})
+	}))
+	defer server.Close()
+
+	output, err := executeCommand("switch", "disable", "1", "--url", server.URL, "--color=false")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, `"status": "pending"`) {
+		t.Errorf("expected output to show the pending change, got %q", output)
+	}
+}
+
+func Test_ApproveCommand(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/changes/3/approve" {
+			t.Errorf("expected POST %q, got %s %q", "/changes/3/approve", r.Method, r.URL.Path)
+		}
+
+		id := 3
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(api.PendingChange{
+			Id:       &id,
+			SwitchId: 1,
+			Action:   api.PendingChangeActionDisable,
+			Status:   api.PendingChangeStatusApplied,
+			Summary:  "Disable the switch",
+		})
+	}))
+	defer server.Close()
+
+	output, err := executeCommand("switch", "approve", "3", "--url", server.URL, "--color=false")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, `"status": "applied"`) {
+		t.Errorf("expected output to show the applied change, got %q", output)
+	}
+}
+
 func Test_GetCommand_NotFound(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -84,6 +376,117 @@ func Test_GetCommand_NotFound(t *testing.T) {
 	}
 }
 
+func Test_GetCommand_ClientCert(t *testing.T) {
+	t.Cleanup(func() { clientCertFile, clientKeyFile = "", "" })
+
+	dir := t.TempDir()
+	_, err := executeCommand("switch", "get", "--url", "https://localhost:1", "--color=false",
+		"--client-cert", filepath.Join(dir, "device.pem"), "--client-key", filepath.Join(dir, "device.key"))
+
+	if err == nil || !strings.Contains(err.Error(), "failed to load client certificate") {
+		t.Errorf("expected the missing client certificate to be reported, got %v", err)
+	}
+}
+
+// writeTestClientCert writes a self-signed client certificate and its key to PEM files in dir.
+func writeTestClientCert(t *testing.T, dir string) (*x509.Certificate, string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "garage-pi"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPath := filepath.Join(dir, "device.pem")
+	keyPath := filepath.Join(dir, "device.key")
+
+	err = os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert, certPath, keyPath
+}
+
+func Test_GetCommand_ClientCertSignsIn(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv(apiTokenEnvVar, "dms_stale")
+	t.Cleanup(func() { clientCertFile, clientKeyFile = "", "" })
+
+	// A stale cached login would be rejected before the certificate is checked
+	err := saveToken(&tokenCache{AccessToken: "expired-token", TokenType: "Bearer"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, certPath, keyPath := writeTestClientCert(t, dir)
+
+	var receivedAuth, receivedCN string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedAuth = r.Header.Get("Authorization")
+		if len(r.TLS.PeerCertificates) > 0 {
+			receivedCN = r.TLS.PeerCertificates[0].Subject.CommonName
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(cert)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	// Trust the test server's certificate for the duration of the test
+	defaultTransport := http.DefaultTransport
+	http.DefaultTransport = server.Client().Transport
+	t.Cleanup(func() { http.DefaultTransport = defaultTransport })
+
+	_, err = executeCommand("switch", "get", "--url", server.URL, "--color=false", "--client-cert", certPath, "--client-key", keyPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if receivedCN != "garage-pi" {
+		t.Errorf("expected the client certificate to be presented, got common name %q", receivedCN)
+	}
+	if receivedAuth != "" {
+		t.Errorf("expected no Authorization header alongside the client certificate, got %q", receivedAuth)
+	}
+}
+
 func Test_ResetCommand(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/switch/1/reset" {
@@ -133,3 +536,132 @@ func Test_DisableCommand(t *testing.T) {
 		t.Errorf("expected output to contain %q, got %q", `"status": "disabled"`, output)
 	}
 }
+
+func Test_PauseCommand(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/switch/1/pause" {
+			t.Errorf("expected path %q, got %q", "/switch/1/pause", r.URL.Path)
+		}
+
+		body := api.PauseRequest{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body.Duration == nil || *body.Duration != "72h0m0s" {
+			t.Errorf("expected duration %q, got %v", "72h0m0s", body.Duration)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		id := 1
+		status := api.SwitchStatusPaused
+		_ = json.NewEncoder(w).Encode(api.Switch{
+			Id:     &id,
+			Status: &status,
+		})
+	}))
+	defer server.Close()
+
+	output, err := executeCommand("switch", "pause", "1", "--duration", "72h", "--url", server.URL, "--color=false")
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, `"status": "paused"`) {
+		t.Errorf("expected output to contain %q, got %q", `"status": "paused"`, output)
+	}
+}
+
+func Test_ResetCommand_WithCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := api.CheckInRequest{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body.Code == nil || *body.Code != "1234" {
+			t.Errorf("expected code %q, got %v", "1234", body.Code)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		id := 1
+		_ = json.NewEncoder(w).Encode(api.Switch{Id: &id})
+	}))
+	defer server.Close()
+
+	_, err := executeCommand("switch", "reset", "1", "--code", "1234", "--url", server.URL, "--color=false")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func Test_CheckInsCommand(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/switch/1/checkins" {
+			t.Errorf("expected path %q, got %q", "/switch/1/checkins", r.URL.Path)
+		}
+		if r.URL.Query().Get("limit") != "5" {
+			t.Errorf("expected limit %q, got %q", "5", r.URL.Query().Get("limit"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		id := 1
+		_ = json.NewEncoder(w).Encode([]api.CheckIn{{Id: &id, SwitchId: 1, Method: api.CheckInMethodCLI}})
+	}))
+	defer server.Close()
+
+	output, err := executeCommand("switch", "checkins", "1", "--limit", "5", "--url", server.URL, "--color=false")
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, `"method": "cli"`) {
+		t.Errorf("expected output to contain %q, got %q", `"method": "cli"`, output)
+	}
+}
+
+func Test_DeliveriesCommand(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/switch/1/deliveries" {
+			t.Errorf("expected path %q, got %q", "/switch/1/deliveries", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		id, exitCode := 1, 0
+		_ = json.NewEncoder(w).Encode([]api.Delivery{{Id: &id, SwitchId: 1, Kind: api.DeliveryKindAction, Target: "wipe-secrets", Success: true, ExitCode: &exitCode}})
+	}))
+	defer server.Close()
+
+	output, err := executeCommand("switch", "deliveries", "1", "--url", server.URL, "--color=false")
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, `"target": "wipe-secrets"`) {
+		t.Errorf("expected output to contain %q, got %q", `"target": "wipe-secrets"`, output)
+	}
+}
+
+func Test_ResetCommand_Label(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/checkin" {
+			t.Errorf("expected path %q, got %q", "/checkin", r.URL.Path)
+		}
+		if r.URL.Query().Get("label") != "travel" {
+			t.Errorf("expected label %q, got %q", "travel", r.URL.Query().Get("label"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(api.BulkCheckInResponse{
+			CheckedIn: 1,
+			Results:   []api.BulkCheckInResult{{Id: 1, Success: true}},
+		})
+	}))
+	defer server.Close()
+
+	output, err := executeCommand("switch", "reset", "--label", "travel", "--url", server.URL, "--color=false")
+	resetSwitchCmd.Flags().Set("label", "")
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, `"checkedIn": 1`) {
+		t.Errorf("expected output to contain %q, got %q", `"checkedIn": 1`, output)
+	}
+}
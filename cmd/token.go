@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/spf13/cobra"
+)
+
+// apiTokenEnvVar holds an API token to use instead of the cached login, such as in CI.
+const apiTokenEnvVar = "DEAD_MANS_SWITCH_TOKEN"
+
+var tokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Manage personal access tokens for scripts and CI",
+	Long: `Manage personal access tokens for scripts and CI.
+
+Tokens are sent as "Authorization: Bearer <token>" and are limited to their scopes:
+  read     view switches, check-ins, audit logs and webhooks
+  checkin  check in to and reset switches
+  write    everything but managing tokens
+  admin    everything, including managing tokens
+
+The CLI uses the token in the ` + apiTokenEnvVar + ` environment variable instead of the cached login when it is set.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initClient(cmd.ErrOrStderr())
+	},
+}
+
+var createTokenCmd = &cobra.Command{
+	Use:   "create [name]",
+	Short: "Create a token, which is only shown once",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		values, _ := cmd.Flags().GetStringSlice("scopes")
+		scopes := make([]api.TokenScope, 0, len(values))
+		for _, value := range values {
+			scopes = append(scopes, api.TokenScope(value))
+		}
+
+		body := api.APIToken{Name: args[0], Scopes: scopes}
+		if cmd.Flags().Changed("expires-in") {
+			expiresIn, _ := cmd.Flags().GetDuration("expires-in")
+			expiresAt := time.Now().Add(expiresIn).Unix()
+			body.ExpiresAt = &expiresAt
+		}
+
+		resp, err := client.PostTokensWithResponse(context.Background(), body)
+		if err != nil {
+			return err
+		}
+		dumpResponse(cmd, resp.StatusCode(), resp.Body, resp.JSON201)
+		return nil
+	},
+}
+
+var listTokensCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List your tokens and when they were last used",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resp, err := client.GetTokensWithResponse(context.Background())
+		if err != nil {
+			return err
+		}
+		dumpResponse(cmd, resp.StatusCode(), resp.Body, resp.JSON200)
+		return nil
+	},
+}
+
+var revokeTokenCmd = &cobra.Command{
+	Use:   "revoke [id]",
+	Short: "Revoke a token so it can no longer be used",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var id int
+		_, err := fmt.Sscanf(args[0], "%d", &id)
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.DeleteTokensIdWithResponse(context.Background(), id)
+		if err != nil {
+			return err
+		}
+		dumpResponse(cmd, resp.StatusCode(), resp.Body, nil)
+		return nil
+	},
+}
+
+func init() {
+	tokenCmd.PersistentFlags().StringVarP(&apiURL, "url", "u", "http://localhost:8080/api/v1", "API base URL")
+	tokenCmd.PersistentFlags().StringVar(&clientCertFile, "client-cert", "", "PEM client certificate for servers that sign in with client certificates")
+	tokenCmd.PersistentFlags().StringVar(&clientKeyFile, "client-key", "", "PEM key of the client certificate")
+	tokenCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "json", "Output format (json, yaml)")
+	tokenCmd.PersistentFlags().BoolVar(&useColor, "color", true, "Enable colorized output")
+
+	createTokenCmd.Flags().StringSlice("scopes", []string{string(api.TokenScopeRead)}, "Scopes of the token (read, checkin, write, admin)")
+	createTokenCmd.Flags().Duration("expires-in", 0, "How long until the token expires, such as 720h (defaults to never)")
+
+	tokenCmd.AddCommand(createTokenCmd, listTokensCmd, revokeTokenCmd)
+	rootCmd.AddCommand(tokenCmd)
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+func Test_TokenCreateCommand(t *testing.T) {
+	t.Cleanup(func() { resetFlags(createTokenCmd) })
+	t.Setenv(apiTokenEnvVar, "dms_ci-token")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tokens" || r.Method != http.MethodPost {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer dms_ci-token" {
+			t.Errorf("expected the token from the environment, got %q", auth)
+		}
+
+		var body api.APIToken
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		if body.Name != "ci" || len(body.Scopes) != 2 || body.Scopes[1] != api.TokenScopeCheckIn {
+			t.Errorf("unexpected token %+v", body)
+		}
+		if body.ExpiresAt == nil || *body.ExpiresAt < time.Now().Add(23*time.Hour).Unix() {
+			t.Errorf("unexpected expiry %v", body.ExpiresAt)
+		}
+
+		id := 1
+		token := "dms_new-token"
+		body.Id = &id
+		body.Token = &token
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer server.Close()
+
+	out, err := executeCommand("token", "create", "ci", "--scopes", "read,checkin", "--expires-in", "24h",
+		"--url", server.URL, "--color=false")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "dms_new-token") {
+		t.Errorf("expected the token in the output, got %q", out)
+	}
+}
+
+func Test_TokenRevokeCommand(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tokens/3" || r.Method != http.MethodDelete {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	out, err := executeCommand("token", "revoke", "3", "--url", server.URL, "--color=false")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Success") {
+		t.Errorf("expected success, got %q", out)
+	}
+
+	_, err = executeCommand("token", "revoke", "abc", "--url", server.URL, "--color=false")
+	if err == nil {
+		t.Error("expected an error for an invalid ID")
+	}
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/spf13/cobra"
+)
+
+// watchRetry is how long watch waits before reconnecting a dropped stream. Tests shorten it.
+var watchRetry = 5 * time.Second
+
+var watchSwitchCmd = &cobra.Command{
+	Use:   "watch [id]",
+	Short: "Stream live updates of your switches, or of a single switch by ID",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var id int
+		if len(args) > 0 {
+			_, err := fmt.Sscanf(args[0], "%d", &id)
+			if err != nil {
+				return err
+			}
+		}
+
+		count, _ := cmd.Flags().GetInt("count")
+
+		// The stream stays open, so it can't share the request timeout of other commands
+		streamClient, err := newClient(cmd.ErrOrStderr(), &http.Client{})
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		var lastID *int64
+		received := 0
+
+		for {
+			err = streamEvents(ctx, streamClient, lastID, func(event api.Event) bool {
+				lastID = &event.Id
+				if id != 0 && event.SwitchId != id {
+					return true
+				}
+
+				formatOutput(cmd, event, false)
+				received++
+				return count == 0 || received < count
+			})
+			if ctx.Err() != nil || (count > 0 && received >= count) {
+				return nil
+			}
+
+			var streamErr *streamError
+			if errors.As(err, &streamErr) {
+				return err
+			}
+			if err != nil {
+				cmd.PrintErrf("Event stream interrupted: %v, reconnecting in %s\n", err, watchRetry)
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(watchRetry):
+			}
+		}
+	},
+}
+
+// streamError is a response that reconnecting won't fix, such as an authentication failure.
+type streamError struct {
+	statusCode int
+	body       []byte
+}
+
+func (e *streamError) Error() string {
+	var apiErr api.Error
+	if json.Unmarshal(e.body, &apiErr) == nil && apiErr.Message != "" {
+		return apiErr.Message
+	}
+	return fmt.Sprintf("received status code %d", e.statusCode)
+}
+
+// streamEvents reads the event stream, resuming after lastID when set, and passes each event to handle
+// until it returns false or the stream ends.
+func streamEvents(ctx context.Context, c *api.ClientWithResponses, lastID *int64, handle func(api.Event) bool) error {
+	resp, err := c.GetEvents(ctx, &api.GetEventsParams{LastEventID: lastID})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return &streamError{statusCode: resp.StatusCode, body: body}
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			// Comments, retry hints and the id and event fields, which the data repeats
+			continue
+		}
+
+		var event api.Event
+		err = json.Unmarshal([]byte(strings.TrimSpace(data)), &event)
+		if err != nil {
+			return fmt.Errorf("invalid event %q: %w", data, err)
+		}
+
+		if !handle(event) {
+			return nil
+		}
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return err
+	}
+
+	return io.ErrUnexpectedEOF
+}
+
+func init() {
+	watchSwitchCmd.Flags().Int("count", 0, "Stop after this many events (0 watches until interrupted)")
+
+	switchCmd.AddCommand(watchSwitchCmd)
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+func Test_WatchCommand(t *testing.T) {
+	t.Cleanup(func() { resetFlags(watchSwitchCmd) })
+
+	previous := watchRetry
+	watchRetry = 0
+	t.Cleanup(func() { watchRetry = previous })
+
+	writeEvent := func(w http.ResponseWriter, id int64, eventType api.EventType, switchID int) {
+		data, _ := json.Marshal(api.Event{Id: id, Type: eventType, SwitchId: switchID, UserId: "admin"})
+		_, _ = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", id, eventType, data)
+	}
+
+	var connections atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/events" {
+			t.Errorf("expected path %q, got %q", "/events", r.URL.Path)
+		}
+
+		connection := connections.Add(1)
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprint(w, "retry: 5000\n\n: heartbeat\n\n")
+
+		switch connection {
+		case 1:
+			if r.Header.Get("Last-Event-ID") != "" {
+				t.Errorf("expected no Last-Event-ID on the first connection")
+			}
+			writeEvent(w, 10, api.EventTypeCheckedIn, 1)
+			writeEvent(w, 11, api.EventTypeCreated, 2)
+		default:
+			if r.Header.Get("Last-Event-ID") != "11" {
+				t.Errorf("expected to resume after event 11, got %q", r.Header.Get("Last-Event-ID"))
+			}
+			writeEvent(w, 12, api.EventTypeTriggered, 1)
+		}
+	}))
+	defer server.Close()
+
+	out, err := executeCommand("switch", "watch", "1", "--count", "2", "--url", server.URL, "--color=false")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(out, `"switch.checked_in"`) || !strings.Contains(out, `"switch.triggered"`) || strings.Contains(out, `"switch.created"`) {
+		t.Errorf("expected the events of switch 1 only, got %s", out)
+	}
+	if connections.Load() != 2 {
+		t.Errorf("expected the stream to reconnect once, got %d connections", connections.Load())
+	}
+}
+
+func Test_WatchCommand_Unauthorized(t *testing.T) {
+	t.Cleanup(func() { resetFlags(watchSwitchCmd) })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(api.Error{Code: http.StatusUnauthorized, Message: "Unauthorized"})
+	}))
+	defer server.Close()
+
+	_, err := executeCommand("switch", "watch", "--url", server.URL, "--color=false")
+	if err == nil || err.Error() != "Unauthorized" {
+		t.Errorf("expected the API error, got %v", err)
+	}
+}
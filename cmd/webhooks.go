@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/spf13/cobra"
+)
+
+var webhooksCmd = &cobra.Command{
+	Use:   "webhooks",
+	Short: "Manage webhooks that receive the lifecycle events of your switches",
+}
+
+var listWebhooksCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List your webhook subscriptions",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resp, err := client.GetWebhooksWithResponse(context.Background())
+		if err != nil {
+			return err
+		}
+		dumpResponse(cmd, resp.StatusCode(), resp.Body, resp.JSON200)
+		return nil
+	},
+}
+
+var createWebhookCmd = &cobra.Command{
+	Use:   "create [url]",
+	Short: "Subscribe a webhook to the lifecycle events of your switches",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		body := api.WebhookSubscription{Url: args[0]}
+		setSubscriptionFlags(cmd, &body)
+
+		resp, err := client.PostWebhooksWithResponse(context.Background(), body)
+		if err != nil {
+			return err
+		}
+		dumpResponse(cmd, resp.StatusCode(), resp.Body, resp.JSON201)
+		return nil
+	},
+}
+
+var updateWebhookCmd = &cobra.Command{
+	Use:   "update [id] [url]",
+	Short: "Replace a webhook subscription, keeping its secret unless --secret is set",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var id int
+		_, err := fmt.Sscanf(args[0], "%d", &id)
+		if err != nil {
+			return err
+		}
+
+		body := api.WebhookSubscription{Url: args[1]}
+		setSubscriptionFlags(cmd, &body)
+
+		resp, err := client.PutWebhooksIdWithResponse(context.Background(), id, body)
+		if err != nil {
+			return err
+		}
+		dumpResponse(cmd, resp.StatusCode(), resp.Body, resp.JSON200)
+		return nil
+	},
+}
+
+var deleteWebhookCmd = &cobra.Command{
+	Use:   "delete [id]",
+	Short: "Remove a webhook subscription and its delivery log",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var id int
+		_, err := fmt.Sscanf(args[0], "%d", &id)
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.DeleteWebhooksIdWithResponse(context.Background(), id)
+		if err != nil {
+			return err
+		}
+		dumpResponse(cmd, resp.StatusCode(), resp.Body, nil)
+		return nil
+	},
+}
+
+var webhookDeliveriesCmd = &cobra.Command{
+	Use:   "deliveries [id]",
+	Short: "Show the events sent to a webhook subscription",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var id int
+		_, err := fmt.Sscanf(args[0], "%d", &id)
+		if err != nil {
+			return err
+		}
+
+		limit, _ := cmd.Flags().GetInt("limit")
+		offset, _ := cmd.Flags().GetInt("offset")
+
+		resp, err := client.GetWebhooksIdDeliveriesWithResponse(context.Background(), id, &api.GetWebhooksIdDeliveriesParams{
+			Limit:  &limit,
+			Offset: &offset,
+		})
+		if err != nil {
+			return err
+		}
+		dumpResponse(cmd, resp.StatusCode(), resp.Body, resp.JSON200)
+		return nil
+	},
+}
+
+// setSubscriptionFlags copies the subscription flags onto a webhook subscription request body when they are set.
+func setSubscriptionFlags(cmd *cobra.Command, body *api.WebhookSubscription) {
+	if cmd.Flags().Changed("secret") {
+		secret, _ := cmd.Flags().GetString("secret")
+		body.Secret = &secret
+	}
+	if cmd.Flags().Changed("events") {
+		values, _ := cmd.Flags().GetStringSlice("events")
+		eventTypes := make([]api.EventType, 0, len(values))
+		for _, value := range values {
+			eventTypes = append(eventTypes, api.EventType(value))
+		}
+		body.Events = &eventTypes
+	}
+	if cmd.Flags().Changed("disabled") {
+		disabled, _ := cmd.Flags().GetBool("disabled")
+		enabled := !disabled
+		body.Enabled = &enabled
+	}
+	if cmd.Flags().Changed("max-attempts") {
+		maxAttempts, _ := cmd.Flags().GetInt("max-attempts")
+		body.MaxAttempts = &maxAttempts
+	}
+	if cmd.Flags().Changed("retry-backoff") {
+		backoff, _ := cmd.Flags().GetDuration("retry-backoff")
+		retryBackoff := backoff.String()
+		body.RetryBackoff = &retryBackoff
+	}
+}
+
+func init() {
+	for _, c := range []*cobra.Command{createWebhookCmd, updateWebhookCmd} {
+		c.Flags().String("secret", "", "Key used to sign requests, at least 16 characters")
+		c.Flags().StringSlice("events", []string{}, "Event types to send, such as switch.triggered (defaults to every event)")
+		c.Flags().Bool("disabled", false, "Stop sending events without removing the subscription")
+		c.Flags().Int("max-attempts", 0, "How many times an event is attempted before giving up (defaults to 3)")
+		c.Flags().Duration("retry-backoff", 0, "Wait before the first retry, doubling for each later one (defaults to 2s)")
+	}
+	_ = createWebhookCmd.MarkFlagRequired("secret")
+
+	webhookDeliveriesCmd.Flags().Int("limit", 50, "Maximum number of deliveries to show")
+	webhookDeliveriesCmd.Flags().Int("offset", 0, "Number of deliveries to skip")
+
+	webhooksCmd.AddCommand(listWebhooksCmd, createWebhookCmd, updateWebhookCmd, deleteWebhookCmd, webhookDeliveriesCmd)
+	switchCmd.AddCommand(webhooksCmd)
+}
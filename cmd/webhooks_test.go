@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+func Test_WebhooksCreateCommand(t *testing.T) {
+	t.Cleanup(func() { resetFlags(createWebhookCmd) })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/webhooks" || r.Method != http.MethodPost {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var body api.WebhookSubscription
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		if body.Url != "https://example.com/events" || body.Secret == nil || *body.Secret != "0123456789abcdef" {
+			t.Errorf("unexpected subscription %+v", body)
+		}
+		if body.Events == nil || len(*body.Events) != 2 || (*body.Events)[1] != api.EventTypeFailed {
+			t.Errorf("unexpected events %v", body.Events)
+		}
+		if body.RetryBackoff == nil || *body.RetryBackoff != "5s" || body.Enabled != nil {
+			t.Errorf("unexpected retry settings %+v", body)
+		}
+
+		id := 1
+		body.Id = &id
+		body.Secret = nil
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer server.Close()
+
+	out, err := executeCommand("switch", "webhooks", "create", "https://example.com/events",
+		"--secret", "0123456789abcdef", "--events", "switch.triggered,switch.failed", "--retry-backoff", "5s",
+		"--url", server.URL, "--color=false")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !json.Valid([]byte(out)) {
+		t.Errorf("expected JSON output, got %q", out)
+	}
+}
+
+func Test_WebhooksDeliveriesCommand(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/webhooks/4/deliveries" || r.URL.Query().Get("limit") != "10" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+
+		subscriptionID := 4
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode([]api.Delivery{{
+			SwitchId:       1,
+			SubscriptionId: &subscriptionID,
+			Kind:           api.DeliveryKindEvent,
+			Target:         string(api.EventTypeTriggered),
+			Success:        true,
+		}})
+	}))
+	defer server.Close()
+
+	_, err := executeCommand("switch", "webhooks", "deliveries", "4", "--limit", "10", "--url", server.URL, "--color=false")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,225 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+// adminSwitchColumns are the switch columns admins see. They never include switch content, so an admin
+// can't read a message or notifier whether or not the switch is encrypted.
+const adminSwitchColumns = `id, user_id, status, check_in_interval, encrypted, protected, trigger_at, last_check_in_at, trigger_count`
+
+// UserSettings are what admins assigned to a user. Users without settings have the user role and the
+// server's default switch quota.
+type UserSettings struct {
+	UserID string
+	// Role is the role assigned to the user. Empty if none was assigned.
+	Role api.Role
+	// MaxSwitches overrides the server's default switch quota when set. 0 means unlimited.
+	MaxSwitches *int
+}
+
+// UserSummary is a user as listed to admins.
+type UserSummary struct {
+	UserSettings
+	SwitchCount  int
+	LocalAccount bool
+}
+
+// CountSwitches returns how many switches the given user owns.
+func (s *sqliteStore) CountSwitches(userID string) (int, error) {
+	count := 0
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM switches WHERE user_id = ?`, userID).Scan(&count)
+	return count, err
+}
+
+// DisableSwitch disables a switch regardless of its owner and returns its metadata. Returns sql.ErrNoRows if not found.
+func (s *sqliteStore) DisableSwitch(id int) (api.AdminSwitch, error) {
+	res, err := s.db.Exec(`UPDATE switches SET status = ? WHERE id = ?`, api.SwitchStatusDisabled, id)
+	if err != nil {
+		return api.AdminSwitch{}, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return api.AdminSwitch{}, err
+	}
+
+	if rows == 0 {
+		return api.AdminSwitch{}, sql.ErrNoRows
+	}
+
+	return scanAdminSwitch(s.db.QueryRow(`SELECT `+adminSwitchColumns+` FROM switches WHERE id = ?`, id))
+}
+
+// GetAdminStats returns usage statistics of the server, counting check-ins made since the given time.
+func (s *sqliteStore) GetAdminStats(since int64) (api.AdminStats, error) {
+	stats := api.AdminStats{SwitchesByStatus: map[string]int{}}
+
+	err := s.db.QueryRow(`SELECT COUNT(DISTINCT user_id), COUNT(*), COALESCE(SUM(encrypted), 0) FROM switches`).
+		Scan(&stats.Users, &stats.Switches, &stats.EncryptedSwitches)
+	if err != nil {
+		return api.AdminStats{}, err
+	}
+
+	rows, err := s.db.Query(`SELECT status, COUNT(*) FROM switches WHERE status IS NOT NULL GROUP BY status`)
+	if err != nil {
+		return api.AdminStats{}, err
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var status string
+		var count int
+		err := rows.Scan(&status, &count)
+		if err != nil {
+			return api.AdminStats{}, err
+		}
+		stats.SwitchesByStatus[status] = count
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return api.AdminStats{}, err
+	}
+
+	err = s.db.QueryRow(`SELECT COUNT(*) FROM checkins WHERE checked_in_at >= ?`, since).Scan(&stats.CheckInsLastDay)
+	if err != nil {
+		return api.AdminStats{}, err
+	}
+
+	return stats, nil
+}
+
+// GetAdminSwitches returns a page of the metadata of every user's switches, newest first. An empty user ID
+// lists the switches of all users.
+func (s *sqliteStore) GetAdminSwitches(userID string, limit, offset int) ([]api.AdminSwitch, error) {
+	rows, err := s.db.Query(`SELECT `+adminSwitchColumns+` FROM switches WHERE ? = '' OR user_id = ? ORDER BY id DESC LIMIT ? OFFSET ?`,
+		userID, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	switches := []api.AdminSwitch{}
+	for rows.Next() {
+		sw, err := scanAdminSwitch(rows)
+		if err != nil {
+			return nil, err
+		}
+		switches = append(switches, sw)
+	}
+
+	return switches, rows.Err()
+}
+
+// GetUserSettings returns what admins assigned to a user, or empty settings if nothing was assigned.
+func (s *sqliteStore) GetUserSettings(userID string) (UserSettings, error) {
+	settings := UserSettings{UserID: userID}
+	var role sql.NullString
+	var maxSwitches sql.NullInt64
+
+	err := s.db.QueryRow(`SELECT role, max_switches FROM user_settings WHERE user_id = ?`, userID).Scan(&role, &maxSwitches)
+	if errors.Is(err, sql.ErrNoRows) {
+		return settings, nil
+	}
+	if err != nil {
+		return UserSettings{}, err
+	}
+
+	settings.Role = api.Role(role.String)
+	if maxSwitches.Valid {
+		quota := int(maxSwitches.Int64)
+		settings.MaxSwitches = &quota
+	}
+
+	return settings, nil
+}
+
+// GetUserSummaries returns every user who owns a switch, has a local account or was assigned settings.
+func (s *sqliteStore) GetUserSummaries() ([]UserSummary, error) {
+	rows, err := s.db.Query(`
+SELECT u.user_id, us.role, us.max_switches,
+    (SELECT COUNT(*) FROM switches WHERE user_id = u.user_id),
+    EXISTS (SELECT 1 FROM users WHERE username = u.user_id)
+FROM (SELECT user_id FROM switches UNION SELECT username FROM users UNION SELECT user_id FROM user_settings) u
+LEFT JOIN user_settings us ON us.user_id = u.user_id
+ORDER BY u.user_id`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	users := []UserSummary{}
+	for rows.Next() {
+		user := UserSummary{}
+		var role sql.NullString
+		var maxSwitches sql.NullInt64
+
+		err := rows.Scan(&user.UserID, &role, &maxSwitches, &user.SwitchCount, &user.LocalAccount)
+		if err != nil {
+			return nil, err
+		}
+
+		user.Role = api.Role(role.String)
+		if maxSwitches.Valid {
+			quota := int(maxSwitches.Int64)
+			user.MaxSwitches = &quota
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+// SetUserQuota overrides the server's default switch quota for a user. A nil quota restores the default.
+func (s *sqliteStore) SetUserQuota(userID string, maxSwitches *int) error {
+	_, err := s.db.Exec(`INSERT INTO user_settings (user_id, max_switches, updated_at) VALUES (?, ?, ?)
+ON CONFLICT (user_id) DO UPDATE SET max_switches = excluded.max_switches, updated_at = excluded.updated_at`,
+		userID, maxSwitches, time.Now().Unix())
+	return err
+}
+
+// SetUserRole assigns a role to a user.
+func (s *sqliteStore) SetUserRole(userID string, role api.Role) error {
+	_, err := s.db.Exec(`INSERT INTO user_settings (user_id, role, updated_at) VALUES (?, ?, ?)
+ON CONFLICT (user_id) DO UPDATE SET role = excluded.role, updated_at = excluded.updated_at`,
+		userID, role, time.Now().Unix())
+	return err
+}
+
+// scanAdminSwitch scans a row of adminSwitchColumns.
+func scanAdminSwitch(row interface{ Scan(...any) error }) (api.AdminSwitch, error) {
+	sw := api.AdminSwitch{}
+	var status sql.NullString
+	var protected sql.NullBool
+	var triggerAt, lastCheckInAt, triggerCount sql.NullInt64
+
+	err := row.Scan(&sw.Id, &sw.UserId, &status, &sw.CheckInInterval, &sw.Encrypted, &protected, &triggerAt, &lastCheckInAt, &triggerCount)
+	if err != nil {
+		return api.AdminSwitch{}, err
+	}
+
+	sw.Status = api.SwitchStatus(status.String)
+	if protected.Valid && protected.Bool {
+		sw.Protected = &protected.Bool
+	}
+	if triggerAt.Valid {
+		sw.TriggerAt = &triggerAt.Int64
+	}
+	if lastCheckInAt.Valid {
+		sw.LastCheckInAt = &lastCheckInAt.Int64
+	}
+	if triggerCount.Valid && triggerCount.Int64 > 0 {
+		count := int(triggerCount.Int64)
+		sw.TriggerCount = &count
+	}
+
+	return sw, nil
+}
@@ -0,0 +1,158 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+func TestSQLiteStore_AdminSwitches(t *testing.T) {
+	store := setupTestStore(t)
+
+	now := time.Now().Unix()
+
+	ids := []int{}
+	for _, sw := range []api.Switch{
+		{UserId: ptr("alice"), Message: "secret", Notifiers: []string{"logger://"}, CheckInInterval: "1h", Status: &statusActive, TriggerAt: &now},
+		{UserId: ptr("alice"), Message: "secret", Notifiers: []string{"logger://"}, CheckInInterval: "1h", Status: &statusActive, Encrypted: ptr(true)},
+		{UserId: ptr("bob"), Message: "secret", Notifiers: []string{"logger://"}, CheckInInterval: "2h", Status: &statusActive},
+	} {
+		err := store.EncryptSwitch(&sw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		created, err := store.Create(sw)
+		if err != nil {
+			t.Fatalf("failed to create switch: %v", err)
+		}
+		ids = append(ids, *created.Id)
+	}
+
+	_, err := store.CreateCheckIn("alice", api.CheckIn{SwitchId: ids[0], CheckedInAt: now, Method: api.CheckInMethodUI})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = store.CreateCheckIn("alice", api.CheckIn{SwitchId: ids[0], CheckedInAt: now - 2*86400, Method: api.CheckInMethodUI})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Every user's switches are listed newest first", func(t *testing.T) {
+		switches, err := store.GetAdminSwitches("", 10, 0)
+		if err != nil {
+			t.Fatalf("failed to list switches: %v", err)
+		}
+		if len(switches) != 3 || switches[0].Id != ids[2] || switches[0].UserId != "bob" {
+			t.Fatalf("unexpected switches %+v", switches)
+		}
+		if !switches[1].Encrypted || switches[2].TriggerAt == nil || *switches[2].TriggerAt != now {
+			t.Errorf("unexpected switch metadata %+v", switches)
+		}
+
+		switches, err = store.GetAdminSwitches("alice", 1, 1)
+		if err != nil || len(switches) != 1 || switches[0].Id != ids[0] {
+			t.Errorf("unexpected page of alice's switches %+v (%v)", switches, err)
+		}
+	})
+
+	t.Run("Any user's switch can be disabled", func(t *testing.T) {
+		sw, err := store.DisableSwitch(ids[2])
+		if err != nil {
+			t.Fatalf("failed to disable switch: %v", err)
+		}
+		if sw.Status != api.SwitchStatusDisabled || sw.UserId != "bob" {
+			t.Errorf("unexpected switch %+v", sw)
+		}
+
+		_, err = store.DisableSwitch(999)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows, got %v", err)
+		}
+	})
+
+	t.Run("Stats count switches, users and recent check-ins", func(t *testing.T) {
+		stats, err := store.GetAdminStats(now - 86400)
+		if err != nil {
+			t.Fatalf("failed to get stats: %v", err)
+		}
+		if stats.Users != 2 || stats.Switches != 3 || stats.EncryptedSwitches != 1 || stats.CheckInsLastDay != 1 {
+			t.Errorf("unexpected stats %+v", stats)
+		}
+		if stats.SwitchesByStatus["active"] != 2 || stats.SwitchesByStatus["disabled"] != 1 {
+			t.Errorf("unexpected switches by status %+v", stats.SwitchesByStatus)
+		}
+
+		count, err := store.CountSwitches("alice")
+		if err != nil || count != 2 {
+			t.Errorf("expected alice to own 2 switches, got %d (%v)", count, err)
+		}
+	})
+}
+
+func TestSQLiteStore_UserSettings(t *testing.T) {
+	store := setupTestStore(t)
+
+	_, err := store.Create(api.Switch{UserId: ptr("alice"), Message: "m", Notifiers: []string{"logger://"}, CheckInInterval: "1h", Status: &statusActive})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.CreateUser(User{Username: "carol", PasswordHash: "hash", CreatedAt: time.Now().Unix()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Users without settings get empty settings", func(t *testing.T) {
+		settings, err := store.GetUserSettings("alice")
+		if err != nil || settings.Role != "" || settings.MaxSwitches != nil {
+			t.Errorf("unexpected settings %+v (%v)", settings, err)
+		}
+	})
+
+	t.Run("Roles and quotas are kept apart", func(t *testing.T) {
+		err := store.SetUserRole("bob", api.RoleAdmin)
+		if err != nil {
+			t.Fatalf("failed to set role: %v", err)
+		}
+		err = store.SetUserQuota("bob", ptr(5))
+		if err != nil {
+			t.Fatalf("failed to set quota: %v", err)
+		}
+
+		settings, _ := store.GetUserSettings("bob")
+		if settings.Role != api.RoleAdmin || settings.MaxSwitches == nil || *settings.MaxSwitches != 5 {
+			t.Errorf("unexpected settings %+v", settings)
+		}
+
+		err = store.SetUserQuota("bob", nil)
+		if err != nil {
+			t.Fatalf("failed to reset quota: %v", err)
+		}
+
+		settings, _ = store.GetUserSettings("bob")
+		if settings.Role != api.RoleAdmin || settings.MaxSwitches != nil {
+			t.Errorf("expected only the quota to be reset, got %+v", settings)
+		}
+	})
+
+	t.Run("Users are listed from switches, accounts and settings", func(t *testing.T) {
+		users, err := store.GetUserSummaries()
+		if err != nil {
+			t.Fatalf("failed to list users: %v", err)
+		}
+		if len(users) != 3 {
+			t.Fatalf("expected 3 users, got %+v", users)
+		}
+		if users[0].UserID != "alice" || users[0].SwitchCount != 1 {
+			t.Errorf("unexpected user %+v", users[0])
+		}
+		if users[1].UserID != "bob" || users[1].Role != api.RoleAdmin {
+			t.Errorf("unexpected user %+v", users[1])
+		}
+		if users[2].UserID != "carol" || !users[2].LocalAccount {
+			t.Errorf("unexpected user %+v", users[2])
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"database/sql"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+// CreateAuditEvent records an event in the audit trail of a switch owned by the given user.
+func (s *sqliteStore) CreateAuditEvent(userID string, event api.AuditEvent) (api.AuditEvent, error) {
+	res, err := s.db.Exec(`INSERT INTO audit_events (switch_id, user_id, created_at, actor, action, detail) VALUES (?, ?, ?, ?, ?, ?)`,
+		event.SwitchId,
+		userID,
+		event.CreatedAt,
+		event.Actor,
+		event.Action,
+		event.Detail,
+	)
+	if err != nil {
+		return api.AuditEvent{}, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return api.AuditEvent{}, err
+	}
+
+	eventID := int(id)
+	event.Id = &eventID
+
+	return event, nil
+}
+
+// GetAuditEvents returns a page of a switch's audit trail, newest first, scoped to the given user.
+func (s *sqliteStore) GetAuditEvents(userID string, switchID, limit, offset int) ([]api.AuditEvent, error) {
+	rows, err := s.db.Query(`SELECT id, switch_id, created_at, actor, action, detail FROM audit_events WHERE user_id = ? AND switch_id = ? ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?`,
+		userID,
+		switchID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	events := []api.AuditEvent{}
+	for rows.Next() {
+		event := api.AuditEvent{}
+		var id int
+		var detail sql.NullString
+
+		err := rows.Scan(&id, &event.SwitchId, &event.CreatedAt, &event.Actor, &event.Action, &detail)
+		if err != nil {
+			return nil, err
+		}
+
+		event.Id = &id
+		if detail.Valid {
+			event.Detail = &detail.String
+		}
+
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
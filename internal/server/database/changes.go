@@ -0,0 +1,167 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+const pendingChangeColumns = `id, switch_id, requested_by, action, status, summary, payload, created_at, apply_at, resolved_by, resolved_at`
+
+// PendingChange is a change to a protected switch that waits for approval or a delay before it applies.
+type PendingChange struct {
+	api.PendingChange
+	// Payload holds the switch an update applies, encrypted like the switch itself, or when a pause ends.
+	Payload *string
+}
+
+// CreatePendingChange stores a change requested for a protected switch.
+func (s *sqliteStore) CreatePendingChange(change PendingChange) (PendingChange, error) {
+	res, err := s.db.Exec(`INSERT INTO pending_changes (switch_id, requested_by, action, status, summary, payload, created_at, apply_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		change.SwitchId,
+		change.RequestedBy,
+		change.Action,
+		change.Status,
+		change.Summary,
+		change.Payload,
+		change.CreatedAt,
+		change.ApplyAt,
+	)
+	if err != nil {
+		return PendingChange{}, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return PendingChange{}, err
+	}
+
+	changeID := int(id)
+	change.Id = &changeID
+
+	return change, nil
+}
+
+// GetPendingChange returns a change by its ID. Returns sql.ErrNoRows if not found.
+func (s *sqliteStore) GetPendingChange(id int) (PendingChange, error) {
+	changes, err := s.queryPendingChanges(fmt.Sprintf("SELECT %s FROM pending_changes WHERE id = ?", pendingChangeColumns), id)
+	if err != nil {
+		return PendingChange{}, err
+	}
+
+	if len(changes) == 0 {
+		return PendingChange{}, sql.ErrNoRows
+	}
+
+	return changes[0], nil
+}
+
+// GetPendingChanges returns the pending changes a user requested or is an approver of, oldest first.
+func (s *sqliteStore) GetPendingChanges(userID string) ([]PendingChange, error) {
+	query := fmt.Sprintf(`SELECT %s FROM pending_changes WHERE status = ? AND (requested_by = ? OR switch_id IN (
+            SELECT switches.id FROM switches, json_each(switches.approvers) WHERE json_each.value = ?)) ORDER BY created_at, id`, pendingChangeColumns)
+
+	return s.queryPendingChanges(query, api.PendingChangeStatusPending, userID, userID)
+}
+
+// GetDueChanges returns pending changes whose delay has passed.
+func (s *sqliteStore) GetDueChanges(limit int) ([]PendingChange, error) {
+	query := fmt.Sprintf("SELECT %s FROM pending_changes WHERE status = ? AND apply_at IS NOT NULL AND apply_at <= ? ORDER BY apply_at LIMIT ?", pendingChangeColumns)
+
+	return s.queryPendingChanges(query, api.PendingChangeStatusPending, time.Now().Unix(), limit)
+}
+
+// ResolvePendingChange marks a pending change as applied or cancelled. Returns sql.ErrNoRows if it doesn't
+// exist or was already resolved, so a change can't be resolved twice even by concurrent requests.
+func (s *sqliteStore) ResolvePendingChange(id int, status api.PendingChangeStatus, resolvedBy string, resolvedAt int64) error {
+	res, err := s.db.Exec(`UPDATE pending_changes SET status = ?, resolved_by = ?, resolved_at = ? WHERE id = ? AND status = ?`,
+		status,
+		resolvedBy,
+		resolvedAt,
+		id,
+		api.PendingChangeStatusPending,
+	)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// ReopenPendingChange sets a change that was marked applied back to pending, for when applying it failed.
+// Returns sql.ErrNoRows if it doesn't exist or isn't marked applied.
+func (s *sqliteStore) ReopenPendingChange(id int) error {
+	res, err := s.db.Exec(`UPDATE pending_changes SET status = ?, resolved_by = NULL, resolved_at = NULL WHERE id = ? AND status = ?`,
+		api.PendingChangeStatusPending,
+		id,
+		api.PendingChangeStatusApplied,
+	)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// queryPendingChanges runs a query selecting pendingChangeColumns and scans the results.
+func (s *sqliteStore) queryPendingChanges(query string, args ...any) ([]PendingChange, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	changes := []PendingChange{}
+	for rows.Next() {
+		change := PendingChange{}
+		var id int
+		var payload sql.NullString
+		var applyAt sql.NullInt64
+		var resolvedBy sql.NullString
+		var resolvedAt sql.NullInt64
+
+		err := rows.Scan(&id, &change.SwitchId, &change.RequestedBy, &change.Action, &change.Status, &change.Summary, &payload, &change.CreatedAt, &applyAt, &resolvedBy, &resolvedAt)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+
+		change.Id = &id
+		if payload.Valid {
+			change.Payload = &payload.String
+		}
+		if applyAt.Valid {
+			change.ApplyAt = &applyAt.Int64
+		}
+		if resolvedBy.Valid {
+			change.ResolvedBy = &resolvedBy.String
+		}
+		if resolvedAt.Valid {
+			change.ResolvedAt = &resolvedAt.Int64
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, rows.Err()
+}
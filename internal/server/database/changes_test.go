@@ -0,0 +1,146 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+func TestSQLiteStore_PendingChanges(t *testing.T) {
+	store := setupTestStore(t)
+
+	created, err := store.Create(api.Switch{
+		Message:         "protected",
+		Notifiers:       []string{"logger://"},
+		CheckInInterval: "24h",
+		Protected:       ptr(true),
+		Approvers:       &[]string{"bob"},
+		ChangeDelay:     ptr("48h"),
+		Status:          &statusActive,
+	})
+	if err != nil {
+		t.Fatalf("failed to create switch: %v", err)
+	}
+
+	t.Run("protection settings round trip", func(t *testing.T) {
+		if !*created.Protected || len(*created.Approvers) != 1 || (*created.Approvers)[0] != "bob" || *created.ChangeDelay != "48h" {
+			t.Errorf("unexpected protection settings %v %v %v", *created.Protected, *created.Approvers, *created.ChangeDelay)
+		}
+	})
+
+	change, err := store.CreatePendingChange(PendingChange{
+		PendingChange: api.PendingChange{
+			SwitchId:    *created.Id,
+			RequestedBy: "admin",
+			Action:      api.PendingChangeActionDisable,
+			Status:      api.PendingChangeStatusPending,
+			Summary:     "Disable the switch",
+			CreatedAt:   time.Now().Unix(),
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create pending change: %v", err)
+	}
+
+	t.Run("pending changes are listed for the requester and approvers", func(t *testing.T) {
+		for _, userID := range []string{"admin", "bob"} {
+			changes, err := store.GetPendingChanges(userID)
+			if err != nil {
+				t.Fatalf("failed to get pending changes: %v", err)
+			}
+			if len(changes) != 1 || *changes[0].Id != *change.Id {
+				t.Errorf("expected %s to see the change, got %+v", userID, changes)
+			}
+		}
+
+		changes, err := store.GetPendingChanges("mallory")
+		if err != nil {
+			t.Fatalf("failed to get pending changes: %v", err)
+		}
+		if len(changes) != 0 {
+			t.Errorf("expected no changes for other users, got %d", len(changes))
+		}
+	})
+
+	t.Run("only delayed changes become due", func(t *testing.T) {
+		applyAt := time.Now().Add(-time.Minute).Unix()
+		delayed, err := store.CreatePendingChange(PendingChange{
+			PendingChange: api.PendingChange{
+				SwitchId:    *created.Id,
+				RequestedBy: "admin",
+				Action:      api.PendingChangeActionUpdate,
+				Status:      api.PendingChangeStatusPending,
+				Summary:     "Remove 1 notifiers",
+				CreatedAt:   time.Now().Unix(),
+				ApplyAt:     &applyAt,
+			},
+			Payload: ptr(`{"message":"updated"}`),
+		})
+		if err != nil {
+			t.Fatalf("failed to create pending change: %v", err)
+		}
+
+		due, err := store.GetDueChanges(10)
+		if err != nil {
+			t.Fatalf("failed to get due changes: %v", err)
+		}
+		if len(due) != 1 || *due[0].Id != *delayed.Id || *due[0].Payload != `{"message":"updated"}` {
+			t.Errorf("expected only the delayed change to be due, got %+v", due)
+		}
+	})
+
+	t.Run("changes can only be resolved once", func(t *testing.T) {
+		err := store.ResolvePendingChange(*change.Id, api.PendingChangeStatusApplied, "bob", time.Now().Unix())
+		if err != nil {
+			t.Fatalf("failed to resolve change: %v", err)
+		}
+
+		err = store.ResolvePendingChange(*change.Id, api.PendingChangeStatusCancelled, "admin", time.Now().Unix())
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows for a resolved change, got %v", err)
+		}
+
+		resolved, err := store.GetPendingChange(*change.Id)
+		if err != nil {
+			t.Fatalf("failed to get change: %v", err)
+		}
+		if resolved.Status != api.PendingChangeStatusApplied || *resolved.ResolvedBy != "bob" {
+			t.Errorf("expected change applied by bob, got %+v", resolved.PendingChange)
+		}
+	})
+
+	t.Run("changes that failed to apply can be reopened", func(t *testing.T) {
+		err := store.ReopenPendingChange(*change.Id)
+		if err != nil {
+			t.Fatalf("failed to reopen change: %v", err)
+		}
+
+		reopened, err := store.GetPendingChange(*change.Id)
+		if err != nil {
+			t.Fatalf("failed to get change: %v", err)
+		}
+		if reopened.Status != api.PendingChangeStatusPending || reopened.ResolvedBy != nil || reopened.ResolvedAt != nil {
+			t.Errorf("expected change to be pending again, got %+v", reopened.PendingChange)
+		}
+
+		err = store.ReopenPendingChange(*change.Id)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows for a pending change, got %v", err)
+		}
+	})
+
+	t.Run("Delete removes pending changes", func(t *testing.T) {
+		err := store.Delete("admin", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to delete switch: %v", err)
+		}
+
+		_, err = store.GetPendingChange(*change.Id)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows after delete, got %v", err)
+		}
+	})
+}
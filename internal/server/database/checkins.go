@@ -0,0 +1,72 @@
+package database
+
+import (
+	"database/sql"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+// CreateCheckIn records a check-in for a switch owned by the given user.
+func (s *sqliteStore) CreateCheckIn(userID string, checkIn api.CheckIn) (api.CheckIn, error) {
+	res, err := s.db.Exec(`INSERT INTO checkins (switch_id, user_id, checked_in_at, method, ip_address, user_agent) VALUES (?, ?, ?, ?, ?, ?)`,
+		checkIn.SwitchId,
+		userID,
+		checkIn.CheckedInAt,
+		checkIn.Method,
+		checkIn.IpAddress,
+		checkIn.UserAgent,
+	)
+	if err != nil {
+		return api.CheckIn{}, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return api.CheckIn{}, err
+	}
+
+	checkInID := int(id)
+	checkIn.Id = &checkInID
+
+	return checkIn, nil
+}
+
+// GetCheckIns returns a page of check-ins for a switch, newest first, scoped to the given user.
+func (s *sqliteStore) GetCheckIns(userID string, switchID, limit, offset int) ([]api.CheckIn, error) {
+	rows, err := s.db.Query(`SELECT id, switch_id, checked_in_at, method, ip_address, user_agent FROM checkins WHERE user_id = ? AND switch_id = ? ORDER BY checked_in_at DESC, id DESC LIMIT ? OFFSET ?`,
+		userID,
+		switchID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	checkIns := []api.CheckIn{}
+	for rows.Next() {
+		checkIn := api.CheckIn{}
+		var id int
+		var ipAddress sql.NullString
+		var userAgent sql.NullString
+
+		err := rows.Scan(&id, &checkIn.SwitchId, &checkIn.CheckedInAt, &checkIn.Method, &ipAddress, &userAgent)
+		if err != nil {
+			return nil, err
+		}
+
+		checkIn.Id = &id
+		if ipAddress.Valid {
+			checkIn.IpAddress = &ipAddress.String
+		}
+		if userAgent.Valid {
+			checkIn.UserAgent = &userAgent.String
+		}
+
+		checkIns = append(checkIns, checkIn)
+	}
+
+	return checkIns, rows.Err()
+}
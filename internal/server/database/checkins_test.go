@@ -0,0 +1,88 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+func TestSQLiteStore_CheckIns(t *testing.T) {
+	store := setupTestStore(t)
+
+	sw, err := store.Create(api.Switch{
+		Message:         "checkins",
+		Notifiers:       []string{"logger://"},
+		CheckInInterval: "1h",
+		Status:          &statusActive,
+	})
+	if err != nil {
+		t.Fatalf("failed to create switch: %v", err)
+	}
+
+	now := time.Now().Unix()
+
+	t.Run("CreateCheckIn and GetCheckIns", func(t *testing.T) {
+		for i, method := range []api.CheckInMethod{api.CheckInMethodUI, api.CheckInMethodCLI, api.CheckInMethodPush} {
+			created, err := store.CreateCheckIn("admin", api.CheckIn{
+				SwitchId:    *sw.Id,
+				CheckedInAt: now + int64(i),
+				Method:      method,
+				IpAddress:   ptr("127.0.0.1"),
+			})
+			if err != nil {
+				t.Fatalf("failed to create check-in: %v", err)
+			}
+			if created.Id == nil {
+				t.Fatal("expected check-in id to be set")
+			}
+		}
+
+		checkIns, err := store.GetCheckIns("admin", *sw.Id, 10, 0)
+		if err != nil {
+			t.Fatalf("failed to get check-ins: %v", err)
+		}
+		if len(checkIns) != 3 {
+			t.Fatalf("expected 3 check-ins, got %d", len(checkIns))
+		}
+		if checkIns[0].Method != api.CheckInMethodPush {
+			t.Errorf("expected newest check-in first, got %s", checkIns[0].Method)
+		}
+		if checkIns[0].UserAgent != nil {
+			t.Errorf("expected nil user agent, got %v", *checkIns[0].UserAgent)
+		}
+
+		page, err := store.GetCheckIns("admin", *sw.Id, 1, 2)
+		if err != nil {
+			t.Fatalf("failed to get check-ins: %v", err)
+		}
+		if len(page) != 1 || page[0].Method != api.CheckInMethodUI {
+			t.Errorf("expected oldest check-in on last page, got %v", page)
+		}
+	})
+
+	t.Run("GetCheckIns is scoped to the user", func(t *testing.T) {
+		checkIns, err := store.GetCheckIns("other-user", *sw.Id, 10, 0)
+		if err != nil {
+			t.Fatalf("failed to get check-ins: %v", err)
+		}
+		if len(checkIns) != 0 {
+			t.Errorf("expected no check-ins for another user, got %d", len(checkIns))
+		}
+	})
+
+	t.Run("Delete removes check-in history", func(t *testing.T) {
+		err := store.Delete("admin", *sw.Id)
+		if err != nil {
+			t.Fatalf("failed to delete switch: %v", err)
+		}
+
+		checkIns, err := store.GetCheckIns("admin", *sw.Id, 10, 0)
+		if err != nil {
+			t.Fatalf("failed to get check-ins: %v", err)
+		}
+		if len(checkIns) != 0 {
+			t.Errorf("expected history to be deleted, got %d", len(checkIns))
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package database
+
+import (
+	"database/sql"
+)
+
+// ContactToken is a single use token a trusted contact uses to verify an expired switch.
+// Only the hash of the token is stored.
+type ContactToken struct {
+	TokenHash   string
+	SwitchID    int
+	UserID      string
+	ContactName string
+	ExpiresAt   int64
+	UsedAt      *int64
+}
+
+// CreateContactToken stores a token issued to a trusted contact.
+func (s *sqliteStore) CreateContactToken(token ContactToken) error {
+	_, err := s.db.Exec(`INSERT INTO contact_tokens (token_hash, switch_id, user_id, contact_name, expires_at) VALUES (?, ?, ?, ?, ?)`,
+		token.TokenHash,
+		token.SwitchID,
+		token.UserID,
+		token.ContactName,
+		token.ExpiresAt,
+	)
+	return err
+}
+
+// DeleteContactTokens revokes every token issued for a switch.
+func (s *sqliteStore) DeleteContactTokens(switchID int) error {
+	_, err := s.db.Exec(`DELETE FROM contact_tokens WHERE switch_id = ?`, switchID)
+	return err
+}
+
+// GetContactToken returns the token with the given hash. Returns sql.ErrNoRows if not found.
+func (s *sqliteStore) GetContactToken(tokenHash string) (ContactToken, error) {
+	token := ContactToken{}
+	var usedAt sql.NullInt64
+
+	err := s.db.QueryRow(`SELECT token_hash, switch_id, user_id, contact_name, expires_at, used_at FROM contact_tokens WHERE token_hash = ?`, tokenHash).
+		Scan(&token.TokenHash, &token.SwitchID, &token.UserID, &token.ContactName, &token.ExpiresAt, &usedAt)
+	if err != nil {
+		return ContactToken{}, err
+	}
+
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Int64
+	}
+
+	return token, nil
+}
+
+// UseContactToken marks a token as used. Returns sql.ErrNoRows if it doesn't exist or was already used,
+// so a token can't be used twice even by concurrent requests.
+func (s *sqliteStore) UseContactToken(tokenHash string, usedAt int64) error {
+	res, err := s.db.Exec(`UPDATE contact_tokens SET used_at = ? WHERE token_hash = ? AND used_at IS NULL`, usedAt, tokenHash)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+func TestSQLiteStore_TrustedContacts(t *testing.T) {
+	store := setupTestStore(t)
+
+	created, err := store.Create(api.Switch{
+		Message:            "verified",
+		Notifiers:          []string{"logger://"},
+		CheckInInterval:    "24h",
+		TrustedContacts:    &[]api.TrustedContact{{Name: "sam", Notifier: ptr("logger://sam")}},
+		VerificationWindow: ptr("12h"),
+		MaxPostpone:        ptr("48h"),
+		Status:             &statusActive,
+	})
+	if err != nil {
+		t.Fatalf("failed to create switch: %v", err)
+	}
+
+	t.Run("trusted contact settings round trip", func(t *testing.T) {
+		if created.TrustedContacts == nil || len(*created.TrustedContacts) != 1 {
+			t.Fatalf("expected 1 trusted contact, got %v", created.TrustedContacts)
+		}
+		contact := (*created.TrustedContacts)[0]
+		if contact.Name != "sam" || *contact.Notifier != "logger://sam" {
+			t.Errorf("unexpected trusted contact %+v", contact)
+		}
+		if *created.VerificationWindow != "12h" || *created.MaxPostpone != "48h" {
+			t.Errorf("expected verification window 12h and max postpone 48h, got %s and %s", *created.VerificationWindow, *created.MaxPostpone)
+		}
+	})
+
+	t.Run("contact notifiers are encrypted with the switch", func(t *testing.T) {
+		encrypted, err := store.Create(api.Switch{
+			Message:         "secret",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "24h",
+			TrustedContacts: &[]api.TrustedContact{{Name: "sam", Notifier: ptr("discord://token@id")}},
+			Encrypted:       ptr(true),
+			Status:          &statusActive,
+		})
+		if err != nil {
+			t.Fatalf("failed to create switch: %v", err)
+		}
+
+		if *(*encrypted.TrustedContacts)[0].Notifier == "discord://token@id" {
+			t.Fatal("expected contact notifier to be encrypted at rest")
+		}
+
+		err = store.DecryptSwitch(&encrypted)
+		if err != nil {
+			t.Fatalf("failed to decrypt switch: %v", err)
+		}
+		if *(*encrypted.TrustedContacts)[0].Notifier != "discord://token@id" {
+			t.Errorf("expected decrypted contact notifier, got %q", *(*encrypted.TrustedContacts)[0].Notifier)
+		}
+	})
+
+	t.Run("contact tokens can only be used once", func(t *testing.T) {
+		err := store.CreateContactToken(ContactToken{
+			TokenHash:   "hash",
+			SwitchID:    *created.Id,
+			UserID:      "admin",
+			ContactName: "sam",
+			ExpiresAt:   time.Now().Add(time.Hour).Unix(),
+		})
+		if err != nil {
+			t.Fatalf("failed to create token: %v", err)
+		}
+
+		err = store.UseContactToken("hash", time.Now().Unix())
+		if err != nil {
+			t.Fatalf("failed to use token: %v", err)
+		}
+
+		err = store.UseContactToken("hash", time.Now().Unix())
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows for a used token, got %v", err)
+		}
+
+		token, err := store.GetContactToken("hash")
+		if err != nil {
+			t.Fatalf("failed to get token: %v", err)
+		}
+		if token.UsedAt == nil || token.ContactName != "sam" {
+			t.Errorf("expected a used token for sam, got %+v", token)
+		}
+	})
+
+	t.Run("DeleteContactTokens revokes tokens", func(t *testing.T) {
+		err := store.DeleteContactTokens(*created.Id)
+		if err != nil {
+			t.Fatalf("failed to delete tokens: %v", err)
+		}
+
+		_, err = store.GetContactToken("hash")
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows after revoking, got %v", err)
+		}
+	})
+
+	t.Run("audit events are scoped to the owner and newest first", func(t *testing.T) {
+		now := time.Now().Unix()
+		for i, action := range []api.AuditEventAction{api.AuditActionVerificationStarted, api.AuditActionPostponed} {
+			_, err := store.CreateAuditEvent("admin", api.AuditEvent{
+				SwitchId:  *created.Id,
+				CreatedAt: now + int64(i),
+				Actor:     "system",
+				Action:    action,
+			})
+			if err != nil {
+				t.Fatalf("failed to create audit event: %v", err)
+			}
+		}
+
+		events, err := store.GetAuditEvents("admin", *created.Id, 10, 0)
+		if err != nil {
+			t.Fatalf("failed to get audit events: %v", err)
+		}
+		if len(events) != 2 || events[0].Action != api.AuditActionPostponed {
+			t.Errorf("expected 2 events newest first, got %+v", events)
+		}
+
+		events, err = store.GetAuditEvents("mallory", *created.Id, 10, 0)
+		if err != nil {
+			t.Fatalf("failed to get audit events: %v", err)
+		}
+		if len(events) != 0 {
+			t.Errorf("expected no events for another user, got %d", len(events))
+		}
+	})
+
+	t.Run("Delete removes the audit trail", func(t *testing.T) {
+		err := store.Delete("admin", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to delete switch: %v", err)
+		}
+
+		events, err := store.GetAuditEvents("admin", *created.Id, 10, 0)
+		if err != nil {
+			t.Fatalf("failed to get audit events: %v", err)
+		}
+		if len(events) != 0 {
+			t.Errorf("expected audit trail to be deleted, got %d events", len(events))
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+const deliveryColumns = `id, switch_id, subscription_id, created_at, kind, target, success, exit_code, status_code, attempts, output, error, duration`
+
+// CreateDelivery records the result of an action run, webhook request or event sent for a switch owned by the given user.
+func (s *sqliteStore) CreateDelivery(userID string, delivery api.Delivery) (api.Delivery, error) {
+	res, err := s.db.Exec(`INSERT INTO deliveries (switch_id, subscription_id, user_id, created_at, kind, target, success, exit_code, status_code, attempts, output, error, duration) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		delivery.SwitchId,
+		delivery.SubscriptionId,
+		userID,
+		delivery.CreatedAt,
+		delivery.Kind,
+		delivery.Target,
+		delivery.Success,
+		delivery.ExitCode,
+		delivery.StatusCode,
+		delivery.Attempts,
+		delivery.Output,
+		delivery.Error,
+		delivery.Duration,
+	)
+	if err != nil {
+		return api.Delivery{}, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return api.Delivery{}, err
+	}
+
+	deliveryID := int(id)
+	delivery.Id = &deliveryID
+
+	return delivery, nil
+}
+
+// GetDeliveries returns a page of a switch's delivery results, newest first, scoped to the given user.
+// Events sent to subscriptions are listed with their subscription instead.
+func (s *sqliteStore) GetDeliveries(userID string, switchID, limit, offset int) ([]api.Delivery, error) {
+	query := fmt.Sprintf("SELECT %s FROM deliveries WHERE user_id = ? AND switch_id = ? AND subscription_id IS NULL ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?", deliveryColumns)
+
+	return s.queryDeliveries(query, userID, switchID, limit, offset)
+}
+
+// queryDeliveries runs a query selecting deliveryColumns and scans the results.
+func (s *sqliteStore) queryDeliveries(query string, args ...any) ([]api.Delivery, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	deliveries := []api.Delivery{}
+	for rows.Next() {
+		delivery := api.Delivery{}
+		var id int
+		var subscriptionID sql.NullInt64
+		var exitCode sql.NullInt64
+		var statusCode sql.NullInt64
+		var attempts sql.NullInt64
+		var output sql.NullString
+		var errorRaw sql.NullString
+		var duration sql.NullInt64
+
+		err := rows.Scan(&id, &delivery.SwitchId, &subscriptionID, &delivery.CreatedAt, &delivery.Kind, &delivery.Target, &delivery.Success, &exitCode, &statusCode, &attempts, &output, &errorRaw, &duration)
+		if err != nil {
+			return nil, err
+		}
+
+		delivery.Id = &id
+		if subscriptionID.Valid {
+			subID := int(subscriptionID.Int64)
+			delivery.SubscriptionId = &subID
+		}
+		if exitCode.Valid {
+			code := int(exitCode.Int64)
+			delivery.ExitCode = &code
+		}
+		if statusCode.Valid {
+			code := int(statusCode.Int64)
+			delivery.StatusCode = &code
+		}
+		if attempts.Valid {
+			count := int(attempts.Int64)
+			delivery.Attempts = &count
+		}
+		if output.Valid {
+			delivery.Output = &output.String
+		}
+		if errorRaw.Valid {
+			delivery.Error = &errorRaw.String
+		}
+		if duration.Valid {
+			delivery.Duration = &duration.Int64
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
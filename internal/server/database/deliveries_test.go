@@ -0,0 +1,162 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+func TestSQLiteStore_Deliveries(t *testing.T) {
+	store := setupTestStore(t)
+
+	created, err := store.Create(api.Switch{
+		Message:         "actions",
+		Notifiers:       []string{"logger://"},
+		CheckInInterval: "24h",
+		Actions:         &[]string{"wipe", "shutdown"},
+		Status:          &statusActive,
+	})
+	if err != nil {
+		t.Fatalf("failed to create switch: %v", err)
+	}
+
+	t.Run("actions round trip", func(t *testing.T) {
+		if created.Actions == nil || len(*created.Actions) != 2 || (*created.Actions)[0] != "wipe" {
+			t.Errorf("unexpected actions %v", created.Actions)
+		}
+	})
+
+	t.Run("webhooks round trip", func(t *testing.T) {
+		hooked, err := store.Create(api.Switch{
+			Message:         "webhooks",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "24h",
+			Webhooks:        &[]api.Webhook{{Url: "https://example.com/hook", Secret: ptr("0123456789abcdef"), MaxAttempts: ptr(5)}},
+			Status:          &statusActive,
+		})
+		if err != nil {
+			t.Fatalf("failed to create switch: %v", err)
+		}
+
+		hook := (*hooked.Webhooks)[0]
+		if hook.Url != "https://example.com/hook" || *hook.Secret != "0123456789abcdef" || *hook.MaxAttempts != 5 {
+			t.Errorf("unexpected webhook %+v", hook)
+		}
+	})
+
+	t.Run("webhook credentials are encrypted with the switch", func(t *testing.T) {
+		encrypted, err := store.Create(api.Switch{
+			Message:         "secret",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "24h",
+			Webhooks: &[]api.Webhook{{
+				Url:     "https://example.com/hook?token=abc",
+				Secret:  ptr("0123456789abcdef"),
+				Headers: &map[string]string{"Authorization": "Bearer abc"},
+			}},
+			Encrypted: ptr(true),
+			Status:    &statusActive,
+		})
+		if err != nil {
+			t.Fatalf("failed to create switch: %v", err)
+		}
+
+		hook := (*encrypted.Webhooks)[0]
+		if hook.Url == "https://example.com/hook?token=abc" || *hook.Secret == "0123456789abcdef" || (*hook.Headers)["Authorization"] == "Bearer abc" {
+			t.Fatalf("expected webhook credentials to be encrypted at rest, got %+v", hook)
+		}
+
+		err = store.DecryptSwitch(&encrypted)
+		if err != nil {
+			t.Fatalf("failed to decrypt switch: %v", err)
+		}
+
+		hook = (*encrypted.Webhooks)[0]
+		if hook.Url != "https://example.com/hook?token=abc" || *hook.Secret != "0123456789abcdef" || (*hook.Headers)["Authorization"] != "Bearer abc" {
+			t.Errorf("unexpected decrypted webhook %+v", hook)
+		}
+	})
+
+	now := time.Now().Unix()
+	for i, success := range []bool{true, false} {
+		_, err := store.CreateDelivery(AdminUser, api.Delivery{
+			SwitchId:  *created.Id,
+			CreatedAt: now + int64(i),
+			Kind:      api.DeliveryKindAction,
+			Target:    "wipe",
+			Success:   success,
+			ExitCode:  ptr(i),
+			Output:    ptr("done"),
+			Duration:  ptr(int64(20)),
+		})
+		if err != nil {
+			t.Fatalf("failed to create delivery: %v", err)
+		}
+	}
+
+	_, err = store.CreateDelivery(AdminUser, api.Delivery{
+		SwitchId:   *created.Id,
+		CreatedAt:  now - 1,
+		Kind:       api.DeliveryKindWebhook,
+		Target:     "https://example.com/hook",
+		Success:    true,
+		StatusCode: ptr(204),
+		Attempts:   ptr(2),
+	})
+	if err != nil {
+		t.Fatalf("failed to create delivery: %v", err)
+	}
+
+	t.Run("deliveries are listed newest first", func(t *testing.T) {
+		deliveries, err := store.GetDeliveries(AdminUser, *created.Id, 10, 0)
+		if err != nil {
+			t.Fatalf("failed to get deliveries: %v", err)
+		}
+		if len(deliveries) != 3 {
+			t.Fatalf("expected 3 deliveries, got %d", len(deliveries))
+		}
+		if deliveries[0].Success || *deliveries[0].ExitCode != 1 || deliveries[0].Error != nil {
+			t.Errorf("unexpected newest delivery %+v", deliveries[0])
+		}
+		if *deliveries[1].Output != "done" || *deliveries[1].Duration != 20 {
+			t.Errorf("unexpected action delivery %+v", deliveries[1])
+		}
+		if deliveries[2].Kind != api.DeliveryKindWebhook || *deliveries[2].StatusCode != 204 || *deliveries[2].Attempts != 2 {
+			t.Errorf("unexpected webhook delivery %+v", deliveries[2])
+		}
+
+		deliveries, err = store.GetDeliveries(AdminUser, *created.Id, 10, 1)
+		if err != nil {
+			t.Fatalf("failed to get deliveries: %v", err)
+		}
+		if len(deliveries) != 2 || !deliveries[0].Success {
+			t.Errorf("expected the offset to skip the newest delivery, got %+v", deliveries)
+		}
+	})
+
+	t.Run("deliveries are scoped to the owner", func(t *testing.T) {
+		deliveries, err := store.GetDeliveries("mallory", *created.Id, 10, 0)
+		if err != nil {
+			t.Fatalf("failed to get deliveries: %v", err)
+		}
+		if len(deliveries) != 0 {
+			t.Errorf("expected no deliveries for another user, got %d", len(deliveries))
+		}
+	})
+
+	t.Run("Delete removes deliveries", func(t *testing.T) {
+		err := store.Delete(AdminUser, *created.Id)
+		if err != nil {
+			t.Fatalf("failed to delete switch: %v", err)
+		}
+
+		deliveries, err := store.GetDeliveries(AdminUser, *created.Id, 10, 0)
+		if err != nil {
+			t.Fatalf("failed to get deliveries: %v", err)
+		}
+		if len(deliveries) != 0 {
+			t.Errorf("expected deliveries to be deleted, got %d", len(deliveries))
+		}
+	})
+}
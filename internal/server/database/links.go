@@ -0,0 +1,150 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+// LinkRequires is the kind of link from a switch to a switch that must expire before it fires.
+// Chain actions are stored with their action as the kind.
+const LinkRequires = "requires"
+
+// SwitchLink is a relationship between two switches of the same owner.
+type SwitchLink struct {
+	SwitchID int
+	TargetID int
+	Kind     string
+}
+
+// GetSwitchLinks returns every link between the switches of the given user.
+func (s *sqliteStore) GetSwitchLinks(userID string) ([]SwitchLink, error) {
+	rows, err := s.db.Query(`SELECT l.switch_id, l.target_id, l.kind FROM switch_links l
+        JOIN switches s ON s.id = l.switch_id WHERE s.user_id = ? ORDER BY l.switch_id, l.target_id`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	links := []SwitchLink{}
+	for rows.Next() {
+		link := SwitchLink{}
+
+		err := rows.Scan(&link.SwitchID, &link.TargetID, &link.Kind)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+
+		links = append(links, link)
+	}
+
+	return links, rows.Err()
+}
+
+// GetWaitingDependents returns the waiting switches that require the given switch, decrypted for the worker.
+func (s *sqliteStore) GetWaitingDependents(switchID int) ([]api.Switch, error) {
+	rows, err := s.db.Query(fmt.Sprintf(`SELECT %s FROM switches WHERE status = ? AND trigger_at <= ?
+        AND id IN (SELECT switch_id FROM switch_links WHERE target_id = ? AND kind = ?)`, switchColumns),
+		api.SwitchStatusWaiting, time.Now().Unix(), switchID, LinkRequires)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	switches, err := s.scanSwitches(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range switches {
+		err := s.DecryptSwitch(&switches[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return switches, nil
+}
+
+// saveLinks replaces the chain actions and requirements of a switch.
+func (s *sqliteStore) saveLinks(switchID int, sw api.Switch) error {
+	_, err := s.db.Exec(`DELETE FROM switch_links WHERE switch_id = ?`, switchID)
+	if err != nil {
+		return err
+	}
+
+	links := []SwitchLink{}
+	if sw.OnTrigger != nil {
+		for _, action := range *sw.OnTrigger {
+			links = append(links, SwitchLink{SwitchID: switchID, TargetID: action.SwitchId, Kind: string(action.Action)})
+		}
+	}
+	if sw.Requires != nil {
+		for _, id := range *sw.Requires {
+			links = append(links, SwitchLink{SwitchID: switchID, TargetID: id, Kind: LinkRequires})
+		}
+	}
+
+	for _, link := range links {
+		_, err := s.db.Exec(`INSERT OR IGNORE INTO switch_links (switch_id, target_id, kind) VALUES (?, ?, ?)`, link.SwitchID, link.TargetID, link.Kind)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadLinks attaches chain actions and requirements to scanned switches.
+func (s *sqliteStore) loadLinks(switches []api.Switch) error {
+	if len(switches) == 0 {
+		return nil
+	}
+
+	index := make(map[int]int, len(switches))
+	ids := make([]any, 0, len(switches))
+	placeholders := make([]string, 0, len(switches))
+	for i, sw := range switches {
+		index[*sw.Id] = i
+		ids = append(ids, *sw.Id)
+		placeholders = append(placeholders, "?")
+	}
+
+	query := fmt.Sprintf("SELECT switch_id, target_id, kind FROM switch_links WHERE switch_id IN (%s) ORDER BY target_id", strings.Join(placeholders, ", "))
+
+	rows, err := s.db.Query(query, ids...)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		link := SwitchLink{}
+
+		err := rows.Scan(&link.SwitchID, &link.TargetID, &link.Kind)
+		if err != nil {
+			return fmt.Errorf("scan error: %w", err)
+		}
+
+		sw := &switches[index[link.SwitchID]]
+		if link.Kind == LinkRequires {
+			if sw.Requires == nil {
+				sw.Requires = &[]int{}
+			}
+			*sw.Requires = append(*sw.Requires, link.TargetID)
+			continue
+		}
+
+		if sw.OnTrigger == nil {
+			sw.OnTrigger = &[]api.ChainAction{}
+		}
+		*sw.OnTrigger = append(*sw.OnTrigger, api.ChainAction{SwitchId: link.TargetID, Action: api.ChainActionAction(link.Kind)})
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,123 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+func TestSQLiteStore_SwitchLinks(t *testing.T) {
+	store := setupTestStore(t)
+
+	create := func(sw api.Switch) api.Switch {
+		t.Helper()
+
+		sw.Message = "linked"
+		sw.Notifiers = []string{"logger://"}
+		sw.CheckInInterval = "24h"
+
+		created, err := store.Create(sw)
+		if err != nil {
+			t.Fatalf("failed to create switch: %v", err)
+		}
+		return created
+	}
+
+	past := time.Now().Add(-time.Hour).Unix()
+	statusWaiting := api.SwitchStatusWaiting
+
+	required := create(api.Switch{Status: &statusActive})
+	armed := create(api.Switch{Status: &statusActive})
+	dependent := create(api.Switch{
+		Status:    &statusWaiting,
+		TriggerAt: &past,
+		OnTrigger: &[]api.ChainAction{{SwitchId: *armed.Id, Action: api.ChainActionArm}},
+		Requires:  &[]int{*required.Id},
+	})
+
+	t.Run("links round trip", func(t *testing.T) {
+		if dependent.OnTrigger == nil || len(*dependent.OnTrigger) != 1 || (*dependent.OnTrigger)[0].SwitchId != *armed.Id || (*dependent.OnTrigger)[0].Action != api.ChainActionArm {
+			t.Errorf("unexpected chain actions %+v", dependent.OnTrigger)
+		}
+		if dependent.Requires == nil || len(*dependent.Requires) != 1 || (*dependent.Requires)[0] != *required.Id {
+			t.Errorf("unexpected requirements %+v", dependent.Requires)
+		}
+		if required.OnTrigger != nil || required.Requires != nil {
+			t.Errorf("expected no links on the required switch, got %+v %+v", required.OnTrigger, required.Requires)
+		}
+	})
+
+	t.Run("GetSwitchLinks is scoped to the owner", func(t *testing.T) {
+		links, err := store.GetSwitchLinks(AdminUser)
+		if err != nil {
+			t.Fatalf("failed to get links: %v", err)
+		}
+		if len(links) != 2 {
+			t.Errorf("expected 2 links, got %+v", links)
+		}
+
+		links, err = store.GetSwitchLinks("mallory")
+		if err != nil {
+			t.Fatalf("failed to get links: %v", err)
+		}
+		if len(links) != 0 {
+			t.Errorf("expected no links for another user, got %+v", links)
+		}
+	})
+
+	t.Run("GetWaitingDependents returns decrypted waiting switches", func(t *testing.T) {
+		dependents, err := store.GetWaitingDependents(*required.Id)
+		if err != nil {
+			t.Fatalf("failed to get dependents: %v", err)
+		}
+		if len(dependents) != 1 || *dependents[0].Id != *dependent.Id {
+			t.Fatalf("expected the waiting switch, got %+v", dependents)
+		}
+		if dependents[0].Message != "linked" {
+			t.Errorf("expected a decrypted message, got %q", dependents[0].Message)
+		}
+
+		dependents, err = store.GetWaitingDependents(*armed.Id)
+		if err != nil {
+			t.Fatalf("failed to get dependents: %v", err)
+		}
+		if len(dependents) != 0 {
+			t.Errorf("chain actions aren't requirements, got %+v", dependents)
+		}
+	})
+
+	t.Run("Update replaces links", func(t *testing.T) {
+		err := store.DecryptSwitch(&dependent)
+		if err != nil {
+			t.Fatalf("failed to decrypt switch: %v", err)
+		}
+
+		dependent.OnTrigger = nil
+		updated, err := store.Update(*dependent.Id, dependent)
+		if err != nil {
+			t.Fatalf("failed to update switch: %v", err)
+		}
+		if updated.OnTrigger != nil || updated.Requires == nil {
+			t.Errorf("expected only the requirement to remain, got %+v %+v", updated.OnTrigger, updated.Requires)
+		}
+	})
+
+	t.Run("Delete releases waiting switches and removes links", func(t *testing.T) {
+		err := store.Delete(AdminUser, *required.Id)
+		if err != nil {
+			t.Fatalf("failed to delete switch: %v", err)
+		}
+
+		released, err := store.GetByID(AdminUser, *dependent.Id)
+		if err != nil {
+			t.Fatalf("failed to get switch: %v", err)
+		}
+		if *released.Status != api.SwitchStatusActive {
+			t.Errorf("expected the waiting switch to be active again, got %s", *released.Status)
+		}
+		if released.Requires != nil {
+			t.Errorf("expected the requirement to be removed, got %+v", *released.Requires)
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+// CheckInMember records a check-in by a member of a quorum switch. Returns sql.ErrNoRows if the user isn't a member.
+func (s *sqliteStore) CheckInMember(switchID int, userID string, checkedInAt int64) error {
+	res, err := s.db.Exec(`UPDATE switch_members SET last_check_in_at = ? WHERE switch_id = ? AND user_id = ?`, checkedInAt, switchID, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetByMember returns a single switch by its ID if the given user is one of its members. Returns sql.ErrNoRows if not found.
+func (s *sqliteStore) GetByMember(userID string, id int) (api.Switch, error) {
+	rows, err := s.db.Query(fmt.Sprintf("SELECT %s FROM switches WHERE id = ? AND id IN (SELECT switch_id FROM switch_members WHERE user_id = ?)", switchColumns), id, userID)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	switches, err := s.scanSwitches(rows)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
+	if len(switches) == 0 {
+		return api.Switch{}, sql.ErrNoRows
+	}
+
+	return switches[0], nil
+}
+
+// saveMembers replaces the members of a switch. Existing members keep their check-in state.
+func (s *sqliteStore) saveMembers(switchID int, members *[]api.SwitchMember) error {
+	userIDs := []any{switchID}
+	placeholders := []string{}
+
+	if members != nil {
+		for _, member := range *members {
+			_, err := s.db.Exec(`INSERT INTO switch_members (switch_id, user_id, notifier) VALUES (?, ?, ?)
+                ON CONFLICT (switch_id, user_id) DO UPDATE SET notifier = excluded.notifier`,
+				switchID,
+				member.UserId,
+				member.Notifier,
+			)
+			if err != nil {
+				return err
+			}
+
+			userIDs = append(userIDs, member.UserId)
+			placeholders = append(placeholders, "?")
+		}
+	}
+
+	query := `DELETE FROM switch_members WHERE switch_id = ?`
+	if len(placeholders) > 0 {
+		query += fmt.Sprintf(" AND user_id NOT IN (%s)", strings.Join(placeholders, ", "))
+	}
+
+	_, err := s.db.Exec(query, userIDs...)
+	return err
+}
+
+// loadMembers attaches members and their check-in state to scanned switches.
+func (s *sqliteStore) loadMembers(switches []api.Switch) error {
+	if len(switches) == 0 {
+		return nil
+	}
+
+	index := make(map[int]int, len(switches))
+	ids := make([]any, 0, len(switches))
+	placeholders := make([]string, 0, len(switches))
+	for i, sw := range switches {
+		index[*sw.Id] = i
+		ids = append(ids, *sw.Id)
+		placeholders = append(placeholders, "?")
+	}
+
+	query := fmt.Sprintf("SELECT switch_id, user_id, notifier, last_check_in_at FROM switch_members WHERE switch_id IN (%s) ORDER BY user_id", strings.Join(placeholders, ", "))
+
+	rows, err := s.db.Query(query, ids...)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var switchID int
+		member := api.SwitchMember{}
+		var notifier sql.NullString
+		var lastCheckInAt sql.NullInt64
+
+		err := rows.Scan(&switchID, &member.UserId, &notifier, &lastCheckInAt)
+		if err != nil {
+			return fmt.Errorf("scan error: %w", err)
+		}
+
+		if notifier.Valid {
+			member.Notifier = &notifier.String
+		}
+		if lastCheckInAt.Valid {
+			member.LastCheckInAt = &lastCheckInAt.Int64
+		}
+
+		sw := &switches[index[switchID]]
+		if sw.Members == nil {
+			sw.Members = &[]api.SwitchMember{}
+		}
+		*sw.Members = append(*sw.Members, member)
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,136 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+func TestSQLiteStore_Members(t *testing.T) {
+	store := setupTestStore(t)
+
+	created, err := store.Create(api.Switch{
+		Message:         "team",
+		Notifiers:       []string{"logger://"},
+		CheckInInterval: "24h",
+		Members: &[]api.SwitchMember{
+			{UserId: "alice", Notifier: ptr("logger://alice")},
+			{UserId: "bob"},
+		},
+		Quorum: ptr(1),
+		Status: &statusActive,
+	})
+	if err != nil {
+		t.Fatalf("failed to create switch: %v", err)
+	}
+
+	t.Run("members and quorum round trip", func(t *testing.T) {
+		if created.Quorum == nil || *created.Quorum != 1 {
+			t.Errorf("expected quorum 1, got %v", created.Quorum)
+		}
+		if created.Members == nil || len(*created.Members) != 2 {
+			t.Fatalf("expected 2 members, got %v", created.Members)
+		}
+		if (*created.Members)[0].UserId != "alice" || *(*created.Members)[0].Notifier != "logger://alice" {
+			t.Errorf("unexpected first member %+v", (*created.Members)[0])
+		}
+	})
+
+	t.Run("GetByMember is scoped to members", func(t *testing.T) {
+		_, err := store.GetByMember("bob", *created.Id)
+		if err != nil {
+			t.Errorf("expected member to find switch, got %v", err)
+		}
+
+		_, err = store.GetByMember("mallory", *created.Id)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows for non-member, got %v", err)
+		}
+	})
+
+	t.Run("CheckInMember records member state", func(t *testing.T) {
+		now := time.Now().Unix()
+
+		err := store.CheckInMember(*created.Id, "bob", now)
+		if err != nil {
+			t.Fatalf("failed to check in member: %v", err)
+		}
+
+		err = store.CheckInMember(*created.Id, "mallory", now)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows for non-member, got %v", err)
+		}
+
+		found, err := store.GetByID("admin", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to get switch: %v", err)
+		}
+		bob := (*found.Members)[1]
+		if bob.LastCheckInAt == nil || *bob.LastCheckInAt != now {
+			t.Errorf("expected bob's check-in at %d, got %v", now, bob.LastCheckInAt)
+		}
+	})
+
+	t.Run("Update replaces members and keeps their state", func(t *testing.T) {
+		found, err := store.GetByID("admin", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to get switch: %v", err)
+		}
+
+		found.Members = &[]api.SwitchMember{{UserId: "bob"}, {UserId: "carol"}}
+		updated, err := store.Update(*created.Id, found)
+		if err != nil {
+			t.Fatalf("failed to update switch: %v", err)
+		}
+
+		members := *updated.Members
+		if len(members) != 2 || members[0].UserId != "bob" || members[1].UserId != "carol" {
+			t.Fatalf("expected members bob and carol, got %+v", members)
+		}
+		if members[0].LastCheckInAt == nil {
+			t.Error("expected bob to keep their check-in")
+		}
+	})
+
+	t.Run("member notifiers are encrypted with the switch", func(t *testing.T) {
+		encrypted, err := store.Create(api.Switch{
+			Message:         "secret team",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "24h",
+			Members:         &[]api.SwitchMember{{UserId: "alice", Notifier: ptr("discord://token@id")}},
+			Encrypted:       ptr(true),
+			Status:          &statusActive,
+		})
+		if err != nil {
+			t.Fatalf("failed to create switch: %v", err)
+		}
+
+		member := (*encrypted.Members)[0]
+		if *member.Notifier == "discord://token@id" {
+			t.Fatal("expected member notifier to be encrypted at rest")
+		}
+
+		err = store.DecryptSwitch(&encrypted)
+		if err != nil {
+			t.Fatalf("failed to decrypt switch: %v", err)
+		}
+		if *(*encrypted.Members)[0].Notifier != "discord://token@id" {
+			t.Errorf("expected decrypted member notifier, got %q", *(*encrypted.Members)[0].Notifier)
+		}
+	})
+
+	t.Run("Delete removes members", func(t *testing.T) {
+		err := store.Delete("admin", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to delete switch: %v", err)
+		}
+
+		_, err = store.GetByMember("bob", *created.Id)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows after delete, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,165 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Passkey is a WebAuthn credential registered to a local user account.
+type Passkey struct {
+	ID       int
+	Username string
+	Name     string
+	// CredentialID is the base64url encoded ID the authenticator identifies the credential with.
+	CredentialID string
+	// Credential is the JSON encoded public key, flags and sign count of the credential.
+	Credential string
+	CreatedAt  int64
+	LastUsedAt *int64
+}
+
+// PasskeyChallenge is a pending WebAuthn ceremony. It is single use and only valid for its purpose, so a
+// challenge issued to sign in can't confirm a check-in.
+type PasskeyChallenge struct {
+	// Challenge is the base64url encoded challenge the authenticator signs.
+	Challenge string
+	Username  string
+	Purpose   string
+	// Session is the JSON encoded session data the ceremony is verified against.
+	Session   string
+	ExpiresAt int64
+}
+
+const passkeyColumns = `id, username, name, credential_id, credential, created_at, last_used_at`
+
+// CreatePasskey stores a passkey of a local user account.
+func (s *sqliteStore) CreatePasskey(passkey Passkey) (Passkey, error) {
+	res, err := s.db.Exec(`INSERT INTO passkeys (username, name, credential_id, credential, created_at) VALUES (?, ?, ?, ?, ?)`,
+		passkey.Username,
+		passkey.Name,
+		passkey.CredentialID,
+		passkey.Credential,
+		passkey.CreatedAt,
+	)
+	if err != nil {
+		return Passkey{}, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return Passkey{}, err
+	}
+
+	passkey.ID = int(id)
+	return passkey, nil
+}
+
+// CreatePasskeyChallenge stores a pending WebAuthn ceremony, removing ceremonies that have expired.
+func (s *sqliteStore) CreatePasskeyChallenge(challenge PasskeyChallenge) error {
+	_, err := s.db.Exec(`DELETE FROM passkey_challenges WHERE expires_at <= ?`, time.Now().Unix())
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`INSERT INTO passkey_challenges (challenge, username, purpose, session, expires_at) VALUES (?, ?, ?, ?, ?)`,
+		challenge.Challenge,
+		challenge.Username,
+		challenge.Purpose,
+		challenge.Session,
+		challenge.ExpiresAt,
+	)
+	return err
+}
+
+// DeletePasskey removes a passkey, scoped to the given user. Returns sql.ErrNoRows if not found.
+func (s *sqliteStore) DeletePasskey(username string, id int) error {
+	res, err := s.db.Exec(`DELETE FROM passkeys WHERE id = ? AND username = ?`, id, username)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetPasskeyByCredentialID returns the passkey with the given credential ID, regardless of its owner.
+// Returns sql.ErrNoRows if not found.
+func (s *sqliteStore) GetPasskeyByCredentialID(credentialID string) (Passkey, error) {
+	passkeys, err := s.queryPasskeys(fmt.Sprintf("SELECT %s FROM passkeys WHERE credential_id = ?", passkeyColumns), credentialID)
+	if err != nil {
+		return Passkey{}, err
+	}
+
+	if len(passkeys) == 0 {
+		return Passkey{}, sql.ErrNoRows
+	}
+
+	return passkeys[0], nil
+}
+
+// GetPasskeys returns every passkey of the given user, oldest first.
+func (s *sqliteStore) GetPasskeys(username string) ([]Passkey, error) {
+	return s.queryPasskeys(fmt.Sprintf("SELECT %s FROM passkeys WHERE username = ? ORDER BY id", passkeyColumns), username)
+}
+
+// TakePasskeyChallenge returns the pending ceremony with the given challenge and purpose and removes it, so it
+// can only be completed once. Returns sql.ErrNoRows if not found or expired by now.
+func (s *sqliteStore) TakePasskeyChallenge(challenge, purpose string, now int64) (PasskeyChallenge, error) {
+	c := PasskeyChallenge{}
+
+	err := s.db.QueryRow(`DELETE FROM passkey_challenges WHERE challenge = ? AND purpose = ? RETURNING challenge, username, purpose, session, expires_at`, challenge, purpose).
+		Scan(&c.Challenge, &c.Username, &c.Purpose, &c.Session, &c.ExpiresAt)
+	if err != nil {
+		return PasskeyChallenge{}, err
+	}
+
+	if c.ExpiresAt <= now {
+		return PasskeyChallenge{}, sql.ErrNoRows
+	}
+
+	return c, nil
+}
+
+// UpdatePasskeyCredential stores the credential of a passkey after it was used, which carries its new sign count.
+func (s *sqliteStore) UpdatePasskeyCredential(id int, credential string, usedAt int64) error {
+	_, err := s.db.Exec(`UPDATE passkeys SET credential = ?, last_used_at = ? WHERE id = ?`, credential, usedAt, id)
+	return err
+}
+
+// queryPasskeys runs a query selecting passkeyColumns and scans the results.
+func (s *sqliteStore) queryPasskeys(query string, args ...any) ([]Passkey, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	passkeys := []Passkey{}
+	for rows.Next() {
+		p := Passkey{}
+		var lastUsedAt sql.NullInt64
+
+		err := rows.Scan(&p.ID, &p.Username, &p.Name, &p.CredentialID, &p.Credential, &p.CreatedAt, &lastUsedAt)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+
+		if lastUsedAt.Valid {
+			p.LastUsedAt = &lastUsedAt.Int64
+		}
+
+		passkeys = append(passkeys, p)
+	}
+
+	return passkeys, rows.Err()
+}
@@ -0,0 +1,120 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSQLiteStore_Passkeys(t *testing.T) {
+	store := setupTestStore(t)
+
+	now := time.Now().Unix()
+
+	created, err := store.CreatePasskey(Passkey{Username: "alice", Name: "YubiKey", CredentialID: "cred-1", Credential: `{"id":"1"}`, CreatedAt: now})
+	if err != nil {
+		t.Fatalf("failed to create passkey: %v", err)
+	}
+
+	t.Run("Credential IDs are unique", func(t *testing.T) {
+		_, err := store.CreatePasskey(Passkey{Username: "bob", Name: "Phone", CredentialID: "cred-1", Credential: `{}`, CreatedAt: now})
+		if err == nil {
+			t.Error("expected a duplicate credential ID to be rejected")
+		}
+	})
+
+	t.Run("Passkeys are found by credential ID and owner", func(t *testing.T) {
+		passkey, err := store.GetPasskeyByCredentialID("cred-1")
+		if err != nil || passkey.ID != created.ID || passkey.Username != "alice" {
+			t.Fatalf("unexpected passkey %+v (%v)", passkey, err)
+		}
+
+		passkeys, err := store.GetPasskeys("alice")
+		if err != nil || len(passkeys) != 1 {
+			t.Fatalf("expected 1 passkey, got %d (%v)", len(passkeys), err)
+		}
+
+		passkeys, err = store.GetPasskeys("bob")
+		if err != nil || len(passkeys) != 0 {
+			t.Errorf("expected no passkeys for bob, got %d (%v)", len(passkeys), err)
+		}
+	})
+
+	t.Run("Using a passkey stores its credential", func(t *testing.T) {
+		err := store.UpdatePasskeyCredential(created.ID, `{"id":"1","signCount":2}`, now+10)
+		if err != nil {
+			t.Fatalf("failed to update passkey: %v", err)
+		}
+
+		passkey, _ := store.GetPasskeyByCredentialID("cred-1")
+		if passkey.Credential != `{"id":"1","signCount":2}` || passkey.LastUsedAt == nil || *passkey.LastUsedAt != now+10 {
+			t.Errorf("unexpected passkey %+v", passkey)
+		}
+	})
+
+	t.Run("Passkeys are only deleted by their owner", func(t *testing.T) {
+		err := store.DeletePasskey("bob", created.ID)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows, got %v", err)
+		}
+
+		err = store.DeletePasskey("alice", created.ID)
+		if err != nil {
+			t.Fatalf("failed to delete passkey: %v", err)
+		}
+
+		_, err = store.GetPasskeyByCredentialID("cred-1")
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows, got %v", err)
+		}
+	})
+}
+
+func TestSQLiteStore_PasskeyChallenges(t *testing.T) {
+	store := setupTestStore(t)
+
+	now := time.Now().Unix()
+
+	for _, c := range []PasskeyChallenge{
+		{Challenge: "login", Purpose: "login", Session: "{}", ExpiresAt: now + 300},
+		{Challenge: "checkin", Username: "alice", Purpose: "checkin:1", Session: "{}", ExpiresAt: now + 300},
+		{Challenge: "expired", Purpose: "login", Session: "{}", ExpiresAt: now - 1},
+	} {
+		err := store.CreatePasskeyChallenge(c)
+		if err != nil {
+			t.Fatalf("failed to create challenge: %v", err)
+		}
+	}
+
+	t.Run("Challenges are single use", func(t *testing.T) {
+		c, err := store.TakePasskeyChallenge("login", "login", now)
+		if err != nil || c.Challenge != "login" {
+			t.Fatalf("unexpected challenge %+v (%v)", c, err)
+		}
+
+		_, err = store.TakePasskeyChallenge("login", "login", now)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows, got %v", err)
+		}
+	})
+
+	t.Run("Challenges are only valid for their purpose", func(t *testing.T) {
+		_, err := store.TakePasskeyChallenge("checkin", "checkin:2", now)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows, got %v", err)
+		}
+
+		c, err := store.TakePasskeyChallenge("checkin", "checkin:1", now)
+		if err != nil || c.Username != "alice" {
+			t.Errorf("unexpected challenge %+v (%v)", c, err)
+		}
+	})
+
+	t.Run("Expired challenges are rejected", func(t *testing.T) {
+		_, err := store.TakePasskeyChallenge("expired", "login", now)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,42 @@
+package database
+
+import "time"
+
+// ReplyToken lets a user check in to a switch by replying to a reminder email that contains it.
+// Only the hash of the token is stored.
+type ReplyToken struct {
+	TokenHash string
+	SwitchID  int
+	// UserID is who checks in, the owner or a member of a quorum switch.
+	UserID    string
+	ExpiresAt int64
+}
+
+// CreateReplyToken stores a token sent in a reminder email, removing tokens that have expired.
+func (s *sqliteStore) CreateReplyToken(token ReplyToken) error {
+	_, err := s.db.Exec(`DELETE FROM reply_tokens WHERE expires_at <= ?`, time.Now().Unix())
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`INSERT INTO reply_tokens (token_hash, switch_id, user_id, expires_at) VALUES (?, ?, ?, ?)`,
+		token.TokenHash,
+		token.SwitchID,
+		token.UserID,
+		token.ExpiresAt,
+	)
+	return err
+}
+
+// GetReplyToken returns the token with the given hash if it hasn't expired by now. Returns sql.ErrNoRows if not found.
+func (s *sqliteStore) GetReplyToken(tokenHash string, now int64) (ReplyToken, error) {
+	token := ReplyToken{}
+
+	err := s.db.QueryRow(`SELECT token_hash, switch_id, user_id, expires_at FROM reply_tokens WHERE token_hash = ? AND expires_at > ?`, tokenHash, now).
+		Scan(&token.TokenHash, &token.SwitchID, &token.UserID, &token.ExpiresAt)
+	if err != nil {
+		return ReplyToken{}, err
+	}
+
+	return token, nil
+}
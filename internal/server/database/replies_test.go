@@ -0,0 +1,63 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+func TestSQLiteStore_ReplyTokens(t *testing.T) {
+	store := setupTestStore(t)
+
+	created, err := store.Create(api.Switch{
+		Message:         "reply",
+		Notifiers:       []string{"logger://"},
+		CheckInInterval: "24h",
+		Status:          &statusActive,
+	})
+	if err != nil {
+		t.Fatalf("failed to create switch: %v", err)
+	}
+
+	now := time.Now().Unix()
+
+	for _, token := range []ReplyToken{
+		{TokenHash: "current", SwitchID: *created.Id, UserID: "alice", ExpiresAt: now + 3600},
+		{TokenHash: "expired", SwitchID: *created.Id, UserID: "alice", ExpiresAt: now - 1},
+	} {
+		err = store.CreateReplyToken(token)
+		if err != nil {
+			t.Fatalf("failed to create reply token: %v", err)
+		}
+	}
+
+	t.Run("Tokens are found until they expire", func(t *testing.T) {
+		token, err := store.GetReplyToken("current", now)
+		if err != nil {
+			t.Fatalf("failed to get reply token: %v", err)
+		}
+		if token.SwitchID != *created.Id || token.UserID != "alice" {
+			t.Errorf("unexpected token %+v", token)
+		}
+
+		_, err = store.GetReplyToken("expired", now)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows for an expired token, got %v", err)
+		}
+	})
+
+	t.Run("Tokens are removed with the switch", func(t *testing.T) {
+		err := store.Delete(AdminUser, *created.Id)
+		if err != nil {
+			t.Fatalf("failed to delete switch: %v", err)
+		}
+
+		_, err = store.GetReplyToken("current", now)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows, got %v", err)
+		}
+	})
+}
@@ -3,20 +3,254 @@ package database
 const schema = `
 CREATE TABLE IF NOT EXISTS switches (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
+    actions TEXT,
+    alert_matchers TEXT,
+    approvers TEXT,
+    change_delay TEXT,
     check_in_interval TEXT NOT NULL,
+    check_in_token TEXT,
     delete_after_triggered BOOLEAN DEFAULT 0,
+    duress_code TEXT,
+    duress_notifiers TEXT,
     encrypted BOOLEAN DEFAULT 0,
     failure_reason TEXT,
+    labels TEXT,
+    last_check_in_at INTEGER,
+    max_postpone TEXT,
+    max_triggers INTEGER,
     message TEXT NOT NULL,
     notifiers TEXT NOT NULL,
+    paused_at INTEGER,
+    paused_until INTEGER,
+    protected BOOLEAN DEFAULT 0,
     push_subscription TEXT,
+    quorum INTEGER,
+    rearm BOOLEAN DEFAULT 0,
     reminder_enabled BOOLEAN DEFAULT 0,
     reminder_sent BOOLEAN DEFAULT 0,
     reminder_threshold TEXT,
+    repeat_interval TEXT,
+    require_passkey BOOLEAN DEFAULT 0,
+    resume_policy TEXT,
     status TEXT NOT NULL,
     trigger_at INTEGER DEFAULT 0,
-    user_id TEXT NOT NULL DEFAULT 'admin'
+    trigger_count INTEGER DEFAULT 0,
+    trusted_contacts TEXT,
+    user_id TEXT NOT NULL DEFAULT 'admin',
+    verification_window TEXT,
+    webhooks TEXT
 );
 
 CREATE INDEX IF NOT EXISTS idx_pending_active_switches ON switches (user_id, status, trigger_at);
+
+CREATE TABLE IF NOT EXISTS checkins (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    switch_id INTEGER NOT NULL,
+    user_id TEXT NOT NULL,
+    checked_in_at INTEGER NOT NULL,
+    method TEXT NOT NULL,
+    ip_address TEXT,
+    user_agent TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_checkins_switch ON checkins (user_id, switch_id, checked_in_at);
+
+CREATE TABLE IF NOT EXISTS switch_members (
+    switch_id INTEGER NOT NULL,
+    user_id TEXT NOT NULL,
+    notifier TEXT,
+    last_check_in_at INTEGER,
+    PRIMARY KEY (switch_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_switch_members_user ON switch_members (user_id, switch_id);
+
+CREATE TABLE IF NOT EXISTS switch_links (
+    switch_id INTEGER NOT NULL,
+    target_id INTEGER NOT NULL,
+    kind TEXT NOT NULL,
+    PRIMARY KEY (switch_id, target_id, kind)
+);
+
+CREATE INDEX IF NOT EXISTS idx_switch_links_target ON switch_links (target_id, kind);
+
+CREATE TABLE IF NOT EXISTS contact_tokens (
+    token_hash TEXT PRIMARY KEY,
+    switch_id INTEGER NOT NULL,
+    user_id TEXT NOT NULL,
+    contact_name TEXT NOT NULL,
+    expires_at INTEGER NOT NULL,
+    used_at INTEGER
+);
+
+CREATE INDEX IF NOT EXISTS idx_contact_tokens_switch ON contact_tokens (switch_id);
+
+CREATE TABLE IF NOT EXISTS audit_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    switch_id INTEGER NOT NULL,
+    user_id TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    actor TEXT NOT NULL,
+    action TEXT NOT NULL,
+    detail TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_switch ON audit_events (user_id, switch_id, created_at);
+
+CREATE TABLE IF NOT EXISTS deliveries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    switch_id INTEGER NOT NULL,
+    subscription_id INTEGER,
+    user_id TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    kind TEXT NOT NULL,
+    target TEXT NOT NULL,
+    success BOOLEAN NOT NULL,
+    exit_code INTEGER,
+    status_code INTEGER,
+    attempts INTEGER,
+    output TEXT,
+    error TEXT,
+    duration INTEGER
+);
+
+CREATE INDEX IF NOT EXISTS idx_deliveries_switch ON deliveries (user_id, switch_id, created_at);
+
+CREATE TABLE IF NOT EXISTS subscriptions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id TEXT NOT NULL,
+    url TEXT NOT NULL,
+    events TEXT,
+    secret TEXT NOT NULL,
+    enabled BOOLEAN DEFAULT 1,
+    max_attempts INTEGER,
+    retry_backoff TEXT,
+    created_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_user ON subscriptions (user_id);
+
+CREATE TABLE IF NOT EXISTS pending_changes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    switch_id INTEGER NOT NULL,
+    requested_by TEXT NOT NULL,
+    action TEXT NOT NULL,
+    status TEXT NOT NULL,
+    summary TEXT NOT NULL,
+    payload TEXT,
+    created_at INTEGER NOT NULL,
+    apply_at INTEGER,
+    resolved_by TEXT,
+    resolved_at INTEGER
+);
+
+CREATE INDEX IF NOT EXISTS idx_pending_changes_status ON pending_changes (status, apply_at);
+
+CREATE TABLE IF NOT EXISTS reply_tokens (
+    token_hash TEXT PRIMARY KEY,
+    switch_id INTEGER NOT NULL,
+    user_id TEXT NOT NULL,
+    expires_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_reply_tokens_switch ON reply_tokens (switch_id);
+
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    scopes TEXT NOT NULL,
+    expires_at INTEGER,
+    created_at INTEGER NOT NULL,
+    last_used_at INTEGER
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_tokens_user ON api_tokens (user_id);
+
+CREATE TABLE IF NOT EXISTS users (
+    username TEXT PRIMARY KEY,
+    password_hash TEXT NOT NULL,
+    totp_secret TEXT,
+    totp_enabled INTEGER NOT NULL DEFAULT 0,
+    totp_last_step INTEGER NOT NULL DEFAULT 0,
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS sessions (
+    token_hash TEXT PRIMARY KEY,
+    username TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    expires_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_sessions_username ON sessions (username);
+
+CREATE TABLE IF NOT EXISTS passkeys (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    username TEXT NOT NULL,
+    name TEXT NOT NULL,
+    credential_id TEXT NOT NULL UNIQUE,
+    credential TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    last_used_at INTEGER
+);
+
+CREATE INDEX IF NOT EXISTS idx_passkeys_username ON passkeys (username);
+
+CREATE TABLE IF NOT EXISTS passkey_challenges (
+    challenge TEXT PRIMARY KEY,
+    username TEXT NOT NULL,
+    purpose TEXT NOT NULL,
+    session TEXT NOT NULL,
+    expires_at INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS user_settings (
+    user_id TEXT PRIMARY KEY,
+    role TEXT,
+    max_switches INTEGER,
+    updated_at INTEGER NOT NULL
+);
+`
+
+// columnMigrations adds columns introduced after the initial schema to databases created by older versions.
+// New databases already get these columns from the CREATE TABLE statements above.
+var columnMigrations = []struct {
+	table      string
+	column     string
+	definition string
+}{
+	{table: "switches", column: "paused_at", definition: "INTEGER"},
+	{table: "switches", column: "paused_until", definition: "INTEGER"},
+	{table: "switches", column: "resume_policy", definition: "TEXT"},
+	{table: "switches", column: "duress_code", definition: "TEXT"},
+	{table: "switches", column: "duress_notifiers", definition: "TEXT"},
+	{table: "switches", column: "last_check_in_at", definition: "INTEGER"},
+	{table: "switches", column: "labels", definition: "TEXT"},
+	{table: "switches", column: "quorum", definition: "INTEGER"},
+	{table: "switches", column: "max_postpone", definition: "TEXT"},
+	{table: "switches", column: "trusted_contacts", definition: "TEXT"},
+	{table: "switches", column: "verification_window", definition: "TEXT"},
+	{table: "switches", column: "approvers", definition: "TEXT"},
+	{table: "switches", column: "change_delay", definition: "TEXT"},
+	{table: "switches", column: "protected", definition: "BOOLEAN DEFAULT 0"},
+	{table: "switches", column: "max_triggers", definition: "INTEGER"},
+	{table: "switches", column: "rearm", definition: "BOOLEAN DEFAULT 0"},
+	{table: "switches", column: "repeat_interval", definition: "TEXT"},
+	{table: "switches", column: "trigger_count", definition: "INTEGER DEFAULT 0"},
+	{table: "switches", column: "actions", definition: "TEXT"},
+	{table: "switches", column: "webhooks", definition: "TEXT"},
+	{table: "deliveries", column: "status_code", definition: "INTEGER"},
+	{table: "deliveries", column: "attempts", definition: "INTEGER"},
+	{table: "deliveries", column: "subscription_id", definition: "INTEGER"},
+	{table: "switches", column: "check_in_token", definition: "TEXT"},
+	{table: "switches", column: "alert_matchers", definition: "TEXT"},
+	{table: "switches", column: "require_passkey", definition: "BOOLEAN DEFAULT 0"},
+}
+
+// migratedIndexes covers columns that older databases only have after columnMigrations ran.
+const migratedIndexes = `
+CREATE UNIQUE INDEX IF NOT EXISTS idx_switches_check_in_token ON switches (check_in_token) WHERE check_in_token IS NOT NULL;
 `
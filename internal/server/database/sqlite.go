@@ -14,19 +14,21 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"time"
 
 	"github.com/circa10a/dead-mans-switch/api"
 	"github.com/circa10a/dead-mans-switch/internal/server/secrets"
 
 	// Import the sqlite driver that requires no CGO deps
-	_ "modernc.org/sqlite"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
 )
 
 const (
 	sqliteDBName = "switches_sqlite.db"
 	// switchColumns centralizes the field list to prevent Scan errors
-	switchColumns = `id, check_in_interval, delete_after_triggered, encrypted, failure_reason, message, notifiers, push_subscription, reminder_enabled, reminder_sent, reminder_threshold, status, trigger_at, user_id`
+	switchColumns = `id, actions, alert_matchers, approvers, change_delay, check_in_interval, check_in_token, delete_after_triggered, duress_code, duress_notifiers, encrypted, failure_reason, labels, last_check_in_at, max_postpone, max_triggers, message, notifiers, paused_at, paused_until, protected, push_subscription, quorum, rearm, reminder_enabled, reminder_sent, reminder_threshold, repeat_interval, require_passkey, resume_policy, status, trigger_at, trigger_count, trusted_contacts, user_id, verification_window, webhooks`
 )
 
 // getUserID extracts the user ID from a switch, defaulting to "admin".
@@ -37,6 +39,14 @@ func getUserID(sw api.Switch) string {
 	return AdminUser
 }
 
+// triggerCount returns how many times a switch has triggered since its last check-in, defaulting to 0.
+func triggerCount(sw api.Switch) int {
+	if sw.TriggerCount != nil {
+		return *sw.TriggerCount
+	}
+	return 0
+}
+
 // sqliteStore is an implementation of the Store interface for SQLite.
 type sqliteStore struct {
 	db            *sql.DB
@@ -107,6 +117,28 @@ func (s *sqliteStore) Init() error {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
+	for _, m := range columnMigrations {
+		var exists int
+		err = s.db.QueryRow("SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?", m.table, m.column).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("failed to inspect table %s: %w", m.table, err)
+		}
+
+		if exists > 0 {
+			continue
+		}
+
+		_, err = s.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", m.table, m.column, m.definition))
+		if err != nil {
+			return fmt.Errorf("failed to add column %s.%s: %w", m.table, m.column, err)
+		}
+	}
+
+	_, err = s.db.Exec(migratedIndexes)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes: %w", err)
+	}
+
 	return nil
 }
 
@@ -143,28 +175,86 @@ func (s *sqliteStore) Create(sw api.Switch) (api.Switch, error) {
 		}
 	}
 
+	duressNotifiers, err := marshalDuressNotifiers(sw)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
+	actions, err := marshalStrings(sw.Actions)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
+	alertMatchers, err := marshalAlertMatchers(sw.AlertMatchers)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
+	labels, err := marshalStrings(sw.Labels)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
+	approvers, err := marshalStrings(sw.Approvers)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
+	trustedContacts, err := marshalTrustedContacts(sw)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
+	webhooks, err := marshalWebhooks(sw)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
 	userID := getUserID(sw)
 
-	query := `INSERT INTO switches (check_in_interval, delete_after_triggered, encrypted, failure_reason, message, notifiers, push_subscription, reminder_enabled, reminder_sent, reminder_threshold, status, trigger_at, user_id)
-              VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	query := `INSERT INTO switches (actions, alert_matchers, approvers, change_delay, check_in_interval, check_in_token, delete_after_triggered, duress_code, duress_notifiers, encrypted, failure_reason, labels, last_check_in_at, max_postpone, max_triggers, message, notifiers, paused_at, paused_until, protected, push_subscription, quorum, rearm, reminder_enabled, reminder_sent, reminder_threshold, repeat_interval, require_passkey, resume_policy, status, trigger_at, trigger_count, trusted_contacts, user_id, verification_window, webhooks)
+              VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	res, err := s.db.Exec(query,
+		actions,
+		alertMatchers,
+		approvers,
+		sw.ChangeDelay,
 		sw.CheckInInterval,
+		sw.CheckInToken,
 		sw.DeleteAfterTriggered != nil && *sw.DeleteAfterTriggered,
+		sw.DuressCode,
+		duressNotifiers,
 		sw.Encrypted != nil && *sw.Encrypted,
 		sw.FailureReason,
+		labels,
+		sw.LastCheckInAt,
+		sw.MaxPostpone,
+		sw.MaxTriggers,
 		sw.Message,
 		notifiers,
+		sw.PausedAt,
+		sw.PausedUntil,
+		sw.Protected != nil && *sw.Protected,
 		pushSubscription,
+		sw.Quorum,
+		sw.Rearm != nil && *sw.Rearm,
 		sw.ReminderEnabled != nil && *sw.ReminderEnabled,
 		false, // ReminderSent default to false
 		sw.ReminderThreshold,
+		sw.RepeatInterval,
+		sw.RequirePasskey != nil && *sw.RequirePasskey,
+		sw.ResumePolicy,
 		sw.Status,
 		sw.TriggerAt,
+		triggerCount(sw),
+		trustedContacts,
 		userID,
+		sw.VerificationWindow,
+		webhooks,
 	)
 	if err != nil {
-		return api.Switch{}, err
+		return api.Switch{}, checkInTokenError(err)
 	}
 
 	id, err := res.LastInsertId()
@@ -172,6 +262,16 @@ func (s *sqliteStore) Create(sw api.Switch) (api.Switch, error) {
 		return api.Switch{}, err
 	}
 
+	err = s.saveMembers(int(id), sw.Members)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
+	err = s.saveLinks(int(id), sw)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
 	return s.GetByID(userID, int(id))
 }
 
@@ -210,9 +310,31 @@ func (s *sqliteStore) GetByID(userID string, id int) (api.Switch, error) {
 	return switches[0], nil
 }
 
-// GetExpired returns switches that have timed out and are ready for notification.
+// GetByCheckInToken returns the switch with the given check-in token hash. Returns sql.ErrNoRows if not found.
+func (s *sqliteStore) GetByCheckInToken(tokenHash string) (api.Switch, error) {
+	rows, err := s.db.Query(fmt.Sprintf("SELECT %s FROM switches WHERE check_in_token = ?", switchColumns), tokenHash)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	switches, err := s.scanSwitches(rows)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
+	if len(switches) == 0 {
+		return api.Switch{}, sql.ErrNoRows
+	}
+
+	return switches[0], nil
+}
+
+// GetExpired returns switches that have timed out, finished verification or are due another still missing
+// alert, and are ready for notification.
 func (s *sqliteStore) GetExpired(limit int) ([]api.Switch, error) {
-	rows, err := s.db.Query(fmt.Sprintf("SELECT %s FROM switches WHERE status = ? AND trigger_at <= ? LIMIT ?", switchColumns), api.SwitchStatusActive, time.Now().Unix(), limit)
+	rows, err := s.db.Query(fmt.Sprintf("SELECT %s FROM switches WHERE status IN (?, ?, ?) AND trigger_at <= ? LIMIT ?", switchColumns), api.SwitchStatusActive, api.SwitchStatusMissing, api.SwitchStatusVerifying, time.Now().Unix(), limit)
 	if err != nil {
 		return nil, err
 	}
@@ -258,6 +380,30 @@ func (s *sqliteStore) GetEligibleReminders(limit int) ([]api.Switch, error) {
 	return switches, nil
 }
 
+// GetResumable returns paused switches whose pause has ended and are ready to be re-armed.
+func (s *sqliteStore) GetResumable(limit int) ([]api.Switch, error) {
+	rows, err := s.db.Query(fmt.Sprintf("SELECT %s FROM switches WHERE status = ? AND paused_until <= ? LIMIT ?", switchColumns), api.SwitchStatusPaused, time.Now().Unix(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	switches, err := s.scanSwitches(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range switches {
+		err := s.DecryptSwitch(&switches[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return switches, nil
+}
+
 // Update updates an existing switch's configuration and resets its expiration timer.
 func (s *sqliteStore) Update(id int, sw api.Switch) (api.Switch, error) {
 	err := s.EncryptSwitch(&sw)
@@ -291,29 +437,87 @@ func (s *sqliteStore) Update(id int, sw api.Switch) (api.Switch, error) {
 		}
 	}
 
+	duressNotifiers, err := marshalDuressNotifiers(sw)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
+	actions, err := marshalStrings(sw.Actions)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
+	alertMatchers, err := marshalAlertMatchers(sw.AlertMatchers)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
+	labels, err := marshalStrings(sw.Labels)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
+	approvers, err := marshalStrings(sw.Approvers)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
+	trustedContacts, err := marshalTrustedContacts(sw)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
+	webhooks, err := marshalWebhooks(sw)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
 	userID := getUserID(sw)
 
-	query := `UPDATE switches SET check_in_interval=?, delete_after_triggered=?, encrypted=?, failure_reason=?, message=?, notifiers=?, push_subscription=?, reminder_enabled=?, reminder_sent=?, reminder_threshold=?, status=?, trigger_at=? WHERE id=? AND user_id=?`
+	query := `UPDATE switches SET actions=?, alert_matchers=?, approvers=?, change_delay=?, check_in_interval=?, check_in_token=?, delete_after_triggered=?, duress_code=?, duress_notifiers=?, encrypted=?, failure_reason=?, labels=?, last_check_in_at=?, max_postpone=?, max_triggers=?, message=?, notifiers=?, paused_at=?, paused_until=?, protected=?, push_subscription=?, quorum=?, rearm=?, reminder_enabled=?, reminder_sent=?, reminder_threshold=?, repeat_interval=?, require_passkey=?, resume_policy=?, status=?, trigger_at=?, trigger_count=?, trusted_contacts=?, verification_window=?, webhooks=? WHERE id=? AND user_id=?`
 
 	res, err := s.db.Exec(
 		query,
+		actions,
+		alertMatchers,
+		approvers,
+		sw.ChangeDelay,
 		sw.CheckInInterval,
+		sw.CheckInToken,
 		sw.DeleteAfterTriggered != nil && *sw.DeleteAfterTriggered,
+		sw.DuressCode,
+		duressNotifiers,
 		sw.Encrypted != nil && *sw.Encrypted,
 		sw.FailureReason,
+		labels,
+		sw.LastCheckInAt,
+		sw.MaxPostpone,
+		sw.MaxTriggers,
 		sw.Message,
 		notifiers,
+		sw.PausedAt,
+		sw.PausedUntil,
+		sw.Protected != nil && *sw.Protected,
 		pushSubscription,
+		sw.Quorum,
+		sw.Rearm != nil && *sw.Rearm,
 		sw.ReminderEnabled != nil && *sw.ReminderEnabled,
 		sw.ReminderSent != nil && *sw.ReminderSent,
 		sw.ReminderThreshold,
+		sw.RepeatInterval,
+		sw.RequirePasskey != nil && *sw.RequirePasskey,
+		sw.ResumePolicy,
 		sw.Status,
 		sw.TriggerAt,
+		triggerCount(sw),
+		trustedContacts,
+		sw.VerificationWindow,
+		webhooks,
 		id,
 		userID,
 	)
 	if err != nil {
-		return api.Switch{}, err
+		return api.Switch{}, checkInTokenError(err)
 	}
 
 	rows, err := res.RowsAffected()
@@ -325,12 +529,78 @@ func (s *sqliteStore) Update(id int, sw api.Switch) (api.Switch, error) {
 		return api.Switch{}, sql.ErrNoRows
 	}
 
+	err = s.saveMembers(id, sw.Members)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
+	err = s.saveLinks(id, sw)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
 	return s.GetByID(userID, id)
 }
 
-// Delete permanently removes a switch from the database, scoped to the given user.
+// Delete permanently removes a switch, its members, links, check-in history, contact and reply tokens, audit trail and deliveries from the database, scoped to the given user.
 func (s *sqliteStore) Delete(userID string, id int) error {
-	_, err := s.db.Exec(`DELETE FROM switches WHERE id = ? AND user_id = ?`, id, userID)
+	res, err := s.db.Exec(`DELETE FROM switches WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	// Members, their reply tokens and links aren't scoped to the owner, so only remove them once the switch is gone
+	if rows > 0 {
+		_, err = s.db.Exec(`DELETE FROM switch_members WHERE switch_id = ?`, id)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.db.Exec(`DELETE FROM reply_tokens WHERE switch_id = ?`, id)
+		if err != nil {
+			return err
+		}
+
+		// Switches waiting on this one go back to the worker, which no longer finds it among their requirements
+		_, err = s.db.Exec(`UPDATE switches SET status = ? WHERE status = ?
+        AND id IN (SELECT switch_id FROM switch_links WHERE target_id = ? AND kind = ?)`, api.SwitchStatusActive, api.SwitchStatusWaiting, id, LinkRequires)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.db.Exec(`DELETE FROM switch_links WHERE switch_id = ? OR target_id = ?`, id, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = s.db.Exec(`DELETE FROM checkins WHERE switch_id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`DELETE FROM contact_tokens WHERE switch_id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`DELETE FROM audit_events WHERE switch_id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	// Events sent to subscriptions stay in their delivery log
+	_, err = s.db.Exec(`DELETE FROM deliveries WHERE switch_id = ? AND user_id = ? AND subscription_id IS NULL`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`DELETE FROM pending_changes WHERE switch_id = ? AND requested_by = ?`, id, userID)
 	return err
 }
 
@@ -355,44 +625,149 @@ func (s *sqliteStore) scanSwitches(rows *sql.Rows) ([]api.Switch, error) {
 		var msgRaw string
 		var notifiersRaw string
 		var pushRaw sql.NullString
+		var actionsRaw sql.NullString
+		var alertMatchersRaw sql.NullString
+		var approversRaw sql.NullString
+		var changeDelayRaw sql.NullString
+		var checkInTokenRaw sql.NullString
 		var DeleteAfterTriggered sql.NullBool
+		var duressCodeRaw sql.NullString
+		var duressNotifiersRaw sql.NullString
 		var encrypted sql.NullBool
 		var failureReasonRaw sql.NullString
+		var labelsRaw sql.NullString
+		var lastCheckInAt sql.NullInt64
+		var maxPostponeRaw sql.NullString
+		var maxTriggers sql.NullInt64
+		var pausedAt sql.NullInt64
+		var pausedUntil sql.NullInt64
+		var protected sql.NullBool
+		var quorum sql.NullInt64
+		var rearm sql.NullBool
 		var reminderEnabled sql.NullBool
 		var reminderThresholdRaw sql.NullString
 		var reminderSent sql.NullBool
+		var repeatIntervalRaw sql.NullString
+		var requirePasskey sql.NullBool
+		var resumePolicyRaw sql.NullString
+		var triggerCount sql.NullInt64
+		var trustedContactsRaw sql.NullString
 		var userIDRaw sql.NullString
+		var verificationWindowRaw sql.NullString
+		var webhooksRaw sql.NullString
 
 		err := rows.Scan(
 			&sw.Id,
+			&actionsRaw,
+			&alertMatchersRaw,
+			&approversRaw,
+			&changeDelayRaw,
 			&sw.CheckInInterval,
+			&checkInTokenRaw,
 			&DeleteAfterTriggered,
+			&duressCodeRaw,
+			&duressNotifiersRaw,
 			&encrypted,
 			&failureReasonRaw,
+			&labelsRaw,
+			&lastCheckInAt,
+			&maxPostponeRaw,
+			&maxTriggers,
 			&msgRaw,
 			&notifiersRaw,
+			&pausedAt,
+			&pausedUntil,
+			&protected,
 			&pushRaw,
+			&quorum,
+			&rearm,
 			&reminderEnabled,
 			&reminderSent,
 			&reminderThresholdRaw,
+			&repeatIntervalRaw,
+			&requirePasskey,
+			&resumePolicyRaw,
 			&sw.Status,
 			&sw.TriggerAt,
+			&triggerCount,
+			&trustedContactsRaw,
 			&userIDRaw,
+			&verificationWindowRaw,
+			&webhooksRaw,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan error: %w", err)
 		}
 
 		// Optional fields
+		if actionsRaw.Valid && actionsRaw.String != "" {
+			err = json.Unmarshal([]byte(actionsRaw.String), &sw.Actions)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if alertMatchersRaw.Valid && alertMatchersRaw.String != "" {
+			err = json.Unmarshal([]byte(alertMatchersRaw.String), &sw.AlertMatchers)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if approversRaw.Valid && approversRaw.String != "" {
+			err = json.Unmarshal([]byte(approversRaw.String), &sw.Approvers)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if changeDelayRaw.Valid && changeDelayRaw.String != "" {
+			sw.ChangeDelay = &changeDelayRaw.String
+		}
+		if checkInTokenRaw.Valid && checkInTokenRaw.String != "" {
+			sw.CheckInToken = &checkInTokenRaw.String
+		}
 		if DeleteAfterTriggered.Valid {
 			sw.DeleteAfterTriggered = &DeleteAfterTriggered.Bool
 		}
+		if duressCodeRaw.Valid && duressCodeRaw.String != "" {
+			sw.DuressCode = &duressCodeRaw.String
+		}
 		if encrypted.Valid {
 			sw.Encrypted = &encrypted.Bool
 		}
 		if failureReasonRaw.Valid {
 			sw.FailureReason = &failureReasonRaw.String
 		}
+		if labelsRaw.Valid && labelsRaw.String != "" {
+			err = json.Unmarshal([]byte(labelsRaw.String), &sw.Labels)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if lastCheckInAt.Valid {
+			sw.LastCheckInAt = &lastCheckInAt.Int64
+		}
+		if maxPostponeRaw.Valid && maxPostponeRaw.String != "" {
+			sw.MaxPostpone = &maxPostponeRaw.String
+		}
+		if maxTriggers.Valid {
+			limit := int(maxTriggers.Int64)
+			sw.MaxTriggers = &limit
+		}
+		if pausedAt.Valid {
+			sw.PausedAt = &pausedAt.Int64
+		}
+		if pausedUntil.Valid {
+			sw.PausedUntil = &pausedUntil.Int64
+		}
+		if protected.Valid {
+			sw.Protected = &protected.Bool
+		}
+		if quorum.Valid {
+			q := int(quorum.Int64)
+			sw.Quorum = &q
+		}
+		if rearm.Valid {
+			sw.Rearm = &rearm.Bool
+		}
 		if reminderEnabled.Valid {
 			sw.ReminderEnabled = &reminderEnabled.Bool
 		}
@@ -403,14 +778,46 @@ func (s *sqliteStore) scanSwitches(rows *sql.Rows) ([]api.Switch, error) {
 		if reminderSent.Valid {
 			sw.ReminderSent = &reminderSent.Bool
 		}
+		if repeatIntervalRaw.Valid && repeatIntervalRaw.String != "" {
+			sw.RepeatInterval = &repeatIntervalRaw.String
+		}
+		if requirePasskey.Valid {
+			sw.RequirePasskey = &requirePasskey.Bool
+		}
+		if resumePolicyRaw.Valid && resumePolicyRaw.String != "" {
+			policy := api.SwitchResumePolicy(resumePolicyRaw.String)
+			sw.ResumePolicy = &policy
+		}
+		if triggerCount.Valid {
+			count := int(triggerCount.Int64)
+			sw.TriggerCount = &count
+		}
+		if trustedContactsRaw.Valid && trustedContactsRaw.String != "" {
+			err = json.Unmarshal([]byte(trustedContactsRaw.String), &sw.TrustedContacts)
+			if err != nil {
+				return nil, err
+			}
+		}
 		if userIDRaw.Valid {
 			sw.UserId = &userIDRaw.String
 		}
+		if verificationWindowRaw.Valid && verificationWindowRaw.String != "" {
+			sw.VerificationWindow = &verificationWindowRaw.String
+		}
+		if webhooksRaw.Valid && webhooksRaw.String != "" {
+			err = json.Unmarshal([]byte(webhooksRaw.String), &sw.Webhooks)
+			if err != nil {
+				return nil, err
+			}
+		}
 
 		// Field Mapping
 		sw.Message = msgRaw
 		if sw.Encrypted != nil && *sw.Encrypted {
 			sw.Notifiers = []string{notifiersRaw}
+			if duressNotifiersRaw.Valid && duressNotifiersRaw.String != "" {
+				sw.DuressNotifiers = &[]string{duressNotifiersRaw.String}
+			}
 			if pushRaw.Valid {
 				sw.PushSubscription = &api.PushSubscription{
 					Endpoint: &pushRaw.String,
@@ -422,6 +829,13 @@ func (s *sqliteStore) scanSwitches(rows *sql.Rows) ([]api.Switch, error) {
 				return nil, err
 			}
 
+			if duressNotifiersRaw.Valid && duressNotifiersRaw.String != "" {
+				err = json.Unmarshal([]byte(duressNotifiersRaw.String), &sw.DuressNotifiers)
+				if err != nil {
+					return nil, err
+				}
+			}
+
 			if pushRaw.Valid && pushRaw.String != "" {
 				err = json.Unmarshal([]byte(pushRaw.String), &sw.PushSubscription)
 				if err != nil {
@@ -432,9 +846,111 @@ func (s *sqliteStore) scanSwitches(rows *sql.Rows) ([]api.Switch, error) {
 		switches = append(switches, sw)
 	}
 
+	err := rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	// Release the connection before loading members and links
+	_ = rows.Close()
+
+	err = s.loadMembers(switches)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.loadLinks(switches)
+	if err != nil {
+		return nil, err
+	}
+
 	return switches, nil
 }
 
+// checkInTokenError reports a violation of the unique check-in token index as ErrCheckInTokenTaken,
+// the only unique constraint on switches besides the primary key.
+func checkInTokenError(err error) error {
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+		return ErrCheckInTokenTaken
+	}
+	return err
+}
+
+// marshalDuressNotifiers prepares duress notifiers for SQL. Encrypted switches already hold a single ciphertext.
+func marshalDuressNotifiers(sw api.Switch) (any, error) {
+	if sw.DuressNotifiers == nil || len(*sw.DuressNotifiers) == 0 {
+		return nil, nil
+	}
+
+	if sw.Encrypted != nil && *sw.Encrypted {
+		return (*sw.DuressNotifiers)[0], nil
+	}
+
+	duressJSON, err := json.Marshal(sw.DuressNotifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(duressJSON), nil
+}
+
+// marshalStrings prepares labels, approvers and actions for SQL. They are never encrypted so switches can be queried by them.
+func marshalStrings(values *[]string) (any, error) {
+	if values == nil || len(*values) == 0 {
+		return nil, nil
+	}
+
+	valuesJSON, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(valuesJSON), nil
+}
+
+// marshalAlertMatchers prepares the labels Alertmanager alerts are matched against for SQL.
+func marshalAlertMatchers(matchers *map[string]string) (any, error) {
+	if matchers == nil || len(*matchers) == 0 {
+		return nil, nil
+	}
+
+	matchersJSON, err := json.Marshal(matchers)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(matchersJSON), nil
+}
+
+// marshalTrustedContacts prepares trusted contacts for SQL. Contact notifiers of encrypted switches are already encrypted.
+func marshalTrustedContacts(sw api.Switch) (any, error) {
+	if sw.TrustedContacts == nil || len(*sw.TrustedContacts) == 0 {
+		return nil, nil
+	}
+
+	contactsJSON, err := json.Marshal(sw.TrustedContacts)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(contactsJSON), nil
+}
+
+// marshalWebhooks prepares webhooks for SQL. Credentials of encrypted switches are already encrypted.
+func marshalWebhooks(sw api.Switch) (any, error) {
+	if sw.Webhooks == nil || len(*sw.Webhooks) == 0 {
+		return nil, nil
+	}
+
+	webhooksJSON, err := json.Marshal(sw.Webhooks)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(webhooksJSON), nil
+}
+
 // EncryptSwitch encrypts sensitive switch fields before storing
 func (s *sqliteStore) EncryptSwitch(sw *api.Switch) error {
 	if sw.Encrypted == nil || !*sw.Encrypted {
@@ -454,6 +970,60 @@ func (s *sqliteStore) EncryptSwitch(sw *api.Switch) error {
 	}
 	sw.Notifiers = []string{encNotifiers}
 
+	if sw.DuressNotifiers != nil && len(*sw.DuressNotifiers) > 0 {
+		duressJSON, _ := json.Marshal(sw.DuressNotifiers)
+		encDuress, err := s.encrypt(duressJSON)
+		if err != nil {
+			return err
+		}
+		sw.DuressNotifiers = &[]string{encDuress}
+	}
+
+	if sw.Members != nil {
+		// Copy so the caller's members aren't encrypted in place
+		members := slices.Clone(*sw.Members)
+		for i, member := range members {
+			if member.Notifier == nil {
+				continue
+			}
+			encNotifier, err := s.encrypt([]byte(*member.Notifier))
+			if err != nil {
+				return err
+			}
+			members[i].Notifier = &encNotifier
+		}
+		sw.Members = &members
+	}
+
+	if sw.TrustedContacts != nil {
+		// Copy so the caller's contacts aren't encrypted in place
+		contacts := slices.Clone(*sw.TrustedContacts)
+		for i, contact := range contacts {
+			if contact.Notifier == nil {
+				continue
+			}
+			encNotifier, err := s.encrypt([]byte(*contact.Notifier))
+			if err != nil {
+				return err
+			}
+			contacts[i].Notifier = &encNotifier
+		}
+		sw.TrustedContacts = &contacts
+	}
+
+	if sw.Webhooks != nil {
+		// Copy so the caller's webhooks aren't encrypted in place
+		webhooks := slices.Clone(*sw.Webhooks)
+		for i, hook := range webhooks {
+			encHook, err := s.cryptWebhook(hook, s.encrypt)
+			if err != nil {
+				return err
+			}
+			webhooks[i] = encHook
+		}
+		sw.Webhooks = &webhooks
+	}
+
 	if sw.PushSubscription != nil {
 		pushJSON, _ := json.Marshal(sw.PushSubscription)
 		encPush, err := s.encrypt(pushJSON)
@@ -488,6 +1058,58 @@ func (s *sqliteStore) DecryptSwitch(sw *api.Switch) error {
 		}
 	}
 
+	if sw.DuressNotifiers != nil && len(*sw.DuressNotifiers) > 0 {
+		decryptedDuress, err := s.decrypt((*sw.DuressNotifiers)[0])
+		if err != nil {
+			return fmt.Errorf("duress notifiers decryption failed: %w", err)
+		}
+		err = json.Unmarshal(decryptedDuress, &sw.DuressNotifiers)
+		if err != nil {
+			return fmt.Errorf("duress notifiers unmarshal failed: %w", err)
+		}
+	}
+
+	if sw.Members != nil {
+		for i, member := range *sw.Members {
+			if member.Notifier == nil {
+				continue
+			}
+			decryptedNotifier, err := s.decrypt(*member.Notifier)
+			if err != nil {
+				return fmt.Errorf("member notifier decryption failed: %w", err)
+			}
+			notifier := string(decryptedNotifier)
+			(*sw.Members)[i].Notifier = &notifier
+		}
+	}
+
+	if sw.TrustedContacts != nil {
+		for i, contact := range *sw.TrustedContacts {
+			if contact.Notifier == nil {
+				continue
+			}
+			decryptedNotifier, err := s.decrypt(*contact.Notifier)
+			if err != nil {
+				return fmt.Errorf("trusted contact notifier decryption failed: %w", err)
+			}
+			notifier := string(decryptedNotifier)
+			(*sw.TrustedContacts)[i].Notifier = &notifier
+		}
+	}
+
+	if sw.Webhooks != nil {
+		for i, hook := range *sw.Webhooks {
+			decHook, err := s.cryptWebhook(hook, func(cryptoText []byte) (string, error) {
+				plaintext, err := s.decrypt(string(cryptoText))
+				return string(plaintext), err
+			})
+			if err != nil {
+				return fmt.Errorf("webhook decryption failed: %w", err)
+			}
+			(*sw.Webhooks)[i] = decHook
+		}
+	}
+
 	if sw.PushSubscription != nil && sw.PushSubscription.Endpoint != nil {
 		decryptedPush, err := s.decrypt(*sw.PushSubscription.Endpoint)
 		if err != nil {
@@ -502,6 +1124,39 @@ func (s *sqliteStore) DecryptSwitch(sw *api.Switch) error {
 	return nil
 }
 
+// cryptWebhook applies fn to the URL, secret, header values and client key of a webhook, which can all carry credentials.
+func (s *sqliteStore) cryptWebhook(hook api.Webhook, fn func([]byte) (string, error)) (api.Webhook, error) {
+	url, err := fn([]byte(hook.Url))
+	if err != nil {
+		return api.Webhook{}, err
+	}
+	hook.Url = url
+
+	for _, field := range []**string{&hook.Secret, &hook.ClientKey} {
+		if *field == nil {
+			continue
+		}
+		value, err := fn([]byte(**field))
+		if err != nil {
+			return api.Webhook{}, err
+		}
+		*field = &value
+	}
+
+	if hook.Headers != nil {
+		headers := make(map[string]string, len(*hook.Headers))
+		for key, value := range *hook.Headers {
+			headers[key], err = fn([]byte(value))
+			if err != nil {
+				return api.Webhook{}, err
+			}
+		}
+		hook.Headers = &headers
+	}
+
+	return hook, nil
+}
+
 func (s *sqliteStore) encrypt(plaintext []byte) (string, error) {
 	if len(s.encryptionKey) == 0 {
 		return "", fmt.Errorf("encryption key not configured")
@@ -3,6 +3,7 @@ package database
 import (
 	"bytes"
 	"database/sql"
+	"errors"
 	"testing"
 	"time"
 
@@ -237,6 +238,307 @@ func TestSQLiteStore_StatusAndExpirations(t *testing.T) {
 			t.Error("switch not found in expired list")
 		}
 	})
+
+	t.Run("GetExpired returns missing switches due another alert", func(t *testing.T) {
+		statusMissing := api.SwitchStatusMissing
+		nextAlertAt := time.Now().Unix() - 10
+
+		created, err := store.Create(api.Switch{
+			Message:         "heartbeat",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "1h",
+			Rearm:           ptr(true),
+			MaxTriggers:     ptr(3),
+			RepeatInterval:  ptr("6h"),
+			TriggerCount:    ptr(2),
+			TriggerAt:       &nextAlertAt,
+			Status:          &statusMissing,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !*created.Rearm || *created.MaxTriggers != 3 || *created.RepeatInterval != "6h" || *created.TriggerCount != 2 {
+			t.Errorf("unexpected repeat settings %v %v %v %v", *created.Rearm, *created.MaxTriggers, *created.RepeatInterval, *created.TriggerCount)
+		}
+
+		expiredList, err := store.GetExpired(10)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		found := false
+		for _, s := range expiredList {
+			if *s.Id == *created.Id {
+				found = true
+			}
+		}
+		if !found {
+			t.Error("missing switch not found in expired list")
+		}
+	})
+}
+
+func TestSQLiteStore_Pause(t *testing.T) {
+	store := setupTestStore(t)
+	statusPaused := api.SwitchStatusPaused
+	policy := api.SwitchResumePolicyReset
+
+	t.Run("Create and Retrieve Pause Fields", func(t *testing.T) {
+		pausedAt := time.Now().Unix()
+		pausedUntil := time.Now().Add(time.Hour).Unix()
+
+		created, err := store.Create(api.Switch{
+			Message:         "paused",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "1h",
+			Status:          &statusPaused,
+			PausedAt:        &pausedAt,
+			PausedUntil:     &pausedUntil,
+			ResumePolicy:    &policy,
+		})
+		if err != nil {
+			t.Fatalf("failed to create switch: %v", err)
+		}
+
+		found, err := store.GetByID("admin", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to get switch: %v", err)
+		}
+		if found.PausedAt == nil || *found.PausedAt != pausedAt {
+			t.Errorf("expected pausedAt %d, got %v", pausedAt, found.PausedAt)
+		}
+		if found.PausedUntil == nil || *found.PausedUntil != pausedUntil {
+			t.Errorf("expected pausedUntil %d, got %v", pausedUntil, found.PausedUntil)
+		}
+		if found.ResumePolicy == nil || *found.ResumePolicy != policy {
+			t.Errorf("expected resumePolicy %s, got %v", policy, found.ResumePolicy)
+		}
+	})
+
+	t.Run("GetResumable only returns switches whose pause has ended", func(t *testing.T) {
+		ended := time.Now().Add(-time.Minute).Unix()
+		ongoing := time.Now().Add(time.Hour).Unix()
+
+		endedSwitch, err := store.Create(api.Switch{
+			Message:         "pause ended",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "1h",
+			Encrypted:       ptr(true),
+			Status:          &statusPaused,
+			PausedUntil:     &ended,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ongoingSwitch, err := store.Create(api.Switch{
+			Message:         "still paused",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "1h",
+			Status:          &statusPaused,
+			PausedUntil:     &ongoing,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resumable, err := store.GetResumable(10)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		found := false
+		for _, s := range resumable {
+			if *s.Id == *ongoingSwitch.Id {
+				t.Error("switch with ongoing pause should not be resumable")
+			}
+			if *s.Id == *endedSwitch.Id {
+				found = true
+				if s.Message != "pause ended" {
+					t.Errorf("expected decrypted message, got: %s", s.Message)
+				}
+			}
+		}
+		if !found {
+			t.Error("switch with ended pause not found in resumable list")
+		}
+	})
+}
+
+func TestSQLiteStore_InitMigratesExistingDatabase(t *testing.T) {
+	dbPath := t.TempDir()
+	store, err := NewSQLiteStore(dbPath)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	// Simulate a database created before the pause columns existed
+	_, err = store.(*sqliteStore).db.Exec(`CREATE TABLE switches (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		check_in_interval TEXT NOT NULL,
+		delete_after_triggered BOOLEAN DEFAULT 0,
+		encrypted BOOLEAN DEFAULT 0,
+		failure_reason TEXT,
+		message TEXT NOT NULL,
+		notifiers TEXT NOT NULL,
+		push_subscription TEXT,
+		reminder_enabled BOOLEAN DEFAULT 0,
+		reminder_sent BOOLEAN DEFAULT 0,
+		reminder_threshold TEXT,
+		status TEXT NOT NULL,
+		trigger_at INTEGER DEFAULT 0,
+		user_id TEXT NOT NULL DEFAULT 'admin'
+	)`)
+	if err != nil {
+		t.Fatalf("failed to create legacy table: %v", err)
+	}
+
+	err = store.Init()
+	if err != nil {
+		t.Fatalf("failed to init store: %v", err)
+	}
+
+	// Running Init again must be a no-op
+	err = store.Init()
+	if err != nil {
+		t.Fatalf("failed to re-init store: %v", err)
+	}
+
+	_, err = store.Create(api.Switch{
+		Message:         "migrated",
+		Notifiers:       []string{"logger://"},
+		CheckInInterval: "1h",
+		Status:          &statusActive,
+	})
+	if err != nil {
+		t.Fatalf("failed to create switch after migration: %v", err)
+	}
+}
+
+func TestSQLiteStore_Duress(t *testing.T) {
+	store := setupTestStore(t)
+
+	t.Run("Duress notifiers are encrypted with the switch", func(t *testing.T) {
+		created, err := store.Create(api.Switch{
+			Message:         "duress",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "1h",
+			DuressCode:      ptr("$argon2id$hash"),
+			DuressNotifiers: &[]string{"discord://duress@token"},
+			Encrypted:       ptr(true),
+			Status:          &statusActive,
+		})
+		if err != nil {
+			t.Fatalf("failed to create switch: %v", err)
+		}
+
+		if created.DuressCode == nil || *created.DuressCode != "$argon2id$hash" {
+			t.Errorf("expected duress code to be stored as given, got %v", created.DuressCode)
+		}
+		if created.DuressNotifiers == nil || (*created.DuressNotifiers)[0] == "discord://duress@token" {
+			t.Fatal("expected duress notifiers to be encrypted at rest")
+		}
+
+		err = store.DecryptSwitch(&created)
+		if err != nil {
+			t.Fatalf("failed to decrypt switch: %v", err)
+		}
+		if (*created.DuressNotifiers)[0] != "discord://duress@token" {
+			t.Errorf("expected decrypted duress notifier, got %v", *created.DuressNotifiers)
+		}
+	})
+
+	t.Run("Duress fields are optional", func(t *testing.T) {
+		created, err := store.Create(api.Switch{
+			Message:         "plain",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "1h",
+			Status:          &statusActive,
+		})
+		if err != nil {
+			t.Fatalf("failed to create switch: %v", err)
+		}
+
+		if created.DuressCode != nil || created.DuressNotifiers != nil {
+			t.Error("expected duress fields to be nil")
+		}
+	})
+}
+
+func TestSQLiteStore_CheckInToken(t *testing.T) {
+	store := setupTestStore(t)
+
+	created, err := store.Create(api.Switch{
+		Message:         "token",
+		Notifiers:       []string{"logger://"},
+		CheckInInterval: "1h",
+		CheckInToken:    ptr("token-hash"),
+		AlertMatchers:   &map[string]string{"alertname": "Watchdog", "cluster": "production"},
+		Encrypted:       ptr(true),
+		Status:          &statusActive,
+		UserId:          ptr("someone"),
+	})
+	if err != nil {
+		t.Fatalf("failed to create switch: %v", err)
+	}
+
+	t.Run("Switches are found by token regardless of owner", func(t *testing.T) {
+		found, err := store.GetByCheckInToken("token-hash")
+		if err != nil {
+			t.Fatalf("failed to get switch by token: %v", err)
+		}
+		if *found.Id != *created.Id || *found.UserId != "someone" {
+			t.Errorf("unexpected switch %+v", found)
+		}
+		if found.AlertMatchers == nil || (*found.AlertMatchers)["cluster"] != "production" {
+			t.Errorf("expected alert matchers to round trip, got %v", found.AlertMatchers)
+		}
+
+		_, err = store.GetByCheckInToken("other-hash")
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows, got %v", err)
+		}
+	})
+
+	t.Run("Tokens are unique", func(t *testing.T) {
+		_, err := store.Create(api.Switch{
+			Message:         "duplicate",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "1h",
+			CheckInToken:    ptr("token-hash"),
+			Status:          &statusActive,
+		})
+		if !errors.Is(err, ErrCheckInTokenTaken) {
+			t.Errorf("expected ErrCheckInTokenTaken, got %v", err)
+		}
+	})
+}
+
+func TestSQLiteStore_Labels(t *testing.T) {
+	store := setupTestStore(t)
+
+	created, err := store.Create(api.Switch{
+		Message:         "labelled",
+		Notifiers:       []string{"logger://"},
+		CheckInInterval: "1h",
+		Labels:          &[]string{"travel", "work"},
+		Encrypted:       ptr(true),
+		Status:          &statusActive,
+	})
+	if err != nil {
+		t.Fatalf("failed to create switch: %v", err)
+	}
+
+	fetched, err := store.GetByID("admin", *created.Id)
+	if err != nil {
+		t.Fatalf("failed to get switch: %v", err)
+	}
+
+	// Labels are used for filtering so they are never encrypted
+	if fetched.Labels == nil || len(*fetched.Labels) != 2 || (*fetched.Labels)[0] != "travel" {
+		t.Errorf("expected labels to round trip in plaintext, got %v", fetched.Labels)
+	}
 }
 
 func TestSQLiteStore_SwitchCryptoHelpers(t *testing.T) {
@@ -1,36 +1,166 @@
 package database
 
-import "github.com/circa10a/dead-mans-switch/api"
+import (
+	"errors"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
 
 const (
 	secretName = "switches_encryption.key"
 	AdminUser  = "admin"
 )
 
+// ErrCheckInTokenTaken is returned when a switch is saved with a check-in token another switch already uses.
+var ErrCheckInTokenTaken = errors.New("check-in token already in use")
+
+// ErrUserExists is returned when a local user account is created with a username that is already taken.
+var ErrUserExists = errors.New("user already exists")
+
 // Store defines the behaviors required for persisting and managing dead man switches.
 type Store interface {
 	// Init executes the initial schema setup.
 	Init() error
+	// CheckInMember records a check-in by a member of a quorum switch.
+	CheckInMember(switchID int, userID string, checkedInAt int64) error
 	// Close terminates the database connection.
 	Close() error
+	// CountSwitches returns how many switches the given user owns.
+	CountSwitches(userID string) (int, error)
 	// Create persists a new switch and returns the created record. Uses sw.UserId for ownership.
 	Create(sw api.Switch) (api.Switch, error)
+	// CreateAPIToken stores a personal access token of the given user by the hash of its secret.
+	CreateAPIToken(userID, tokenHash string, token api.APIToken) (api.APIToken, error)
+	// CreateAuditEvent records an event in the audit trail of a switch owned by the given user.
+	CreateAuditEvent(userID string, event api.AuditEvent) (api.AuditEvent, error)
+	// CreateCheckIn records a check-in for a switch owned by the given user.
+	CreateCheckIn(userID string, checkIn api.CheckIn) (api.CheckIn, error)
+	// CreateContactToken stores a token issued to a trusted contact.
+	CreateContactToken(token ContactToken) error
+	// CreateDelivery records the result of an action run, webhook request or event sent for a switch owned by the given user.
+	CreateDelivery(userID string, delivery api.Delivery) (api.Delivery, error)
+	// CreatePasskey stores a passkey of a local user account.
+	CreatePasskey(passkey Passkey) (Passkey, error)
+	// CreatePasskeyChallenge stores a pending passkey registration or assertion.
+	CreatePasskeyChallenge(challenge PasskeyChallenge) error
+	// CreatePendingChange stores a change requested for a protected switch.
+	CreatePendingChange(change PendingChange) (PendingChange, error)
+	// CreateReplyToken stores a token a user checks in with by replying to a reminder email.
+	CreateReplyToken(token ReplyToken) error
+	// CreateSession stores a signed in session of a local user account.
+	CreateSession(session Session) error
+	// CreateSubscription stores a webhook subscribed to the lifecycle events of the given user's switches.
+	CreateSubscription(userID string, sub api.WebhookSubscription) (api.WebhookSubscription, error)
+	// CreateUser stores a local user account.
+	CreateUser(user User) error
 	// DecryptSwitch decrypts sensitive content.
 	DecryptSwitch(*api.Switch) error
-	// Delete removes a switch record from the store, scoped to the given user.
+	// Delete removes a switch record and its history from the store, scoped to the given user.
 	Delete(userID string, id int) error
+	// DeleteAPIToken revokes a personal access token, scoped to the given user.
+	DeleteAPIToken(userID string, id int) error
+	// DeleteContactTokens revokes every token issued for a switch.
+	DeleteContactTokens(switchID int) error
+	// DeletePasskey removes a passkey, scoped to the given user.
+	DeletePasskey(username string, id int) error
+	// DeleteSession signs out a session by the hash of its token.
+	DeleteSession(tokenHash string) error
+	// DeleteSubscription removes an event subscription and its delivery log, scoped to the given user.
+	DeleteSubscription(userID string, id int) error
+	// DeleteUserAPITokens revokes every personal access token of the given user.
+	DeleteUserAPITokens(userID string) error
+	// DeleteUserSessions signs out every session of a user except the one with the given token hash.
+	DeleteUserSessions(username, exceptTokenHash string) error
+	// DisableSwitch disables a switch regardless of its owner and returns its metadata.
+	DisableSwitch(id int) (api.AdminSwitch, error)
 	// EncryptSwitch encrypts sensitive content.
 	EncryptSwitch(*api.Switch) error
+	// GetAdminStats retrieves usage statistics of the server, counting check-ins made since the given time.
+	GetAdminStats(since int64) (api.AdminStats, error)
+	// GetAdminSwitches retrieves a page of the metadata of every user's switches, or of the given user's if not empty.
+	GetAdminSwitches(userID string, limit, offset int) ([]api.AdminSwitch, error)
 	// GetAll retrieves a list of switches up to the specified limit, scoped to the given user.
 	GetAll(userID string, limit int) ([]api.Switch, error)
+	// GetAPIToken retrieves a personal access token by its ID, scoped to the given user.
+	GetAPIToken(userID string, id int) (api.APIToken, error)
+	// GetAPITokenByHash retrieves a personal access token by the hash of its secret and the ID of the user it belongs to.
+	GetAPITokenByHash(tokenHash string) (api.APIToken, string, error)
+	// GetAPITokens retrieves every personal access token of the given user.
+	GetAPITokens(userID string) ([]api.APIToken, error)
+	// GetAuditEvents retrieves a page of a switch's audit trail, newest first, scoped to the given user.
+	GetAuditEvents(userID string, switchID, limit, offset int) ([]api.AuditEvent, error)
+	// GetCheckIns retrieves a page of check-ins for a switch, newest first, scoped to the given user.
+	GetCheckIns(userID string, switchID, limit, offset int) ([]api.CheckIn, error)
+	// GetByCheckInToken retrieves the switch whose check-in token has the given hash, regardless of its owner.
+	GetByCheckInToken(tokenHash string) (api.Switch, error)
 	// GetByID retrieves a single switch by its unique identifier, scoped to the given user.
 	GetByID(userID string, id int) (api.Switch, error)
+	// GetByMember retrieves a single switch by its unique identifier if the given user is one of its members.
+	GetByMember(userID string, id int) (api.Switch, error)
+	// GetContactToken retrieves a trusted contact token by its hash.
+	GetContactToken(tokenHash string) (ContactToken, error)
+	// GetDeliveries retrieves a page of a switch's delivery results, newest first, scoped to the given user.
+	GetDeliveries(userID string, switchID, limit, offset int) ([]api.Delivery, error)
+	// GetDueChanges retrieves pending changes whose delay has passed.
+	GetDueChanges(limit int) ([]PendingChange, error)
 	// GetEligibleReminders retrieves switches that are approaching expiry but haven't had a reminder sent yet.
 	GetEligibleReminders(limit int) ([]api.Switch, error)
 	// GetExpired retrieves switches whose trigger_at time has passed but haven't been sent.
 	GetExpired(limit int) ([]api.Switch, error)
+	// GetPasskeyByCredentialID retrieves a passkey by its credential ID, regardless of its owner.
+	GetPasskeyByCredentialID(credentialID string) (Passkey, error)
+	// GetPasskeys retrieves every passkey of the given user.
+	GetPasskeys(username string) ([]Passkey, error)
+	// GetPendingChange retrieves a change to a protected switch by its ID.
+	GetPendingChange(id int) (PendingChange, error)
+	// GetPendingChanges retrieves the pending changes a user requested or is an approver of.
+	GetPendingChanges(userID string) ([]PendingChange, error)
+	// GetReplyToken retrieves an unexpired reminder reply token by its hash.
+	GetReplyToken(tokenHash string, now int64) (ReplyToken, error)
+	// GetResumable retrieves paused switches whose paused_until time has passed.
+	GetResumable(limit int) ([]api.Switch, error)
+	// GetSession retrieves an unexpired session by the hash of its token.
+	GetSession(tokenHash string, now int64) (Session, error)
+	// GetSubscription retrieves an event subscription by its ID, scoped to the given user.
+	GetSubscription(userID string, id int) (api.WebhookSubscription, error)
+	// GetSubscriptionDeliveries retrieves a page of the events sent to a subscription, newest first, scoped to the given user.
+	GetSubscriptionDeliveries(userID string, subscriptionID, limit, offset int) ([]api.Delivery, error)
+	// GetSubscriptions retrieves every event subscription of the given user.
+	GetSubscriptions(userID string) ([]api.WebhookSubscription, error)
+	// GetSwitchLinks retrieves every chain action and requirement between the switches of the given user.
+	GetSwitchLinks(userID string) ([]SwitchLink, error)
+	// GetUser retrieves a local user account by its username.
+	GetUser(username string) (User, error)
+	// GetUserSettings retrieves the role and switch quota admins assigned to a user.
+	GetUserSettings(userID string) (UserSettings, error)
+	// GetUserSummaries retrieves every user who owns a switch, has a local account or was assigned settings.
+	GetUserSummaries() ([]UserSummary, error)
+	// GetWaitingDependents retrieves waiting switches that require the given switch.
+	GetWaitingDependents(switchID int) ([]api.Switch, error)
 	// Ping verifies the database connection is alive.
 	Ping() error
+	// ReopenPendingChange sets a change that failed to apply back to pending.
+	ReopenPendingChange(id int) error
+	// ResolvePendingChange marks a pending change as applied or cancelled.
+	ResolvePendingChange(id int, status api.PendingChangeStatus, resolvedBy string, resolvedAt int64) error
+	// SetUserQuota overrides the server's default switch quota for a user, or restores it when nil.
+	SetUserQuota(userID string, maxSwitches *int) error
+	// SetUserRole assigns a role to a user.
+	SetUserRole(userID string, role api.Role) error
+	// TakePasskeyChallenge retrieves and removes an unexpired pending passkey ceremony by its challenge and purpose.
+	TakePasskeyChallenge(challenge, purpose string, now int64) (PasskeyChallenge, error)
+	// TouchAPIToken records when a personal access token last authenticated a request.
+	TouchAPIToken(id int, usedAt int64) error
+	// UpdateAPIToken changes the name, scopes and expiry of a personal access token, scoped to the given user.
+	UpdateAPIToken(userID string, id int, token api.APIToken) (api.APIToken, error)
+	// UpdatePasskeyCredential stores the credential of a passkey after it was used.
+	UpdatePasskeyCredential(id int, credential string, usedAt int64) error
+	// UpdateSubscription replaces an event subscription, scoped to the given user.
+	UpdateSubscription(userID string, id int, sub api.WebhookSubscription) (api.WebhookSubscription, error)
+	// UpdateUser replaces the password and TOTP settings of a local user account.
+	UpdateUser(user User) error
+	// UseContactToken marks a trusted contact token as used.
+	UseContactToken(tokenHash string, usedAt int64) error
 	// Update updates an existing switch. Uses sw.UserId for ownership scoping.
 	Update(id int, sw api.Switch) (api.Switch, error)
 }
@@ -0,0 +1,207 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+const subscriptionColumns = `id, url, events, secret, enabled, max_attempts, retry_backoff, created_at`
+
+// CreateSubscription stores a webhook subscribed to the lifecycle events of the given user's switches.
+// Signing secrets are always encrypted at rest.
+func (s *sqliteStore) CreateSubscription(userID string, sub api.WebhookSubscription) (api.WebhookSubscription, error) {
+	events, err := marshalEvents(sub.Events)
+	if err != nil {
+		return api.WebhookSubscription{}, err
+	}
+
+	secret, err := s.encrypt([]byte(deref(sub.Secret)))
+	if err != nil {
+		return api.WebhookSubscription{}, err
+	}
+
+	res, err := s.db.Exec(`INSERT INTO subscriptions (user_id, url, events, secret, enabled, max_attempts, retry_backoff, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		userID,
+		sub.Url,
+		events,
+		secret,
+		sub.Enabled == nil || *sub.Enabled,
+		sub.MaxAttempts,
+		sub.RetryBackoff,
+		sub.CreatedAt,
+	)
+	if err != nil {
+		return api.WebhookSubscription{}, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return api.WebhookSubscription{}, err
+	}
+
+	return s.GetSubscription(userID, int(id))
+}
+
+// DeleteSubscription removes a subscription and its delivery log, scoped to the given user.
+// Returns sql.ErrNoRows if not found.
+func (s *sqliteStore) DeleteSubscription(userID string, id int) error {
+	res, err := s.db.Exec(`DELETE FROM subscriptions WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	_, err = s.db.Exec(`DELETE FROM deliveries WHERE subscription_id = ? AND user_id = ?`, id, userID)
+	return err
+}
+
+// GetSubscription returns a subscription by its ID, scoped to the given user. Returns sql.ErrNoRows if not found.
+func (s *sqliteStore) GetSubscription(userID string, id int) (api.WebhookSubscription, error) {
+	subs, err := s.querySubscriptions(fmt.Sprintf("SELECT %s FROM subscriptions WHERE id = ? AND user_id = ?", subscriptionColumns), id, userID)
+	if err != nil {
+		return api.WebhookSubscription{}, err
+	}
+
+	if len(subs) == 0 {
+		return api.WebhookSubscription{}, sql.ErrNoRows
+	}
+
+	return subs[0], nil
+}
+
+// GetSubscriptionDeliveries returns a page of the events sent to a subscription, newest first, scoped to the given user.
+func (s *sqliteStore) GetSubscriptionDeliveries(userID string, subscriptionID, limit, offset int) ([]api.Delivery, error) {
+	query := fmt.Sprintf("SELECT %s FROM deliveries WHERE user_id = ? AND subscription_id = ? ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?", deliveryColumns)
+
+	return s.queryDeliveries(query, userID, subscriptionID, limit, offset)
+}
+
+// GetSubscriptions returns every subscription of the given user, oldest first.
+func (s *sqliteStore) GetSubscriptions(userID string) ([]api.WebhookSubscription, error) {
+	return s.querySubscriptions(fmt.Sprintf("SELECT %s FROM subscriptions WHERE user_id = ? ORDER BY id", subscriptionColumns), userID)
+}
+
+// UpdateSubscription replaces a subscription, scoped to the given user. Returns sql.ErrNoRows if not found.
+func (s *sqliteStore) UpdateSubscription(userID string, id int, sub api.WebhookSubscription) (api.WebhookSubscription, error) {
+	events, err := marshalEvents(sub.Events)
+	if err != nil {
+		return api.WebhookSubscription{}, err
+	}
+
+	secret, err := s.encrypt([]byte(deref(sub.Secret)))
+	if err != nil {
+		return api.WebhookSubscription{}, err
+	}
+
+	res, err := s.db.Exec(`UPDATE subscriptions SET url = ?, events = ?, secret = ?, enabled = ?, max_attempts = ?, retry_backoff = ? WHERE id = ? AND user_id = ?`,
+		sub.Url,
+		events,
+		secret,
+		sub.Enabled == nil || *sub.Enabled,
+		sub.MaxAttempts,
+		sub.RetryBackoff,
+		id,
+		userID,
+	)
+	if err != nil {
+		return api.WebhookSubscription{}, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return api.WebhookSubscription{}, err
+	}
+
+	if rows == 0 {
+		return api.WebhookSubscription{}, sql.ErrNoRows
+	}
+
+	return s.GetSubscription(userID, id)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// marshalEvents prepares the event filter of a subscription for SQL.
+func marshalEvents(events *[]api.EventType) (any, error) {
+	if events == nil || len(*events) == 0 {
+		return nil, nil
+	}
+
+	eventsJSON, err := json.Marshal(events)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(eventsJSON), nil
+}
+
+// querySubscriptions runs a query selecting subscriptionColumns and scans the results.
+func (s *sqliteStore) querySubscriptions(query string, args ...any) ([]api.WebhookSubscription, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	subs := []api.WebhookSubscription{}
+	for rows.Next() {
+		sub := api.WebhookSubscription{}
+		var id int
+		var eventsRaw sql.NullString
+		var secret string
+		var enabled bool
+		var createdAt int64
+		var maxAttempts sql.NullInt64
+		var retryBackoff sql.NullString
+
+		err := rows.Scan(&id, &sub.Url, &eventsRaw, &secret, &enabled, &maxAttempts, &retryBackoff, &createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+
+		decryptedSecret, err := s.decrypt(secret)
+		if err != nil {
+			return nil, fmt.Errorf("subscription secret decryption failed: %w", err)
+		}
+
+		plaintext := string(decryptedSecret)
+		sub.Id = &id
+		sub.Secret = &plaintext
+		sub.Enabled = &enabled
+		sub.CreatedAt = &createdAt
+		if eventsRaw.Valid && eventsRaw.String != "" {
+			err = json.Unmarshal([]byte(eventsRaw.String), &sub.Events)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if maxAttempts.Valid {
+			attempts := int(maxAttempts.Int64)
+			sub.MaxAttempts = &attempts
+		}
+		if retryBackoff.Valid {
+			sub.RetryBackoff = &retryBackoff.String
+		}
+
+		subs = append(subs, sub)
+	}
+
+	return subs, rows.Err()
+}
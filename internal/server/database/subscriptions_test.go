@@ -0,0 +1,130 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+func TestSQLiteStore_Subscriptions(t *testing.T) {
+	store := setupTestStore(t)
+
+	created, err := store.CreateSubscription(AdminUser, api.WebhookSubscription{
+		Url:         "https://example.com/events",
+		Events:      &[]api.EventType{api.EventTypeTriggered, api.EventTypeFailed},
+		Secret:      ptr("0123456789abcdef"),
+		MaxAttempts: ptr(5),
+		CreatedAt:   ptr(time.Now().Unix()),
+	})
+	if err != nil {
+		t.Fatalf("failed to create subscription: %v", err)
+	}
+
+	t.Run("subscriptions round trip", func(t *testing.T) {
+		if created.Url != "https://example.com/events" || len(*created.Events) != 2 || *created.MaxAttempts != 5 || !*created.Enabled {
+			t.Errorf("unexpected subscription %+v", created)
+		}
+		if *created.Secret != "0123456789abcdef" {
+			t.Errorf("expected the decrypted secret, got %q", *created.Secret)
+		}
+	})
+
+	t.Run("secrets are encrypted at rest", func(t *testing.T) {
+		var secret string
+		err := store.(*sqliteStore).db.QueryRow(`SELECT secret FROM subscriptions WHERE id = ?`, *created.Id).Scan(&secret)
+		if err != nil {
+			t.Fatalf("failed to read secret: %v", err)
+		}
+		if secret == "0123456789abcdef" {
+			t.Error("expected the secret to be encrypted")
+		}
+	})
+
+	t.Run("subscriptions are scoped to the owner", func(t *testing.T) {
+		_, err := store.GetSubscription("mallory", *created.Id)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows, got %v", err)
+		}
+
+		subs, err := store.GetSubscriptions("mallory")
+		if err != nil || len(subs) != 0 {
+			t.Errorf("expected no subscriptions for another user, got %v %v", subs, err)
+		}
+
+		subs, err = store.GetSubscriptions(AdminUser)
+		if err != nil || len(subs) != 1 {
+			t.Errorf("expected 1 subscription, got %v %v", subs, err)
+		}
+	})
+
+	t.Run("subscriptions can be updated", func(t *testing.T) {
+		updated, err := store.UpdateSubscription(AdminUser, *created.Id, api.WebhookSubscription{
+			Url:     "https://example.com/v2/events",
+			Secret:  created.Secret,
+			Enabled: ptr(false),
+		})
+		if err != nil {
+			t.Fatalf("failed to update subscription: %v", err)
+		}
+		if updated.Url != "https://example.com/v2/events" || *updated.Enabled || updated.Events != nil {
+			t.Errorf("unexpected subscription %+v", updated)
+		}
+
+		_, err = store.UpdateSubscription("mallory", *created.Id, updated)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows for another user, got %v", err)
+		}
+	})
+
+	t.Run("event deliveries are listed with their subscription", func(t *testing.T) {
+		_, err := store.CreateDelivery(AdminUser, api.Delivery{
+			SwitchId:       42,
+			SubscriptionId: created.Id,
+			CreatedAt:      time.Now().Unix(),
+			Kind:           api.DeliveryKindEvent,
+			Target:         string(api.EventTypeTriggered),
+			Success:        true,
+			StatusCode:     ptr(200),
+			Attempts:       ptr(1),
+		})
+		if err != nil {
+			t.Fatalf("failed to create delivery: %v", err)
+		}
+
+		deliveries, err := store.GetSubscriptionDeliveries(AdminUser, *created.Id, 10, 0)
+		if err != nil {
+			t.Fatalf("failed to get deliveries: %v", err)
+		}
+		if len(deliveries) != 1 || *deliveries[0].SubscriptionId != *created.Id {
+			t.Errorf("unexpected deliveries %+v", deliveries)
+		}
+
+		deliveries, err = store.GetDeliveries(AdminUser, 42, 10, 0)
+		if err != nil {
+			t.Fatalf("failed to get deliveries: %v", err)
+		}
+		if len(deliveries) != 0 {
+			t.Errorf("expected event deliveries to stay out of the switch deliveries, got %d", len(deliveries))
+		}
+	})
+
+	t.Run("Delete removes the subscription and its deliveries", func(t *testing.T) {
+		err := store.DeleteSubscription(AdminUser, *created.Id)
+		if err != nil {
+			t.Fatalf("failed to delete subscription: %v", err)
+		}
+
+		deliveries, err := store.GetSubscriptionDeliveries(AdminUser, *created.Id, 10, 0)
+		if err != nil || len(deliveries) != 0 {
+			t.Errorf("expected deliveries to be deleted, got %v %v", deliveries, err)
+		}
+
+		err = store.DeleteSubscription(AdminUser, *created.Id)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,189 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+const apiTokenColumns = `id, name, scopes, expires_at, created_at, last_used_at`
+
+// tokenUseInterval is how often, in seconds, the last use of a token is written, so busy tokens don't
+// write on every request.
+const tokenUseInterval = 60
+
+// CreateAPIToken stores a personal access token of the given user. Only the hash of its secret is stored.
+func (s *sqliteStore) CreateAPIToken(userID, tokenHash string, token api.APIToken) (api.APIToken, error) {
+	scopes, err := json.Marshal(token.Scopes)
+	if err != nil {
+		return api.APIToken{}, err
+	}
+
+	res, err := s.db.Exec(`INSERT INTO api_tokens (user_id, token_hash, name, scopes, expires_at, created_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		userID,
+		tokenHash,
+		token.Name,
+		string(scopes),
+		token.ExpiresAt,
+		token.CreatedAt,
+	)
+	if err != nil {
+		return api.APIToken{}, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return api.APIToken{}, err
+	}
+
+	return s.GetAPIToken(userID, int(id))
+}
+
+// DeleteAPIToken revokes a token, scoped to the given user. Returns sql.ErrNoRows if not found.
+func (s *sqliteStore) DeleteAPIToken(userID string, id int) error {
+	res, err := s.db.Exec(`DELETE FROM api_tokens WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// DeleteUserAPITokens revokes every token of the given user.
+func (s *sqliteStore) DeleteUserAPITokens(userID string) error {
+	_, err := s.db.Exec(`DELETE FROM api_tokens WHERE user_id = ?`, userID)
+	return err
+}
+
+// GetAPIToken returns a token by its ID, scoped to the given user. Returns sql.ErrNoRows if not found.
+func (s *sqliteStore) GetAPIToken(userID string, id int) (api.APIToken, error) {
+	tokens, err := s.queryAPITokens(fmt.Sprintf("SELECT %s FROM api_tokens WHERE id = ? AND user_id = ?", apiTokenColumns), id, userID)
+	if err != nil {
+		return api.APIToken{}, err
+	}
+
+	if len(tokens) == 0 {
+		return api.APIToken{}, sql.ErrNoRows
+	}
+
+	return tokens[0], nil
+}
+
+// GetAPITokenByHash returns the token with the given secret hash and the ID of the user it belongs to,
+// regardless of whether it expired. Returns sql.ErrNoRows if not found.
+func (s *sqliteStore) GetAPITokenByHash(tokenHash string) (api.APIToken, string, error) {
+	var id int
+	var userID string
+
+	err := s.db.QueryRow(`SELECT id, user_id FROM api_tokens WHERE token_hash = ?`, tokenHash).Scan(&id, &userID)
+	if err != nil {
+		return api.APIToken{}, "", err
+	}
+
+	token, err := s.GetAPIToken(userID, id)
+	if err != nil {
+		return api.APIToken{}, "", err
+	}
+
+	return token, userID, nil
+}
+
+// GetAPITokens returns every token of the given user, oldest first.
+func (s *sqliteStore) GetAPITokens(userID string) ([]api.APIToken, error) {
+	return s.queryAPITokens(fmt.Sprintf("SELECT %s FROM api_tokens WHERE user_id = ? ORDER BY id", apiTokenColumns), userID)
+}
+
+// TouchAPIToken records that a token authenticated a request. It is written at most once a minute.
+func (s *sqliteStore) TouchAPIToken(id int, usedAt int64) error {
+	_, err := s.db.Exec(`UPDATE api_tokens SET last_used_at = ? WHERE id = ? AND (last_used_at IS NULL OR last_used_at <= ?)`,
+		usedAt,
+		id,
+		usedAt-tokenUseInterval,
+	)
+	return err
+}
+
+// UpdateAPIToken changes the name, scopes and expiry of a token, scoped to the given user. Returns
+// sql.ErrNoRows if not found.
+func (s *sqliteStore) UpdateAPIToken(userID string, id int, token api.APIToken) (api.APIToken, error) {
+	scopes, err := json.Marshal(token.Scopes)
+	if err != nil {
+		return api.APIToken{}, err
+	}
+
+	res, err := s.db.Exec(`UPDATE api_tokens SET name = ?, scopes = ?, expires_at = ? WHERE id = ? AND user_id = ?`,
+		token.Name,
+		string(scopes),
+		token.ExpiresAt,
+		id,
+		userID,
+	)
+	if err != nil {
+		return api.APIToken{}, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return api.APIToken{}, err
+	}
+
+	if rows == 0 {
+		return api.APIToken{}, sql.ErrNoRows
+	}
+
+	return s.GetAPIToken(userID, id)
+}
+
+// queryAPITokens runs a query selecting apiTokenColumns and scans the results.
+func (s *sqliteStore) queryAPITokens(query string, args ...any) ([]api.APIToken, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	tokens := []api.APIToken{}
+	for rows.Next() {
+		token := api.APIToken{}
+		var id int
+		var scopes string
+		var expiresAt sql.NullInt64
+		var createdAt int64
+		var lastUsedAt sql.NullInt64
+
+		err := rows.Scan(&id, &token.Name, &scopes, &expiresAt, &createdAt, &lastUsedAt)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+
+		err = json.Unmarshal([]byte(scopes), &token.Scopes)
+		if err != nil {
+			return nil, err
+		}
+
+		token.Id = &id
+		token.CreatedAt = &createdAt
+		if expiresAt.Valid {
+			token.ExpiresAt = &expiresAt.Int64
+		}
+		if lastUsedAt.Valid {
+			token.LastUsedAt = &lastUsedAt.Int64
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, rows.Err()
+}
@@ -0,0 +1,125 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+func TestSQLiteStore_APITokens(t *testing.T) {
+	store := setupTestStore(t)
+
+	now := time.Now().Unix()
+	expiresAt := now + 3600
+
+	created, err := store.CreateAPIToken("alice", "token-hash", api.APIToken{
+		Name:      "ci",
+		Scopes:    []api.TokenScope{api.TokenScopeRead, api.TokenScopeCheckIn},
+		ExpiresAt: &expiresAt,
+		CreatedAt: &now,
+	})
+	if err != nil {
+		t.Fatalf("failed to create token: %v", err)
+	}
+
+	t.Run("Tokens are found by hash with their owner", func(t *testing.T) {
+		token, userID, err := store.GetAPITokenByHash("token-hash")
+		if err != nil {
+			t.Fatalf("failed to get token: %v", err)
+		}
+		if userID != "alice" || *token.Id != *created.Id || token.Name != "ci" || len(token.Scopes) != 2 || *token.ExpiresAt != expiresAt {
+			t.Errorf("unexpected token %+v for %s", token, userID)
+		}
+
+		_, _, err = store.GetAPITokenByHash("other-hash")
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows, got %v", err)
+		}
+	})
+
+	t.Run("Tokens are scoped to their owner", func(t *testing.T) {
+		_, err := store.GetAPIToken("bob", *created.Id)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows, got %v", err)
+		}
+
+		tokens, err := store.GetAPITokens("bob")
+		if err != nil || len(tokens) != 0 {
+			t.Errorf("expected no tokens for bob, got %v, %v", tokens, err)
+		}
+
+		err = store.DeleteAPIToken("bob", *created.Id)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows, got %v", err)
+		}
+	})
+
+	t.Run("Last use is written at most once a minute", func(t *testing.T) {
+		err := store.TouchAPIToken(*created.Id, now)
+		if err != nil {
+			t.Fatalf("failed to touch token: %v", err)
+		}
+		err = store.TouchAPIToken(*created.Id, now+30)
+		if err != nil {
+			t.Fatalf("failed to touch token: %v", err)
+		}
+
+		token, err := store.GetAPIToken("alice", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to get token: %v", err)
+		}
+		if token.LastUsedAt == nil || *token.LastUsedAt != now {
+			t.Errorf("expected last use %d, got %v", now, token.LastUsedAt)
+		}
+	})
+
+	t.Run("Tokens can be updated and revoked", func(t *testing.T) {
+		updated, err := store.UpdateAPIToken("alice", *created.Id, api.APIToken{
+			Name:   "deploy",
+			Scopes: []api.TokenScope{api.TokenScopeWrite},
+		})
+		if err != nil {
+			t.Fatalf("failed to update token: %v", err)
+		}
+		if updated.Name != "deploy" || updated.Scopes[0] != api.TokenScopeWrite || updated.ExpiresAt != nil {
+			t.Errorf("unexpected token %+v", updated)
+		}
+
+		err = store.DeleteAPIToken("alice", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to delete token: %v", err)
+		}
+
+		_, _, err = store.GetAPITokenByHash("token-hash")
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows, got %v", err)
+		}
+	})
+
+	t.Run("Every token of a user can be revoked", func(t *testing.T) {
+		for hash, owner := range map[string]string{"alice-1": "alice", "alice-2": "alice", "bob-1": "bob"} {
+			_, err := store.CreateAPIToken(owner, hash, api.APIToken{Name: "ci", Scopes: []api.TokenScope{api.TokenScopeRead}, CreatedAt: &now})
+			if err != nil {
+				t.Fatalf("failed to create token: %v", err)
+			}
+		}
+
+		err := store.DeleteUserAPITokens("alice")
+		if err != nil {
+			t.Fatalf("failed to delete tokens: %v", err)
+		}
+
+		tokens, err := store.GetAPITokens("alice")
+		if err != nil || len(tokens) != 0 {
+			t.Errorf("expected alice's tokens to be revoked, got %v, %v", tokens, err)
+		}
+
+		tokens, err = store.GetAPITokens("bob")
+		if err != nil || len(tokens) != 1 {
+			t.Errorf("expected bob's token to remain, got %v, %v", tokens, err)
+		}
+	})
+}
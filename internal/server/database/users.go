@@ -0,0 +1,159 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// User is a local user account, used when the server signs users in itself instead of through an OIDC provider.
+// The username is the user ID switches and tokens are owned by.
+type User struct {
+	Username     string
+	PasswordHash string
+	// TOTPSecret is the secret of a started or enabled TOTP setup. It is encrypted at rest.
+	TOTPSecret  string
+	TOTPEnabled bool
+	// TOTPLastStep is the time step of the last accepted TOTP code, so a code can't be used twice.
+	TOTPLastStep int64
+	CreatedAt    int64
+}
+
+// Session is a signed in session of a local user account. Only the hash of its token is stored.
+type Session struct {
+	TokenHash string
+	Username  string
+	CreatedAt int64
+	ExpiresAt int64
+}
+
+// CreateUser stores a local user account. Returns ErrUserExists if the username is taken.
+func (s *sqliteStore) CreateUser(user User) error {
+	totpSecret, err := s.encryptTOTPSecret(user.TOTPSecret)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`INSERT INTO users (username, password_hash, totp_secret, totp_enabled, totp_last_step, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		user.Username,
+		user.PasswordHash,
+		totpSecret,
+		user.TOTPEnabled,
+		user.TOTPLastStep,
+		user.CreatedAt,
+		user.CreatedAt,
+	)
+
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY {
+		return ErrUserExists
+	}
+
+	return err
+}
+
+// GetUser returns a local user account by its username. Returns sql.ErrNoRows if not found.
+func (s *sqliteStore) GetUser(username string) (User, error) {
+	user := User{}
+	var totpSecret sql.NullString
+
+	err := s.db.QueryRow(`SELECT username, password_hash, totp_secret, totp_enabled, totp_last_step, created_at FROM users WHERE username = ?`, username).
+		Scan(&user.Username, &user.PasswordHash, &totpSecret, &user.TOTPEnabled, &user.TOTPLastStep, &user.CreatedAt)
+	if err != nil {
+		return User{}, err
+	}
+
+	if totpSecret.Valid {
+		secret, err := s.decrypt(totpSecret.String)
+		if err != nil {
+			return User{}, err
+		}
+		user.TOTPSecret = string(secret)
+	}
+
+	return user, nil
+}
+
+// UpdateUser replaces the password and TOTP settings of a local user account. Returns sql.ErrNoRows if not found.
+func (s *sqliteStore) UpdateUser(user User) error {
+	totpSecret, err := s.encryptTOTPSecret(user.TOTPSecret)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.db.Exec(`UPDATE users SET password_hash = ?, totp_secret = ?, totp_enabled = ?, totp_last_step = ?, updated_at = ? WHERE username = ?`,
+		user.PasswordHash,
+		totpSecret,
+		user.TOTPEnabled,
+		user.TOTPLastStep,
+		time.Now().Unix(),
+		user.Username,
+	)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// CreateSession stores a session, removing sessions that have expired.
+func (s *sqliteStore) CreateSession(session Session) error {
+	_, err := s.db.Exec(`DELETE FROM sessions WHERE expires_at <= ?`, time.Now().Unix())
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`INSERT INTO sessions (token_hash, username, created_at, expires_at) VALUES (?, ?, ?, ?)`,
+		session.TokenHash,
+		session.Username,
+		session.CreatedAt,
+		session.ExpiresAt,
+	)
+	return err
+}
+
+// DeleteSession signs out the session with the given token hash.
+func (s *sqliteStore) DeleteSession(tokenHash string) error {
+	_, err := s.db.Exec(`DELETE FROM sessions WHERE token_hash = ?`, tokenHash)
+	return err
+}
+
+// DeleteUserSessions signs out every session of a user except the one with the given token hash, which
+// may be empty to sign out all of them.
+func (s *sqliteStore) DeleteUserSessions(username, exceptTokenHash string) error {
+	_, err := s.db.Exec(`DELETE FROM sessions WHERE username = ? AND token_hash != ?`, username, exceptTokenHash)
+	return err
+}
+
+// GetSession returns the session with the given token hash if it hasn't expired by now. Returns sql.ErrNoRows if not found.
+func (s *sqliteStore) GetSession(tokenHash string, now int64) (Session, error) {
+	session := Session{}
+
+	err := s.db.QueryRow(`SELECT token_hash, username, created_at, expires_at FROM sessions WHERE token_hash = ? AND expires_at > ?`, tokenHash, now).
+		Scan(&session.TokenHash, &session.Username, &session.CreatedAt, &session.ExpiresAt)
+	if err != nil {
+		return Session{}, err
+	}
+
+	return session, nil
+}
+
+// encryptTOTPSecret prepares a TOTP secret for SQL, leaving accounts without one NULL.
+func (s *sqliteStore) encryptTOTPSecret(secret string) (any, error) {
+	if secret == "" {
+		return nil, nil
+	}
+	return s.encrypt([]byte(secret))
+}
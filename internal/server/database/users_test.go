@@ -0,0 +1,117 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSQLiteStore_Users(t *testing.T) {
+	store := setupTestStore(t)
+
+	now := time.Now().Unix()
+
+	err := store.CreateUser(User{Username: "alice", PasswordHash: "hash", CreatedAt: now})
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	t.Run("Usernames are unique", func(t *testing.T) {
+		err := store.CreateUser(User{Username: "alice", PasswordHash: "other", CreatedAt: now})
+		if !errors.Is(err, ErrUserExists) {
+			t.Errorf("expected ErrUserExists, got %v", err)
+		}
+	})
+
+	t.Run("TOTP secrets round trip encrypted", func(t *testing.T) {
+		err := store.UpdateUser(User{Username: "alice", PasswordHash: "new-hash", TOTPSecret: "SECRET", TOTPEnabled: true, TOTPLastStep: 42})
+		if err != nil {
+			t.Fatalf("failed to update user: %v", err)
+		}
+
+		user, err := store.GetUser("alice")
+		if err != nil {
+			t.Fatalf("failed to get user: %v", err)
+		}
+		if user.PasswordHash != "new-hash" || user.TOTPSecret != "SECRET" || !user.TOTPEnabled || user.TOTPLastStep != 42 || user.CreatedAt != now {
+			t.Errorf("unexpected user %+v", user)
+		}
+
+		var stored string
+		err = store.(*sqliteStore).db.QueryRow(`SELECT totp_secret FROM users WHERE username = 'alice'`).Scan(&stored)
+		if err != nil || stored == "SECRET" {
+			t.Errorf("expected the TOTP secret to be encrypted, got %q (%v)", stored, err)
+		}
+	})
+
+	t.Run("Unknown users are not found", func(t *testing.T) {
+		_, err := store.GetUser("bob")
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows, got %v", err)
+		}
+
+		err = store.UpdateUser(User{Username: "bob"})
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows, got %v", err)
+		}
+	})
+}
+
+func TestSQLiteStore_Sessions(t *testing.T) {
+	store := setupTestStore(t)
+
+	now := time.Now().Unix()
+
+	for _, session := range []Session{
+		{TokenHash: "current", Username: "alice", CreatedAt: now, ExpiresAt: now + 3600},
+		{TokenHash: "other", Username: "alice", CreatedAt: now, ExpiresAt: now + 3600},
+		{TokenHash: "expired", Username: "alice", CreatedAt: now - 7200, ExpiresAt: now - 3600},
+	} {
+		err := store.CreateSession(session)
+		if err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+	}
+
+	t.Run("Sessions are found until they expire", func(t *testing.T) {
+		session, err := store.GetSession("current", now)
+		if err != nil || session.Username != "alice" {
+			t.Errorf("unexpected session %+v (%v)", session, err)
+		}
+
+		_, err = store.GetSession("expired", now)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows, got %v", err)
+		}
+	})
+
+	t.Run("Other sessions of a user are signed out", func(t *testing.T) {
+		err := store.DeleteUserSessions("alice", "current")
+		if err != nil {
+			t.Fatalf("failed to delete sessions: %v", err)
+		}
+
+		_, err = store.GetSession("other", now)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows, got %v", err)
+		}
+
+		_, err = store.GetSession("current", now)
+		if err != nil {
+			t.Errorf("expected the current session to remain, got %v", err)
+		}
+	})
+
+	t.Run("Sessions are signed out", func(t *testing.T) {
+		err := store.DeleteSession("current")
+		if err != nil {
+			t.Fatalf("failed to delete session: %v", err)
+		}
+
+		_, err = store.GetSession("current", now)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows, got %v", err)
+		}
+	})
+}
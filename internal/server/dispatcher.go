@@ -0,0 +1,163 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/database"
+	"github.com/circa10a/dead-mans-switch/internal/server/events"
+	"github.com/circa10a/dead-mans-switch/internal/server/webhooks"
+)
+
+const (
+	// eventHeader names the event type of a subscription request, so receivers can route it before parsing the body.
+	eventHeader = "X-Dead-Mans-Switch-Event"
+	// dispatchBuffer is how many events can wait for the dispatcher before new ones are dropped.
+	dispatchBuffer = 1000
+	// errEventDropped is recorded as the failed delivery of an event the dispatcher had no room for.
+	errEventDropped = "event was dropped because deliveries fell behind"
+)
+
+// dispatcher sends switch lifecycle events to the webhooks their owners subscribed.
+type dispatcher struct {
+	store  database.Store
+	bus    *events.Bus
+	logger *slog.Logger
+
+	mu sync.Mutex
+	// dropped are the events the dispatcher had no room for, waiting to be recorded as failed deliveries.
+	dropped []api.Event
+}
+
+// start sends events until the context is cancelled, then waits for deliveries in flight.
+func (d *dispatcher) start(ctx context.Context) {
+	ch, stop := d.bus.SubscribeWithDropped(dispatchBuffer, d.drop)
+	defer stop()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-ch:
+			d.dispatch(ctx, event, &wg)
+			d.recordDropped()
+		}
+	}
+}
+
+// drop logs an event the dispatcher had no room for and keeps it to be recorded as a failed delivery.
+// It is called while the event is published, so it leaves the database to the dispatcher.
+func (d *dispatcher) drop(event api.Event) {
+	d.logger.Warn("Dropped event, deliveries fell behind", "event", event.Type, "id", event.SwitchId, "user", event.UserId)
+
+	d.mu.Lock()
+	d.dropped = append(d.dropped, event)
+	d.mu.Unlock()
+}
+
+// recordDropped records dropped events as failed deliveries to every subscription that wanted them, so
+// owners can see what their receivers missed.
+func (d *dispatcher) recordDropped() {
+	d.mu.Lock()
+	dropped := d.dropped
+	d.dropped = nil
+	d.mu.Unlock()
+
+	for _, event := range dropped {
+		subs, err := d.store.GetSubscriptions(event.UserId)
+		if err != nil {
+			d.logger.Error("Failed to fetch subscriptions", "error", err, "user", event.UserId)
+			continue
+		}
+
+		for _, sub := range subs {
+			if !events.Subscribed(sub, event.Type) {
+				continue
+			}
+
+			reason := errEventDropped
+			delivery := api.Delivery{
+				SwitchId:       event.SwitchId,
+				SubscriptionId: sub.Id,
+				Kind:           api.DeliveryKindEvent,
+				Target:         string(event.Type),
+				Error:          &reason,
+				CreatedAt:      time.Now().Unix(),
+			}
+
+			_, err = d.store.CreateDelivery(event.UserId, delivery)
+			if err != nil {
+				d.logger.Error("Failed to record delivery", "subscription", *sub.Id, "event", event.Type, "error", err)
+			}
+		}
+	}
+}
+
+// dispatch starts a delivery for every subscription of the switch owner that wants the event.
+// Deliveries run concurrently, so a slow or retrying receiver doesn't hold up the others.
+func (d *dispatcher) dispatch(ctx context.Context, event api.Event, wg *sync.WaitGroup) {
+	subs, err := d.store.GetSubscriptions(event.UserId)
+	if err != nil {
+		d.logger.Error("Failed to fetch subscriptions", "error", err, "user", event.UserId)
+		return
+	}
+
+	for _, sub := range subs {
+		if !events.Subscribed(sub, event.Type) {
+			continue
+		}
+
+		wg.Go(func() {
+			d.deliver(ctx, sub, event)
+		})
+	}
+}
+
+// deliver sends an event to a subscription and records the result in its delivery log.
+func (d *dispatcher) deliver(ctx context.Context, sub api.WebhookSubscription, event api.Event) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		d.logger.Error("Failed to encode event", "error", err, "event", event.Id)
+		return
+	}
+
+	result := webhooks.Send(ctx, api.Webhook{
+		Url:          sub.Url,
+		Secret:       sub.Secret,
+		Headers:      &map[string]string{eventHeader: string(event.Type)},
+		MaxAttempts:  sub.MaxAttempts,
+		RetryBackoff: sub.RetryBackoff,
+	}, body)
+
+	duration := result.Duration.Milliseconds()
+	delivery := api.Delivery{
+		SwitchId:       event.SwitchId,
+		SubscriptionId: sub.Id,
+		Kind:           api.DeliveryKindEvent,
+		Target:         string(event.Type),
+		Success:        result.Err == nil,
+		Attempts:       &result.Attempts,
+		Duration:       &duration,
+		CreatedAt:      time.Now().Unix(),
+	}
+	if result.StatusCode != 0 {
+		delivery.StatusCode = &result.StatusCode
+	}
+	if result.Err != nil {
+		reason := result.Err.Error()
+		delivery.Error = &reason
+		d.logger.Error("Event delivery failed", "subscription", *sub.Id, "event", event.Type, "attempts", result.Attempts, "error", reason)
+	}
+
+	_, err = d.store.CreateDelivery(event.UserId, delivery)
+	if err != nil {
+		d.logger.Error("Failed to record delivery", "subscription", *sub.Id, "event", event.Type, "error", err)
+	}
+}
@@ -0,0 +1,166 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/database"
+	"github.com/circa10a/dead-mans-switch/internal/server/events"
+	"github.com/circa10a/dead-mans-switch/internal/server/webhooks"
+)
+
+func TestDispatcher(t *testing.T) {
+	store, err := database.NewInMemorySQLiteStore()
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	err = store.Init()
+	if err != nil {
+		t.Fatalf("failed to init store: %v", err)
+	}
+
+	secret := "0123456789abcdef"
+	now := time.Now().Unix()
+
+	var mu sync.Mutex
+	received := []api.Event{}
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		err := webhooks.Verify(secret, r.Header.Get(webhooks.SignatureHeader), body, time.Minute, time.Now())
+		if err != nil {
+			t.Errorf("expected a signed request: %v", err)
+		}
+
+		event := api.Event{}
+		_ = json.Unmarshal(body, &event)
+		if r.Header.Get(eventHeader) != string(event.Type) {
+			t.Errorf("expected the %s header to name the event, got %q", eventHeader, r.Header.Get(eventHeader))
+		}
+
+		mu.Lock()
+		received = append(received, event)
+		mu.Unlock()
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	triggered, err := store.CreateSubscription("admin", api.WebhookSubscription{
+		Url:       srv.URL,
+		Secret:    &secret,
+		Events:    &[]api.EventType{api.EventTypeTriggered},
+		CreatedAt: &now,
+	})
+	if err != nil {
+		t.Fatalf("failed to create subscription: %v", err)
+	}
+
+	other, err := store.CreateSubscription("someone-else", api.WebhookSubscription{
+		Url:       srv.URL,
+		Secret:    &secret,
+		CreatedAt: &now,
+	})
+	if err != nil {
+		t.Fatalf("failed to create subscription: %v", err)
+	}
+
+	d := &dispatcher{
+		store:  store,
+		bus:    events.NewBus(),
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	var wg sync.WaitGroup
+	d.dispatch(context.Background(), api.Event{Id: 1, Type: api.EventTypeCreated, SwitchId: 3, UserId: "admin"}, &wg)
+	d.dispatch(context.Background(), api.Event{Id: 2, Type: api.EventTypeTriggered, SwitchId: 3, UserId: "admin"}, &wg)
+	wg.Wait()
+
+	if len(received) != 1 || received[0].Id != 2 || received[0].SwitchId != 3 {
+		t.Fatalf("expected only the triggered event to be sent, got %+v", received)
+	}
+
+	deliveries, err := store.GetSubscriptionDeliveries("admin", *triggered.Id, 10, 0)
+	if err != nil {
+		t.Fatalf("failed to get deliveries: %v", err)
+	}
+	if len(deliveries) != 1 {
+		t.Fatalf("expected 1 delivery, got %d", len(deliveries))
+	}
+
+	delivery := deliveries[0]
+	if !delivery.Success || delivery.Kind != api.DeliveryKindEvent || delivery.Target != string(api.EventTypeTriggered) || *delivery.SubscriptionId != *triggered.Id {
+		t.Errorf("unexpected delivery %+v", delivery)
+	}
+
+	deliveries, err = store.GetSubscriptionDeliveries("someone-else", *other.Id, 10, 0)
+	if err != nil {
+		t.Fatalf("failed to get deliveries: %v", err)
+	}
+	if len(deliveries) != 0 {
+		t.Errorf("expected no deliveries to another user's subscription, got %d", len(deliveries))
+	}
+}
+
+func TestDispatcher_Dropped(t *testing.T) {
+	store, err := database.NewInMemorySQLiteStore()
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	err = store.Init()
+	if err != nil {
+		t.Fatalf("failed to init store: %v", err)
+	}
+
+	now := time.Now().Unix()
+
+	sub, err := store.CreateSubscription("admin", api.WebhookSubscription{
+		Url:       "https://example.com/hooks/events",
+		Events:    &[]api.EventType{api.EventTypeTriggered},
+		CreatedAt: &now,
+	})
+	if err != nil {
+		t.Fatalf("failed to create subscription: %v", err)
+	}
+
+	bus := events.NewBus()
+	d := &dispatcher{
+		store:  store,
+		bus:    bus,
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	// Nothing reads the subscription, so every event after the first fills or overflows its buffer
+	_, stop := bus.SubscribeWithDropped(1, d.drop)
+	defer stop()
+
+	id := 3
+	userID := "admin"
+	bus.Publish(api.EventTypeCreated, api.Switch{Id: &id, UserId: &userID})
+	bus.Publish(api.EventTypeCheckedIn, api.Switch{Id: &id, UserId: &userID})
+	bus.Publish(api.EventTypeTriggered, api.Switch{Id: &id, UserId: &userID})
+	d.recordDropped()
+
+	deliveries, err := store.GetSubscriptionDeliveries("admin", *sub.Id, 10, 0)
+	if err != nil {
+		t.Fatalf("failed to get deliveries: %v", err)
+	}
+	if len(deliveries) != 1 {
+		t.Fatalf("expected the dropped triggered event to be recorded, got %+v", deliveries)
+	}
+
+	delivery := deliveries[0]
+	if delivery.Success || delivery.Target != string(api.EventTypeTriggered) || delivery.Error == nil || *delivery.Error != errEventDropped {
+		t.Errorf("unexpected delivery %+v", delivery)
+	}
+}
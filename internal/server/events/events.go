@@ -0,0 +1,126 @@
+package events
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+// historySize is how many recent events are kept for clients that reconnect.
+const historySize = 1000
+
+// Bus fans out switch lifecycle events to subscribers, such as outgoing webhooks and live streams.
+// A nil Bus drops events, so publishers don't need to check whether one is configured.
+type Bus struct {
+	mu          sync.Mutex
+	lastID      int64
+	history     []api.Event
+	subscribers map[chan api.Event]func(api.Event)
+}
+
+// NewBus returns a bus without subscribers. Event IDs start from the current time in milliseconds,
+// so they keep increasing across server restarts and clients resuming from an old ID don't skip new events.
+func NewBus() *Bus {
+	return &Bus{
+		lastID:      time.Now().UnixMilli(),
+		subscribers: map[chan api.Event]func(api.Event){},
+	}
+}
+
+// Publish sends an event about a switch to every subscriber. Subscribers that have fallen behind
+// miss the event rather than holding up the request or sweep that published it, and are told so if
+// they subscribed with SubscribeWithDropped.
+func (b *Bus) Publish(eventType api.EventType, sw api.Switch) {
+	if b == nil || sw.Id == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event := api.Event{
+		Id:        b.lastID,
+		Type:      eventType,
+		CreatedAt: time.Now().Unix(),
+		SwitchId:  *sw.Id,
+		Labels:    sw.Labels,
+	}
+	if sw.UserId != nil {
+		event.UserId = *sw.UserId
+	}
+	if sw.Status != nil {
+		status := string(*sw.Status)
+		event.Status = &status
+	}
+	if sw.TriggerAt != nil {
+		triggerAt := *sw.TriggerAt
+		event.TriggerAt = &triggerAt
+	}
+
+	if len(b.history) == historySize {
+		b.history = b.history[1:]
+	}
+	b.history = append(b.history, event)
+
+	for ch, dropped := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			if dropped != nil {
+				dropped(event)
+			}
+		}
+	}
+}
+
+// Subscribe returns a channel that receives published events, buffering up to size of them,
+// and a function that stops the subscription and closes the channel.
+func (b *Bus) Subscribe(size int) (<-chan api.Event, func()) {
+	return b.SubscribeWithDropped(size, nil)
+}
+
+// SubscribeWithDropped is like Subscribe, but calls dropped with every event the subscriber missed
+// because its buffer was full. dropped is called while the event is published, so it must not block
+// or publish events itself.
+func (b *Bus) SubscribeWithDropped(size int, dropped func(api.Event)) (<-chan api.Event, func()) {
+	ch := make(chan api.Event, size)
+
+	b.mu.Lock()
+	b.subscribers[ch] = dropped
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// Since returns the recent events published after the given ID, oldest first.
+func (b *Bus) Since(id int64) []api.Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	i, _ := slices.BinarySearchFunc(b.history, id, func(event api.Event, id int64) int {
+		return cmp.Compare(event.Id, id+1)
+	})
+
+	return slices.Clone(b.history[i:])
+}
+
+// Subscribed reports whether a webhook subscription receives events of a type.
+func Subscribed(sub api.WebhookSubscription, eventType api.EventType) bool {
+	if sub.Enabled != nil && !*sub.Enabled {
+		return false
+	}
+
+	return sub.Events == nil || len(*sub.Events) == 0 || slices.Contains(*sub.Events, eventType)
+}
@@ -0,0 +1,130 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+func TestBus(t *testing.T) {
+	id := 7
+	userID := "admin"
+	status := api.SwitchStatusTriggered
+	sw := api.Switch{Id: &id, UserId: &userID, Status: &status}
+
+	t.Run("subscribers receive events in order", func(t *testing.T) {
+		bus := NewBus()
+		ch, stop := bus.Subscribe(10)
+		defer stop()
+
+		bus.Publish(api.EventTypeTriggered, sw)
+		bus.Publish(api.EventTypeDeleted, sw)
+
+		first, second := <-ch, <-ch
+		if first.Type != api.EventTypeTriggered || first.SwitchId != 7 || first.UserId != "admin" || *first.Status != "triggered" {
+			t.Errorf("unexpected first event %+v", first)
+		}
+		if second.Type != api.EventTypeDeleted || second.Id <= first.Id {
+			t.Errorf("expected a later second event, got %+v", second)
+		}
+	})
+
+	t.Run("full subscribers miss events instead of blocking", func(t *testing.T) {
+		bus := NewBus()
+		ch, stop := bus.Subscribe(1)
+		defer stop()
+
+		bus.Publish(api.EventTypeCreated, sw)
+		bus.Publish(api.EventTypeCheckedIn, sw)
+
+		if event := <-ch; event.Type != api.EventTypeCreated {
+			t.Errorf("expected the first event to be kept, got %s", event.Type)
+		}
+		if len(ch) != 0 {
+			t.Errorf("expected the second event to be dropped")
+		}
+	})
+
+	t.Run("subscribers can be told about missed events", func(t *testing.T) {
+		bus := NewBus()
+		dropped := []api.Event{}
+		ch, stop := bus.SubscribeWithDropped(1, func(event api.Event) {
+			dropped = append(dropped, event)
+		})
+		defer stop()
+
+		bus.Publish(api.EventTypeCreated, sw)
+		bus.Publish(api.EventTypeCheckedIn, sw)
+
+		if event := <-ch; event.Type != api.EventTypeCreated {
+			t.Errorf("expected the first event to be kept, got %s", event.Type)
+		}
+		if len(dropped) != 1 || dropped[0].Type != api.EventTypeCheckedIn {
+			t.Errorf("expected to be told about the missed event, got %+v", dropped)
+		}
+	})
+
+	t.Run("stopping closes the channel", func(t *testing.T) {
+		bus := NewBus()
+		ch, stop := bus.Subscribe(1)
+		stop()
+		stop()
+
+		bus.Publish(api.EventTypeCreated, sw)
+		if _, ok := <-ch; ok {
+			t.Error("expected the channel to be closed")
+		}
+	})
+
+	t.Run("a nil bus drops events", func(t *testing.T) {
+		var bus *Bus
+		bus.Publish(api.EventTypeCreated, sw)
+	})
+}
+
+func TestBus_Since(t *testing.T) {
+	id := 7
+	sw := api.Switch{Id: &id}
+
+	bus := NewBus()
+	for range historySize + 5 {
+		bus.Publish(api.EventTypeCheckedIn, sw)
+	}
+
+	all := bus.Since(0)
+	if len(all) != historySize {
+		t.Fatalf("expected %d events to be kept, got %d", historySize, len(all))
+	}
+
+	missed := bus.Since(all[len(all)-3].Id)
+	if len(missed) != 2 || missed[0].Id != all[len(all)-2].Id {
+		t.Errorf("expected the last 2 events, got %+v", missed)
+	}
+
+	if latest := bus.Since(all[len(all)-1].Id); len(latest) != 0 {
+		t.Errorf("expected no events after the latest, got %d", len(latest))
+	}
+}
+
+func TestSubscribed(t *testing.T) {
+	disabled := false
+
+	tests := []struct {
+		name string
+		sub  api.WebhookSubscription
+		want bool
+	}{
+		{name: "no filter", sub: api.WebhookSubscription{}, want: true},
+		{name: "matching filter", sub: api.WebhookSubscription{Events: &[]api.EventType{api.EventTypeCreated, api.EventTypeTriggered}}, want: true},
+		{name: "other events", sub: api.WebhookSubscription{Events: &[]api.EventType{api.EventTypeCreated}}, want: false},
+		{name: "disabled", sub: api.WebhookSubscription{Enabled: &disabled}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Subscribed(tt.sub, api.EventTypeTriggered); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,374 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/database"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+	"github.com/circa10a/dead-mans-switch/internal/server/secrets"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	errAccountNotFound    = "Account not found"
+	errInvalidCredentials = "Invalid username, password or TOTP code"
+	errInvalidTOTPCode    = "Invalid TOTP code"
+	errTOTPEnabled        = "Two-factor authentication is already enabled"
+	errTOTPNotEnabled     = "Two-factor authentication isn't enabled"
+	errTOTPNotStarted     = "Start TOTP setup before enabling it"
+	errTOTPRequired       = "TOTP code required"
+	errWrongPassword      = "Current password is incorrect"
+)
+
+// totpIssuer names the server in authenticator apps.
+const totpIssuer = "Dead Man's Switch"
+
+var accountValidator = validator.New()
+
+// LoginHandleFunc signs in to a local user account with its password and starts a session.
+func (s *Switch) LoginHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	payload := api.LoginRequest{}
+	if !s.decodeAccountRequest(w, r, &payload) {
+		return
+	}
+
+	user, err := s.Store.GetUser(payload.Username)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	// Unknown users have no password hash, which is checked against a dummy hash so they take as long as a wrong password
+	if !secrets.VerifyCode(payload.Password, user.PasswordHash) {
+		s.Logger.Warn("Failed sign in", "username", payload.Username, "ip", clientIP(r))
+		s.sendError(w, http.StatusUnauthorized, errInvalidCredentials, nil)
+		return
+	}
+
+	if user.TOTPEnabled {
+		if payload.TotpCode == nil || *payload.TotpCode == "" {
+			s.sendError(w, http.StatusUnauthorized, errTOTPRequired, nil)
+			return
+		}
+
+		step, ok := secrets.VerifyTOTP(user.TOTPSecret, *payload.TotpCode, time.Now(), user.TOTPLastStep)
+		if !ok {
+			s.Logger.Warn("Failed sign in", "username", payload.Username, "ip", clientIP(r))
+			s.sendError(w, http.StatusUnauthorized, errInvalidCredentials, nil)
+			return
+		}
+
+		user.TOTPLastStep = step
+		err = s.Store.UpdateUser(user)
+		if err != nil {
+			s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+			return
+		}
+	}
+
+	s.startSession(w, user.Username)
+}
+
+// startSession starts a session for a user who just signed in. The session token is returned for the CLI and
+// set as a cookie for the UI.
+func (s *Switch) startSession(w http.ResponseWriter, username string) {
+	secret, _, err := secrets.NewToken()
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, "Failed to create session", err)
+		return
+	}
+	token := middleware.SessionTokenPrefix + secret
+
+	now := time.Now()
+	session := database.Session{
+		TokenHash: secrets.HashToken(token),
+		Username:  username,
+		CreatedAt: now.Unix(),
+		ExpiresAt: now.Add(s.SessionDuration).Unix(),
+	}
+
+	err = s.Store.CreateSession(session)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     middleware.SessionCookie,
+		Value:    token,
+		Path:     "/",
+		Expires:  time.Unix(session.ExpiresAt, 0),
+		HttpOnly: true,
+		Secure:   s.SecureCookies,
+		SameSite: http.SameSiteStrictMode,
+	})
+
+	s.Logger.Info("Signed in", "username", username)
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(api.Session{
+		Token:     token,
+		ExpiresAt: session.ExpiresAt,
+		Username:  username,
+	})
+}
+
+// LogoutHandleFunc ends the session the request was made with and clears the session cookie.
+func (s *Switch) LogoutHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	token := middleware.SessionToken(r)
+	if token != "" {
+		err := s.Store.DeleteSession(secrets.HashToken(token))
+		if err != nil {
+			s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+			return
+		}
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     middleware.SessionCookie,
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   s.SecureCookies,
+		SameSite: http.SameSiteStrictMode,
+	})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AccountHandleFunc returns the local user account the request is authenticated as.
+func (s *Switch) AccountHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	user, ok := s.lookupAccount(w, r)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(api.User{
+		Username:    user.Username,
+		TotpEnabled: user.TOTPEnabled,
+		CreatedAt:   user.CreatedAt,
+	})
+}
+
+// ChangePasswordHandleFunc changes the password of the user's account and signs out their other sessions.
+func (s *Switch) ChangePasswordHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	payload := api.PasswordChange{}
+	if !s.decodeAccountRequest(w, r, &payload) {
+		return
+	}
+
+	user, ok := s.lookupAccount(w, r)
+	if !ok {
+		return
+	}
+
+	if !secrets.VerifyCode(payload.CurrentPassword, user.PasswordHash) {
+		s.sendError(w, http.StatusBadRequest, errWrongPassword, nil)
+		return
+	}
+
+	hash, err := secrets.HashCode(payload.NewPassword)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, "Failed to hash password", err)
+		return
+	}
+
+	user.PasswordHash = hash
+	err = s.Store.UpdateUser(user)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	current := ""
+	if token := middleware.SessionToken(r); token != "" {
+		current = secrets.HashToken(token)
+	}
+
+	err = s.Store.DeleteUserSessions(user.Username, current)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	// Tokens minted with a compromised password would otherwise outlive the change
+	err = s.Store.DeleteUserAPITokens(user.Username)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetupTOTPHandleFunc generates a TOTP secret for the user's account. Two-factor authentication is
+// enabled once a code from it is confirmed with EnableTOTPHandleFunc.
+func (s *Switch) SetupTOTPHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	user, ok := s.lookupAccount(w, r)
+	if !ok {
+		return
+	}
+
+	if user.TOTPEnabled {
+		s.sendError(w, http.StatusConflict, errTOTPEnabled, nil)
+		return
+	}
+
+	secret, err := secrets.NewTOTPSecret()
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, "Failed to create TOTP secret", err)
+		return
+	}
+
+	user.TOTPSecret = secret
+	err = s.Store.UpdateUser(user)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(api.TOTPSetup{
+		Secret: secret,
+		Url:    secrets.TOTPURL(totpIssuer, user.Username, secret),
+	})
+}
+
+// EnableTOTPHandleFunc enables two-factor authentication once a code from the secret created by
+// SetupTOTPHandleFunc is confirmed.
+func (s *Switch) EnableTOTPHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	payload := api.TOTPCode{}
+	if !s.decodeAccountRequest(w, r, &payload) {
+		return
+	}
+
+	user, ok := s.lookupAccount(w, r)
+	if !ok {
+		return
+	}
+
+	if user.TOTPEnabled {
+		s.sendError(w, http.StatusConflict, errTOTPEnabled, nil)
+		return
+	}
+
+	if user.TOTPSecret == "" {
+		s.sendError(w, http.StatusBadRequest, errTOTPNotStarted, nil)
+		return
+	}
+
+	step, ok := secrets.VerifyTOTP(user.TOTPSecret, payload.Code, time.Now(), user.TOTPLastStep)
+	if !ok {
+		s.sendError(w, http.StatusBadRequest, errInvalidTOTPCode, nil)
+		return
+	}
+
+	user.TOTPEnabled = true
+	user.TOTPLastStep = step
+	err := s.Store.UpdateUser(user)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DisableTOTPHandleFunc disables two-factor authentication after checking a current code.
+func (s *Switch) DisableTOTPHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	payload := api.TOTPCode{}
+	if !s.decodeAccountRequest(w, r, &payload) {
+		return
+	}
+
+	user, ok := s.lookupAccount(w, r)
+	if !ok {
+		return
+	}
+
+	if !user.TOTPEnabled {
+		s.sendError(w, http.StatusBadRequest, errTOTPNotEnabled, nil)
+		return
+	}
+
+	_, ok = secrets.VerifyTOTP(user.TOTPSecret, payload.Code, time.Now(), user.TOTPLastStep)
+	if !ok {
+		s.sendError(w, http.StatusBadRequest, errInvalidTOTPCode, nil)
+		return
+	}
+
+	user.TOTPEnabled = false
+	user.TOTPSecret = ""
+	err := s.Store.UpdateUser(user)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// lookupAccount finds the local user account the request is authenticated as. It sends the error response
+// itself and reports whether the request can continue.
+func (s *Switch) lookupAccount(w http.ResponseWriter, r *http.Request) (database.User, bool) {
+	user, err := s.Store.GetUser(middleware.GetUserIDFromContext(r))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.sendError(w, http.StatusNotFound, errAccountNotFound, err)
+			return database.User{}, false
+		}
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return database.User{}, false
+	}
+
+	return user, true
+}
+
+// decodeAccountRequest reads and validates an account request from the request body into payload. It sends
+// the error response itself and reports whether the request can continue.
+func (s *Switch) decodeAccountRequest(w http.ResponseWriter, r *http.Request, payload any) bool {
+	err := json.NewDecoder(r.Body).Decode(payload)
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errInvalidJSON, err)
+		return false
+	}
+
+	err = accountValidator.Struct(payload)
+	if err != nil {
+		fields := []string{}
+
+		var ve validator.ValidationErrors
+		if errors.As(err, &ve) {
+			for _, fe := range ve {
+				fields = append(fields, fmt.Sprintf("field '%s' failed on validation: %s", fe.Field(), fe.Tag()))
+			}
+		}
+
+		s.sendError(w, http.StatusBadRequest, "Validation failed: "+strings.Join(fields, ", "), err)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/database"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+	"github.com/circa10a/dead-mans-switch/internal/server/secrets"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestAccounts(t *testing.T) {
+	s, store := setupTestHandler(t)
+	s.SessionDuration = time.Hour
+	s.SecureCookies = true
+
+	hash, err := secrets.HashCode("correct horse")
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+	err = store.CreateUser(database.User{Username: "alice", PasswordHash: hash, CreatedAt: time.Now().Unix()})
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/auth/login", s.LoginHandleFunc)
+	r.Post("/api/v1/auth/logout", s.LogoutHandleFunc)
+	r.Get("/api/v1/auth/me", s.AccountHandleFunc)
+	r.Post("/api/v1/auth/password", s.ChangePasswordHandleFunc)
+	r.Post("/api/v1/auth/totp", s.SetupTOTPHandleFunc)
+	r.Post("/api/v1/auth/totp/enable", s.EnableTOTPHandleFunc)
+	r.Post("/api/v1/auth/totp/disable", s.DisableTOTPHandleFunc)
+
+	do := func(method, path, userID, token string, body any) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			_ = json.NewEncoder(&buf).Encode(body)
+		}
+		req := httptest.NewRequest(method, path, &buf)
+		req = req.WithContext(middleware.WithUserID(req.Context(), userID))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	login := func(t *testing.T, password string) api.Session {
+		t.Helper()
+		rec := do(http.MethodPost, "/api/v1/auth/login", "", "", api.LoginRequest{Username: "alice", Password: password})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		session := api.Session{}
+		_ = json.NewDecoder(rec.Body).Decode(&session)
+		return session
+	}
+
+	t.Run("wrong passwords and unknown users are rejected alike", func(t *testing.T) {
+		for _, req := range []api.LoginRequest{
+			{Username: "alice", Password: "wrong"},
+			{Username: "bob", Password: "correct horse"},
+		} {
+			rec := do(http.MethodPost, "/api/v1/auth/login", "", "", req)
+			if rec.Code != http.StatusUnauthorized || !strings.Contains(rec.Body.String(), errInvalidCredentials) {
+				t.Errorf("expected 401 %q, got %d: %s", errInvalidCredentials, rec.Code, rec.Body.String())
+			}
+		}
+	})
+
+	t.Run("login starts a session and sets a secure cookie", func(t *testing.T) {
+		rec := do(http.MethodPost, "/api/v1/auth/login", "", "", api.LoginRequest{Username: "alice", Password: "correct horse"})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		session := api.Session{}
+		_ = json.NewDecoder(rec.Body).Decode(&session)
+		if !strings.HasPrefix(session.Token, middleware.SessionTokenPrefix) || session.Username != "alice" {
+			t.Errorf("unexpected session %+v", session)
+		}
+
+		cookies := rec.Result().Cookies()
+		if len(cookies) != 1 || cookies[0].Name != middleware.SessionCookie || cookies[0].Value != session.Token {
+			t.Fatalf("expected the session cookie, got %+v", cookies)
+		}
+		if !cookies[0].HttpOnly || !cookies[0].Secure || cookies[0].SameSite != http.SameSiteStrictMode {
+			t.Errorf("expected an HttpOnly, Secure, SameSite=Strict cookie, got %+v", cookies[0])
+		}
+
+		_, err := store.GetSession(secrets.HashToken(session.Token), time.Now().Unix())
+		if err != nil {
+			t.Errorf("expected the session to be stored, got %v", err)
+		}
+	})
+
+	t.Run("me returns the account", func(t *testing.T) {
+		rec := do(http.MethodGet, "/api/v1/auth/me", "alice", "", nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+		user := api.User{}
+		_ = json.NewDecoder(rec.Body).Decode(&user)
+		if user.Username != "alice" || user.TotpEnabled {
+			t.Errorf("unexpected user %+v", user)
+		}
+
+		rec = do(http.MethodGet, "/api/v1/auth/me", "bob", "", nil)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", rec.Code)
+		}
+	})
+
+	t.Run("changing the password signs out other sessions and revokes API tokens", func(t *testing.T) {
+		current := login(t, "correct horse")
+		other := login(t, "correct horse")
+
+		createdAt := time.Now().Unix()
+		_, err := store.CreateAPIToken("alice", "token-hash", api.APIToken{Name: "ci", Scopes: []api.TokenScope{api.TokenScopeWrite}, CreatedAt: &createdAt})
+		if err != nil {
+			t.Fatalf("failed to create token: %v", err)
+		}
+
+		rec := do(http.MethodPost, "/api/v1/auth/password", "alice", current.Token, api.PasswordChange{CurrentPassword: "wrong", NewPassword: "battery staple"})
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400 for a wrong current password, got %d", rec.Code)
+		}
+
+		rec = do(http.MethodPost, "/api/v1/auth/password", "alice", current.Token, api.PasswordChange{CurrentPassword: "correct horse", NewPassword: "short"})
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400 for a short password, got %d", rec.Code)
+		}
+
+		rec = do(http.MethodPost, "/api/v1/auth/password", "alice", current.Token, api.PasswordChange{CurrentPassword: "correct horse", NewPassword: "battery staple"})
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		now := time.Now().Unix()
+		_, err = store.GetSession(secrets.HashToken(current.Token), now)
+		if err != nil {
+			t.Errorf("expected the current session to remain, got %v", err)
+		}
+		_, err = store.GetSession(secrets.HashToken(other.Token), now)
+		if err == nil {
+			t.Error("expected other sessions to be signed out")
+		}
+		_, _, err = store.GetAPITokenByHash("token-hash")
+		if err == nil {
+			t.Error("expected API tokens to be revoked")
+		}
+
+		login(t, "battery staple")
+	})
+
+	t.Run("logout ends the session and clears the cookie", func(t *testing.T) {
+		session := login(t, "battery staple")
+
+		rec := do(http.MethodPost, "/api/v1/auth/logout", "alice", session.Token, nil)
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("expected 204, got %d", rec.Code)
+		}
+
+		cookies := rec.Result().Cookies()
+		if len(cookies) != 1 || cookies[0].MaxAge >= 0 {
+			t.Errorf("expected the session cookie to be cleared, got %+v", cookies)
+		}
+
+		_, err := store.GetSession(secrets.HashToken(session.Token), time.Now().Unix())
+		if err == nil {
+			t.Error("expected the session to be deleted")
+		}
+	})
+
+	t.Run("TOTP must be confirmed before it is enabled", func(t *testing.T) {
+		rec := do(http.MethodPost, "/api/v1/auth/totp/enable", "alice", "", api.TOTPCode{Code: "123456"})
+		if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), errTOTPNotStarted) {
+			t.Errorf("expected 400 %q, got %d: %s", errTOTPNotStarted, rec.Code, rec.Body.String())
+		}
+
+		rec = do(http.MethodPost, "/api/v1/auth/totp", "alice", "", nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		setup := api.TOTPSetup{}
+		_ = json.NewDecoder(rec.Body).Decode(&setup)
+		if setup.Secret == "" || !strings.HasPrefix(setup.Url, "otpauth://totp/") {
+			t.Errorf("unexpected setup %+v", setup)
+		}
+
+		rec = do(http.MethodPost, "/api/v1/auth/totp/enable", "alice", "", api.TOTPCode{Code: "abcdef"})
+		if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), errInvalidTOTPCode) {
+			t.Errorf("expected 400 %q, got %d: %s", errInvalidTOTPCode, rec.Code, rec.Body.String())
+		}
+
+		user, _ := store.GetUser("alice")
+		if user.TOTPEnabled || user.TOTPSecret != setup.Secret {
+			t.Errorf("expected a pending TOTP secret, got %+v", user)
+		}
+	})
+
+	t.Run("login asks for a TOTP code once enabled", func(t *testing.T) {
+		user, _ := store.GetUser("alice")
+		user.TOTPEnabled = true
+		err := store.UpdateUser(user)
+		if err != nil {
+			t.Fatalf("failed to update user: %v", err)
+		}
+
+		rec := do(http.MethodPost, "/api/v1/auth/login", "", "", api.LoginRequest{Username: "alice", Password: "battery staple"})
+		if rec.Code != http.StatusUnauthorized || !strings.Contains(rec.Body.String(), errTOTPRequired) {
+			t.Errorf("expected 401 %q, got %d: %s", errTOTPRequired, rec.Code, rec.Body.String())
+		}
+
+		code := "abcdef"
+		rec = do(http.MethodPost, "/api/v1/auth/login", "", "", api.LoginRequest{Username: "alice", Password: "battery staple", TotpCode: &code})
+		if rec.Code != http.StatusUnauthorized || !strings.Contains(rec.Body.String(), errInvalidCredentials) {
+			t.Errorf("expected 401 %q, got %d: %s", errInvalidCredentials, rec.Code, rec.Body.String())
+		}
+
+		rec = do(http.MethodPost, "/api/v1/auth/totp", "alice", "", nil)
+		if rec.Code != http.StatusConflict {
+			t.Errorf("expected 409 setting up TOTP twice, got %d", rec.Code)
+		}
+	})
+}
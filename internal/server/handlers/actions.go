@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	errActionNotAllowed = "Only admins and the users an action lists can run it"
+	errUnknownAction    = "Actions must be configured on the server"
+)
+
+// checkActions makes sure a switch only refers to actions configured on the server that the caller is
+// allowed to run. It sends the error response itself and reports whether the request can continue.
+func (s *Switch) checkActions(w http.ResponseWriter, r *http.Request, sw api.Switch) bool {
+	if sw.Actions == nil {
+		return true
+	}
+
+	userID := middleware.GetUserIDFromContext(r)
+	admin := middleware.IsAdmin(r)
+
+	for _, name := range *sw.Actions {
+		action, ok := s.Actions[name]
+		if !ok {
+			s.sendError(w, http.StatusBadRequest, errUnknownAction, nil)
+			return false
+		}
+
+		if !action.Allows(userID, admin) {
+			s.sendError(w, http.StatusForbidden, errActionNotAllowed, nil)
+			return false
+		}
+	}
+
+	return true
+}
+
+// DeliveriesHandleFunc returns a page of the results of actions run for a switch, newest first.
+func (s *Switch) DeliveriesHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID := middleware.GetUserIDFromContext(r)
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errInvalidSwitchID, err)
+		return
+	}
+
+	limit, offset, errMsg, err := parsePagination(r)
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errMsg, err)
+		return
+	}
+
+	_, err = s.Store.GetByID(userID, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.sendError(w, http.StatusNotFound, errSwitchNotFound, err)
+			return
+		}
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	deliveries, err := s.Store.GetDeliveries(userID, id, limit, offset)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(deliveries)
+}
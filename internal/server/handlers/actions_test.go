@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/hooks"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestSwitchActions(t *testing.T) {
+	s, store := setupTestHandler(t)
+	s.Actions = map[string]hooks.Action{
+		"shutdown": {Command: "/sbin/poweroff"},
+		"wipe":     {Command: "/usr/local/bin/wipe", Users: []string{"bob"}},
+	}
+	mw := middleware.SwitchValidator(validator.New())
+
+	r := chi.NewRouter()
+	r.With(mw).Post("/api/v1/switch", s.PostHandleFunc)
+	r.With(mw).Put("/api/v1/switch/{id}", s.PutByIDHandleFunc)
+	r.Get("/api/v1/switch/{id}/deliveries", s.DeliveriesHandleFunc)
+
+	do := func(userID, method, path string, body any) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			_ = json.NewEncoder(&buf).Encode(body)
+		}
+		req := httptest.NewRequest(method, path, &buf)
+		ctx := middleware.WithUserID(req.Context(), userID)
+		if userID == "admin" {
+			ctx = middleware.WithRole(ctx, api.RoleAdmin)
+		}
+		req = req.WithContext(ctx)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	payload := func(actions *[]string) api.Switch {
+		return api.Switch{
+			Message:         "Actions",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "24h",
+			Actions:         actions,
+		}
+	}
+
+	created := api.Switch{}
+
+	t.Run("configured actions are saved", func(t *testing.T) {
+		rec := do("admin", http.MethodPost, "/api/v1/switch", payload(&[]string{"wipe"}))
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		_ = json.NewDecoder(rec.Body).Decode(&created)
+		if created.Actions == nil || (*created.Actions)[0] != "wipe" {
+			t.Errorf("expected the action in the response, got %v", created.Actions)
+		}
+	})
+
+	t.Run("unknown actions are rejected", func(t *testing.T) {
+		rec := do("admin", http.MethodPost, "/api/v1/switch", payload(&[]string{"rm -rf /"}))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("users can only add the actions that list them", func(t *testing.T) {
+		rec := do("bob", http.MethodPost, "/api/v1/switch", payload(&[]string{"wipe"}))
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		rec = do("bob", http.MethodPost, "/api/v1/switch", payload(&[]string{"shutdown"}))
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("expected 403 for an action that doesn't list the user, got %d", rec.Code)
+		}
+
+		rec = do("mallory", http.MethodPost, "/api/v1/switch", payload(&[]string{"wipe"}))
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("expected 403 for another user, got %d", rec.Code)
+		}
+	})
+
+	t.Run("actions are kept when not sent", func(t *testing.T) {
+		rec := do("admin", http.MethodPut, fmt.Sprintf("/api/v1/switch/%d", *created.Id), payload(nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		updated := api.Switch{}
+		_ = json.NewDecoder(rec.Body).Decode(&updated)
+		if updated.Actions == nil || (*updated.Actions)[0] != "wipe" {
+			t.Errorf("expected the action to be kept, got %v", updated.Actions)
+		}
+	})
+
+	t.Run("deliveries are listed for the owner", func(t *testing.T) {
+		_, err := store.CreateDelivery("admin", api.Delivery{
+			SwitchId:  *created.Id,
+			CreatedAt: time.Now().Unix(),
+			Kind:      api.DeliveryKindAction,
+			Target:    "wipe",
+			Success:   true,
+		})
+		if err != nil {
+			t.Fatalf("failed to create delivery: %v", err)
+		}
+
+		rec := do("admin", http.MethodGet, fmt.Sprintf("/api/v1/switch/%d/deliveries", *created.Id), nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+
+		deliveries := []api.Delivery{}
+		_ = json.NewDecoder(rec.Body).Decode(&deliveries)
+		if len(deliveries) != 1 || deliveries[0].Target != "wipe" {
+			t.Errorf("unexpected deliveries %+v", deliveries)
+		}
+
+		rec = do("mallory", http.MethodGet, fmt.Sprintf("/api/v1/switch/%d/deliveries", *created.Id), nil)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404 for another user, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,331 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/database"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	errInvalidRole   = "Role must be admin or user"
+	errOwnRole       = "Admins can't remove their own admin role"
+	errQuotaReached  = "Switch quota reached"
+	errInvalidUserID = "Invalid user ID"
+)
+
+var adminValidator = validator.New()
+
+// AdminSwitchesHandleFunc lists the switches of every user, or of the user given by the userId query
+// parameter. Only metadata is listed, never switch content.
+func (s *Switch) AdminSwitchesHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	limit, offset, msg, err := parsePagination(r)
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, msg, err)
+		return
+	}
+
+	switches, err := s.Store.GetAdminSwitches(r.URL.Query().Get("userId"), limit, offset)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(switches)
+}
+
+// AdminDisableHandleFunc disables a switch of any user. Protection is bypassed, since the owner of an
+// abusive switch would otherwise have to approve disabling it.
+func (s *Switch) AdminDisableHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID := middleware.GetUserIDFromContext(r)
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errInvalidSwitchID, err)
+		return
+	}
+
+	req := api.AdminDisable{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.sendError(w, http.StatusBadRequest, errInvalidJSON, err)
+		return
+	}
+
+	if !s.validateAdminRequest(w, req) {
+		return
+	}
+
+	sw, err := s.Store.DisableSwitch(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.sendError(w, http.StatusNotFound, errSwitchNotFound, err)
+			return
+		}
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	detail := "Disabled by an admin"
+	if req.Reason != nil && *req.Reason != "" {
+		detail += ": " + *req.Reason
+	}
+	s.audit(sw.UserId, id, userID, api.AuditActionAdminDisabled, detail)
+
+	s.Logger.Info("Switch disabled by an admin", "id", id, "owner", sw.UserId, "admin", userID)
+
+	statusDisabled := api.SwitchStatusDisabled
+	s.Events.Publish(api.EventTypeDisabled, api.Switch{Id: &sw.Id, UserId: &sw.UserId, Status: &statusDisabled})
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(sw)
+}
+
+// AdminStatsHandleFunc returns usage statistics of the server.
+func (s *Switch) AdminStatsHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	stats, err := s.Store.GetAdminStats(time.Now().Add(-24 * time.Hour).Unix())
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(stats)
+}
+
+// AdminUsersHandleFunc lists users with their assigned role and switch quota.
+func (s *Switch) AdminUsersHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	summaries, err := s.Store.GetUserSummaries()
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	users := []api.AdminUser{}
+	for _, summary := range summaries {
+		users = append(users, s.adminUser(summary))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(users)
+}
+
+// AdminRoleHandleFunc assigns a role to a user.
+func (s *Switch) AdminRoleHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID := middleware.GetUserIDFromContext(r)
+
+	targetID, ok := s.adminTargetUser(w, r)
+	if !ok {
+		return
+	}
+
+	req := api.RoleUpdate{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errInvalidJSON, err)
+		return
+	}
+
+	if req.Role != api.RoleAdmin && req.Role != api.RoleUser {
+		s.sendError(w, http.StatusBadRequest, errInvalidRole, nil)
+		return
+	}
+
+	// An admin demoting themselves could leave nobody able to assign roles
+	if targetID == userID && req.Role != api.RoleAdmin {
+		s.sendError(w, http.StatusBadRequest, errOwnRole, nil)
+		return
+	}
+
+	err = s.Store.SetUserRole(targetID, req.Role)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	s.Logger.Info("User role assigned", "user", targetID, "role", req.Role, "admin", userID)
+
+	s.sendAdminUser(w, targetID)
+}
+
+// AdminQuotaHandleFunc overrides the server's default switch quota for a user.
+func (s *Switch) AdminQuotaHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	targetID, ok := s.adminTargetUser(w, r)
+	if !ok {
+		return
+	}
+
+	req := api.QuotaUpdate{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errInvalidJSON, err)
+		return
+	}
+
+	if !s.validateAdminRequest(w, req) {
+		return
+	}
+
+	err = s.Store.SetUserQuota(targetID, &req.MaxSwitches)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	s.sendAdminUser(w, targetID)
+}
+
+// AdminDeleteQuotaHandleFunc restores the server's default switch quota for a user.
+func (s *Switch) AdminDeleteQuotaHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	targetID, ok := s.adminTargetUser(w, r)
+	if !ok {
+		return
+	}
+
+	err := s.Store.SetUserQuota(targetID, nil)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	s.sendAdminUser(w, targetID)
+}
+
+// checkQuota rejects creating a switch once the user owns as many as their quota allows. It returns false
+// after sending the error.
+func (s *Switch) checkQuota(w http.ResponseWriter, userID string) bool {
+	settings, err := s.Store.GetUserSettings(userID)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return false
+	}
+
+	maxSwitches, _ := s.maxSwitches(settings)
+	if maxSwitches == 0 {
+		return true
+	}
+
+	count, err := s.Store.CountSwitches(userID)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return false
+	}
+
+	if count >= maxSwitches {
+		s.sendError(w, http.StatusForbidden, errQuotaReached, fmt.Errorf("user owns %d of %d switches", count, maxSwitches))
+		return false
+	}
+
+	return true
+}
+
+// maxSwitches returns the switch quota of a user and whether it is the server's default.
+func (s *Switch) maxSwitches(settings database.UserSettings) (int, bool) {
+	if settings.MaxSwitches != nil {
+		return *settings.MaxSwitches, false
+	}
+	return s.MaxSwitches, true
+}
+
+// adminUser converts a user summary to what admins are shown.
+func (s *Switch) adminUser(summary database.UserSummary) api.AdminUser {
+	role := summary.Role
+	if role == "" {
+		role = api.RoleUser
+	}
+
+	maxSwitches, defaultQuota := s.maxSwitches(summary.UserSettings)
+
+	return api.AdminUser{
+		UserId:       summary.UserID,
+		Role:         role,
+		SwitchCount:  summary.SwitchCount,
+		MaxSwitches:  maxSwitches,
+		DefaultQuota: defaultQuota,
+		LocalAccount: &summary.LocalAccount,
+	}
+}
+
+// sendAdminUser responds with a user as admins see them after changing their settings.
+func (s *Switch) sendAdminUser(w http.ResponseWriter, userID string) {
+	settings, err := s.Store.GetUserSettings(userID)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	count, err := s.Store.CountSwitches(userID)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	_, err = s.Store.GetUser(userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(s.adminUser(database.UserSummary{
+		UserSettings: settings,
+		SwitchCount:  count,
+		LocalAccount: err == nil,
+	}))
+}
+
+// adminTargetUser reads the user an admin request is about from the URL.
+func (s *Switch) adminTargetUser(w http.ResponseWriter, r *http.Request) (string, bool) {
+	userID := strings.TrimSpace(chi.URLParam(r, "userId"))
+	if userID == "" {
+		s.sendError(w, http.StatusBadRequest, errInvalidUserID, nil)
+		return "", false
+	}
+	return userID, true
+}
+
+// validateAdminRequest checks the validation tags of an admin request body.
+func (s *Switch) validateAdminRequest(w http.ResponseWriter, req any) bool {
+	err := adminValidator.Struct(req)
+	if err == nil {
+		return true
+	}
+
+	fields := []string{}
+
+	var ve validator.ValidationErrors
+	if errors.As(err, &ve) {
+		for _, fe := range ve {
+			fields = append(fields, fmt.Sprintf("field '%s' failed on validation: %s", fe.Field(), fe.Tag()))
+		}
+	}
+
+	s.sendError(w, http.StatusBadRequest, "Validation failed: "+strings.Join(fields, ", "), err)
+	return false
+}
@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestAdmin(t *testing.T) {
+	s, store := setupTestHandler(t)
+
+	r := chi.NewRouter()
+	r.Get("/api/v1/admin/switches", s.AdminSwitchesHandleFunc)
+	r.Post("/api/v1/admin/switches/{id}/disable", s.AdminDisableHandleFunc)
+	r.Get("/api/v1/admin/stats", s.AdminStatsHandleFunc)
+	r.Get("/api/v1/admin/users", s.AdminUsersHandleFunc)
+	r.Put("/api/v1/admin/users/{userId}/role", s.AdminRoleHandleFunc)
+	r.Put("/api/v1/admin/users/{userId}/quota", s.AdminQuotaHandleFunc)
+	r.Delete("/api/v1/admin/users/{userId}/quota", s.AdminDeleteQuotaHandleFunc)
+
+	do := func(method, path string, body any) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			_ = json.NewEncoder(&buf).Encode(body)
+		}
+		req := httptest.NewRequest(method, path, &buf)
+		req = req.WithContext(middleware.WithUserID(req.Context(), "root"))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	protected, err := store.Create(api.Switch{
+		UserId:          ptr("alice"),
+		Message:         "Secret Message",
+		Notifiers:       []string{"logger://"},
+		CheckInInterval: "24h",
+		Status:          &statusActive,
+		Protected:       ptr(true),
+		Approvers:       &[]string{"bob"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("switches of every user are listed without their content", func(t *testing.T) {
+		rec := do(http.MethodGet, "/api/v1/admin/switches", nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if strings.Contains(rec.Body.String(), "Secret Message") || strings.Contains(rec.Body.String(), "logger://") {
+			t.Errorf("expected switch content to be left out, got %s", rec.Body.String())
+		}
+
+		switches := []api.AdminSwitch{}
+		_ = json.NewDecoder(rec.Body).Decode(&switches)
+		if len(switches) != 1 || switches[0].UserId != "alice" {
+			t.Errorf("unexpected switches %+v", switches)
+		}
+
+		rec = do(http.MethodGet, "/api/v1/admin/switches?userId=bob", nil)
+		if rec.Code != http.StatusOK || strings.TrimSpace(rec.Body.String()) != "[]" {
+			t.Errorf("expected no switches for bob, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		rec = do(http.MethodGet, "/api/v1/admin/switches?limit=0", nil)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("protected switches are disabled without approval", func(t *testing.T) {
+		rec := do(http.MethodPost, fmt.Sprintf("/api/v1/admin/switches/%d/disable", *protected.Id), api.AdminDisable{Reason: ptr("Sends spam")})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		sw, _ := store.GetByID("alice", *protected.Id)
+		if *sw.Status != api.SwitchStatusDisabled {
+			t.Errorf("expected the switch to be disabled, got %s", *sw.Status)
+		}
+
+		changes, _ := store.GetPendingChanges("alice")
+		if len(changes) != 0 {
+			t.Errorf("expected no change to be requested, got %+v", changes)
+		}
+
+		events, _ := store.GetAuditEvents("alice", *protected.Id, 10, 0)
+		if len(events) != 1 || events[0].Action != api.AuditActionAdminDisabled || events[0].Actor != "root" ||
+			events[0].Detail == nil || !strings.Contains(*events[0].Detail, "Sends spam") {
+			t.Errorf("expected the owner's audit trail to record the admin, got %+v", events)
+		}
+	})
+
+	t.Run("disabling a missing switch fails", func(t *testing.T) {
+		rec := do(http.MethodPost, "/api/v1/admin/switches/999/disable", nil)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", rec.Code)
+		}
+	})
+
+	t.Run("stats count every user's switches", func(t *testing.T) {
+		rec := do(http.MethodGet, "/api/v1/admin/stats", nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+
+		stats := api.AdminStats{}
+		_ = json.NewDecoder(rec.Body).Decode(&stats)
+		if stats.Users != 1 || stats.Switches != 1 || stats.SwitchesByStatus["disabled"] != 1 {
+			t.Errorf("unexpected stats %+v", stats)
+		}
+	})
+
+	t.Run("roles are assigned", func(t *testing.T) {
+		rec := do(http.MethodPut, "/api/v1/admin/users/bob/role", api.RoleUpdate{Role: api.RoleAdmin})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		user := api.AdminUser{}
+		_ = json.NewDecoder(rec.Body).Decode(&user)
+		if user.UserId != "bob" || user.Role != api.RoleAdmin {
+			t.Errorf("unexpected user %+v", user)
+		}
+
+		rec = do(http.MethodPut, "/api/v1/admin/users/bob/role", api.RoleUpdate{Role: "owner"})
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400 for an unknown role, got %d", rec.Code)
+		}
+	})
+
+	t.Run("admins can't demote themselves", func(t *testing.T) {
+		rec := do(http.MethodPut, "/api/v1/admin/users/root/role", api.RoleUpdate{Role: api.RoleUser})
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("quotas override the default until reset", func(t *testing.T) {
+		s.MaxSwitches = 10
+
+		rec := do(http.MethodPut, "/api/v1/admin/users/alice/quota", api.QuotaUpdate{MaxSwitches: 1})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		user := api.AdminUser{}
+		_ = json.NewDecoder(rec.Body).Decode(&user)
+		if user.MaxSwitches != 1 || user.DefaultQuota || user.SwitchCount != 1 {
+			t.Errorf("unexpected user %+v", user)
+		}
+
+		rec = do(http.MethodPut, "/api/v1/admin/users/alice/quota", api.QuotaUpdate{MaxSwitches: -1})
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400 for a negative quota, got %d", rec.Code)
+		}
+
+		rec = do(http.MethodDelete, "/api/v1/admin/users/alice/quota", nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+
+		user = api.AdminUser{}
+		_ = json.NewDecoder(rec.Body).Decode(&user)
+		if user.MaxSwitches != 10 || !user.DefaultQuota {
+			t.Errorf("expected the default quota, got %+v", user)
+		}
+	})
+
+	t.Run("users are listed with their settings", func(t *testing.T) {
+		rec := do(http.MethodGet, "/api/v1/admin/users", nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+
+		users := []api.AdminUser{}
+		_ = json.NewDecoder(rec.Body).Decode(&users)
+		if len(users) != 2 || users[0].UserId != "alice" || users[0].Role != api.RoleUser || users[1].Role != api.RoleAdmin {
+			t.Errorf("unexpected users %+v", users)
+		}
+	})
+}
+
+func TestPostHandleFuncQuota(t *testing.T) {
+	s, store := setupTestHandler(t)
+	handler := middleware.SwitchValidator(validator.New())(http.HandlerFunc(s.PostHandleFunc))
+
+	create := func(userID string) int {
+		body, _ := json.Marshal(api.Switch{Message: "m", Notifiers: []string{"logger://"}, CheckInInterval: "24h"})
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/switch", bytes.NewBuffer(body))
+		req = req.WithContext(middleware.WithUserID(req.Context(), userID))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	s.MaxSwitches = 1
+
+	if code := create("alice"); code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", code)
+	}
+	if code := create("alice"); code != http.StatusForbidden {
+		t.Errorf("expected the default quota to be enforced, got %d", code)
+	}
+	if code := create("bob"); code != http.StatusCreated {
+		t.Errorf("expected quotas to be per user, got %d", code)
+	}
+
+	err := store.SetUserQuota("alice", ptr(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code := create("alice"); code != http.StatusCreated {
+		t.Errorf("expected an unlimited quota to override the default, got %d", code)
+	}
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/secrets"
+	"github.com/go-chi/chi/v5"
+)
+
+// defaultAlertMatchers match the always firing alert of the Prometheus Operator and kube-prometheus.
+var defaultAlertMatchers = map[string]string{"alertname": "Watchdog"}
+
+// AlertmanagerHandleFunc receives Alertmanager webhook notifications and checks in to the switch with the
+// check-in token in the URL when a matching alert is firing. An always firing alert such as Watchdog then
+// acts as a heartbeat, and the switch triggers once alerting stops delivering it.
+func (s *Switch) AlertmanagerHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	notification := api.AlertmanagerWebhook{}
+
+	err := json.NewDecoder(r.Body).Decode(&notification)
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errInvalidJSON, err)
+		return
+	}
+
+	sw, err := s.Store.GetByCheckInToken(secrets.HashToken(chi.URLParam(r, "token")))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.sendError(w, http.StatusNotFound, errSwitchNotFound, err)
+			return
+		}
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	result := api.AlertmanagerResult{
+		CheckedIn: firingAlert(notification, sw),
+	}
+
+	if result.CheckedIn {
+		source := checkInSource{
+			userID:    switchOwner(sw),
+			method:    api.CheckInMethodAlertmanager,
+			ipAddress: clientIP(r),
+			userAgent: userAgent(r),
+		}
+
+		_, err = s.checkIn(source, *sw.Id, sw)
+		if err != nil {
+			// Retrying won't help a switch that needs a passkey, so don't report a server error
+			if errors.Is(err, errMissingPasskey) {
+				s.sendError(w, http.StatusForbidden, errPasskeyRequired, err)
+				return
+			}
+			s.sendError(w, http.StatusInternalServerError, errFailedToReset, err)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+// firingAlert reports whether a notification holds a firing alert with every label the switch matches on.
+func firingAlert(notification api.AlertmanagerWebhook, sw api.Switch) bool {
+	matchers := defaultAlertMatchers
+	if sw.AlertMatchers != nil && len(*sw.AlertMatchers) > 0 {
+		matchers = *sw.AlertMatchers
+	}
+
+	for _, alert := range notification.Alerts {
+		if alert.Status != api.AlertStatusFiring {
+			continue
+		}
+
+		matched := true
+		for name, value := range matchers {
+			if alert.Labels[name] != value {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+
+	return false
+}
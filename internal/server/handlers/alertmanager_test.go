@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/secrets"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestAlertmanagerHandleFunc(t *testing.T) {
+	s, store := setupTestHandler(t)
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/integrations/alertmanager/{token}", s.AlertmanagerHandleFunc)
+
+	triggerAt := time.Now().Add(time.Minute).Unix()
+	created, err := store.Create(api.Switch{
+		Message:         "Alerting is down",
+		Notifiers:       []string{"logger://"},
+		CheckInInterval: "1h",
+		CheckInToken:    ptr(secrets.HashToken("watchdog-check-in-token")),
+		AlertMatchers:   &map[string]string{"alertname": "Watchdog", "cluster": "production"},
+		Status:          &statusActive,
+		TriggerAt:       &triggerAt,
+	})
+	if err != nil {
+		t.Fatalf("failed to seed switch: %v", err)
+	}
+
+	send := func(token string, notification any) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(notification)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/integrations/alertmanager/"+token, bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	alert := func(status api.AlertmanagerAlertStatus, labels map[string]string) api.AlertmanagerWebhook {
+		return api.AlertmanagerWebhook{Alerts: []api.AlertmanagerAlert{{Status: status, Labels: labels}}}
+	}
+
+	expectCheckedIn := func(t *testing.T, rec *httptest.ResponseRecorder, expected bool) {
+		t.Helper()
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+		result := api.AlertmanagerResult{}
+		_ = json.NewDecoder(rec.Body).Decode(&result)
+		if result.CheckedIn != expected {
+			t.Errorf("expected checkedIn %v, got %v", expected, result.CheckedIn)
+		}
+	}
+
+	t.Run("alerts that don't match don't check in", func(t *testing.T) {
+		expectCheckedIn(t, send("watchdog-check-in-token", alert(api.AlertStatusFiring, map[string]string{"alertname": "Watchdog", "cluster": "staging"})), false)
+		expectCheckedIn(t, send("watchdog-check-in-token", alert(api.AlertStatusResolved, map[string]string{"alertname": "Watchdog", "cluster": "production"})), false)
+
+		sw, err := store.GetByID("admin", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to get switch: %v", err)
+		}
+		if *sw.TriggerAt != triggerAt {
+			t.Error("expected the countdown to continue")
+		}
+	})
+
+	t.Run("a firing matching alert checks in", func(t *testing.T) {
+		expectCheckedIn(t, send("watchdog-check-in-token", alert(api.AlertStatusFiring, map[string]string{"alertname": "Watchdog", "cluster": "production", "severity": "none"})), true)
+
+		sw, err := store.GetByID("admin", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to get switch: %v", err)
+		}
+		if *sw.TriggerAt <= triggerAt {
+			t.Error("expected the switch to be re-armed")
+		}
+
+		checkIns, err := store.GetCheckIns("admin", *created.Id, 10, 0)
+		if err != nil {
+			t.Fatalf("failed to get check-ins: %v", err)
+		}
+		if len(checkIns) != 1 || checkIns[0].Method != api.CheckInMethodAlertmanager {
+			t.Errorf("expected one alertmanager check-in, got %+v", checkIns)
+		}
+	})
+
+	t.Run("switches that require a passkey are rejected as forbidden", func(t *testing.T) {
+		passkeyTriggerAt := time.Now().Add(time.Minute).Unix()
+		_, err := store.Create(api.Switch{
+			Message:         "Alerting is down",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "1h",
+			CheckInToken:    ptr(secrets.HashToken("passkey-check-in-token")),
+			RequirePasskey:  ptr(true),
+			Status:          &statusActive,
+			TriggerAt:       &passkeyTriggerAt,
+		})
+		if err != nil {
+			t.Fatalf("failed to seed switch: %v", err)
+		}
+
+		rec := send("passkey-check-in-token", alert(api.AlertStatusFiring, map[string]string{"alertname": "Watchdog"}))
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("unknown tokens are rejected", func(t *testing.T) {
+		rec := send("not-the-check-in-token", alert(api.AlertStatusFiring, map[string]string{"alertname": "Watchdog"}))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", rec.Code)
+		}
+	})
+
+	t.Run("invalid payloads are rejected", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/integrations/alertmanager/watchdog-check-in-token", bytes.NewBufferString("{"))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+}
+
+func TestFiringAlert(t *testing.T) {
+	watchdog := map[string]string{"alertname": "Watchdog", "severity": "none"}
+
+	tests := []struct {
+		name     string
+		matchers *map[string]string
+		alerts   []api.AlertmanagerAlert
+		expected bool
+	}{
+		{name: "watchdog by default", alerts: []api.AlertmanagerAlert{{Status: api.AlertStatusFiring, Labels: watchdog}}, expected: true},
+		{name: "resolved", alerts: []api.AlertmanagerAlert{{Status: api.AlertStatusResolved, Labels: watchdog}}, expected: false},
+		{name: "other alert", alerts: []api.AlertmanagerAlert{{Status: api.AlertStatusFiring, Labels: map[string]string{"alertname": "HighLatency"}}}, expected: false},
+		{name: "no alerts", expected: false},
+		{
+			name:     "any alert of the group",
+			alerts:   []api.AlertmanagerAlert{{Status: api.AlertStatusFiring, Labels: map[string]string{"alertname": "HighLatency"}}, {Status: api.AlertStatusFiring, Labels: watchdog}},
+			expected: true,
+		},
+		{
+			name:     "custom matchers",
+			matchers: &map[string]string{"alertname": "DeadMansSwitch"},
+			alerts:   []api.AlertmanagerAlert{{Status: api.AlertStatusFiring, Labels: watchdog}},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := firingAlert(api.AlertmanagerWebhook{Alerts: tt.alerts}, api.Switch{AlertMatchers: tt.matchers})
+			if got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/database"
+)
+
+const (
+	errChainCycle  = "Switches can't arm, trigger or require each other in a cycle"
+	errChainTarget = "Linked switches must be other switches you own"
+)
+
+// checkLinks makes sure a switch only links to other switches of the same owner and that its links don't
+// form a cycle. It sends the error response itself and reports whether the request can continue.
+// id is 0 for a new switch.
+func (s *Switch) checkLinks(w http.ResponseWriter, userID string, id int, sw api.Switch) bool {
+	targets := []int{}
+	if sw.OnTrigger != nil {
+		for _, action := range *sw.OnTrigger {
+			targets = append(targets, action.SwitchId)
+		}
+	}
+	if sw.Requires != nil {
+		targets = append(targets, *sw.Requires...)
+	}
+
+	if len(targets) == 0 {
+		return true
+	}
+
+	for _, target := range targets {
+		if target == id {
+			s.sendError(w, http.StatusBadRequest, errChainTarget, nil)
+			return false
+		}
+
+		_, err := s.Store.GetByID(userID, target)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				s.sendError(w, http.StatusBadRequest, errChainTarget, err)
+				return false
+			}
+			s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+			return false
+		}
+	}
+
+	// Nothing links to a new switch yet, so it can't be part of a cycle
+	if id == 0 {
+		return true
+	}
+
+	links, err := s.Store.GetSwitchLinks(userID)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return false
+	}
+
+	// Chain actions and requirements are separate graphs. A switch can trigger a switch that requires it
+	chains := map[int][]int{}
+	requires := map[int][]int{}
+	for _, link := range links {
+		if link.SwitchID == id {
+			continue
+		}
+		if link.Kind == database.LinkRequires {
+			requires[link.SwitchID] = append(requires[link.SwitchID], link.TargetID)
+		} else {
+			chains[link.SwitchID] = append(chains[link.SwitchID], link.TargetID)
+		}
+	}
+
+	if sw.OnTrigger != nil {
+		for _, action := range *sw.OnTrigger {
+			chains[id] = append(chains[id], action.SwitchId)
+		}
+	}
+	if sw.Requires != nil {
+		requires[id] = append(requires[id], *sw.Requires...)
+	}
+
+	if reaches(chains, id, id) || reaches(requires, id, id) {
+		s.sendError(w, http.StatusBadRequest, errChainCycle, nil)
+		return false
+	}
+
+	return true
+}
+
+// reaches reports whether target can be reached by following at least one edge from start.
+func reaches(graph map[int][]int, start, target int) bool {
+	visited := map[int]bool{}
+	stack := append([]int{}, graph[start]...)
+
+	for len(stack) > 0 {
+		next := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if next == target {
+			return true
+		}
+		if visited[next] {
+			continue
+		}
+		visited[next] = true
+
+		stack = append(stack, graph[next]...)
+	}
+
+	return false
+}
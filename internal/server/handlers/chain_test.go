@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestChainedSwitches(t *testing.T) {
+	s, store := setupTestHandler(t)
+	mw := middleware.SwitchValidator(validator.New())
+
+	r := chi.NewRouter()
+	r.With(mw).Post("/api/v1/switch", s.PostHandleFunc)
+	r.With(mw).Put("/api/v1/switch/{id}", s.PutByIDHandleFunc)
+
+	doAs := func(userID, method, path string, body any) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		_ = json.NewEncoder(&buf).Encode(body)
+		req := httptest.NewRequest(method, path, &buf)
+		req = req.WithContext(middleware.WithUserID(req.Context(), userID))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	seed := func(t *testing.T, userID string) api.Switch {
+		t.Helper()
+
+		created, err := store.Create(api.Switch{
+			UserId:          ptr(userID),
+			Message:         "Linked",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "24h",
+			Status:          &statusActive,
+		})
+		if err != nil {
+			t.Fatalf("failed to seed switch: %v", err)
+		}
+
+		return created
+	}
+
+	payload := func(onTrigger []api.ChainAction, requires []int) api.Switch {
+		return api.Switch{
+			Message:         "Linked",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "24h",
+			OnTrigger:       &onTrigger,
+			Requires:        &requires,
+		}
+	}
+
+	a := seed(t, "admin")
+	b := seed(t, "admin")
+	other := seed(t, "mallory")
+
+	t.Run("links are saved", func(t *testing.T) {
+		rec := doAs("admin", http.MethodPost, "/api/v1/switch", payload([]api.ChainAction{{SwitchId: *a.Id, Action: api.ChainActionTrigger}}, []int{*b.Id}))
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		created := api.Switch{}
+		_ = json.NewDecoder(rec.Body).Decode(&created)
+		if created.OnTrigger == nil || len(*created.OnTrigger) != 1 || created.Requires == nil || len(*created.Requires) != 1 {
+			t.Errorf("expected links in the response, got %+v %+v", created.OnTrigger, created.Requires)
+		}
+	})
+
+	t.Run("links to another user's switch are rejected", func(t *testing.T) {
+		rec := doAs("admin", http.MethodPost, "/api/v1/switch", payload(nil, []int{*other.Id}))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("links to itself are rejected", func(t *testing.T) {
+		rec := doAs("admin", http.MethodPut, fmt.Sprintf("/api/v1/switch/%d", *a.Id), payload([]api.ChainAction{{SwitchId: *a.Id, Action: api.ChainActionArm}}, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("cycles are rejected", func(t *testing.T) {
+		rec := doAs("admin", http.MethodPut, fmt.Sprintf("/api/v1/switch/%d", *a.Id), payload([]api.ChainAction{{SwitchId: *b.Id, Action: api.ChainActionArm}}, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		rec = doAs("admin", http.MethodPut, fmt.Sprintf("/api/v1/switch/%d", *b.Id), payload([]api.ChainAction{{SwitchId: *a.Id, Action: api.ChainActionTrigger}}, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+
+		// Requirements are a separate graph, so b may require the switch it's armed by
+		rec = doAs("admin", http.MethodPut, fmt.Sprintf("/api/v1/switch/%d", *b.Id), payload(nil, []int{*a.Id}))
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+}
@@ -0,0 +1,423 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/database"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+	"github.com/circa10a/dead-mans-switch/internal/server/switches"
+	"github.com/go-chi/chi/v5"
+)
+
+// Error messages
+const (
+	errApproverIsOwner = "Approvers must be other users"
+	errChangeNotFound  = "Change not found"
+	errChangePending   = "A change to this switch is already pending"
+	errChangeResolved  = "Change is no longer pending"
+	errInvalidChangeID = "Invalid change ID"
+	errNotApprover     = "Only another approver of the switch can approve this change"
+)
+
+// ChangesHandleFunc returns the pending changes the caller requested or can approve.
+func (s *Switch) ChangesHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	changes, err := s.Store.GetPendingChanges(middleware.GetUserIDFromContext(r))
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	resp := make([]api.PendingChange, len(changes))
+	for i, change := range changes {
+		resp[i] = change.PendingChange
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// ApproveChangeHandleFunc approves a pending change and applies it immediately. Only an approver of
+// the switch other than the requester can approve.
+func (s *Switch) ApproveChangeHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID := middleware.GetUserIDFromContext(r)
+
+	change, ok := s.lookupChange(w, r)
+	if !ok {
+		return
+	}
+
+	if userID == change.RequestedBy {
+		s.sendError(w, http.StatusForbidden, errNotApprover, nil)
+		return
+	}
+
+	now := time.Now().Unix()
+
+	// Resolving first lets only one approver apply the change
+	err := s.Store.ResolvePendingChange(*change.Id, api.PendingChangeStatusApplied, userID, now)
+	if err != nil {
+		s.sendResolveError(w, err)
+		return
+	}
+
+	err = switches.ApplyChange(s.Store, change)
+	if err != nil {
+		// Leave the change pending so it isn't reported as applied and can be approved again
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, errors.Join(err, s.Store.ReopenPendingChange(*change.Id)))
+		return
+	}
+
+	s.audit(change.RequestedBy, change.SwitchId, userID, api.AuditActionChangeApproved, change.Summary)
+
+	switches.PublishChange(s.Events, change)
+
+	// A deleted switch takes its audit trail with it
+	if change.Action != api.PendingChangeActionDelete {
+		s.audit(change.RequestedBy, change.SwitchId, userID, api.AuditActionChangeApplied, change.Summary)
+	}
+
+	change.Status = api.PendingChangeStatusApplied
+	change.ResolvedBy = &userID
+	change.ResolvedAt = &now
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(change.PendingChange)
+}
+
+// CancelChangeHandleFunc cancels a pending change. The requester or an approver of the switch can cancel.
+func (s *Switch) CancelChangeHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID := middleware.GetUserIDFromContext(r)
+
+	change, ok := s.lookupChange(w, r)
+	if !ok {
+		return
+	}
+
+	now := time.Now().Unix()
+
+	err := s.Store.ResolvePendingChange(*change.Id, api.PendingChangeStatusCancelled, userID, now)
+	if err != nil {
+		s.sendResolveError(w, err)
+		return
+	}
+
+	s.audit(change.RequestedBy, change.SwitchId, userID, api.AuditActionChangeCancelled, change.Summary)
+
+	change.Status = api.PendingChangeStatusCancelled
+	change.ResolvedBy = &userID
+	change.ResolvedAt = &now
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(change.PendingChange)
+}
+
+// requestChange holds back a sensitive change to a protected switch until it is approved or its delay passes.
+// sw is the decrypted switch and payload what the change needs to apply, if anything.
+func (s *Switch) requestChange(w http.ResponseWriter, sw api.Switch, action api.PendingChangeAction, summary string, payload *string) {
+	owner := *sw.UserId
+
+	pending, err := s.Store.GetPendingChanges(owner)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	for _, change := range pending {
+		if change.SwitchId == *sw.Id {
+			s.sendError(w, http.StatusConflict, errChangePending, nil)
+			return
+		}
+	}
+
+	now := time.Now()
+	change := database.PendingChange{
+		PendingChange: api.PendingChange{
+			SwitchId:    *sw.Id,
+			RequestedBy: owner,
+			Action:      action,
+			Status:      api.PendingChangeStatusPending,
+			Summary:     summary,
+			CreatedAt:   now.Unix(),
+		},
+		Payload: payload,
+	}
+
+	// Without approvers the change applies on its own once the delay passes
+	if !hasApprovers(sw) {
+		applyAt := now.Add(switches.ChangeDelay(sw)).Unix()
+		change.ApplyAt = &applyAt
+	}
+
+	created, err := s.Store.CreatePendingChange(change)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	s.audit(owner, *sw.Id, owner, api.AuditActionChangeRequested, summary)
+
+	if created.ApplyAt != nil && s.ChangeRequested != nil {
+		go s.ChangeRequested(sw, created.PendingChange)
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(created.PendingChange)
+}
+
+// requestUpdate holds back an update to a protected switch. sw is the decrypted switch and updated the
+// switch the update would write.
+func (s *Switch) requestUpdate(w http.ResponseWriter, sw api.Switch, summary string, updated api.Switch) {
+	// The pending switch is kept as private as the switch itself
+	err := s.Store.EncryptSwitch(&updated)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	payload, err := json.Marshal(updated)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	encoded := string(payload)
+	s.requestChange(w, sw, api.PendingChangeActionUpdate, summary, &encoded)
+}
+
+// requestProtectedChange holds back disabling, deleting or pausing a protected switch read from the store.
+func (s *Switch) requestProtectedChange(w http.ResponseWriter, sw api.Switch, action api.PendingChangeAction, summary string, payload *string) {
+	err := s.Store.DecryptSwitch(&sw)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	s.requestChange(w, sw, action, summary, payload)
+}
+
+// lookupChange finds a pending change for its requester or one of the switch's approvers. It sends the
+// error response itself and reports whether the request can continue.
+func (s *Switch) lookupChange(w http.ResponseWriter, r *http.Request) (database.PendingChange, bool) {
+	userID := middleware.GetUserIDFromContext(r)
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errInvalidChangeID, err)
+		return database.PendingChange{}, false
+	}
+
+	change, err := s.Store.GetPendingChange(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.sendError(w, http.StatusNotFound, errChangeNotFound, err)
+			return database.PendingChange{}, false
+		}
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return database.PendingChange{}, false
+	}
+
+	sw, err := s.Store.GetByID(change.RequestedBy, change.SwitchId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.sendError(w, http.StatusNotFound, errChangeNotFound, err)
+			return database.PendingChange{}, false
+		}
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return database.PendingChange{}, false
+	}
+
+	// Changes are hidden from everyone but the requester and the approvers
+	if userID != change.RequestedBy && !isApprover(sw, userID) {
+		s.sendError(w, http.StatusNotFound, errChangeNotFound, nil)
+		return database.PendingChange{}, false
+	}
+
+	if change.Status != api.PendingChangeStatusPending {
+		s.sendError(w, http.StatusConflict, errChangeResolved, nil)
+		return database.PendingChange{}, false
+	}
+
+	return change, true
+}
+
+// sendResolveError reports a change that was resolved by a concurrent request as no longer pending.
+func (s *Switch) sendResolveError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		s.sendError(w, http.StatusConflict, errChangeResolved, err)
+		return
+	}
+	s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+}
+
+// sensitiveChanges describes the parts of an update to a protected switch that need approval or a delay.
+func sensitiveChanges(previous, updated api.Switch) []string {
+	changes := []string{}
+
+	if updated.Status != nil && *updated.Status == api.SwitchStatusDisabled && (previous.Status == nil || *previous.Status != api.SwitchStatusDisabled) {
+		changes = append(changes, "disable the switch")
+	}
+
+	previousInterval, err := time.ParseDuration(previous.CheckInInterval)
+	if err == nil {
+		interval, err := time.ParseDuration(updated.CheckInInterval)
+		if err == nil && interval > previousInterval {
+			changes = append(changes, fmt.Sprintf("lengthen the check-in interval from %s to %s", previous.CheckInInterval, updated.CheckInInterval))
+		}
+	}
+
+	removed := 0
+	for _, notifier := range previous.Notifiers {
+		if !slices.Contains(updated.Notifiers, notifier) {
+			removed++
+		}
+	}
+	if removed > 0 {
+		changes = append(changes, fmt.Sprintf("remove %d notifiers", removed))
+	}
+
+	if !switches.IsProtected(updated) {
+		changes = append(changes, "turn off protection")
+	}
+
+	if requiresPasskey(previous) && !requiresPasskey(updated) {
+		changes = append(changes, "stop requiring a passkey to check in")
+	}
+
+	if !slices.Equal(approvers(previous), approvers(updated)) {
+		changes = append(changes, "change the approvers")
+	}
+
+	if switches.ChangeDelay(updated) < switches.ChangeDelay(previous) {
+		changes = append(changes, "shorten the change delay")
+	}
+
+	removedActions := 0
+	for _, action := range deref(previous.OnTrigger) {
+		if !slices.Contains(deref(updated.OnTrigger), action) {
+			removedActions++
+		}
+	}
+	if removedActions > 0 {
+		changes = append(changes, fmt.Sprintf("remove %d chain actions", removedActions))
+	}
+
+	removedHostActions := 0
+	for _, name := range deref(previous.Actions) {
+		if !slices.Contains(deref(updated.Actions), name) {
+			removedHostActions++
+		}
+	}
+	if removedHostActions > 0 {
+		changes = append(changes, fmt.Sprintf("remove %d actions", removedHostActions))
+	}
+
+	removedWebhooks := 0
+	for _, hook := range deref(previous.Webhooks) {
+		if !slices.ContainsFunc(deref(updated.Webhooks), func(h api.Webhook) bool { return h.Url == hook.Url }) {
+			removedWebhooks++
+		}
+	}
+	if removedWebhooks > 0 {
+		changes = append(changes, fmt.Sprintf("remove %d webhooks", removedWebhooks))
+	}
+
+	removedMembers := 0
+	for _, member := range deref(previous.Members) {
+		if !slices.ContainsFunc(deref(updated.Members), func(m api.SwitchMember) bool { return m.UserId == member.UserId }) {
+			removedMembers++
+		}
+	}
+	if removedMembers > 0 {
+		changes = append(changes, fmt.Sprintf("remove %d members", removedMembers))
+	}
+
+	removedContacts := 0
+	for _, contact := range deref(previous.TrustedContacts) {
+		if !slices.ContainsFunc(deref(updated.TrustedContacts), func(c api.TrustedContact) bool { return c.Name == contact.Name }) {
+			removedContacts++
+		}
+	}
+	if removedContacts > 0 {
+		changes = append(changes, fmt.Sprintf("remove %d trusted contacts", removedContacts))
+	}
+
+	// Duress codes are hashed, so any new code counts as a change
+	if previous.DuressCode != nil {
+		switch {
+		case updated.DuressCode == nil:
+			changes = append(changes, "remove the duress code")
+		case *updated.DuressCode != *previous.DuressCode:
+			changes = append(changes, "change the duress code")
+		}
+	}
+
+	removedDuressNotifiers := 0
+	for _, notifier := range deref(previous.DuressNotifiers) {
+		if !slices.Contains(deref(updated.DuressNotifiers), notifier) {
+			removedDuressNotifiers++
+		}
+	}
+	if removedDuressNotifiers > 0 {
+		changes = append(changes, fmt.Sprintf("remove %d duress notifiers", removedDuressNotifiers))
+	}
+
+	for _, id := range deref(updated.Requires) {
+		if !slices.Contains(deref(previous.Requires), id) {
+			changes = append(changes, "add required switches")
+			break
+		}
+	}
+
+	return changes
+}
+
+// deref returns the slice a pointer refers to, or nil.
+func deref[T any](values *[]T) []T {
+	if values == nil {
+		return nil
+	}
+	return *values
+}
+
+// changeSummary joins the descriptions of sensitive changes into a sentence.
+func changeSummary(changes []string) string {
+	summary := strings.Join(changes, ", ")
+	return strings.ToUpper(summary[:1]) + summary[1:]
+}
+
+// hasApprovers reports whether changes to a protected switch need approval rather than a delay.
+func hasApprovers(sw api.Switch) bool {
+	return len(approvers(sw)) > 0
+}
+
+// isApprover reports whether a user can approve changes to a switch.
+func isApprover(sw api.Switch, userID string) bool {
+	return slices.Contains(approvers(sw), userID)
+}
+
+// approvers returns the sorted approvers of a switch.
+func approvers(sw api.Switch) []string {
+	if sw.Approvers == nil {
+		return nil
+	}
+
+	sorted := slices.Clone(*sw.Approvers)
+	slices.Sort(sorted)
+
+	return sorted
+}
@@ -0,0 +1,408 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/database"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+	"github.com/circa10a/dead-mans-switch/internal/server/switches"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestProtectedSwitchChanges(t *testing.T) {
+	s, store := setupTestHandler(t)
+	mw := middleware.SwitchValidator(validator.New())
+
+	requested := make(chan api.PendingChange, 1)
+	s.ChangeRequested = func(_ api.Switch, change api.PendingChange) {
+		requested <- change
+	}
+
+	r := chi.NewRouter()
+	r.With(mw).Post("/api/v1/switch", s.PostHandleFunc)
+	r.With(mw).Put("/api/v1/switch/{id}", s.PutByIDHandleFunc)
+	r.Delete("/api/v1/switch/{id}", s.DeleteHandleFunc)
+	r.Post("/api/v1/switch/{id}/disable", s.DisableHandleFunc)
+	r.Post("/api/v1/switch/{id}/pause", s.PauseHandleFunc)
+	r.Post("/api/v1/switch/pause", s.PauseAllHandleFunc)
+	r.Get("/api/v1/changes", s.ChangesHandleFunc)
+	r.Post("/api/v1/changes/{id}/approve", s.ApproveChangeHandleFunc)
+	r.Post("/api/v1/changes/{id}/cancel", s.CancelChangeHandleFunc)
+
+	doAs := func(userID, method, path string, body any) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			_ = json.NewEncoder(&buf).Encode(body)
+		}
+		req := httptest.NewRequest(method, path, &buf)
+		req = req.WithContext(middleware.WithUserID(req.Context(), userID))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	seed := func(t *testing.T, approvers *[]string) api.Switch {
+		t.Helper()
+
+		created, err := store.Create(api.Switch{
+			UserId:          ptr("admin"),
+			Message:         "Protected",
+			Notifiers:       []string{"logger://", "logger://backup"},
+			CheckInInterval: "24h",
+			Protected:       ptr(true),
+			Approvers:       approvers,
+			Status:          &statusActive,
+		})
+		if err != nil {
+			t.Fatalf("failed to seed switch: %v", err)
+		}
+
+		return created
+	}
+
+	decodeChange := func(t *testing.T, rec *httptest.ResponseRecorder) api.PendingChange {
+		t.Helper()
+
+		change := api.PendingChange{}
+		err := json.NewDecoder(rec.Body).Decode(&change)
+		if err != nil {
+			t.Fatalf("failed to decode change: %v", err)
+		}
+
+		return change
+	}
+
+	t.Run("owner cannot be an approver", func(t *testing.T) {
+		rec := doAs("admin", http.MethodPost, "/api/v1/switch", api.Switch{
+			Message:         "Protected",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "24h",
+			Protected:       ptr(true),
+			Approvers:       &[]string{"admin"},
+		})
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("disable waits for an approver", func(t *testing.T) {
+		created := seed(t, &[]string{"bob"})
+		path := fmt.Sprintf("/api/v1/switch/%d/disable", *created.Id)
+
+		rec := doAs("admin", http.MethodPost, path, nil)
+		if rec.Code != http.StatusAccepted {
+			t.Fatalf("expected 202, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		change := decodeChange(t, rec)
+		if change.Action != api.PendingChangeActionDisable || change.ApplyAt != nil {
+			t.Errorf("expected a disable change waiting for approval, got %+v", change)
+		}
+
+		rec = doAs("admin", http.MethodPost, path, nil)
+		if rec.Code != http.StatusConflict {
+			t.Errorf("expected 409 while a change is pending, got %d", rec.Code)
+		}
+
+		rec = doAs("bob", http.MethodGet, "/api/v1/changes", nil)
+		changes := []api.PendingChange{}
+		_ = json.NewDecoder(rec.Body).Decode(&changes)
+		if len(changes) != 1 || *changes[0].Id != *change.Id {
+			t.Errorf("expected the approver to see the change, got %+v", changes)
+		}
+
+		approvePath := fmt.Sprintf("/api/v1/changes/%d/approve", *change.Id)
+
+		rec = doAs("admin", http.MethodPost, approvePath, nil)
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("expected 403 for the requester, got %d", rec.Code)
+		}
+
+		rec = doAs("mallory", http.MethodPost, approvePath, nil)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404 for other users, got %d", rec.Code)
+		}
+
+		rec = doAs("bob", http.MethodPost, approvePath, nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		stored, err := store.GetByID("admin", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to get switch: %v", err)
+		}
+		if *stored.Status != api.SwitchStatusDisabled {
+			t.Errorf("expected switch to be disabled, got %s", *stored.Status)
+		}
+
+		rec = doAs("bob", http.MethodPost, approvePath, nil)
+		if rec.Code != http.StatusConflict {
+			t.Errorf("expected 409 for a resolved change, got %d", rec.Code)
+		}
+	})
+
+	t.Run("approved changes that fail to apply stay pending", func(t *testing.T) {
+		created := seed(t, &[]string{"bob"})
+
+		rec := doAs("admin", http.MethodPost, fmt.Sprintf("/api/v1/switch/%d/disable", *created.Id), nil)
+		if rec.Code != http.StatusAccepted {
+			t.Fatalf("expected 202, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		change := decodeChange(t, rec)
+		approvePath := fmt.Sprintf("/api/v1/changes/%d/approve", *change.Id)
+
+		s.Store = failingUpdateStore{Store: store}
+		rec = doAs("bob", http.MethodPost, approvePath, nil)
+		s.Store = store
+		if rec.Code != http.StatusInternalServerError {
+			t.Fatalf("expected 500, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		pending, err := store.GetPendingChange(*change.Id)
+		if err != nil {
+			t.Fatalf("failed to get change: %v", err)
+		}
+		if pending.Status != api.PendingChangeStatusPending {
+			t.Errorf("expected the change to stay pending, got %s", pending.Status)
+		}
+
+		rec = doAs("bob", http.MethodPost, approvePath, nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected the change to be approved again, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		stored, err := store.GetByID("admin", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to get switch: %v", err)
+		}
+		if *stored.Status != api.SwitchStatusDisabled {
+			t.Errorf("expected switch to be disabled, got %s", *stored.Status)
+		}
+	})
+
+	t.Run("updates can't defuse the switch by changing its status", func(t *testing.T) {
+		created := seed(t, &[]string{"bob"})
+		path := fmt.Sprintf("/api/v1/switch/%d", *created.Id)
+
+		for _, status := range []api.SwitchStatus{api.SwitchStatusPaused, api.SwitchStatusTriggered, api.SwitchStatusFailed} {
+			rec := doAs("admin", http.MethodPut, path, api.Switch{
+				Message:         "Protected",
+				Notifiers:       []string{"logger://", "logger://backup"},
+				CheckInInterval: "24h",
+				Status:          &status,
+			})
+			if rec.Code != http.StatusOK {
+				t.Fatalf("%s: expected 200, got %d. Body: %s", status, rec.Code, rec.Body.String())
+			}
+
+			stored, err := store.GetByID("admin", *created.Id)
+			if err != nil {
+				t.Fatalf("failed to get switch: %v", err)
+			}
+			if *stored.Status != api.SwitchStatusActive {
+				t.Errorf("%s: expected the switch to stay active, got %s", status, *stored.Status)
+			}
+		}
+
+		bogus := api.SwitchStatus("bogus")
+		rec := doAs("admin", http.MethodPut, path, api.Switch{
+			Message:         "Protected",
+			Notifiers:       []string{"logger://", "logger://backup"},
+			CheckInInterval: "24h",
+			Status:          &bogus,
+		})
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400 for an unknown status, got %d", rec.Code)
+		}
+
+		rec = doAs("admin", http.MethodPut, path, api.Switch{
+			Message:         "Protected",
+			Notifiers:       []string{"logger://", "logger://backup"},
+			CheckInInterval: "24h",
+			Status:          &statusDisabled,
+		})
+		if rec.Code != http.StatusAccepted {
+			t.Errorf("expected disabling to wait for approval, got %d", rec.Code)
+		}
+	})
+
+	t.Run("pausing waits for an approver", func(t *testing.T) {
+		created := seed(t, &[]string{"bob"})
+
+		rec := doAs("admin", http.MethodPost, "/api/v1/switch/pause", api.PauseRequest{Duration: ptr("24h")})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		paused := []api.Switch{}
+		_ = json.NewDecoder(rec.Body).Decode(&paused)
+		for _, sw := range paused {
+			if *sw.Id == *created.Id {
+				t.Errorf("expected pausing every switch to leave the protected switch running")
+			}
+		}
+
+		rec = doAs("admin", http.MethodPost, fmt.Sprintf("/api/v1/switch/%d/pause", *created.Id), api.PauseRequest{Duration: ptr("24h")})
+		if rec.Code != http.StatusAccepted {
+			t.Fatalf("expected 202, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		change := decodeChange(t, rec)
+		if change.Action != api.PendingChangeActionPause {
+			t.Errorf("expected a pause change, got %+v", change)
+		}
+
+		stored, err := store.GetByID("admin", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to get switch: %v", err)
+		}
+		if *stored.Status != api.SwitchStatusActive {
+			t.Errorf("expected the switch to keep running until the pause is approved, got %s", *stored.Status)
+		}
+
+		rec = doAs("bob", http.MethodPost, fmt.Sprintf("/api/v1/changes/%d/approve", *change.Id), nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		stored, err = store.GetByID("admin", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to get switch: %v", err)
+		}
+		if *stored.Status != api.SwitchStatusPaused || stored.PausedUntil == nil {
+			t.Errorf("expected the approved pause to apply, got %+v", stored)
+		}
+	})
+
+	t.Run("removing members, contacts or duress settings is held back", func(t *testing.T) {
+		created, err := store.Create(api.Switch{
+			UserId:          ptr("admin"),
+			Message:         "Protected",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "24h",
+			Protected:       ptr(true),
+			Approvers:       &[]string{"bob"},
+			Status:          &statusActive,
+			Members:         &[]api.SwitchMember{{UserId: "carol"}},
+			TrustedContacts: &[]api.TrustedContact{{Name: "dave", Notifier: ptr("logger://dave")}},
+			DuressCode:      ptr("hashed"),
+			DuressNotifiers: &[]string{"logger://duress"},
+		})
+		if err != nil {
+			t.Fatalf("failed to seed switch: %v", err)
+		}
+
+		rec := doAs("admin", http.MethodPut, fmt.Sprintf("/api/v1/switch/%d", *created.Id), api.Switch{
+			Message:         "Protected",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "24h",
+			Members:         &[]api.SwitchMember{},
+			TrustedContacts: &[]api.TrustedContact{},
+			DuressCode:      ptr(""),
+			DuressNotifiers: &[]string{},
+		})
+		if rec.Code != http.StatusAccepted {
+			t.Fatalf("expected 202, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		change := decodeChange(t, rec)
+		expected := "Remove 1 members, remove 1 trusted contacts, remove the duress code, remove 1 duress notifiers"
+		if change.Summary != expected {
+			t.Errorf("expected summary %q, got %q", expected, change.Summary)
+		}
+	})
+
+	t.Run("delete without approvers is delayed and can be cancelled", func(t *testing.T) {
+		created := seed(t, nil)
+
+		rec := doAs("admin", http.MethodDelete, fmt.Sprintf("/api/v1/switch/%d", *created.Id), nil)
+		if rec.Code != http.StatusAccepted {
+			t.Fatalf("expected 202, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		change := decodeChange(t, rec)
+		if change.ApplyAt == nil || *change.ApplyAt < change.CreatedAt+int64(switches.ChangeDelay(api.Switch{}).Seconds()) {
+			t.Errorf("expected the change to apply after the default delay, got %+v", change)
+		}
+
+		notified := <-requested
+		if *notified.Id != *change.Id {
+			t.Errorf("expected the owner to be notified of change %d, got %d", *change.Id, *notified.Id)
+		}
+
+		rec = doAs("admin", http.MethodPost, fmt.Sprintf("/api/v1/changes/%d/cancel", *change.Id), nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		_, err := store.GetByID("admin", *created.Id)
+		if err != nil {
+			t.Errorf("expected switch to still exist, got %v", err)
+		}
+	})
+
+	t.Run("only sensitive updates are held back", func(t *testing.T) {
+		created := seed(t, &[]string{"bob"})
+		path := fmt.Sprintf("/api/v1/switch/%d", *created.Id)
+
+		rec := doAs("admin", http.MethodPut, path, api.Switch{
+			Message:         "Updated",
+			Notifiers:       []string{"logger://", "logger://backup"},
+			CheckInInterval: "12h",
+		})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected a shorter interval to apply immediately, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		rec = doAs("admin", http.MethodPut, path, api.Switch{
+			Message:         "Longer",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "48h",
+		})
+		if rec.Code != http.StatusAccepted {
+			t.Fatalf("expected 202, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		change := decodeChange(t, rec)
+		if change.Summary != "Lengthen the check-in interval from 12h to 48h, remove 1 notifiers" {
+			t.Errorf("unexpected summary %q", change.Summary)
+		}
+
+		rec = doAs("bob", http.MethodPost, fmt.Sprintf("/api/v1/changes/%d/approve", *change.Id), nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		stored, err := store.GetByID("admin", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to get switch: %v", err)
+		}
+
+		err = store.DecryptSwitch(&stored)
+		if err != nil {
+			t.Fatalf("failed to decrypt switch: %v", err)
+		}
+		if stored.Message != "Longer" || stored.CheckInInterval != "48h" || len(stored.Notifiers) != 1 {
+			t.Errorf("expected the approved update to apply, got %+v", stored)
+		}
+	})
+}
+
+// failingUpdateStore is a store whose switch updates fail.
+type failingUpdateStore struct {
+	database.Store
+}
+
+func (f failingUpdateStore) Update(id int, sw api.Switch) (api.Switch, error) {
+	return api.Switch{}, errors.New("disk full")
+}
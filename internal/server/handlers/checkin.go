@@ -0,0 +1,340 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/metrics"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+	"github.com/circa10a/dead-mans-switch/internal/server/switches"
+	"github.com/go-chi/chi/v5"
+)
+
+// ClientHeader lets first party clients identify themselves so check-ins record how they were made.
+const ClientHeader = "X-Dead-Mans-Switch-Client"
+
+// Check-in history pagination
+const (
+	defaultCheckInLimit = 50
+	maxCheckInLimit     = 1000
+)
+
+// Error messages
+const (
+	errOffsetValue = "Invalid offset value"
+)
+
+var (
+	errInvalidInterval   = switches.ErrInvalidInterval
+	errInvalidPagination = errors.New("invalid pagination")
+)
+
+// CheckInsHandleFunc returns a page of a switch's check-in history, newest first.
+func (s *Switch) CheckInsHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID := middleware.GetUserIDFromContext(r)
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errInvalidSwitchID, err)
+		return
+	}
+
+	limit, offset, errMsg, err := parsePagination(r)
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errMsg, err)
+		return
+	}
+
+	_, err = s.Store.GetByID(userID, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.sendError(w, http.StatusNotFound, errSwitchNotFound, err)
+			return
+		}
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	checkIns, err := s.Store.GetCheckIns(userID, id, limit, offset)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(checkIns)
+}
+
+// parsePagination reads the limit and offset query parameters of history endpoints. On failure it
+// returns the message to report.
+func parsePagination(r *http.Request) (int, int, string, error) {
+	limit := defaultCheckInLimit
+	if l := r.URL.Query().Get("limit"); l != "" {
+		var err error
+		limit, err = strconv.Atoi(l)
+		if err != nil || limit < 1 || limit > maxCheckInLimit {
+			return 0, 0, errLimitValue, errors.Join(errInvalidPagination, err)
+		}
+	}
+
+	offset := 0
+	if o := r.URL.Query().Get("offset"); o != "" {
+		var err error
+		offset, err = strconv.Atoi(o)
+		if err != nil || offset < 0 {
+			return 0, 0, errOffsetValue, errors.Join(errInvalidPagination, err)
+		}
+	}
+
+	return limit, offset, "", nil
+}
+
+// CheckInAllHandleFunc checks in to every active switch owned by the caller, optionally filtered by label.
+// Each switch is re-armed using its own check-in interval.
+func (s *Switch) CheckInAllHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID := middleware.GetUserIDFromContext(r)
+	label := r.URL.Query().Get("label")
+
+	req := api.CheckInRequest{}
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.sendError(w, http.StatusBadRequest, errInvalidJSON, err)
+		return
+	}
+
+	code := ""
+	if req.Code != nil {
+		code = *req.Code
+	}
+
+	switches, err := s.Store.GetAll(userID, defaultLimit)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	resp := api.BulkCheckInResponse{
+		Results: []api.BulkCheckInResult{},
+	}
+
+	for _, sw := range switches {
+		if sw.Status == nil || *sw.Status != api.SwitchStatusActive {
+			continue
+		}
+
+		if label != "" && !hasLabel(sw, label) {
+			continue
+		}
+
+		// Every switch with a duress code is verified regardless of the code sent,
+		// so the response time doesn't reveal whether the duress code was used
+		duress := sw.DuressCode != nil && isDuressCode(sw, code)
+
+		checkedIn, err := s.checkIn(requestSource(r), *sw.Id, sw)
+		if err != nil {
+			s.Logger.Error(errFailedToReset, "error", err, "id", *sw.Id)
+
+			errMsg := errFailedToReset
+			switch {
+			case errors.Is(err, errInvalidInterval):
+				errMsg = errTimeParse
+			case errors.Is(err, errNotAMember):
+				errMsg = errNotMember
+			case errors.Is(err, errMissingPasskey):
+				errMsg = errPasskeyRequired
+			}
+
+			resp.Failed++
+			resp.Results = append(resp.Results, api.BulkCheckInResult{
+				Id:      *sw.Id,
+				Success: false,
+				Error:   &errMsg,
+			})
+			continue
+		}
+
+		if duress && s.Duress != nil {
+			go s.Duress(checkedIn)
+		}
+
+		resp.CheckedIn++
+		resp.Results = append(resp.Results, api.BulkCheckInResult{
+			Id:        *sw.Id,
+			Success:   true,
+			TriggerAt: checkedIn.TriggerAt,
+		})
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// checkInSource describes who made a check-in and how, as recorded in the switch's history.
+type checkInSource struct {
+	userID    string
+	method    api.CheckInMethod
+	ipAddress *string
+	userAgent *string
+	// passkey is set once the check-in was confirmed with a passkey of the user.
+	passkey bool
+}
+
+// requestSource describes a check-in made by the authenticated user of an API request.
+func requestSource(r *http.Request) checkInSource {
+	return checkInSource{
+		userID:    middleware.GetUserIDFromContext(r),
+		method:    checkInMethod(r),
+		ipAddress: clientIP(r),
+		userAgent: userAgent(r),
+	}
+}
+
+// checkIn re-arms a switch that was read from the store and records the check-in in its history.
+// Members of a running quorum switch check in individually instead, and only the owner can re-arm one that stopped.
+func (s *Switch) checkIn(source checkInSource, id int, sw api.Switch) (api.Switch, error) {
+	// Tokens, emails and alerts can't present a passkey, so they can't check in to switches that require one
+	if requiresPasskey(sw) && !source.passkey {
+		return api.Switch{}, errMissingPasskey
+	}
+
+	duration, err := time.ParseDuration(sw.CheckInInterval)
+	if err != nil {
+		return api.Switch{}, fmt.Errorf("%w: %w", errInvalidInterval, err)
+	}
+
+	now := time.Now()
+	userID := source.userID
+
+	ownerID := userID
+	if sw.UserId != nil {
+		ownerID = *sw.UserId
+	}
+
+	running := sw.Status != nil && *sw.Status == api.SwitchStatusActive
+	verifying := sw.Status != nil && *sw.Status == api.SwitchStatusVerifying
+
+	// Only a running countdown has a meaningful margin
+	var margin *int64
+	if running && sw.TriggerAt != nil {
+		remaining := max(*sw.TriggerAt-now.Unix(), 0)
+		margin = &remaining
+	}
+
+	var checkedIn api.Switch
+	if switches.IsQuorumSwitch(sw) && (running || userID != ownerID) {
+		checkedIn, err = s.checkInMember(userID, id, sw, now)
+	} else {
+		checkedIn, err = s.rearm(id, sw, duration, now)
+	}
+	if err != nil {
+		return api.Switch{}, err
+	}
+
+	// The owner checking in during verification means trusted contacts are no longer needed
+	if verifying && *checkedIn.Status == api.SwitchStatusActive {
+		s.cancelVerification(ownerID, id, userID)
+	}
+
+	// History is informational, so failing to record it doesn't fail the check-in
+	_, err = s.Store.CreateCheckIn(ownerID, api.CheckIn{
+		SwitchId:    id,
+		CheckedInAt: now.Unix(),
+		Method:      source.method,
+		IpAddress:   source.ipAddress,
+		UserAgent:   source.userAgent,
+	})
+	if err != nil {
+		s.Logger.Error("Failed to record check-in", "error", err, "id", id)
+	}
+
+	if margin != nil {
+		metrics.CheckInMargin.WithLabelValues(string(source.method)).Observe(float64(*margin))
+	}
+
+	s.Events.Publish(api.EventTypeCheckedIn, checkedIn)
+
+	return checkedIn, nil
+}
+
+// rearm starts a fresh countdown for a switch. Re-arming a quorum switch also counts as the owner's
+// check-in when they are one of its members.
+func (s *Switch) rearm(id int, sw api.Switch, duration time.Duration, now time.Time) (api.Switch, error) {
+	statusActive := api.SwitchStatusActive
+	sw.Status = &statusActive
+
+	triggerAt := now.UTC().Add(duration).Unix()
+	sw.TriggerAt = &triggerAt
+
+	reminderSent := false
+	sw.ReminderSent = &reminderSent
+
+	lastCheckInAt := now.Unix()
+	sw.LastCheckInAt = &lastCheckInAt
+
+	// Checking in ends a run of triggers without one
+	sw.TriggerCount = nil
+
+	// Checking in ends any pause
+	sw.PausedAt = nil
+	sw.PausedUntil = nil
+	sw.ResumePolicy = nil
+
+	if switches.IsQuorumSwitch(sw) && sw.UserId != nil {
+		err := s.Store.CheckInMember(id, *sw.UserId, now.Unix())
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return api.Switch{}, err
+		}
+	}
+
+	return s.save(id, sw)
+}
+
+// hasLabel reports whether a switch has the given label.
+func hasLabel(sw api.Switch, label string) bool {
+	return sw.Labels != nil && slices.Contains(*sw.Labels, label)
+}
+
+// checkInMethod determines how a check-in was made from the client header, defaulting to the API.
+func checkInMethod(r *http.Request) api.CheckInMethod {
+	switch method := api.CheckInMethod(r.Header.Get(ClientHeader)); method {
+	case api.CheckInMethodCLI, api.CheckInMethodPush, api.CheckInMethodUI:
+		return method
+	default:
+		return api.CheckInMethodAPI
+	}
+}
+
+// clientIP returns the address the request came from.
+func clientIP(r *http.Request) *string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if host == "" {
+		return nil
+	}
+	return &host
+}
+
+// userAgent returns the request's user agent if one was sent.
+func userAgent(r *http.Request) *string {
+	ua := r.UserAgent()
+	if ua == "" {
+		return nil
+	}
+	return &ua
+}
@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestCheckInsHandleFunc(t *testing.T) {
+	s, store := setupTestHandler(t)
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/switch/{id}/reset", s.ResetHandleFunc)
+	r.Get("/api/v1/switch/{id}/checkins", s.CheckInsHandleFunc)
+
+	triggerAt := time.Now().Add(time.Hour).Unix()
+	sw, err := store.Create(api.Switch{
+		Message:         "History",
+		Notifiers:       []string{"logger://"},
+		CheckInInterval: "24h",
+		Status:          &statusActive,
+		TriggerAt:       &triggerAt,
+	})
+	if err != nil {
+		t.Fatalf("failed to seed switch: %v", err)
+	}
+
+	checkIn := func(client string) api.Switch {
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/switch/%d/reset", *sw.Id), nil)
+		req.Header.Set("User-Agent", "test-agent")
+		if client != "" {
+			req.Header.Set(ClientHeader, client)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+		resp := api.Switch{}
+		_ = json.NewDecoder(rec.Body).Decode(&resp)
+		return resp
+	}
+
+	getCheckIns := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/switch/%d/checkins%s", *sw.Id, query), nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("check-in sets lastCheckInAt", func(t *testing.T) {
+		resp := checkIn("ui")
+		if resp.LastCheckInAt == nil {
+			t.Fatal("expected lastCheckInAt to be set")
+		}
+		if *resp.LastCheckInAt < time.Now().Unix()-5 {
+			t.Errorf("expected lastCheckInAt to be recent, got %d", *resp.LastCheckInAt)
+		}
+	})
+
+	t.Run("records method, IP and user agent", func(t *testing.T) {
+		checkIn("cli")
+		checkIn("")
+		checkIn("bogus")
+
+		rec := getCheckIns("")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+
+		checkIns := []api.CheckIn{}
+		_ = json.NewDecoder(rec.Body).Decode(&checkIns)
+		if len(checkIns) != 4 {
+			t.Fatalf("expected 4 check-ins, got %d", len(checkIns))
+		}
+
+		// Newest first
+		expected := []api.CheckInMethod{api.CheckInMethodAPI, api.CheckInMethodAPI, api.CheckInMethodCLI, api.CheckInMethodUI}
+		for i, method := range expected {
+			if checkIns[i].Method != method {
+				t.Errorf("check-in %d: expected method %s, got %s", i, method, checkIns[i].Method)
+			}
+		}
+
+		if checkIns[0].IpAddress == nil || *checkIns[0].IpAddress != "192.0.2.1" {
+			t.Errorf("expected ip address 192.0.2.1, got %v", checkIns[0].IpAddress)
+		}
+		if checkIns[0].UserAgent == nil || *checkIns[0].UserAgent != "test-agent" {
+			t.Errorf("expected user agent test-agent, got %v", checkIns[0].UserAgent)
+		}
+	})
+
+	t.Run("paginates with limit and offset", func(t *testing.T) {
+		rec := getCheckIns("?limit=2&offset=2")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+
+		checkIns := []api.CheckIn{}
+		_ = json.NewDecoder(rec.Body).Decode(&checkIns)
+		if len(checkIns) != 2 {
+			t.Fatalf("expected 2 check-ins, got %d", len(checkIns))
+		}
+		if checkIns[1].Method != api.CheckInMethodUI {
+			t.Errorf("expected oldest check-in on the last page, got %s", checkIns[1].Method)
+		}
+	})
+
+	t.Run("returns 400 for invalid pagination", func(t *testing.T) {
+		for _, query := range []string{"?limit=0", "?limit=abc", "?limit=5000", "?offset=-1"} {
+			rec := getCheckIns(query)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("%s: expected 400, got %d", query, rec.Code)
+			}
+		}
+	})
+
+	t.Run("check-in stops still missing alerts", func(t *testing.T) {
+		statusMissing := api.SwitchStatusMissing
+		nextAlertAt := time.Now().Add(time.Hour).Unix()
+		missing, err := store.Create(api.Switch{
+			Message:         "Heartbeat",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "24h",
+			Rearm:           ptr(true),
+			RepeatInterval:  ptr("6h"),
+			TriggerCount:    ptr(3),
+			Status:          &statusMissing,
+			TriggerAt:       &nextAlertAt,
+		})
+		if err != nil {
+			t.Fatalf("failed to seed switch: %v", err)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/switch/%d/reset", *missing.Id), nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		resp := api.Switch{}
+		_ = json.NewDecoder(rec.Body).Decode(&resp)
+		if *resp.Status != api.SwitchStatusActive || *resp.TriggerCount != 0 {
+			t.Errorf("expected an active switch with no triggers, got status %s and %d triggers", *resp.Status, *resp.TriggerCount)
+		}
+	})
+
+	t.Run("returns 404 for non-existent switch", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/switch/999/checkins", nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", rec.Code)
+		}
+	})
+}
+
+func TestCheckInAllHandleFunc(t *testing.T) {
+	s, store := setupTestHandler(t)
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/checkin", s.CheckInAllHandleFunc)
+
+	soon := time.Now().Add(time.Minute).Unix()
+	seed := func(interval string, status api.SwitchStatus, labels ...string) api.Switch {
+		sw := api.Switch{
+			Message:         "Bulk",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: interval,
+			Status:          &status,
+			TriggerAt:       &soon,
+		}
+		if len(labels) > 0 {
+			sw.Labels = &labels
+		}
+		created, err := store.Create(sw)
+		if err != nil {
+			t.Fatalf("failed to seed switch: %v", err)
+		}
+		return created
+	}
+
+	travel := seed("24h", api.SwitchStatusActive, "travel")
+	work := seed("1h", api.SwitchStatusActive, "work")
+	disabled := seed("24h", api.SwitchStatusDisabled, "travel")
+
+	checkInAll := func(query string) api.BulkCheckInResponse {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/checkin"+query, nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+		resp := api.BulkCheckInResponse{}
+		_ = json.NewDecoder(rec.Body).Decode(&resp)
+		return resp
+	}
+
+	t.Run("filters by label", func(t *testing.T) {
+		resp := checkInAll("?label=travel")
+
+		if resp.CheckedIn != 1 || resp.Failed != 0 || len(resp.Results) != 1 {
+			t.Fatalf("expected exactly one successful check-in, got %+v", resp)
+		}
+		if resp.Results[0].Id != *travel.Id {
+			t.Errorf("expected switch %d, got %d", *travel.Id, resp.Results[0].Id)
+		}
+	})
+
+	t.Run("checks in every active switch using its own interval", func(t *testing.T) {
+		resp := checkInAll("")
+
+		if resp.CheckedIn != 2 || len(resp.Results) != 2 {
+			t.Fatalf("expected two check-ins, got %+v", resp)
+		}
+
+		intervals := map[int]time.Duration{*travel.Id: 24 * time.Hour, *work.Id: time.Hour}
+		for _, result := range resp.Results {
+			if result.Id == *disabled.Id {
+				t.Error("expected disabled switch to be skipped")
+			}
+			if !result.Success || result.TriggerAt == nil {
+				t.Errorf("expected switch %d to succeed with a triggerAt", result.Id)
+				continue
+			}
+			expected := time.Now().Add(intervals[result.Id]).Unix()
+			if *result.TriggerAt < expected-5 || *result.TriggerAt > expected+5 {
+				t.Errorf("switch %d: expected triggerAt approx %d, got %d", result.Id, expected, *result.TriggerAt)
+			}
+		}
+	})
+
+	t.Run("reports per-switch failures", func(t *testing.T) {
+		broken := seed("24h", api.SwitchStatusActive, "broken")
+
+		// Corrupt the interval directly so the check-in fails
+		broken.CheckInInterval = "not-a-duration"
+		_, err := store.Update(*broken.Id, broken)
+		if err != nil {
+			t.Fatalf("failed to corrupt switch: %v", err)
+		}
+
+		resp := checkInAll("?label=broken")
+		if resp.Failed != 1 || resp.Results[0].Success || resp.Results[0].Error == nil {
+			t.Errorf("expected a failed result with an error, got %+v", resp)
+		}
+	})
+
+	t.Run("returns an empty summary when nothing matches", func(t *testing.T) {
+		resp := checkInAll("?label=none")
+		if resp.CheckedIn != 0 || resp.Failed != 0 || len(resp.Results) != 0 {
+			t.Errorf("expected empty summary, got %+v", resp)
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/database"
+	"github.com/circa10a/dead-mans-switch/internal/server/secrets"
+)
+
+const errCheckInTokenTaken = "Check-in token is already used by another switch"
+
+// hashCheckInToken replaces a plaintext check-in token with its hash so switches can be looked up by it
+// without storing it. An empty token removes the check-in token.
+func hashCheckInToken(sw *api.Switch) {
+	if sw.CheckInToken == nil {
+		return
+	}
+
+	if *sw.CheckInToken == "" {
+		sw.CheckInToken = nil
+		return
+	}
+
+	hash := secrets.HashToken(*sw.CheckInToken)
+	sw.CheckInToken = &hash
+}
+
+// sendSaveError reports a failure to store a switch, telling the client when its check-in token is taken.
+func (s *Switch) sendSaveError(w http.ResponseWriter, publicMsg string, err error) {
+	if errors.Is(err, database.ErrCheckInTokenTaken) {
+		s.sendError(w, http.StatusConflict, errCheckInTokenTaken, err)
+		return
+	}
+	s.sendError(w, http.StatusInternalServerError, publicMsg, err)
+}
+
+// CheckInWithToken checks in to the switch with the given check-in token on behalf of its owner, for
+// integrations that can't authenticate as a user. Returns sql.ErrNoRows if no switch has the token.
+func (s *Switch) CheckInWithToken(token string, method api.CheckInMethod) (api.Switch, error) {
+	sw, err := s.Store.GetByCheckInToken(secrets.HashToken(token))
+	if err != nil {
+		return api.Switch{}, err
+	}
+
+	return s.checkIn(checkInSource{userID: switchOwner(sw), method: method}, *sw.Id, sw)
+}
+
+// switchOwner returns the ID of the user who owns a switch.
+func switchOwner(sw api.Switch) string {
+	if sw.UserId != nil {
+		return *sw.UserId
+	}
+	return database.AdminUser
+}
+
+// CheckInWithReplyToken checks in to a switch with a token from a reminder email, as the user the reminder
+// was sent to. Returns sql.ErrNoRows if the token doesn't exist or has expired.
+func (s *Switch) CheckInWithReplyToken(token string, method api.CheckInMethod) (api.Switch, error) {
+	reply, err := s.Store.GetReplyToken(secrets.HashToken(token), time.Now().Unix())
+	if err != nil {
+		return api.Switch{}, err
+	}
+
+	sw, err := s.getSwitchForCheckIn(reply.UserID, reply.SwitchID)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
+	return s.checkIn(checkInSource{userID: reply.UserID, method: method}, reply.SwitchID, sw)
+}
+
+// CheckInByEmail checks in with a token from an email, either a switch's check-in token or a token from
+// a reminder. Returns sql.ErrNoRows if it is neither.
+func (s *Switch) CheckInByEmail(token string) (api.Switch, error) {
+	sw, err := s.CheckInWithToken(token, api.CheckInMethodEmail)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return sw, err
+	}
+
+	return s.CheckInWithReplyToken(token, api.CheckInMethodEmail)
+}
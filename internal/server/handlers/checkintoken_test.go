@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/database"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+	"github.com/circa10a/dead-mans-switch/internal/server/secrets"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestCheckInToken(t *testing.T) {
+	s, store := setupTestHandler(t)
+	mw := middleware.SwitchValidator(validator.New())
+
+	r := chi.NewRouter()
+	r.With(mw).Post("/api/v1/switch", s.PostHandleFunc)
+	r.With(mw).Put("/api/v1/switch/{id}", s.PutByIDHandleFunc)
+
+	send := func(method, path string, sw api.Switch, userID string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(sw)
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(body))
+		req = req.WithContext(middleware.WithUserID(req.Context(), userID))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	token := "a-long-random-check-in-token"
+
+	rec := send(http.MethodPost, "/api/v1/switch", api.Switch{
+		Message:         "Token",
+		Notifiers:       []string{"logger://"},
+		CheckInInterval: "1h",
+		CheckInToken:    &token,
+	}, "someone")
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	created := api.Switch{}
+	_ = json.NewDecoder(rec.Body).Decode(&created)
+
+	t.Run("token is never returned", func(t *testing.T) {
+		if created.CheckInToken != nil {
+			t.Error("expected the token to be redacted")
+		}
+		if created.CheckInTokenEnabled == nil || !*created.CheckInTokenEnabled {
+			t.Error("expected checkInTokenEnabled to be true")
+		}
+
+		stored, err := store.GetByID("someone", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to get switch: %v", err)
+		}
+		if stored.CheckInToken == nil || *stored.CheckInToken == token {
+			t.Error("expected the token to be stored hashed")
+		}
+	})
+
+	t.Run("short tokens are rejected", func(t *testing.T) {
+		rec := send(http.MethodPost, "/api/v1/switch", api.Switch{
+			Message:         "Short",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "1h",
+			CheckInToken:    ptr("short"),
+		}, "someone")
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("tokens can't be reused", func(t *testing.T) {
+		rec := send(http.MethodPost, "/api/v1/switch", api.Switch{
+			Message:         "Duplicate",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "1h",
+			CheckInToken:    &token,
+		}, "someone-else")
+		if rec.Code != http.StatusConflict {
+			t.Errorf("expected 409, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("token checks in as the owner", func(t *testing.T) {
+		before := time.Now().Unix()
+
+		checkedIn, err := s.CheckInWithToken(token, api.CheckInMethodMQTT)
+		if err != nil {
+			t.Fatalf("failed to check in: %v", err)
+		}
+		if *checkedIn.Id != *created.Id || *checkedIn.Status != api.SwitchStatusActive || *checkedIn.LastCheckInAt < before {
+			t.Errorf("unexpected switch %+v", checkedIn)
+		}
+
+		checkIns, err := store.GetCheckIns("someone", *created.Id, 10, 0)
+		if err != nil {
+			t.Fatalf("failed to get check-ins: %v", err)
+		}
+		if len(checkIns) != 1 || checkIns[0].Method != api.CheckInMethodMQTT {
+			t.Errorf("expected one mqtt check-in, got %+v", checkIns)
+		}
+	})
+
+	t.Run("unknown tokens are rejected", func(t *testing.T) {
+		_, err := s.CheckInWithToken("not-the-check-in-token", api.CheckInMethodMQTT)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows, got %v", err)
+		}
+	})
+
+	update := func(t *testing.T, checkInToken *string) {
+		t.Helper()
+		rec := send(http.MethodPut, fmt.Sprintf("/api/v1/switch/%d", *created.Id), api.Switch{
+			Message:         "Updated",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "1h",
+			CheckInToken:    checkInToken,
+		}, "someone")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+	}
+
+	t.Run("updates keep the token unless it is changed", func(t *testing.T) {
+		update(t, nil)
+		_, err := s.CheckInWithToken(token, api.CheckInMethodMQTT)
+		if err != nil {
+			t.Errorf("expected the token to be kept, got %v", err)
+		}
+
+		update(t, ptr(""))
+		_, err = s.CheckInWithToken(token, api.CheckInMethodMQTT)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected the token to be removed, got %v", err)
+		}
+	})
+}
+
+func TestCheckInByEmail(t *testing.T) {
+	s, store := setupTestHandler(t)
+
+	triggerAt := time.Now().Add(time.Hour).Unix()
+	created, err := store.Create(api.Switch{
+		Message:         "Team",
+		Notifiers:       []string{"logger://"},
+		CheckInInterval: "24h",
+		CheckInToken:    ptr(secrets.HashToken("switch-check-in-token")),
+		Members:         &[]api.SwitchMember{{UserId: "admin"}, {UserId: "bob"}},
+		Status:          &statusActive,
+		TriggerAt:       &triggerAt,
+	})
+	if err != nil {
+		t.Fatalf("failed to seed switch: %v", err)
+	}
+
+	for _, token := range []database.ReplyToken{
+		{TokenHash: secrets.HashToken("bob-reply-token"), SwitchID: *created.Id, UserID: "bob", ExpiresAt: triggerAt},
+		{TokenHash: secrets.HashToken("expired-reply-token"), SwitchID: *created.Id, UserID: "bob", ExpiresAt: time.Now().Add(-time.Minute).Unix()},
+	} {
+		err = store.CreateReplyToken(token)
+		if err != nil {
+			t.Fatalf("failed to seed reply token: %v", err)
+		}
+	}
+
+	t.Run("reply tokens check in as the member the reminder was sent to", func(t *testing.T) {
+		_, err := s.CheckInByEmail("bob-reply-token")
+		if err != nil {
+			t.Fatalf("failed to check in: %v", err)
+		}
+
+		sw, err := store.GetByID("admin", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to get switch: %v", err)
+		}
+		for _, member := range *sw.Members {
+			checkedIn := member.LastCheckInAt != nil
+			if checkedIn != (member.UserId == "bob") {
+				t.Errorf("expected only bob to have checked in, got %+v", member)
+			}
+		}
+
+		checkIns, err := store.GetCheckIns("admin", *created.Id, 10, 0)
+		if err != nil {
+			t.Fatalf("failed to get check-ins: %v", err)
+		}
+		if len(checkIns) != 1 || checkIns[0].Method != api.CheckInMethodEmail {
+			t.Errorf("expected one email check-in, got %+v", checkIns)
+		}
+	})
+
+	t.Run("check-in tokens check in as the owner", func(t *testing.T) {
+		sw, err := s.CheckInByEmail("switch-check-in-token")
+		if err != nil {
+			t.Fatalf("failed to check in: %v", err)
+		}
+		if *sw.Id != *created.Id {
+			t.Errorf("expected switch %d, got %d", *created.Id, *sw.Id)
+		}
+	})
+
+	t.Run("expired and unknown tokens are rejected", func(t *testing.T) {
+		for _, token := range []string{"expired-reply-token", "unknown-token"} {
+			_, err := s.CheckInByEmail(token)
+			if !errors.Is(err, sql.ErrNoRows) {
+				t.Errorf("expected sql.ErrNoRows for %q, got %v", token, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,283 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/database"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+	"github.com/circa10a/dead-mans-switch/internal/server/secrets"
+	"github.com/circa10a/dead-mans-switch/internal/server/switches"
+	"github.com/go-chi/chi/v5"
+)
+
+// Error messages
+const (
+	errInvalidContactToken  = "Invalid or expired token"
+	errNotVerifying         = "Switch is not awaiting verification"
+	errPostponeDuration     = "Invalid postpone duration"
+	errContactNotifier      = "Trusted contacts require a notifier"
+	errFailedToVerifySwitch = "Failed to update switch"
+)
+
+// ContactActor identifies a trusted contact in the audit trail.
+func ContactActor(name string) string {
+	return "contact:" + name
+}
+
+// ContactHandleFunc returns the verification a trusted contact was asked for.
+func (s *Switch) ContactHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	token, sw, ok := s.lookupContactToken(w, r)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(contactVerification(token, sw))
+}
+
+// ContactPostponeHandleFunc lets a trusted contact push back the release of an expired switch.
+func (s *Switch) ContactPostponeHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	req := api.PostponeRequest{}
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errInvalidJSON, err)
+		return
+	}
+
+	token, sw, ok := s.lookupContactToken(w, r)
+	if !ok {
+		return
+	}
+
+	duration, err := time.ParseDuration(req.Duration)
+	if err != nil || duration <= 0 {
+		s.sendError(w, http.StatusBadRequest, errPostponeDuration, err)
+		return
+	}
+
+	maxPostpone := switches.MaxPostpone(sw)
+	if duration > maxPostpone {
+		s.sendError(w, http.StatusBadRequest, fmt.Sprintf("Release can be postponed by at most %s", maxPostpone), nil)
+		return
+	}
+
+	now := time.Now()
+
+	err = s.Store.UseContactToken(token.TokenHash, now.Unix())
+	if err != nil {
+		s.sendContactTokenError(w, err)
+		return
+	}
+
+	// Postponing never brings the release forward
+	releaseAt := max(*sw.TriggerAt, now.Add(duration).Unix())
+	sw.TriggerAt = &releaseAt
+
+	updated, err := s.save(token.SwitchID, sw)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errFailedToVerifySwitch, err)
+		return
+	}
+
+	s.audit(token.UserID, token.SwitchID, ContactActor(token.ContactName), api.AuditActionPostponed, "Postponed release by "+duration.String())
+	s.Events.Publish(api.EventTypeUpdated, updated)
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(contactVerification(token, updated))
+}
+
+// ContactConfirmHandleFunc lets a trusted contact confirm the release of an expired switch. The switch is
+// released by the worker on its next sweep and the other contacts can no longer postpone it.
+func (s *Switch) ContactConfirmHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	token, sw, ok := s.lookupContactToken(w, r)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+
+	err := s.Store.UseContactToken(token.TokenHash, now.Unix())
+	if err != nil {
+		s.sendContactTokenError(w, err)
+		return
+	}
+
+	err = s.Store.DeleteContactTokens(token.SwitchID)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	releaseAt := now.Unix()
+	sw.TriggerAt = &releaseAt
+
+	updated, err := s.save(token.SwitchID, sw)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errFailedToVerifySwitch, err)
+		return
+	}
+
+	s.audit(token.UserID, token.SwitchID, ContactActor(token.ContactName), api.AuditActionConfirmed, "")
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(contactVerification(token, updated))
+}
+
+// AuditHandleFunc returns a page of a switch's audit trail, newest first.
+func (s *Switch) AuditHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID := middleware.GetUserIDFromContext(r)
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errInvalidSwitchID, err)
+		return
+	}
+
+	limit, offset, errMsg, err := parsePagination(r)
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errMsg, err)
+		return
+	}
+
+	_, err = s.Store.GetByID(userID, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.sendError(w, http.StatusNotFound, errSwitchNotFound, err)
+			return
+		}
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	events, err := s.Store.GetAuditEvents(userID, id, limit, offset)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(events)
+}
+
+// lookupContactToken finds the switch a contact token was issued for. It sends the error response
+// itself and reports whether the request can continue.
+func (s *Switch) lookupContactToken(w http.ResponseWriter, r *http.Request) (database.ContactToken, api.Switch, bool) {
+	token, err := s.Store.GetContactToken(secrets.HashToken(chi.URLParam(r, "token")))
+	if err != nil {
+		s.sendContactTokenError(w, err)
+		return database.ContactToken{}, api.Switch{}, false
+	}
+
+	if token.UsedAt != nil || token.ExpiresAt <= time.Now().Unix() {
+		s.sendError(w, http.StatusNotFound, errInvalidContactToken, nil)
+		return database.ContactToken{}, api.Switch{}, false
+	}
+
+	sw, err := s.Store.GetByID(token.UserID, token.SwitchID)
+	if err != nil {
+		s.sendContactTokenError(w, err)
+		return database.ContactToken{}, api.Switch{}, false
+	}
+
+	if sw.Status == nil || *sw.Status != api.SwitchStatusVerifying {
+		s.sendError(w, http.StatusConflict, errNotVerifying, nil)
+		return database.ContactToken{}, api.Switch{}, false
+	}
+
+	return token, sw, true
+}
+
+// sendContactTokenError reports a missing token the same way as an invalid one.
+func (s *Switch) sendContactTokenError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		s.sendError(w, http.StatusNotFound, errInvalidContactToken, err)
+		return
+	}
+	s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+}
+
+// cancelVerification revokes the contacts' tokens once the owner checks in to a switch that was verifying.
+func (s *Switch) cancelVerification(userID string, id int, actor string) {
+	err := s.Store.DeleteContactTokens(id)
+	if err != nil {
+		s.Logger.Error("Failed to revoke trusted contact tokens", "error", err, "id", id)
+	}
+
+	s.audit(userID, id, actor, api.AuditActionVerificationCancelled, "Owner checked in")
+}
+
+// audit records an event in a switch's audit trail. Failures are logged rather than failing the request.
+func (s *Switch) audit(userID string, id int, actor string, action api.AuditEventAction, detail string) {
+	event := api.AuditEvent{
+		SwitchId:  id,
+		CreatedAt: time.Now().Unix(),
+		Actor:     actor,
+		Action:    action,
+	}
+	if detail != "" {
+		event.Detail = &detail
+	}
+
+	_, err := s.Store.CreateAuditEvent(userID, event)
+	if err != nil {
+		s.Logger.Error("Failed to record audit event", "error", err, "id", id, "action", action)
+	}
+}
+
+// keepContactNotifiers copies the current notifier to updated contacts that were sent without one
+// and reports whether every contact ends up with a notifier.
+func keepContactNotifiers(payload *api.Switch, previous api.Switch) bool {
+	if payload.TrustedContacts == nil {
+		return true
+	}
+
+	notifiers := map[string]*string{}
+	if previous.TrustedContacts != nil {
+		for _, contact := range *previous.TrustedContacts {
+			notifiers[contact.Name] = contact.Notifier
+		}
+	}
+
+	contacts := make([]api.TrustedContact, len(*payload.TrustedContacts))
+	for i, contact := range *payload.TrustedContacts {
+		if contact.Notifier == nil || *contact.Notifier == "" {
+			contact.Notifier = notifiers[contact.Name]
+		}
+		if contact.Notifier == nil || *contact.Notifier == "" {
+			return false
+		}
+		contacts[i] = contact
+	}
+	payload.TrustedContacts = &contacts
+
+	return true
+}
+
+// contactVerification builds what a trusted contact is shown about a switch.
+func contactVerification(token database.ContactToken, sw api.Switch) api.ContactVerification {
+	maxPostpone := switches.MaxPostpone(sw).String()
+
+	return api.ContactVerification{
+		ContactName: token.ContactName,
+		MaxPostpone: &maxPostpone,
+		Owner:       token.UserID,
+		ReleaseAt:   *sw.TriggerAt,
+		Status:      string(*sw.Status),
+	}
+}
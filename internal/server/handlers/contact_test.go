@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/database"
+	"github.com/circa10a/dead-mans-switch/internal/server/secrets"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestContactVerification(t *testing.T) {
+	s, store := setupTestHandler(t)
+
+	r := chi.NewRouter()
+	r.Get("/api/v1/contact/{token}", s.ContactHandleFunc)
+	r.Post("/api/v1/contact/{token}/postpone", s.ContactPostponeHandleFunc)
+	r.Post("/api/v1/contact/{token}/confirm", s.ContactConfirmHandleFunc)
+	r.Post("/api/v1/switch/{id}/reset", s.ResetHandleFunc)
+	r.Get("/api/v1/switch/{id}/audit", s.AuditHandleFunc)
+
+	statusVerifying := api.SwitchStatusVerifying
+	releaseAt := time.Now().Add(time.Hour).Unix()
+
+	seed := func(t *testing.T) (api.Switch, map[string]string) {
+		t.Helper()
+
+		created, err := store.Create(api.Switch{
+			Message:         "Verified",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "24h",
+			TrustedContacts: &[]api.TrustedContact{
+				{Name: "sam", Notifier: ptr("logger://sam")},
+				{Name: "alex", Notifier: ptr("logger://alex")},
+			},
+			MaxPostpone: ptr("48h"),
+			Status:      &statusVerifying,
+			TriggerAt:   &releaseAt,
+		})
+		if err != nil {
+			t.Fatalf("failed to seed switch: %v", err)
+		}
+
+		tokens := map[string]string{}
+		for _, contact := range *created.TrustedContacts {
+			token, hash, err := secrets.NewToken()
+			if err != nil {
+				t.Fatalf("failed to create token: %v", err)
+			}
+
+			err = store.CreateContactToken(database.ContactToken{
+				TokenHash:   hash,
+				SwitchID:    *created.Id,
+				UserID:      "admin",
+				ContactName: contact.Name,
+				ExpiresAt:   time.Now().Add(time.Hour).Unix(),
+			})
+			if err != nil {
+				t.Fatalf("failed to store token: %v", err)
+			}
+			tokens[contact.Name] = token
+		}
+
+		return created, tokens
+	}
+
+	do := func(method, path string, body any) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			_ = json.NewEncoder(&buf).Encode(body)
+		}
+		req := httptest.NewRequest(method, path, &buf)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("contacts can view the verification", func(t *testing.T) {
+		_, tokens := seed(t)
+
+		rec := do(http.MethodGet, "/api/v1/contact/"+tokens["sam"], nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		resp := api.ContactVerification{}
+		_ = json.NewDecoder(rec.Body).Decode(&resp)
+		if resp.ContactName != "sam" || resp.Owner != "admin" || resp.ReleaseAt != releaseAt || *resp.MaxPostpone != "48h0m0s" {
+			t.Errorf("unexpected verification %+v", resp)
+		}
+	})
+
+	t.Run("returns 404 for unknown tokens", func(t *testing.T) {
+		rec := do(http.MethodGet, "/api/v1/contact/unknown", nil)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", rec.Code)
+		}
+	})
+
+	t.Run("postpone is limited and single use", func(t *testing.T) {
+		created, tokens := seed(t)
+		path := "/api/v1/contact/" + tokens["sam"] + "/postpone"
+
+		rec := do(http.MethodPost, path, api.PostponeRequest{Duration: "72h"})
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400 beyond max postpone, got %d", rec.Code)
+		}
+
+		rec = do(http.MethodPost, path, api.PostponeRequest{Duration: "24h"})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		resp := api.ContactVerification{}
+		_ = json.NewDecoder(rec.Body).Decode(&resp)
+		if resp.ReleaseAt < time.Now().Add(23*time.Hour).Unix() {
+			t.Errorf("expected release to be postponed by 24h, got %d", resp.ReleaseAt)
+		}
+
+		rec = do(http.MethodPost, path, api.PostponeRequest{Duration: "24h"})
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404 for a used token, got %d", rec.Code)
+		}
+
+		events, err := store.GetAuditEvents("admin", *created.Id, 10, 0)
+		if err != nil {
+			t.Fatalf("failed to get audit events: %v", err)
+		}
+		if len(events) != 1 || events[0].Action != api.AuditActionPostponed || events[0].Actor != "contact:sam" {
+			t.Errorf("expected a postponed event by sam, got %+v", events)
+		}
+	})
+
+	t.Run("confirm releases on the next sweep and revokes other tokens", func(t *testing.T) {
+		created, tokens := seed(t)
+
+		rec := do(http.MethodPost, "/api/v1/contact/"+tokens["sam"]+"/confirm", nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		stored, err := store.GetByID("admin", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to get switch: %v", err)
+		}
+		if *stored.TriggerAt > time.Now().Unix() {
+			t.Errorf("expected switch to be due for release, got triggerAt %d", *stored.TriggerAt)
+		}
+
+		rec = do(http.MethodGet, "/api/v1/contact/"+tokens["alex"], nil)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404 for a revoked token, got %d", rec.Code)
+		}
+	})
+
+	t.Run("owner check-in cancels verification", func(t *testing.T) {
+		created, tokens := seed(t)
+
+		rec := do(http.MethodPost, fmt.Sprintf("/api/v1/switch/%d/reset", *created.Id), nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		rec = do(http.MethodGet, "/api/v1/contact/"+tokens["sam"], nil)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404 once verification is cancelled, got %d", rec.Code)
+		}
+
+		rec = do(http.MethodGet, fmt.Sprintf("/api/v1/switch/%d/audit", *created.Id), nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		events := []api.AuditEvent{}
+		_ = json.NewDecoder(rec.Body).Decode(&events)
+		if len(events) != 1 || events[0].Action != api.AuditActionVerificationCancelled {
+			t.Errorf("expected a verification cancelled event, got %+v", events)
+		}
+	})
+
+	t.Run("returns 409 when the switch isn't verifying", func(t *testing.T) {
+		created, tokens := seed(t)
+
+		stored, err := store.GetByID("admin", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to get switch: %v", err)
+		}
+		stored.Status = &statusActive
+		_, err = store.Update(*created.Id, stored)
+		if err != nil {
+			t.Fatalf("failed to update switch: %v", err)
+		}
+
+		rec := do(http.MethodGet, "/api/v1/contact/"+tokens["sam"], nil)
+		if rec.Code != http.StatusConflict {
+			t.Errorf("expected 409, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/secrets"
+)
+
+// hashDuressCode replaces a plaintext duress code with its hash so it is never stored in the clear.
+// An empty code removes the duress code.
+func hashDuressCode(sw *api.Switch) error {
+	if sw.DuressCode == nil {
+		return nil
+	}
+
+	if *sw.DuressCode == "" {
+		sw.DuressCode = nil
+		return nil
+	}
+
+	hash, err := secrets.HashCode(*sw.DuressCode)
+	if err != nil {
+		return err
+	}
+	sw.DuressCode = &hash
+
+	return nil
+}
+
+// isDuressCode reports whether code is the switch's duress code. The comparison always runs,
+// even without a code configured, so a duress check-in takes as long as a normal one.
+func isDuressCode(sw api.Switch, code string) bool {
+	hash := ""
+	if sw.DuressCode != nil {
+		hash = *sw.DuressCode
+	}
+
+	return secrets.VerifyCode(code, hash)
+}
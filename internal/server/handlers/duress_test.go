@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+	"github.com/circa10a/dead-mans-switch/internal/server/secrets"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestDuressCheckIn(t *testing.T) {
+	s, store := setupTestHandler(t)
+
+	duressCalls := make(chan api.Switch, 1)
+	s.Duress = func(sw api.Switch) {
+		duressCalls <- sw
+	}
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/switch", middleware.SwitchValidator(validator.New())(http.HandlerFunc(s.PostHandleFunc)).ServeHTTP)
+	r.Post("/api/v1/switch/{id}/reset", s.ResetHandleFunc)
+
+	createSwitch := func(t *testing.T, sw api.Switch) api.Switch {
+		t.Helper()
+		body, _ := json.Marshal(sw)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/switch", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+		created := api.Switch{}
+		_ = json.NewDecoder(rec.Body).Decode(&created)
+		return created
+	}
+
+	checkIn := func(id int, code *string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(api.CheckInRequest{Code: code})
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/switch/%d/reset", id), bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	created := createSwitch(t, api.Switch{
+		Message:         "Coerced",
+		Notifiers:       []string{"logger://"},
+		CheckInInterval: "24h",
+		DuressCode:      ptr("4321"),
+		DuressNotifiers: &[]string{"generic://duress"},
+	})
+
+	t.Run("duress code is never returned", func(t *testing.T) {
+		if created.DuressCode != nil || created.DuressNotifiers != nil {
+			t.Error("expected duress code and notifiers to be redacted")
+		}
+		if created.DuressEnabled == nil || !*created.DuressEnabled {
+			t.Error("expected duressEnabled to be true")
+		}
+
+		stored, err := store.GetByID("admin", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to get switch: %v", err)
+		}
+		if stored.DuressCode == nil || *stored.DuressCode == "4321" {
+			t.Error("expected duress code to be stored hashed")
+		}
+	})
+
+	t.Run("duress check-in looks like a normal check-in", func(t *testing.T) {
+		normal := checkIn(*created.Id, nil)
+		duress := checkIn(*created.Id, ptr("4321"))
+
+		if normal.Code != http.StatusOK || duress.Code != http.StatusOK {
+			t.Fatalf("expected 200 for both, got %d and %d", normal.Code, duress.Code)
+		}
+
+		// Normalize the only fields expected to differ between two check-ins
+		stripTriggerAt := func(body string) string {
+			resp := map[string]any{}
+			_ = json.Unmarshal([]byte(body), &resp)
+			delete(resp, "lastCheckInAt")
+			delete(resp, "triggerAt")
+			out, _ := json.Marshal(resp)
+			return string(out)
+		}
+		if stripTriggerAt(normal.Body.String()) != stripTriggerAt(duress.Body.String()) {
+			t.Errorf("expected identical responses\nnormal: %s\nduress: %s", normal.Body.String(), duress.Body.String())
+		}
+
+		resp := api.Switch{}
+		_ = json.NewDecoder(strings.NewReader(duress.Body.String())).Decode(&resp)
+		if *resp.Status != api.SwitchStatusActive {
+			t.Errorf("expected switch to remain active, got %s", *resp.Status)
+		}
+
+		select {
+		case sw := <-duressCalls:
+			if *sw.Id != *created.Id {
+				t.Errorf("expected duress for switch %d, got %d", *created.Id, *sw.Id)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected duress hook to be called")
+		}
+	})
+
+	t.Run("wrong code is a normal check-in", func(t *testing.T) {
+		rec := checkIn(*created.Id, ptr("0000"))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+
+		select {
+		case <-duressCalls:
+			t.Fatal("expected duress hook not to be called")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	t.Run("switch without duress code never fires", func(t *testing.T) {
+		plain := createSwitch(t, api.Switch{
+			Message:         "Plain",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "24h",
+		})
+
+		rec := checkIn(*plain.Id, ptr(""))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+
+		select {
+		case <-duressCalls:
+			t.Fatal("expected duress hook not to be called")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	t.Run("returns 400 for invalid JSON", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/switch/%d/reset", *created.Id), bytes.NewBufferString("{bad"))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+}
+
+func TestPutKeepsDuressSettings(t *testing.T) {
+	s, store := setupTestHandler(t)
+	mw := middleware.SwitchValidator(validator.New())
+
+	r := chi.NewRouter()
+	r.With(mw).Put("/api/v1/switch/{id}", s.PutByIDHandleFunc)
+
+	hash, err := secrets.HashCode("4321")
+	if err != nil {
+		t.Fatalf("failed to hash code: %v", err)
+	}
+
+	created, err := store.Create(api.Switch{
+		Message:         "Original",
+		Notifiers:       []string{"logger://"},
+		CheckInInterval: "24h",
+		DuressCode:      &hash,
+		DuressNotifiers: &[]string{"generic://duress"},
+		Encrypted:       ptr(true),
+		Status:          &statusActive,
+	})
+	if err != nil {
+		t.Fatalf("failed to seed switch: %v", err)
+	}
+
+	body, _ := json.Marshal(api.Switch{
+		Message:         "Updated",
+		Notifiers:       []string{"logger://"},
+		CheckInInterval: "24h",
+		Encrypted:       ptr(true),
+	})
+	req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/switch/%d", *created.Id), bytes.NewBuffer(body))
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+
+	stored, err := store.GetByID("admin", *created.Id)
+	if err != nil {
+		t.Fatalf("failed to get switch: %v", err)
+	}
+
+	err = store.DecryptSwitch(&stored)
+	if err != nil {
+		t.Fatalf("failed to decrypt switch: %v", err)
+	}
+
+	if !isDuressCode(stored, "4321") {
+		t.Error("expected duress code to be kept")
+	}
+	if stored.DuressNotifiers == nil || (*stored.DuressNotifiers)[0] != "generic://duress" {
+		t.Errorf("expected duress notifiers to be kept, got %v", stored.DuressNotifiers)
+	}
+}
+
+func TestPutRemovesDuressCode(t *testing.T) {
+	s, store := setupTestHandler(t)
+	mw := middleware.SwitchValidator(validator.New())
+
+	r := chi.NewRouter()
+	r.With(mw).Put("/api/v1/switch/{id}", s.PutByIDHandleFunc)
+
+	hash, err := secrets.HashCode("4321")
+	if err != nil {
+		t.Fatalf("failed to hash code: %v", err)
+	}
+
+	created, err := store.Create(api.Switch{
+		Message:         "Original",
+		Notifiers:       []string{"logger://"},
+		CheckInInterval: "24h",
+		DuressCode:      &hash,
+		Status:          &statusActive,
+	})
+	if err != nil {
+		t.Fatalf("failed to seed switch: %v", err)
+	}
+
+	put := func(code string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(api.Switch{
+			Message:         "Original",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "24h",
+			DuressCode:      &code,
+		})
+		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/switch/%d", *created.Id), bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := put("123")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a short code, got %d", rec.Code)
+	}
+
+	rec = put("")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+
+	stored, err := store.GetByID("admin", *created.Id)
+	if err != nil {
+		t.Fatalf("failed to get switch: %v", err)
+	}
+	if stored.DuressCode != nil {
+		t.Error("expected an empty code to remove the duress code")
+	}
+}
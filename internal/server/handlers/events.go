@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+)
+
+const (
+	errInvalidEventID    = "Invalid Last-Event-ID"
+	errStreamUnavailable = "Event stream unavailable"
+	lastEventIDHeader    = "Last-Event-ID"
+	// streamBuffer is how many events can wait for a slow client before new ones are dropped.
+	streamBuffer = 100
+	// streamRetry is how long clients wait before reconnecting a dropped stream.
+	streamRetry = 5 * time.Second
+)
+
+// heartbeatInterval is how often a comment is sent on event streams, so proxies and clients
+// don't close idle ones. Tests shorten it.
+var heartbeatInterval = 15 * time.Second
+
+// EventsHandleFunc streams the lifecycle events of the user's switches as server-sent events.
+// Clients reconnecting with Last-Event-ID first receive the recent events they missed.
+func (s *Switch) EventsHandleFunc(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserIDFromContext(r)
+
+	var lastID int64
+	resume := r.Header.Get(lastEventIDHeader)
+	if resume != "" {
+		var err error
+		lastID, err = strconv.ParseInt(resume, 10, 64)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			s.sendError(w, http.StatusBadRequest, errInvalidEventID, err)
+			return
+		}
+	}
+
+	if s.Events == nil {
+		w.Header().Set("Content-Type", "application/json")
+		s.sendError(w, http.StatusServiceUnavailable, errStreamUnavailable, nil)
+		return
+	}
+
+	// Subscribe before reading the history, so no event falls between the two
+	ch, stop := s.Events.Subscribe(streamBuffer)
+	defer stop()
+
+	var missed []api.Event
+	if resume != "" {
+		missed = s.Events.Since(lastID)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// Ask reverse proxies such as nginx not to buffer the stream
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	rc := http.NewResponseController(w)
+
+	_, err := fmt.Fprintf(w, "retry: %d\n\n", streamRetry.Milliseconds())
+	if err != nil {
+		return
+	}
+
+	send := func(event api.Event) error {
+		if event.UserId != userID || event.Id <= lastID {
+			return nil
+		}
+		lastID = event.Id
+
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Id, event.Type, data)
+		return err
+	}
+
+	for _, event := range missed {
+		err = send(event)
+		if err != nil {
+			return
+		}
+	}
+
+	err = rc.Flush()
+	if err != nil {
+		s.Logger.Error("Event stream can't be flushed", "error", err)
+		return
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event := <-ch:
+			err = send(event)
+		case <-heartbeat.C:
+			_, err = fmt.Fprint(w, ": heartbeat\n\n")
+		}
+		if err != nil {
+			return
+		}
+
+		err = rc.Flush()
+		if err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/events"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+)
+
+func TestEventsHandleFunc(t *testing.T) {
+	s, _ := setupTestHandler(t)
+	s.Events = events.NewBus()
+
+	previous := heartbeatInterval
+	heartbeatInterval = 20 * time.Millisecond
+	t.Cleanup(func() { heartbeatInterval = previous })
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.EventsHandleFunc(w, r.WithContext(middleware.WithUserID(r.Context(), "admin")))
+	}))
+	defer srv.Close()
+
+	mine, theirs := 1, 2
+	admin, other := "admin", "someone-else"
+
+	// connect opens a stream and returns a function that reads its next event or heartbeat
+	connect := func(t *testing.T, lastEventID string) func() string {
+		t.Helper()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+		if lastEventID != "" {
+			req.Header.Set(lastEventIDHeader, lastEventID)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("failed to connect: %v", err)
+		}
+		t.Cleanup(func() { _ = resp.Body.Close() })
+
+		if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+			t.Fatalf("unexpected response %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+		}
+
+		reader := bufio.NewReader(resp.Body)
+		return func() string {
+			var lines []string
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					t.Fatalf("failed to read stream: %v", err)
+				}
+				line = strings.TrimSuffix(line, "\n")
+				if line == "" {
+					if len(lines) > 0 && !strings.HasPrefix(lines[0], "retry:") {
+						return strings.Join(lines, "\n")
+					}
+					lines = nil
+					continue
+				}
+				lines = append(lines, line)
+			}
+		}
+	}
+
+	// eventData waits for the next event on the stream and decodes it
+	eventData := func(t *testing.T, next func() string) api.Event {
+		t.Helper()
+
+		for {
+			message := next()
+			if strings.HasPrefix(message, ":") {
+				continue
+			}
+
+			event := api.Event{}
+			_, data, _ := strings.Cut(message, "data: ")
+			err := json.Unmarshal([]byte(data), &event)
+			if err != nil {
+				t.Fatalf("invalid event %q: %v", message, err)
+			}
+			if !strings.HasPrefix(message, "id: "+strconv.FormatInt(event.Id, 10)+"\nevent: "+string(event.Type)) {
+				t.Errorf("unexpected event fields %q", message)
+			}
+			return event
+		}
+	}
+
+	t.Run("only the user's events are streamed", func(t *testing.T) {
+		next := connect(t, "")
+
+		// Wait for the stream to subscribe before publishing
+		if heartbeat := next(); heartbeat != ": heartbeat" {
+			t.Fatalf("expected a heartbeat, got %q", heartbeat)
+		}
+
+		s.Events.Publish(api.EventTypeCreated, api.Switch{Id: &theirs, UserId: &other})
+		s.Events.Publish(api.EventTypeTriggered, api.Switch{Id: &mine, UserId: &admin})
+
+		event := eventData(t, next)
+		if event.Type != api.EventTypeTriggered || event.SwitchId != mine {
+			t.Errorf("unexpected event %+v", event)
+		}
+	})
+
+	t.Run("reconnecting replays missed events", func(t *testing.T) {
+		missed := s.Events.Since(0)
+		last := missed[len(missed)-1].Id
+
+		s.Events.Publish(api.EventTypeCheckedIn, api.Switch{Id: &mine, UserId: &admin})
+
+		next := connect(t, strconv.FormatInt(last, 10))
+		event := eventData(t, next)
+		if event.Type != api.EventTypeCheckedIn || event.Id <= last {
+			t.Errorf("expected the missed check-in, got %+v", event)
+		}
+	})
+
+	t.Run("invalid Last-Event-ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/events", nil)
+		req.Header.Set(lastEventIDHeader, "abc")
+		rec := httptest.NewRecorder()
+		s.EventsHandleFunc(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+}
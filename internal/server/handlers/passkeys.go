@@ -0,0 +1,624 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/database"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+const (
+	errInvalidPasskey      = "Passkey couldn't be verified"
+	errInvalidPasskeyID    = "Invalid passkey ID"
+	errNoPasskeys          = "Register a passkey first"
+	errPasskeyConfirmation = "Confirm this change with one of your passkeys"
+	errPasskeyNotFound     = "Passkey not found"
+	errPasskeyRequired     = "This switch requires a passkey to check in"
+	errPasskeysUnavailable = "Passkeys require local auth mode"
+)
+
+// passkeyChallengeTTL is how long a passkey ceremony can take before its challenge expires.
+const passkeyChallengeTTL = 5 * time.Minute
+
+// Purposes of passkey challenges, so a challenge is only accepted by the endpoint that issued it.
+const (
+	passkeyPurposeConfirm  = "confirm"
+	passkeyPurposeLogin    = "login"
+	passkeyPurposeRegister = "register"
+)
+
+var (
+	errMissingPasskey    = errors.New("switch requires a passkey to check in")
+	errPasskeyChallenge  = errors.New("unknown or expired passkey challenge")
+	errPasskeyCloned     = errors.New("passkey sign count went backwards, it may have been cloned")
+	errPasskeyOtherOwner = errors.New("passkey belongs to another user")
+)
+
+// passkeyUser is a local user account with its passkeys, as the WebAuthn library sees it.
+type passkeyUser struct {
+	username    string
+	credentials []webauthn.Credential
+}
+
+// WebAuthnID returns a user handle derived from the username, so it doesn't reveal it.
+func (u passkeyUser) WebAuthnID() []byte {
+	return passkeyUserHandle(u.username)
+}
+
+func (u passkeyUser) WebAuthnName() string {
+	return u.username
+}
+
+func (u passkeyUser) WebAuthnDisplayName() string {
+	return u.username
+}
+
+func (u passkeyUser) WebAuthnCredentials() []webauthn.Credential {
+	return u.credentials
+}
+
+// passkeyUserHandle returns the WebAuthn user handle of a local user account.
+func passkeyUserHandle(username string) []byte {
+	handle := sha256.Sum256([]byte(username))
+	return handle[:]
+}
+
+// checkInPurpose is the purpose of a challenge that confirms a check-in to the given switch.
+func checkInPurpose(id int) string {
+	return fmt.Sprintf("checkin:%d", id)
+}
+
+// requiresPasskey reports whether checking in to a switch must be confirmed with a passkey.
+func requiresPasskey(sw api.Switch) bool {
+	return sw.RequirePasskey != nil && *sw.RequirePasskey
+}
+
+// checkRequirePasskey makes sure switches only require passkeys when the server can verify them. It sends
+// the error response itself and reports whether the request can continue.
+func (s *Switch) checkRequirePasskey(w http.ResponseWriter, sw api.Switch) bool {
+	if requiresPasskey(sw) && s.WebAuthn == nil {
+		s.sendError(w, http.StatusBadRequest, errPasskeysUnavailable, nil)
+		return false
+	}
+	return true
+}
+
+// PasskeysHandleFunc lists the passkeys of the user.
+func (s *Switch) PasskeysHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	passkeys, err := s.Store.GetPasskeys(middleware.GetUserIDFromContext(r))
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	resp := []api.Passkey{}
+	for _, passkey := range passkeys {
+		resp = append(resp, toAPIPasskey(passkey))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// PasskeyChallengeHandleFunc starts registering a passkey for the user's account. The credential created
+// from the returned options is registered with CreatePasskeyHandleFunc.
+func (s *Switch) PasskeyChallengeHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if !s.passkeysAvailable(w) {
+		return
+	}
+
+	account, ok := s.lookupAccount(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := s.passkeyUser(account.Username)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	exclusions := []protocol.CredentialDescriptor{}
+	for _, credential := range user.credentials {
+		exclusions = append(exclusions, credential.Descriptor())
+	}
+
+	// Passkeys have to be discoverable so they can sign in without entering a username
+	options, session, err := s.WebAuthn.BeginRegistration(user,
+		webauthn.WithExclusions(exclusions),
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementRequired),
+	)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, "Failed to start passkey registration", err)
+		return
+	}
+
+	if !s.storePasskeyChallenge(w, session, account.Username, passkeyPurposeRegister) {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(options)
+}
+
+// CreatePasskeyHandleFunc registers the passkey created from the options returned by PasskeyChallengeHandleFunc.
+func (s *Switch) CreatePasskeyHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if !s.passkeysAvailable(w) {
+		return
+	}
+
+	payload := api.PasskeyRegistration{}
+	if !s.decodeAccountRequest(w, r, &payload) {
+		return
+	}
+
+	account, ok := s.lookupAccount(w, r)
+	if !ok {
+		return
+	}
+
+	if !s.confirmWithPasskey(w, account.Username, payload.Passkey) {
+		return
+	}
+
+	body, err := json.Marshal(payload.Credential)
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errInvalidPasskey, err)
+		return
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(body)
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errInvalidPasskey, err)
+		return
+	}
+
+	session, err := s.takePasskeyChallenge(parsed.Response.CollectedClientData.Challenge, passkeyPurposeRegister, account.Username)
+	if err != nil {
+		s.sendPasskeyError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	user, err := s.passkeyUser(account.Username)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	credential, err := s.WebAuthn.CreateCredential(user, session, parsed)
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errInvalidPasskey, err)
+		return
+	}
+
+	encoded, err := json.Marshal(credential)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, "Failed to store passkey", err)
+		return
+	}
+
+	passkey, err := s.Store.CreatePasskey(database.Passkey{
+		Username:     account.Username,
+		Name:         payload.Name,
+		CredentialID: base64.RawURLEncoding.EncodeToString(credential.ID),
+		Credential:   string(encoded),
+		CreatedAt:    time.Now().Unix(),
+	})
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	s.Logger.Info("Registered passkey", "username", account.Username, "name", payload.Name)
+
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(toAPIPasskey(passkey))
+}
+
+// DeletePasskeyHandleFunc removes a passkey of the user once one of their passkeys confirmed it.
+func (s *Switch) DeletePasskeyHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID := middleware.GetUserIDFromContext(r)
+
+	id, err := strconv.Atoi(chi.URLParam(r, "passkeyId"))
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errInvalidPasskeyID, err)
+		return
+	}
+
+	// The confirmation is optional in the body, so an empty body is a missing confirmation
+	payload := api.PasskeyConfirmation{}
+
+	err = json.NewDecoder(r.Body).Decode(&payload)
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.sendError(w, http.StatusBadRequest, errInvalidJSON, err)
+		return
+	}
+
+	if !s.confirmWithPasskey(w, userID, payload.Passkey) {
+		return
+	}
+
+	err = s.Store.DeletePasskey(userID, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.sendError(w, http.StatusNotFound, errPasskeyNotFound, err)
+			return
+		}
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PasskeyLoginChallengeHandleFunc starts signing in with a passkey. Any passkey registered to the server
+// can sign the challenge, so no username is needed.
+func (s *Switch) PasskeyLoginChallengeHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if !s.passkeysAvailable(w) {
+		return
+	}
+
+	// Signing in replaces the password and TOTP code, so the authenticator has to verify the user as well
+	options, session, err := s.WebAuthn.BeginDiscoverableLogin(webauthn.WithUserVerification(protocol.VerificationRequired))
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, "Failed to start passkey sign in", err)
+		return
+	}
+
+	if !s.storePasskeyChallenge(w, session, "", passkeyPurposeLogin) {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(options)
+}
+
+// PasskeyLoginHandleFunc signs in as the owner of the passkey that signed the challenge returned by
+// PasskeyLoginChallengeHandleFunc and starts a session like LoginHandleFunc.
+func (s *Switch) PasskeyLoginHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if !s.passkeysAvailable(w) {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errInvalidJSON, err)
+		return
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(body)
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errInvalidPasskey, err)
+		return
+	}
+
+	session, err := s.takePasskeyChallenge(parsed.Response.CollectedClientData.Challenge, passkeyPurposeLogin, "")
+	if err != nil {
+		s.sendPasskeyError(w, http.StatusUnauthorized, err)
+		return
+	}
+
+	var passkey database.Passkey
+	lookup := func(rawID, userHandle []byte) (webauthn.User, error) {
+		passkey, err = s.Store.GetPasskeyByCredentialID(base64.RawURLEncoding.EncodeToString(rawID))
+		if err != nil {
+			return nil, err
+		}
+		return s.passkeyUser(passkey.Username)
+	}
+
+	user, credential, err := s.WebAuthn.ValidatePasskeyLogin(lookup, session, parsed)
+	if err == nil {
+		err = s.usePasskey(passkey, credential)
+	}
+	if err != nil {
+		s.Logger.Warn("Failed passkey sign in", "ip", clientIP(r), "error", err)
+		s.sendError(w, http.StatusUnauthorized, errInvalidPasskey, nil)
+		return
+	}
+
+	s.startSession(w, user.WebAuthnName())
+}
+
+// ResetChallengeHandleFunc starts a check-in confirmed with a passkey. The assertion made with the returned
+// options is sent as the passkey of the check-in request to ResetHandleFunc.
+func (s *Switch) ResetChallengeHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if !s.passkeysAvailable(w) {
+		return
+	}
+
+	userID := middleware.GetUserIDFromContext(r)
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errInvalidSwitchID, err)
+		return
+	}
+
+	_, err = s.getSwitchForCheckIn(userID, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.sendError(w, http.StatusNotFound, errSwitchNotFound, err)
+			return
+		}
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	user, err := s.passkeyUser(userID)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	if len(user.credentials) == 0 {
+		s.sendError(w, http.StatusBadRequest, errNoPasskeys, nil)
+		return
+	}
+
+	options, session, err := s.WebAuthn.BeginLogin(user)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, "Failed to start passkey check-in", err)
+		return
+	}
+
+	if !s.storePasskeyChallenge(w, session, userID, checkInPurpose(id)) {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(options)
+}
+
+// PasskeyConfirmChallengeHandleFunc starts confirming a change to the user's passkeys with one of them. The
+// assertion made with the returned options is sent as the passkey of the request making the change.
+func (s *Switch) PasskeyConfirmChallengeHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if !s.passkeysAvailable(w) {
+		return
+	}
+
+	userID := middleware.GetUserIDFromContext(r)
+
+	user, err := s.passkeyUser(userID)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	if len(user.credentials) == 0 {
+		s.sendError(w, http.StatusBadRequest, errNoPasskeys, nil)
+		return
+	}
+
+	options, session, err := s.WebAuthn.BeginLogin(user)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, "Failed to start passkey confirmation", err)
+		return
+	}
+
+	if !s.storePasskeyChallenge(w, session, userID, passkeyPurposeConfirm) {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(options)
+}
+
+// confirmWithPasskey makes sure a user with passkeys confirmed a change to them with one, signing a challenge
+// issued by PasskeyConfirmChallengeHandleFunc, so a stolen session or token alone can't add or remove a passkey
+// or stop requiring one. Users without passkeys have nothing to confirm with. It sends the error response itself
+// and reports whether the request can continue.
+func (s *Switch) confirmWithPasskey(w http.ResponseWriter, userID string, assertion *api.PasskeyCredential) bool {
+	if s.WebAuthn == nil {
+		return true
+	}
+
+	passkeys, err := s.Store.GetPasskeys(userID)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return false
+	}
+
+	if len(passkeys) == 0 {
+		return true
+	}
+
+	err = s.verifyPasskey(userID, passkeyPurposeConfirm, assertion)
+	if err != nil {
+		s.Logger.Warn("Change wasn't confirmed with a passkey", "username", userID, "error", err)
+		s.sendError(w, http.StatusForbidden, errPasskeyConfirmation, nil)
+		return false
+	}
+
+	return true
+}
+
+// verifyCheckInPasskey checks that a passkey of the user signed a challenge issued by ResetChallengeHandleFunc
+// for the given switch.
+func (s *Switch) verifyCheckInPasskey(userID string, id int, assertion *api.PasskeyCredential) error {
+	return s.verifyPasskey(userID, checkInPurpose(id), assertion)
+}
+
+// verifyPasskey checks that a passkey of the user signed a challenge issued for the given purpose.
+func (s *Switch) verifyPasskey(userID, purpose string, assertion *api.PasskeyCredential) error {
+	if s.WebAuthn == nil || assertion == nil {
+		return errMissingPasskey
+	}
+
+	body, err := json.Marshal(assertion)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(body)
+	if err != nil {
+		return err
+	}
+
+	session, err := s.takePasskeyChallenge(parsed.Response.CollectedClientData.Challenge, purpose, userID)
+	if err != nil {
+		return err
+	}
+
+	passkey, err := s.Store.GetPasskeyByCredentialID(base64.RawURLEncoding.EncodeToString(parsed.RawID))
+	if err != nil {
+		return err
+	}
+
+	if passkey.Username != userID {
+		return errPasskeyOtherOwner
+	}
+
+	user, err := s.passkeyUser(userID)
+	if err != nil {
+		return err
+	}
+
+	credential, err := s.WebAuthn.ValidateLogin(user, session, parsed)
+	if err != nil {
+		return err
+	}
+
+	return s.usePasskey(passkey, credential)
+}
+
+// usePasskey stores the sign count of a passkey that was just verified. A sign count that didn't increase means
+// the authenticator may have been cloned, so the passkey is rejected.
+func (s *Switch) usePasskey(passkey database.Passkey, credential *webauthn.Credential) error {
+	if credential.Authenticator.CloneWarning {
+		return errPasskeyCloned
+	}
+
+	encoded, err := json.Marshal(credential)
+	if err != nil {
+		return err
+	}
+
+	return s.Store.UpdatePasskeyCredential(passkey.ID, string(encoded), time.Now().Unix())
+}
+
+// passkeyUser loads a local user account with its passkeys.
+func (s *Switch) passkeyUser(username string) (passkeyUser, error) {
+	passkeys, err := s.Store.GetPasskeys(username)
+	if err != nil {
+		return passkeyUser{}, err
+	}
+
+	user := passkeyUser{username: username}
+	for _, passkey := range passkeys {
+		credential := webauthn.Credential{}
+		err := json.Unmarshal([]byte(passkey.Credential), &credential)
+		if err != nil {
+			return passkeyUser{}, err
+		}
+		user.credentials = append(user.credentials, credential)
+	}
+
+	return user, nil
+}
+
+// storePasskeyChallenge stores a started passkey ceremony until it is completed. It sends the error response
+// itself and reports whether the request can continue.
+func (s *Switch) storePasskeyChallenge(w http.ResponseWriter, session *webauthn.SessionData, username, purpose string) bool {
+	encoded, err := json.Marshal(session)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, "Failed to store passkey challenge", err)
+		return false
+	}
+
+	err = s.Store.CreatePasskeyChallenge(database.PasskeyChallenge{
+		Challenge: session.Challenge,
+		Username:  username,
+		Purpose:   purpose,
+		Session:   string(encoded),
+		ExpiresAt: time.Now().Add(passkeyChallengeTTL).Unix(),
+	})
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return false
+	}
+
+	return true
+}
+
+// takePasskeyChallenge returns the session of the started passkey ceremony with the given challenge, so it can
+// only be completed once, by the user it was issued to.
+func (s *Switch) takePasskeyChallenge(challenge, purpose, username string) (webauthn.SessionData, error) {
+	stored, err := s.Store.TakePasskeyChallenge(challenge, purpose, time.Now().Unix())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return webauthn.SessionData{}, errPasskeyChallenge
+		}
+		return webauthn.SessionData{}, err
+	}
+
+	if stored.Username != username {
+		return webauthn.SessionData{}, errPasskeyChallenge
+	}
+
+	session := webauthn.SessionData{}
+	err = json.Unmarshal([]byte(stored.Session), &session)
+	if err != nil {
+		return webauthn.SessionData{}, err
+	}
+
+	return session, nil
+}
+
+// sendPasskeyError reports a failure to find the challenge of a passkey ceremony.
+func (s *Switch) sendPasskeyError(w http.ResponseWriter, code int, err error) {
+	if errors.Is(err, errPasskeyChallenge) {
+		s.sendError(w, code, errInvalidPasskey, err)
+		return
+	}
+	s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+}
+
+// passkeysAvailable makes sure the server can verify passkeys. It sends the error response itself and reports
+// whether the request can continue.
+func (s *Switch) passkeysAvailable(w http.ResponseWriter) bool {
+	if s.WebAuthn == nil {
+		s.sendError(w, http.StatusBadRequest, errPasskeysUnavailable, nil)
+		return false
+	}
+	return true
+}
+
+// toAPIPasskey converts a stored passkey to its API representation, leaving out the credential.
+func toAPIPasskey(passkey database.Passkey) api.Passkey {
+	return api.Passkey{
+		Id:         passkey.ID,
+		Name:       passkey.Name,
+		CreatedAt:  passkey.CreatedAt,
+		LastUsedAt: passkey.LastUsedAt,
+	}
+}
@@ -0,0 +1,395 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/database"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+	"github.com/circa10a/dead-mans-switch/internal/server/secrets"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+const testOrigin = "https://dms.example.com"
+
+// testAuthenticator is a software passkey that answers WebAuthn ceremonies like a browser would.
+type testAuthenticator struct {
+	t          *testing.T
+	key        *ecdsa.PrivateKey
+	id         []byte
+	userHandle []byte
+	signCount  uint32
+}
+
+func newTestAuthenticator(t *testing.T) *testAuthenticator {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+
+	return &testAuthenticator{t: t, key: key, id: id}
+}
+
+// challenge returns the challenge of WebAuthn options returned by the server.
+func (a *testAuthenticator) challenge(rec *httptest.ResponseRecorder) string {
+	a.t.Helper()
+
+	if rec.Code != http.StatusOK {
+		a.t.Fatalf("expected 200 for the options, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	options := struct {
+		PublicKey struct {
+			Challenge string `json:"challenge"`
+			User      struct {
+				ID string `json:"id"`
+			} `json:"user"`
+		} `json:"publicKey"`
+	}{}
+	_ = json.NewDecoder(rec.Body).Decode(&options)
+
+	if options.PublicKey.User.ID != "" {
+		a.userHandle, _ = base64.RawURLEncoding.DecodeString(options.PublicKey.User.ID)
+	}
+
+	return options.PublicKey.Challenge
+}
+
+func (a *testAuthenticator) clientData(ceremony, challenge string) []byte {
+	data, _ := json.Marshal(map[string]string{"type": ceremony, "challenge": challenge, "origin": testOrigin})
+	return data
+}
+
+func (a *testAuthenticator) authData(flags byte, attested []byte) []byte {
+	rpIDHash := sha256.Sum256([]byte("dms.example.com"))
+
+	data := append([]byte{}, rpIDHash[:]...)
+	data = append(data, flags)
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+	return append(data, attested...)
+}
+
+// create answers registration options with a new credential.
+func (a *testAuthenticator) create(challenge string) api.PasskeyCredential {
+	a.t.Helper()
+
+	publicKey, err := cbor.Marshal(map[int]any{
+		1:  2,
+		3:  -7,
+		-1: 1,
+		-2: a.key.X.FillBytes(make([]byte, 32)),
+		-3: a.key.Y.FillBytes(make([]byte, 32)),
+	})
+	if err != nil {
+		a.t.Fatalf("failed to encode public key: %v", err)
+	}
+
+	attested := make([]byte, 16)
+	attested = binary.BigEndian.AppendUint16(attested, uint16(len(a.id)))
+	attested = append(attested, a.id...)
+	attested = append(attested, publicKey...)
+
+	// User present, user verified and attested credential data included
+	attestation, err := cbor.Marshal(map[string]any{
+		"fmt":      "none",
+		"attStmt":  map[string]any{},
+		"authData": a.authData(0x45, attested),
+	})
+	if err != nil {
+		a.t.Fatalf("failed to encode attestation: %v", err)
+	}
+
+	return a.credential(map[string]any{
+		"clientDataJSON":    base64.RawURLEncoding.EncodeToString(a.clientData("webauthn.create", challenge)),
+		"attestationObject": base64.RawURLEncoding.EncodeToString(attestation),
+	})
+}
+
+// get answers assertion options by signing the challenge.
+func (a *testAuthenticator) get(challenge string) api.PasskeyCredential {
+	a.t.Helper()
+
+	a.signCount++
+
+	// User present and user verified
+	authData := a.authData(0x05, nil)
+	clientData := a.clientData("webauthn.get", challenge)
+	clientDataHash := sha256.Sum256(clientData)
+
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		a.t.Fatalf("failed to sign: %v", err)
+	}
+
+	return a.credential(map[string]any{
+		"clientDataJSON":    base64.RawURLEncoding.EncodeToString(clientData),
+		"authenticatorData": base64.RawURLEncoding.EncodeToString(authData),
+		"signature":         base64.RawURLEncoding.EncodeToString(signature),
+		"userHandle":        base64.RawURLEncoding.EncodeToString(a.userHandle),
+	})
+}
+
+func (a *testAuthenticator) credential(response map[string]any) api.PasskeyCredential {
+	id := base64.RawURLEncoding.EncodeToString(a.id)
+	return api.PasskeyCredential{"id": id, "rawId": id, "type": "public-key", "response": response}
+}
+
+func TestPasskeys(t *testing.T) {
+	s, store := setupTestHandler(t)
+	s.SessionDuration = time.Hour
+
+	var err error
+	s.WebAuthn, err = webauthn.New(&webauthn.Config{RPID: "dms.example.com", RPDisplayName: "Dead Man's Switch", RPOrigins: []string{testOrigin}})
+	if err != nil {
+		t.Fatalf("failed to configure webauthn: %v", err)
+	}
+
+	err = store.CreateUser(database.User{Username: "alice", PasswordHash: "hash", CreatedAt: time.Now().Unix()})
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	r := chi.NewRouter()
+	r.Get("/api/v1/auth/passkeys", s.PasskeysHandleFunc)
+	r.Post("/api/v1/auth/passkeys", s.CreatePasskeyHandleFunc)
+	r.Post("/api/v1/auth/passkeys/challenge", s.PasskeyChallengeHandleFunc)
+	r.Post("/api/v1/auth/passkeys/confirm/challenge", s.PasskeyConfirmChallengeHandleFunc)
+	r.Delete("/api/v1/auth/passkeys/{passkeyId}", s.DeletePasskeyHandleFunc)
+	r.Post("/api/v1/auth/login/passkey", s.PasskeyLoginHandleFunc)
+	r.Post("/api/v1/auth/login/passkey/challenge", s.PasskeyLoginChallengeHandleFunc)
+	r.Post("/api/v1/switch/{id}/reset", s.ResetHandleFunc)
+	r.Post("/api/v1/switch/{id}/reset/challenge", s.ResetChallengeHandleFunc)
+	r.With(middleware.SwitchValidator(validator.New())).Put("/api/v1/switch/{id}", s.PutByIDHandleFunc)
+
+	do := func(method, path, userID string, body any) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			_ = json.NewEncoder(&buf).Encode(body)
+		}
+		req := httptest.NewRequest(method, path, &buf)
+		req = req.WithContext(middleware.WithUserID(req.Context(), userID))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	authenticator := newTestAuthenticator(t)
+
+	// confirm signs a confirmation challenge with the first passkey
+	confirm := func() *api.PasskeyCredential {
+		challenge := authenticator.challenge(do(http.MethodPost, "/api/v1/auth/passkeys/confirm/challenge", "alice", nil))
+		return ptr(authenticator.get(challenge))
+	}
+
+	t.Run("registering a passkey", func(t *testing.T) {
+		challenge := authenticator.challenge(do(http.MethodPost, "/api/v1/auth/passkeys/challenge", "alice", nil))
+
+		rec := do(http.MethodPost, "/api/v1/auth/passkeys", "alice", api.PasskeyRegistration{Name: "YubiKey", Credential: authenticator.create(challenge)})
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		// The challenge was used up
+		rec = do(http.MethodPost, "/api/v1/auth/passkeys", "alice", api.PasskeyRegistration{Name: "Again", Credential: authenticator.create(challenge), Passkey: confirm()})
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400 for a reused challenge, got %d", rec.Code)
+		}
+
+		rec = do(http.MethodGet, "/api/v1/auth/passkeys", "alice", nil)
+		passkeys := []api.Passkey{}
+		_ = json.NewDecoder(rec.Body).Decode(&passkeys)
+		if len(passkeys) != 1 || passkeys[0].Name != "YubiKey" {
+			t.Errorf("unexpected passkeys %+v", passkeys)
+		}
+	})
+
+	t.Run("adding another passkey has to be confirmed with one", func(t *testing.T) {
+		spare := newTestAuthenticator(t)
+
+		challenge := spare.challenge(do(http.MethodPost, "/api/v1/auth/passkeys/challenge", "alice", nil))
+		rec := do(http.MethodPost, "/api/v1/auth/passkeys", "alice", api.PasskeyRegistration{Name: "Stolen session", Credential: spare.create(challenge)})
+		if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), errPasskeyConfirmation) {
+			t.Errorf("expected 403 %q, got %d: %s", errPasskeyConfirmation, rec.Code, rec.Body.String())
+		}
+
+		challenge = spare.challenge(do(http.MethodPost, "/api/v1/auth/passkeys/challenge", "alice", nil))
+		rec = do(http.MethodPost, "/api/v1/auth/passkeys", "alice", api.PasskeyRegistration{Name: "Spare", Credential: spare.create(challenge), Passkey: confirm()})
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("signing in with a passkey", func(t *testing.T) {
+		challenge := authenticator.challenge(do(http.MethodPost, "/api/v1/auth/login/passkey/challenge", "", nil))
+
+		rec := do(http.MethodPost, "/api/v1/auth/login/passkey", "", authenticator.get(challenge))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		session := api.Session{}
+		_ = json.NewDecoder(rec.Body).Decode(&session)
+		if session.Username != "alice" || !strings.HasPrefix(session.Token, middleware.SessionTokenPrefix) {
+			t.Errorf("unexpected session %+v", session)
+		}
+
+		// A replayed assertion is rejected
+		rec = do(http.MethodPost, "/api/v1/auth/login/passkey", "", authenticator.get(challenge))
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("expected 401 for a replayed challenge, got %d", rec.Code)
+		}
+	})
+
+	requirePasskey := true
+	sw, err := store.Create(api.Switch{
+		UserId:          ptr("alice"),
+		Message:         "test",
+		Notifiers:       []string{"logger://"},
+		CheckInInterval: "1h",
+		Status:          ptr(api.SwitchStatusActive),
+		RequirePasskey:  &requirePasskey,
+		CheckInToken:    ptr(secrets.HashToken("check-in-token")),
+	})
+	if err != nil {
+		t.Fatalf("failed to create switch: %v", err)
+	}
+	resetPath := "/api/v1/switch/" + strconv.Itoa(*sw.Id) + "/reset"
+
+	t.Run("checking in without a passkey is rejected", func(t *testing.T) {
+		rec := do(http.MethodPost, resetPath, "alice", nil)
+		if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), errPasskeyRequired) {
+			t.Errorf("expected 403 %q, got %d: %s", errPasskeyRequired, rec.Code, rec.Body.String())
+		}
+
+		_, err := s.CheckInWithToken("check-in-token", api.CheckInMethodMQTT)
+		if !errors.Is(err, errMissingPasskey) {
+			t.Errorf("expected errMissingPasskey for a token check-in, got %v", err)
+		}
+	})
+
+	t.Run("checking in with a passkey", func(t *testing.T) {
+		challenge := authenticator.challenge(do(http.MethodPost, resetPath+"/challenge", "alice", nil))
+
+		rec := do(http.MethodPost, resetPath, "alice", api.CheckInRequest{Passkey: ptr(authenticator.get(challenge))})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		// The assertion can't be replayed by someone who intercepted it
+		rec = do(http.MethodPost, resetPath, "alice", api.CheckInRequest{Passkey: ptr(authenticator.get(challenge))})
+		if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), errInvalidPasskey) {
+			t.Errorf("expected 403 %q, got %d: %s", errInvalidPasskey, rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("challenges are bound to the switch and user", func(t *testing.T) {
+		challenge := authenticator.challenge(do(http.MethodPost, "/api/v1/auth/login/passkey/challenge", "", nil))
+
+		rec := do(http.MethodPost, resetPath, "alice", api.CheckInRequest{Passkey: ptr(authenticator.get(challenge))})
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("expected 403 for a sign in challenge, got %d", rec.Code)
+		}
+
+		rec = do(http.MethodPost, resetPath+"/challenge", "bob", nil)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404 for another user's switch, got %d", rec.Code)
+		}
+	})
+
+	t.Run("no longer requiring a passkey has to be confirmed with one", func(t *testing.T) {
+		update := api.Switch{
+			Message:         "test",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "1h",
+			RequirePasskey:  ptr(false),
+		}
+
+		rec := do(http.MethodPut, "/api/v1/switch/"+strconv.Itoa(*sw.Id), "alice", update)
+		if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), errPasskeyConfirmation) {
+			t.Errorf("expected 403 %q, got %d: %s", errPasskeyConfirmation, rec.Code, rec.Body.String())
+		}
+
+		update.Passkey = confirm()
+		rec = do(http.MethodPut, "/api/v1/switch/"+strconv.Itoa(*sw.Id), "alice", update)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		stored, err := store.GetByID("alice", *sw.Id)
+		if err != nil {
+			t.Fatalf("failed to get switch: %v", err)
+		}
+		if requiresPasskey(stored) {
+			t.Error("expected the switch to no longer require a passkey")
+		}
+	})
+
+	t.Run("removing a passkey", func(t *testing.T) {
+		passkeys, _ := store.GetPasskeys("alice")
+
+		rec := do(http.MethodDelete, "/api/v1/auth/passkeys/"+strconv.Itoa(passkeys[0].ID), "bob", nil)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404 for another user's passkey, got %d", rec.Code)
+		}
+
+		rec = do(http.MethodDelete, "/api/v1/auth/passkeys/"+strconv.Itoa(passkeys[1].ID), "alice", nil)
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("expected 403 without a confirmation, got %d", rec.Code)
+		}
+
+		// The first passkey confirms both removals, so it goes last
+		for _, passkey := range []database.Passkey{passkeys[1], passkeys[0]} {
+			rec = do(http.MethodDelete, "/api/v1/auth/passkeys/"+strconv.Itoa(passkey.ID), "alice", api.PasskeyConfirmation{Passkey: confirm()})
+			if rec.Code != http.StatusNoContent {
+				t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+			}
+		}
+
+		rec = do(http.MethodPost, resetPath+"/challenge", "alice", nil)
+		if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), errNoPasskeys) {
+			t.Errorf("expected 400 %q, got %d: %s", errNoPasskeys, rec.Code, rec.Body.String())
+		}
+	})
+}
+
+func TestRequirePasskeyNeedsLocalAuth(t *testing.T) {
+	s, _ := setupTestHandler(t)
+
+	requirePasskey := true
+	rec := httptest.NewRecorder()
+	if s.checkRequirePasskey(rec, api.Switch{RequirePasskey: &requirePasskey}) {
+		t.Fatal("expected switches requiring a passkey to be rejected without passkey support")
+	}
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), errPasskeysUnavailable) {
+		t.Errorf("expected 400 %q, got %d: %s", errPasskeysUnavailable, rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	s.PasskeyLoginChallengeHandleFunc(rec, httptest.NewRequest(http.MethodPost, "/api/v1/auth/login/passkey/challenge", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 without passkey support, got %d", rec.Code)
+	}
+}
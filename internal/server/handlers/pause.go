@@ -0,0 +1,311 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+	"github.com/circa10a/dead-mans-switch/internal/server/switches"
+	"github.com/go-chi/chi/v5"
+)
+
+// Error messages
+const (
+	errInvalidPauseRequest = "Invalid pause request"
+	errFailedToPause       = "Failed to pause switch"
+	errFailedToResume      = "Failed to resume switch"
+	errSwitchNotPausable   = "Only active or paused switches can be paused"
+	errSwitchNotPaused     = "Switch is not paused"
+)
+
+// PauseHandleFunc pauses a switch until a date or for a duration.
+func (s *Switch) PauseHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID := middleware.GetUserIDFromContext(r)
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errInvalidSwitchID, err)
+		return
+	}
+
+	until, policy, errMsg := s.parsePauseRequest(r)
+	if errMsg != "" {
+		s.sendError(w, http.StatusBadRequest, errMsg, nil)
+		return
+	}
+
+	switchToPause, err := s.Store.GetByID(userID, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.sendError(w, http.StatusNotFound, errSwitchNotFound, err)
+			return
+		}
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	if !switches.Pausable(switchToPause) {
+		s.sendError(w, http.StatusConflict, errSwitchNotPausable, nil)
+		return
+	}
+
+	now := time.Now()
+
+	if s.pauseTooLong(switchToPause, until, now) {
+		s.sendError(w, http.StatusBadRequest, s.pauseTooLongMessage(), nil)
+		return
+	}
+
+	// Pausing a protected switch stops its countdown, so it waits for approval or a delay like disabling it
+	if switches.IsProtected(switchToPause) {
+		s.requestPause(w, switchToPause, until, policy)
+		return
+	}
+
+	switches.Pause(&switchToPause, now, until, policy)
+
+	pausedSwitch, err := s.save(id, switchToPause)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errFailedToPause, err)
+		return
+	}
+
+	s.Events.Publish(api.EventTypePaused, pausedSwitch)
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(s.redact(pausedSwitch))
+}
+
+// PauseAllHandleFunc pauses every active switch owned by the caller. Protected switches are left running.
+func (s *Switch) PauseAllHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID := middleware.GetUserIDFromContext(r)
+
+	until, policy, errMsg := s.parsePauseRequest(r)
+	if errMsg != "" {
+		s.sendError(w, http.StatusBadRequest, errMsg, nil)
+		return
+	}
+
+	owned, err := s.Store.GetAll(userID, defaultLimit)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	now := time.Now()
+
+	// Nothing is paused unless every switch can be paused that long
+	for _, sw := range owned {
+		if canPauseAll(sw) && s.pauseTooLong(sw, until, now) {
+			s.sendError(w, http.StatusBadRequest, s.pauseTooLongMessage(), nil)
+			return
+		}
+	}
+
+	pausedSwitches := []api.Switch{}
+
+	for _, sw := range owned {
+		if !canPauseAll(sw) {
+			continue
+		}
+
+		switches.Pause(&sw, now, until, policy)
+
+		pausedSwitch, err := s.save(*sw.Id, sw)
+		if err != nil {
+			s.sendError(w, http.StatusInternalServerError, errFailedToPause, err)
+			return
+		}
+
+		s.Events.Publish(api.EventTypePaused, pausedSwitch)
+		pausedSwitches = append(pausedSwitches, pausedSwitch)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(s.redactAll(pausedSwitches))
+}
+
+// ResumeHandleFunc re-arms a paused switch before its pause ends.
+func (s *Switch) ResumeHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID := middleware.GetUserIDFromContext(r)
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errInvalidSwitchID, err)
+		return
+	}
+
+	switchToResume, err := s.Store.GetByID(userID, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.sendError(w, http.StatusNotFound, errSwitchNotFound, err)
+			return
+		}
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	if switchToResume.Status == nil || *switchToResume.Status != api.SwitchStatusPaused {
+		s.sendError(w, http.StatusConflict, errSwitchNotPaused, nil)
+		return
+	}
+
+	err = switches.Resume(&switchToResume, time.Now())
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errTimeParse, err)
+		return
+	}
+
+	resumedSwitch, err := s.save(id, switchToResume)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errFailedToResume, err)
+		return
+	}
+
+	s.Events.Publish(api.EventTypeResumed, resumedSwitch)
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(s.redact(resumedSwitch))
+}
+
+// ResumeAllHandleFunc re-arms every paused switch owned by the caller.
+func (s *Switch) ResumeAllHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID := middleware.GetUserIDFromContext(r)
+
+	owned, err := s.Store.GetAll(userID, defaultLimit)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	now := time.Now()
+	resumedSwitches := []api.Switch{}
+
+	for _, sw := range owned {
+		if sw.Status == nil || *sw.Status != api.SwitchStatusPaused {
+			continue
+		}
+
+		err = switches.Resume(&sw, now)
+		if err != nil {
+			s.sendError(w, http.StatusInternalServerError, errFailedToResume, err)
+			return
+		}
+
+		resumedSwitch, err := s.save(*sw.Id, sw)
+		if err != nil {
+			s.sendError(w, http.StatusInternalServerError, errFailedToResume, err)
+			return
+		}
+
+		s.Events.Publish(api.EventTypeResumed, resumedSwitch)
+		resumedSwitches = append(resumedSwitches, resumedSwitch)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(s.redactAll(resumedSwitches))
+}
+
+// parsePauseRequest decodes and validates a pause request, returning when the pause ends.
+// A non-empty message is returned when the request is invalid.
+func (s *Switch) parsePauseRequest(r *http.Request) (time.Time, api.SwitchResumePolicy, string) {
+	req := api.PauseRequest{}
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return time.Time{}, "", errInvalidJSON
+	}
+
+	now := time.Now()
+
+	var until time.Time
+
+	switch {
+	case req.Until != nil && req.Duration != nil:
+		return time.Time{}, "", errInvalidPauseRequest + ": only one of until or duration can be set"
+	case req.Until != nil:
+		until = time.Unix(*req.Until, 0)
+	case req.Duration != nil:
+		d, err := time.ParseDuration(*req.Duration)
+		if err != nil {
+			return time.Time{}, "", "Invalid duration format (e.g., 12h, 72h)"
+		}
+		until = now.Add(d)
+	default:
+		return time.Time{}, "", errInvalidPauseRequest + ": until or duration is required"
+	}
+
+	if !until.After(now) {
+		return time.Time{}, "", errInvalidPauseRequest + ": pause must end in the future"
+	}
+
+	policy := api.SwitchResumePolicyPreserve
+	if req.ResumePolicy != nil {
+		switch *req.ResumePolicy {
+		case api.PauseRequestResumePolicyPreserve:
+		case api.PauseRequestResumePolicyReset:
+			policy = api.SwitchResumePolicyReset
+		default:
+			return time.Time{}, "", errInvalidPauseRequest + ": resumePolicy must be preserve or reset"
+		}
+	}
+
+	return until, policy, ""
+}
+
+// pauseTooLong reports whether pausing a switch until the given time exceeds the maximum pause duration.
+// Extending a pause counts from when the switch was first paused, so a pause can't be renewed indefinitely.
+func (s *Switch) pauseTooLong(sw api.Switch, until, now time.Time) bool {
+	if s.MaxPauseDuration <= 0 {
+		return false
+	}
+
+	start := now
+	if sw.Status != nil && *sw.Status == api.SwitchStatusPaused && sw.PausedAt != nil {
+		start = time.Unix(*sw.PausedAt, 0)
+	}
+
+	return until.Sub(start) > s.MaxPauseDuration
+}
+
+// pauseTooLongMessage describes the maximum pause duration.
+func (s *Switch) pauseTooLongMessage() string {
+	return fmt.Sprintf("%s: pause cannot be longer than %s", errInvalidPauseRequest, s.MaxPauseDuration)
+}
+
+// canPauseAll reports whether pausing every switch at once pauses a switch. Protected switches are paused
+// one at a time, since pausing them waits for approval or a delay.
+func canPauseAll(sw api.Switch) bool {
+	return switches.Pausable(sw) && !switches.IsProtected(sw)
+}
+
+// requestPause holds back pausing a protected switch until it is approved or its delay passes.
+func (s *Switch) requestPause(w http.ResponseWriter, sw api.Switch, until time.Time, policy api.SwitchResumePolicy) {
+	untilUnix := until.Unix()
+	resumePolicy := api.PauseRequestResumePolicy(policy)
+
+	payload, err := json.Marshal(api.PauseRequest{Until: &untilUnix, ResumePolicy: &resumePolicy})
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errFailedToPause, err)
+		return
+	}
+
+	encoded := string(payload)
+	summary := fmt.Sprintf("Pause the switch until %s", until.UTC().Format(time.RFC3339))
+
+	s.requestProtectedChange(w, sw, api.PendingChangeActionPause, summary, &encoded)
+}
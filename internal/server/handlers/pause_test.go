@@ -0,0 +1,332 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestPauseHandleFunc(t *testing.T) {
+	s, store := setupTestHandler(t)
+	s.MaxPauseDuration = 7 * 24 * time.Hour
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/switch/{id}/pause", s.PauseHandleFunc)
+
+	createSwitch := func(t *testing.T, status api.SwitchStatus) api.Switch {
+		t.Helper()
+		triggerAt := time.Now().Add(2 * time.Hour).Unix()
+		sw, err := store.Create(api.Switch{
+			Message:         "Pause Me",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "24h",
+			Status:          &status,
+			TriggerAt:       &triggerAt,
+		})
+		if err != nil {
+			t.Fatalf("failed to seed switch: %v", err)
+		}
+		return sw
+	}
+
+	pause := func(id int, body any) *httptest.ResponseRecorder {
+		b, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/switch/%d/pause", id), bytes.NewBuffer(b))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("pauses an active switch for a duration", func(t *testing.T) {
+		sw := createSwitch(t, api.SwitchStatusActive)
+
+		rec := pause(*sw.Id, api.PauseRequest{Duration: ptr("72h")})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		resp := api.Switch{}
+		err := json.NewDecoder(rec.Body).Decode(&resp)
+		if err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+
+		if *resp.Status != api.SwitchStatusPaused {
+			t.Errorf("expected status paused, got %s", *resp.Status)
+		}
+		if resp.PausedAt == nil || resp.PausedUntil == nil {
+			t.Fatal("expected pausedAt and pausedUntil to be set")
+		}
+		expectedUntil := time.Now().Add(72 * time.Hour).Unix()
+		if *resp.PausedUntil < expectedUntil-5 || *resp.PausedUntil > expectedUntil+5 {
+			t.Errorf("expected pausedUntil to be approx %d, got %d", expectedUntil, *resp.PausedUntil)
+		}
+		if resp.ResumePolicy == nil || *resp.ResumePolicy != api.SwitchResumePolicyPreserve {
+			t.Errorf("expected default resume policy preserve, got %v", resp.ResumePolicy)
+		}
+	})
+
+	t.Run("pauses until a timestamp with reset policy", func(t *testing.T) {
+		sw := createSwitch(t, api.SwitchStatusActive)
+		until := time.Now().Add(24 * time.Hour).Unix()
+		policy := api.PauseRequestResumePolicyReset
+
+		rec := pause(*sw.Id, api.PauseRequest{Until: &until, ResumePolicy: &policy})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		resp := api.Switch{}
+		_ = json.NewDecoder(rec.Body).Decode(&resp)
+		if *resp.PausedUntil != until {
+			t.Errorf("expected pausedUntil %d, got %d", until, *resp.PausedUntil)
+		}
+		if *resp.ResumePolicy != api.SwitchResumePolicyReset {
+			t.Errorf("expected resume policy reset, got %s", *resp.ResumePolicy)
+		}
+	})
+
+	t.Run("rejects pauses longer than the maximum", func(t *testing.T) {
+		sw := createSwitch(t, api.SwitchStatusActive)
+
+		rec := pause(*sw.Id, api.PauseRequest{Duration: ptr("720h")})
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("extending a pause can't exceed the maximum from when it started", func(t *testing.T) {
+		statusPaused := api.SwitchStatusPaused
+		pausedAt := time.Now().Add(-5 * 24 * time.Hour).Unix()
+		pausedUntil := time.Now().Add(time.Hour).Unix()
+
+		sw, err := store.Create(api.Switch{
+			Message:         "Paused",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "24h",
+			Status:          &statusPaused,
+			PausedAt:        &pausedAt,
+			PausedUntil:     &pausedUntil,
+		})
+		if err != nil {
+			t.Fatalf("failed to seed switch: %v", err)
+		}
+
+		rec := pause(*sw.Id, api.PauseRequest{Duration: ptr("72h")})
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+
+		rec = pause(*sw.Id, api.PauseRequest{Duration: ptr("24h")})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		resp := api.Switch{}
+		_ = json.NewDecoder(rec.Body).Decode(&resp)
+		if resp.PausedAt == nil || *resp.PausedAt != pausedAt {
+			t.Errorf("expected pausedAt to stay %d, got %v", pausedAt, resp.PausedAt)
+		}
+	})
+
+	t.Run("rejects invalid requests", func(t *testing.T) {
+		sw := createSwitch(t, api.SwitchStatusActive)
+		past := time.Now().Add(-time.Hour).Unix()
+
+		for name, body := range map[string]api.PauseRequest{
+			"missing until and duration": {},
+			"both until and duration":    {Until: &past, Duration: ptr("1h")},
+			"until in the past":          {Until: &past},
+			"invalid duration":           {Duration: ptr("soon")},
+		} {
+			rec := pause(*sw.Id, body)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("%s: expected 400, got %d", name, rec.Code)
+			}
+		}
+	})
+
+	t.Run("returns 409 for a triggered switch", func(t *testing.T) {
+		sw := createSwitch(t, api.SwitchStatusTriggered)
+
+		rec := pause(*sw.Id, api.PauseRequest{Duration: ptr("1h")})
+		if rec.Code != http.StatusConflict {
+			t.Errorf("expected 409, got %d", rec.Code)
+		}
+	})
+
+	t.Run("returns 404 for non-existent switch", func(t *testing.T) {
+		rec := pause(999, api.PauseRequest{Duration: ptr("1h")})
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", rec.Code)
+		}
+	})
+
+	t.Run("pausing an encrypted switch keeps its content intact", func(t *testing.T) {
+		created, err := store.Create(api.Switch{
+			Message:         "secret",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "24h",
+			Encrypted:       ptr(true),
+			Status:          &statusActive,
+		})
+		if err != nil {
+			t.Fatalf("failed to seed switch: %v", err)
+		}
+
+		rec := pause(*created.Id, api.PauseRequest{Duration: ptr("1h")})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+
+		stored, err := store.GetByID("admin", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to get switch: %v", err)
+		}
+
+		err = store.DecryptSwitch(&stored)
+		if err != nil {
+			t.Fatalf("failed to decrypt switch: %v", err)
+		}
+		if stored.Message != "secret" {
+			t.Errorf("expected message to decrypt to %q, got %q", "secret", stored.Message)
+		}
+	})
+}
+
+func TestResumeHandleFunc(t *testing.T) {
+	s, store := setupTestHandler(t)
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/switch/{id}/resume", s.ResumeHandleFunc)
+
+	t.Run("resumes a paused switch preserving remaining time", func(t *testing.T) {
+		now := time.Now()
+		triggerAt := now.Add(-time.Hour).Add(30 * time.Minute).Unix()
+		pausedAt := now.Add(-time.Hour).Unix()
+		pausedUntil := now.Add(time.Hour).Unix()
+		policy := api.SwitchResumePolicyPreserve
+		status := api.SwitchStatusPaused
+
+		sw, err := store.Create(api.Switch{
+			Message:         "Resume Me",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "24h",
+			Status:          &status,
+			TriggerAt:       &triggerAt,
+			PausedAt:        &pausedAt,
+			PausedUntil:     &pausedUntil,
+			ResumePolicy:    &policy,
+		})
+		if err != nil {
+			t.Fatalf("failed to seed switch: %v", err)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/switch/%d/resume", *sw.Id), nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		resp := api.Switch{}
+		_ = json.NewDecoder(rec.Body).Decode(&resp)
+
+		if *resp.Status != api.SwitchStatusActive {
+			t.Errorf("expected status active, got %s", *resp.Status)
+		}
+		if resp.PausedAt != nil || resp.PausedUntil != nil || resp.ResumePolicy != nil {
+			t.Error("expected pause fields to be cleared")
+		}
+		expected := time.Now().Add(30 * time.Minute).Unix()
+		if *resp.TriggerAt < expected-5 || *resp.TriggerAt > expected+5 {
+			t.Errorf("expected triggerAt approx %d, got %d", expected, *resp.TriggerAt)
+		}
+	})
+
+	t.Run("returns 409 when switch is not paused", func(t *testing.T) {
+		sw, err := store.Create(api.Switch{
+			Message:         "Active",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "24h",
+			Status:          &statusActive,
+		})
+		if err != nil {
+			t.Fatalf("failed to seed switch: %v", err)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/switch/%d/resume", *sw.Id), nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusConflict {
+			t.Errorf("expected 409, got %d", rec.Code)
+		}
+	})
+}
+
+func TestPauseAllAndResumeAllHandleFunc(t *testing.T) {
+	s, store := setupTestHandler(t)
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/switch/pause", s.PauseAllHandleFunc)
+	r.Post("/api/v1/switch/resume", s.ResumeAllHandleFunc)
+
+	for _, status := range []api.SwitchStatus{api.SwitchStatusActive, api.SwitchStatusActive, api.SwitchStatusDisabled} {
+		_, err := store.Create(api.Switch{
+			Message:         "Bulk",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "24h",
+			Status:          &status,
+		})
+		if err != nil {
+			t.Fatalf("failed to seed switch: %v", err)
+		}
+	}
+
+	t.Run("pauses only active switches", func(t *testing.T) {
+		body, _ := json.Marshal(api.PauseRequest{Duration: ptr("12h")})
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/switch/pause", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		resp := []api.Switch{}
+		_ = json.NewDecoder(rec.Body).Decode(&resp)
+		if len(resp) != 2 {
+			t.Errorf("expected 2 paused switches, got %d", len(resp))
+		}
+	})
+
+	t.Run("resumes all paused switches", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/switch/resume", nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		resp := []api.Switch{}
+		_ = json.NewDecoder(rec.Body).Decode(&resp)
+		if len(resp) != 2 {
+			t.Errorf("expected 2 resumed switches, got %d", len(resp))
+		}
+		for _, sw := range resp {
+			if *sw.Status != api.SwitchStatusActive {
+				t.Errorf("expected switch %d to be active, got %s", *sw.Id, *sw.Status)
+			}
+		}
+	})
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/switches"
+)
+
+// Error messages
+const (
+	errNotMember = "Not a member of this switch"
+)
+
+var errNotAMember = errors.New("user is not a member of the switch")
+
+// getSwitchForCheckIn returns a switch the caller owns or is a member of.
+func (s *Switch) getSwitchForCheckIn(userID string, id int) (api.Switch, error) {
+	sw, err := s.Store.GetByID(userID, id)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return sw, err
+	}
+
+	return s.Store.GetByMember(userID, id)
+}
+
+// memberView is what a member who doesn't own a switch is shown of it: its countdown and who has checked in,
+// but not its message or how it is delivered.
+func (s *Switch) memberView(sw api.Switch) api.Switch {
+	redacted := s.redact(sw)
+
+	return api.Switch{
+		Id:              redacted.Id,
+		UserId:          redacted.UserId,
+		Status:          redacted.Status,
+		CheckInInterval: redacted.CheckInInterval,
+		TriggerAt:       redacted.TriggerAt,
+		LastCheckInAt:   redacted.LastCheckInAt,
+		PausedAt:        redacted.PausedAt,
+		PausedUntil:     redacted.PausedUntil,
+		Members:         redacted.Members,
+		Quorum:          redacted.Quorum,
+		RequirePasskey:  redacted.RequirePasskey,
+		Notifiers:       []string{},
+	}
+}
+
+// checkInMember records a member's check-in to a running quorum switch. The switch is re-armed by the worker
+// once its interval ends with the quorum met.
+func (s *Switch) checkInMember(userID string, id int, sw api.Switch, now time.Time) (api.Switch, error) {
+	err := s.Store.CheckInMember(id, userID, now.Unix())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return api.Switch{}, errNotAMember
+		}
+		return api.Switch{}, err
+	}
+
+	lastCheckInAt := now.Unix()
+	sw.LastCheckInAt = &lastCheckInAt
+
+	return s.save(id, sw)
+}
+
+// markCheckedInMembers sets whether each member has checked in during the current interval.
+func markCheckedInMembers(sw *api.Switch) {
+	start, err := switches.IntervalStart(*sw)
+	if err != nil || !switches.IsQuorumSwitch(*sw) {
+		return
+	}
+
+	members := make([]api.SwitchMember, len(*sw.Members))
+	for i, member := range *sw.Members {
+		checkedIn := member.LastCheckInAt != nil && *member.LastCheckInAt >= start
+		member.CheckedIn = &checkedIn
+		members[i] = member
+	}
+	sw.Members = &members
+}
+
+// keepMemberNotifiers copies the current notifier to updated members that were sent without one.
+func keepMemberNotifiers(payload *api.Switch, previous api.Switch) {
+	if previous.Members == nil {
+		return
+	}
+
+	notifiers := map[string]*string{}
+	for _, member := range *previous.Members {
+		notifiers[member.UserId] = member.Notifier
+	}
+
+	members := make([]api.SwitchMember, len(*payload.Members))
+	for i, member := range *payload.Members {
+		if member.Notifier == nil {
+			member.Notifier = notifiers[member.UserId]
+		}
+		members[i] = member
+	}
+	payload.Members = &members
+}
+
+// hasMemberNotifier reports whether any member of a switch can be sent reminders.
+func hasMemberNotifier(sw api.Switch) bool {
+	if sw.Members == nil {
+		return false
+	}
+
+	for _, member := range *sw.Members {
+		if member.Notifier != nil && *member.Notifier != "" {
+			return true
+		}
+	}
+
+	return false
+}
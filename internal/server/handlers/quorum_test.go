@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestQuorumCheckIn(t *testing.T) {
+	s, store := setupTestHandler(t)
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/switch/{id}/reset", s.ResetHandleFunc)
+
+	triggerAt := time.Now().Add(time.Hour).Unix()
+	created, err := store.Create(api.Switch{
+		Message:         "Team",
+		Notifiers:       []string{"logger://"},
+		CheckInInterval: "24h",
+		Members: &[]api.SwitchMember{
+			{UserId: "alice", Notifier: ptr("logger://alice")},
+			{UserId: "bob"},
+		},
+		Actions:   &[]string{"wipe"},
+		Webhooks:  &[]api.Webhook{{Url: "https://hooks.example.com/team"}},
+		Status:    &statusActive,
+		TriggerAt: &triggerAt,
+	})
+	if err != nil {
+		t.Fatalf("failed to seed switch: %v", err)
+	}
+
+	checkInAs := func(userID string, id int) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/switch/%d/reset", id), nil)
+		req = req.WithContext(middleware.WithUserID(req.Context(), userID))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("members check in without re-arming the switch", func(t *testing.T) {
+		rec := checkInAs("alice", *created.Id)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		resp := api.Switch{}
+		_ = json.NewDecoder(rec.Body).Decode(&resp)
+
+		if *resp.TriggerAt != triggerAt {
+			t.Errorf("expected triggerAt to stay %d, got %d", triggerAt, *resp.TriggerAt)
+		}
+
+		alice, bob := (*resp.Members)[0], (*resp.Members)[1]
+		if alice.CheckedIn == nil || !*alice.CheckedIn {
+			t.Error("expected alice to be checked in")
+		}
+		if bob.CheckedIn == nil || *bob.CheckedIn {
+			t.Error("expected bob not to be checked in")
+		}
+		if alice.Notifier != nil {
+			t.Error("expected member notifier to be redacted")
+		}
+	})
+
+	t.Run("members aren't shown the owner's message or how it is delivered", func(t *testing.T) {
+		rec := checkInAs("bob", *created.Id)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		body := rec.Body.String()
+		for _, secret := range []string{"Team", "logger://", "hooks.example.com", "wipe"} {
+			if strings.Contains(body, secret) {
+				t.Errorf("expected %q to be hidden from members, got %s", secret, body)
+			}
+		}
+
+		resp := api.Switch{}
+		_ = json.NewDecoder(rec.Body).Decode(&resp)
+		if resp.TriggerAt == nil || *resp.TriggerAt != triggerAt || resp.Members == nil || len(*resp.Members) != 2 {
+			t.Errorf("expected the countdown and members to be shown, got %+v", resp)
+		}
+	})
+
+	t.Run("returns 404 for users who aren't members", func(t *testing.T) {
+		rec := checkInAs("mallory", *created.Id)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", rec.Code)
+		}
+	})
+
+	t.Run("returns 403 when the owner isn't a member of a running switch", func(t *testing.T) {
+		rec := checkInAs("admin", *created.Id)
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", rec.Code)
+		}
+	})
+
+	t.Run("owner re-arms a triggered switch", func(t *testing.T) {
+		stored, err := store.GetByID("admin", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to get switch: %v", err)
+		}
+		// Alice checked in before the switch triggered
+		err = store.CheckInMember(*created.Id, "alice", time.Now().Add(-time.Minute).Unix())
+		if err != nil {
+			t.Fatalf("failed to check in member: %v", err)
+		}
+
+		statusTriggered := api.SwitchStatusTriggered
+		stored.Status = &statusTriggered
+		_, err = store.Update(*created.Id, stored)
+		if err != nil {
+			t.Fatalf("failed to update switch: %v", err)
+		}
+
+		rec := checkInAs("admin", *created.Id)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		resp := api.Switch{}
+		_ = json.NewDecoder(rec.Body).Decode(&resp)
+		if *resp.Status != api.SwitchStatusActive {
+			t.Errorf("expected status active, got %s", *resp.Status)
+		}
+		// A fresh interval starts, so earlier member check-ins no longer count
+		if *(*resp.Members)[0].CheckedIn {
+			t.Error("expected member check-ins to start over")
+		}
+	})
+}
+
+func TestPutKeepsMembers(t *testing.T) {
+	s, store := setupTestHandler(t)
+
+	r := chi.NewRouter()
+	r.With(middleware.SwitchValidator(validator.New())).Put("/api/v1/switch/{id}", s.PutByIDHandleFunc)
+
+	created, err := store.Create(api.Switch{
+		Message:         "Team",
+		Notifiers:       []string{"logger://"},
+		CheckInInterval: "24h",
+		Members:         &[]api.SwitchMember{{UserId: "alice", Notifier: ptr("logger://alice")}, {UserId: "bob"}},
+		Quorum:          ptr(1),
+		Status:          &statusActive,
+	})
+	if err != nil {
+		t.Fatalf("failed to seed switch: %v", err)
+	}
+
+	put := func(sw api.Switch) {
+		t.Helper()
+		body, _ := json.Marshal(sw)
+		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/switch/%d", *created.Id), bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+	}
+
+	t.Run("omitted members are kept", func(t *testing.T) {
+		put(api.Switch{Message: "Updated", Notifiers: []string{"logger://"}, CheckInInterval: "24h"})
+
+		stored, err := store.GetByID("admin", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to get switch: %v", err)
+		}
+		if stored.Members == nil || len(*stored.Members) != 2 || *stored.Quorum != 1 {
+			t.Errorf("expected members and quorum to be kept, got %v and %v", stored.Members, stored.Quorum)
+		}
+	})
+
+	t.Run("members sent without a notifier keep it", func(t *testing.T) {
+		put(api.Switch{
+			Message:         "Updated",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "24h",
+			Members:         &[]api.SwitchMember{{UserId: "alice"}},
+		})
+
+		stored, err := store.GetByID("admin", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to get switch: %v", err)
+		}
+		members := *stored.Members
+		if len(members) != 1 || members[0].Notifier == nil || *members[0].Notifier != "logger://alice" {
+			t.Errorf("expected alice to keep their notifier, got %+v", members)
+		}
+	})
+}
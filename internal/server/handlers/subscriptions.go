@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+	"github.com/circa10a/dead-mans-switch/internal/server/webhooks"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	errInvalidSubscriptionID = "Invalid subscription ID"
+	errSubscriptionNotFound  = "Subscription not found"
+	errSubscriptionSecret    = "Subscriptions require a signing secret"
+)
+
+var subscriptionValidator = validator.New()
+
+// SubscriptionsHandleFunc lists the event subscriptions of the user.
+func (s *Switch) SubscriptionsHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID := middleware.GetUserIDFromContext(r)
+
+	subs, err := s.Store.GetSubscriptions(userID)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	for i := range subs {
+		subs[i].Secret = nil
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(subs)
+}
+
+// CreateSubscriptionHandleFunc subscribes a webhook to the lifecycle events of the user's switches.
+func (s *Switch) CreateSubscriptionHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID := middleware.GetUserIDFromContext(r)
+
+	payload, ok := s.decodeSubscription(w, r)
+	if !ok {
+		return
+	}
+
+	if payload.Secret == nil {
+		s.sendError(w, http.StatusBadRequest, errSubscriptionSecret, nil)
+		return
+	}
+
+	now := time.Now().Unix()
+	payload.CreatedAt = &now
+
+	created, err := s.Store.CreateSubscription(userID, payload)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	created.Secret = nil
+
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(created)
+}
+
+// SubscriptionHandleFunc returns an event subscription of the user.
+func (s *Switch) SubscriptionHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	sub, ok := s.lookupSubscription(w, r)
+	if !ok {
+		return
+	}
+
+	sub.Secret = nil
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(sub)
+}
+
+// UpdateSubscriptionHandleFunc replaces an event subscription of the user. The secret is kept when omitted.
+func (s *Switch) UpdateSubscriptionHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID := middleware.GetUserIDFromContext(r)
+
+	previous, ok := s.lookupSubscription(w, r)
+	if !ok {
+		return
+	}
+
+	payload, ok := s.decodeSubscription(w, r)
+	if !ok {
+		return
+	}
+
+	if payload.Secret == nil {
+		payload.Secret = previous.Secret
+	}
+
+	updated, err := s.Store.UpdateSubscription(userID, *previous.Id, payload)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	updated.Secret = nil
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(updated)
+}
+
+// DeleteSubscriptionHandleFunc removes an event subscription of the user and its delivery log.
+func (s *Switch) DeleteSubscriptionHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID := middleware.GetUserIDFromContext(r)
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errInvalidSubscriptionID, err)
+		return
+	}
+
+	err = s.Store.DeleteSubscription(userID, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.sendError(w, http.StatusNotFound, errSubscriptionNotFound, err)
+			return
+		}
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SubscriptionDeliveriesHandleFunc returns a page of the events sent to a subscription, newest first.
+func (s *Switch) SubscriptionDeliveriesHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID := middleware.GetUserIDFromContext(r)
+
+	limit, offset, errMsg, err := parsePagination(r)
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errMsg, err)
+		return
+	}
+
+	sub, ok := s.lookupSubscription(w, r)
+	if !ok {
+		return
+	}
+
+	deliveries, err := s.Store.GetSubscriptionDeliveries(userID, *sub.Id, limit, offset)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(deliveries)
+}
+
+// lookupSubscription finds the subscription in the URL among the user's. It sends the error response
+// itself and reports whether the request can continue.
+func (s *Switch) lookupSubscription(w http.ResponseWriter, r *http.Request) (api.WebhookSubscription, bool) {
+	userID := middleware.GetUserIDFromContext(r)
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errInvalidSubscriptionID, err)
+		return api.WebhookSubscription{}, false
+	}
+
+	sub, err := s.Store.GetSubscription(userID, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.sendError(w, http.StatusNotFound, errSubscriptionNotFound, err)
+			return api.WebhookSubscription{}, false
+		}
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return api.WebhookSubscription{}, false
+	}
+
+	return sub, true
+}
+
+// decodeSubscription reads and validates a subscription from the request body. It sends the error response
+// itself and reports whether the request can continue.
+func (s *Switch) decodeSubscription(w http.ResponseWriter, r *http.Request) (api.WebhookSubscription, bool) {
+	payload := api.WebhookSubscription{}
+
+	err := json.NewDecoder(r.Body).Decode(&payload)
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errInvalidJSON, err)
+		return api.WebhookSubscription{}, false
+	}
+
+	err = subscriptionValidator.Struct(payload)
+	if err != nil {
+		fields := []string{}
+
+		var ve validator.ValidationErrors
+		if errors.As(err, &ve) {
+			for _, fe := range ve {
+				fields = append(fields, fmt.Sprintf("field '%s' failed on validation: %s", fe.Field(), fe.Tag()))
+			}
+		}
+
+		s.sendError(w, http.StatusBadRequest, "Validation failed: "+strings.Join(fields, ", "), err)
+		return api.WebhookSubscription{}, false
+	}
+
+	err = webhooks.Validate(api.Webhook{Url: payload.Url, RetryBackoff: payload.RetryBackoff})
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, err.Error(), err)
+		return api.WebhookSubscription{}, false
+	}
+
+	return payload, true
+}
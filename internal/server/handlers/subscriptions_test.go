@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/events"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestSubscriptions(t *testing.T) {
+	s, store := setupTestHandler(t)
+
+	r := chi.NewRouter()
+	r.Get("/api/v1/webhooks", s.SubscriptionsHandleFunc)
+	r.Post("/api/v1/webhooks", s.CreateSubscriptionHandleFunc)
+	r.Get("/api/v1/webhooks/{id}", s.SubscriptionHandleFunc)
+	r.Put("/api/v1/webhooks/{id}", s.UpdateSubscriptionHandleFunc)
+	r.Delete("/api/v1/webhooks/{id}", s.DeleteSubscriptionHandleFunc)
+	r.Get("/api/v1/webhooks/{id}/deliveries", s.SubscriptionDeliveriesHandleFunc)
+
+	do := func(method, path, userID string, body any) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			_ = json.NewEncoder(&buf).Encode(body)
+		}
+		req := httptest.NewRequest(method, path, &buf)
+		req = req.WithContext(middleware.WithUserID(req.Context(), userID))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	secret := "0123456789abcdef"
+	created := api.WebhookSubscription{}
+
+	t.Run("subscriptions require a secret", func(t *testing.T) {
+		rec := do(http.MethodPost, "/api/v1/webhooks", "admin", api.WebhookSubscription{Url: "https://example.com/events"})
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("unknown event types are rejected", func(t *testing.T) {
+		rec := do(http.MethodPost, "/api/v1/webhooks", "admin", api.WebhookSubscription{
+			Url:    "https://example.com/events",
+			Secret: &secret,
+			Events: &[]api.EventType{"switch.exploded"},
+		})
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("create hides the secret", func(t *testing.T) {
+		rec := do(http.MethodPost, "/api/v1/webhooks", "admin", api.WebhookSubscription{
+			Url:    "https://example.com/events",
+			Secret: &secret,
+			Events: &[]api.EventType{api.EventTypeTriggered},
+		})
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		_ = json.NewDecoder(rec.Body).Decode(&created)
+		if created.Id == nil || created.Secret != nil || created.CreatedAt == nil {
+			t.Errorf("unexpected subscription in the response %+v", created)
+		}
+	})
+
+	t.Run("update keeps the secret when omitted", func(t *testing.T) {
+		rec := do(http.MethodPut, fmt.Sprintf("/api/v1/webhooks/%d", *created.Id), "admin", api.WebhookSubscription{
+			Url:         "https://example.com/events",
+			MaxAttempts: ptr(5),
+		})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		stored, err := store.GetSubscription("admin", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to get subscription: %v", err)
+		}
+		if stored.Secret == nil || *stored.Secret != secret || *stored.MaxAttempts != 5 || stored.Events != nil {
+			t.Errorf("unexpected stored subscription %+v", stored)
+		}
+	})
+
+	t.Run("other users can't see the subscription", func(t *testing.T) {
+		rec := do(http.MethodGet, fmt.Sprintf("/api/v1/webhooks/%d", *created.Id), "mallory", nil)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", rec.Code)
+		}
+
+		rec = do(http.MethodGet, "/api/v1/webhooks", "mallory", nil)
+		subs := []api.WebhookSubscription{}
+		_ = json.NewDecoder(rec.Body).Decode(&subs)
+		if len(subs) != 0 {
+			t.Errorf("expected no subscriptions, got %d", len(subs))
+		}
+	})
+
+	t.Run("deliveries are listed per subscription", func(t *testing.T) {
+		_, err := store.CreateDelivery("admin", api.Delivery{
+			SwitchId:       1,
+			SubscriptionId: created.Id,
+			Kind:           api.DeliveryKindEvent,
+			Target:         string(api.EventTypeTriggered),
+			Success:        true,
+		})
+		if err != nil {
+			t.Fatalf("failed to create delivery: %v", err)
+		}
+
+		rec := do(http.MethodGet, fmt.Sprintf("/api/v1/webhooks/%d/deliveries", *created.Id), "admin", nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+
+		deliveries := []api.Delivery{}
+		_ = json.NewDecoder(rec.Body).Decode(&deliveries)
+		if len(deliveries) != 1 || deliveries[0].Kind != api.DeliveryKindEvent {
+			t.Errorf("unexpected deliveries %+v", deliveries)
+		}
+	})
+
+	t.Run("delete removes the subscription", func(t *testing.T) {
+		rec := do(http.MethodDelete, fmt.Sprintf("/api/v1/webhooks/%d", *created.Id), "admin", nil)
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("expected 204, got %d", rec.Code)
+		}
+
+		rec = do(http.MethodDelete, fmt.Sprintf("/api/v1/webhooks/%d", *created.Id), "admin", nil)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", rec.Code)
+		}
+	})
+}
+
+func TestSwitchEvents(t *testing.T) {
+	s, _ := setupTestHandler(t)
+	s.Events = events.NewBus()
+	ch, stop := s.Events.Subscribe(10)
+	defer stop()
+
+	r := chi.NewRouter()
+	r.With(middleware.SwitchValidator(validator.New())).Post("/api/v1/switch", s.PostHandleFunc)
+	r.Delete("/api/v1/switch/{id}", s.DeleteHandleFunc)
+
+	var buf bytes.Buffer
+	_ = json.NewEncoder(&buf).Encode(api.Switch{
+		Message:         "Events",
+		Notifiers:       []string{"logger://"},
+		CheckInInterval: "24h",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/switch", &buf)
+	req = req.WithContext(middleware.WithUserID(req.Context(), "admin"))
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	created := api.Switch{}
+	_ = json.NewDecoder(rec.Body).Decode(&created)
+
+	req = httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/switch/%d", *created.Id), nil)
+	req = req.WithContext(middleware.WithUserID(req.Context(), "admin"))
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", rec.Code)
+	}
+
+	for _, want := range []api.EventType{api.EventTypeCreated, api.EventTypeDeleted} {
+		event := <-ch
+		if event.Type != want || event.SwitchId != *created.Id || event.UserId != "admin" {
+			t.Errorf("expected %s event for switch %d, got %+v", want, *created.Id, event)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -11,8 +12,12 @@ import (
 
 	"github.com/circa10a/dead-mans-switch/api"
 	"github.com/circa10a/dead-mans-switch/internal/server/database"
+	"github.com/circa10a/dead-mans-switch/internal/server/events"
+	"github.com/circa10a/dead-mans-switch/internal/server/hooks"
 	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+	"github.com/circa10a/dead-mans-switch/internal/server/switches"
 	"github.com/go-chi/chi/v5"
+	"github.com/go-webauthn/webauthn/webauthn"
 )
 
 // Error messages
@@ -24,6 +29,7 @@ const (
 	errDatabaseError   = "Database error"
 	errFailedToDelete  = "Failed to delete switch"
 	errFailedToReset   = "Failed to reset switch"
+	errInvalidJSON     = "Invalid JSON"
 )
 
 // Send all unless specified.
@@ -33,6 +39,24 @@ const defaultLimit = -1
 type Switch struct {
 	Store  database.Store
 	Logger *slog.Logger
+	// MaxPauseDuration caps how long a switch can be paused. Zero means no limit.
+	MaxPauseDuration time.Duration
+	// Duress is called in the background when a switch is checked in with its duress code.
+	Duress func(api.Switch)
+	// ChangeRequested is called in the background when a delayed change to a protected switch is requested.
+	ChangeRequested func(api.Switch, api.PendingChange)
+	// Actions are the actions configured on the server that switches can run, by name.
+	Actions map[string]hooks.Action
+	// Events receives the lifecycle events of switches. Events are dropped when it is nil.
+	Events *events.Bus
+	// SessionDuration is how long a session of a local user account lasts before signing in again.
+	SessionDuration time.Duration
+	// SecureCookies only sends session cookies over HTTPS.
+	SecureCookies bool
+	// WebAuthn verifies passkeys of local user accounts. Passkeys are unavailable when it is nil.
+	WebAuthn *webauthn.WebAuthn
+	// MaxSwitches is how many switches a user can have unless an admin sets their quota. Zero means no limit.
+	MaxSwitches int
 }
 
 // PostHandleFunc creates a dead mans switch.
@@ -49,6 +73,10 @@ func (s *Switch) PostHandleFunc(w http.ResponseWriter, r *http.Request) {
 	}
 	payload := val.Payload
 
+	if !s.checkQuota(w, userID) {
+		return
+	}
+
 	// Set as active
 	statusActive := api.SwitchStatusActive
 	payload.Status = &statusActive
@@ -56,20 +84,60 @@ func (s *Switch) PostHandleFunc(w http.ResponseWriter, r *http.Request) {
 	// Set user ownership
 	payload.UserId = &userID
 
+	// A new switch hasn't triggered yet
+	payload.TriggerCount = nil
+
 	// Compute time at which to send using pre-parsed duration
 	triggerAt := time.Now().Add(val.CheckInIntervalDuration).Unix()
 	payload.TriggerAt = &triggerAt
 
 	// Simplified reminder logic using the pre-parsed pointer
-	reminderEnabled := payload.PushSubscription != nil && val.ReminderThresholdDuration != nil
+	reminderEnabled := val.ReminderThresholdDuration != nil && (payload.PushSubscription != nil || hasMemberNotifier(payload))
 	payload.ReminderEnabled = &reminderEnabled
 
-	createdSwitch, err := s.Store.Create(payload)
+	if !keepContactNotifiers(&payload, api.Switch{}) {
+		s.sendError(w, http.StatusBadRequest, errContactNotifier, nil)
+		return
+	}
+
+	if isApprover(payload, userID) {
+		s.sendError(w, http.StatusBadRequest, errApproverIsOwner, nil)
+		return
+	}
+
+	if !s.checkLinks(w, userID, 0, payload) {
+		return
+	}
+
+	if !s.checkActions(w, r, payload) {
+		return
+	}
+
+	if !s.checkRequirePasskey(w, payload) {
+		return
+	}
+
+	if !keepWebhookSecrets(&payload, api.Switch{}) {
+		s.sendError(w, http.StatusBadRequest, errWebhookSecret, nil)
+		return
+	}
+
+	err := hashDuressCode(&payload)
 	if err != nil {
 		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
 		return
 	}
 
+	hashCheckInToken(&payload)
+
+	createdSwitch, err := s.Store.Create(payload)
+	if err != nil {
+		s.sendSaveError(w, errDatabaseError, err)
+		return
+	}
+
+	s.Events.Publish(api.EventTypeCreated, createdSwitch)
+
 	w.WriteHeader(http.StatusCreated)
 	_ = json.NewEncoder(w).Encode(s.redact(createdSwitch))
 }
@@ -168,22 +236,187 @@ func (s *Switch) PutByIDHandleFunc(w http.ResponseWriter, r *http.Request) {
 	// Default to existing trigger time
 	payload.TriggerAt = previousSwitch.TriggerAt
 
+	// Pause state is only changed through the pause/resume endpoints
+	payload.PausedAt = previousSwitch.PausedAt
+	payload.PausedUntil = previousSwitch.PausedUntil
+	payload.ResumePolicy = previousSwitch.ResumePolicy
+
+	// Check-in state is only changed by check-ins and the worker
+	payload.LastCheckInAt = previousSwitch.LastCheckInAt
+	payload.TriggerCount = previousSwitch.TriggerCount
+
+	// Status is otherwise only changed by check-ins, pausing and the worker
+	if payload.Status == nil || *payload.Status != api.SwitchStatusDisabled {
+		payload.Status = previousSwitch.Status
+	}
+
 	// Change trigger time if checkInInterval changed, using pre-parsed duration
 	if previousSwitch.CheckInInterval != payload.CheckInInterval {
 		updatedTriggerAt := time.Now().Add(val.CheckInIntervalDuration).Unix()
 		payload.TriggerAt = &updatedTriggerAt
 	}
 
+	// Keep labels unless they are explicitly changed
+	if payload.Labels == nil {
+		payload.Labels = previousSwitch.Labels
+	}
+
+	err = s.Store.DecryptSwitch(&previousSwitch)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, "Failed to update switch", err)
+		return
+	}
+
+	// Keep members unless they are explicitly changed. Member notifiers are never returned,
+	// so members sent without one keep their current notifier
+	if payload.Members == nil {
+		payload.Members = previousSwitch.Members
+		if payload.Quorum == nil {
+			payload.Quorum = previousSwitch.Quorum
+		}
+	} else {
+		keepMemberNotifiers(&payload, previousSwitch)
+	}
+
+	// Trusted contacts follow the same rules as members
+	if payload.TrustedContacts == nil {
+		payload.TrustedContacts = previousSwitch.TrustedContacts
+	} else if !keepContactNotifiers(&payload, previousSwitch) {
+		s.sendError(w, http.StatusBadRequest, errContactNotifier, nil)
+		return
+	}
+
+	if payload.VerificationWindow == nil {
+		payload.VerificationWindow = previousSwitch.VerificationWindow
+	}
+
+	if payload.MaxPostpone == nil {
+		payload.MaxPostpone = previousSwitch.MaxPostpone
+	}
+
+	// Protection settings are kept unless they are explicitly changed
+	if payload.Protected == nil {
+		payload.Protected = previousSwitch.Protected
+	}
+
+	if payload.Approvers == nil {
+		payload.Approvers = previousSwitch.Approvers
+	}
+
+	if payload.ChangeDelay == nil {
+		payload.ChangeDelay = previousSwitch.ChangeDelay
+	}
+
+	if isApprover(payload, userID) {
+		s.sendError(w, http.StatusBadRequest, errApproverIsOwner, nil)
+		return
+	}
+
+	// Re-arm and repeat settings are kept unless they are explicitly changed
+	if payload.Rearm == nil {
+		payload.Rearm = previousSwitch.Rearm
+	}
+
+	if payload.MaxTriggers == nil {
+		payload.MaxTriggers = previousSwitch.MaxTriggers
+	}
+
+	if payload.RepeatInterval == nil {
+		payload.RepeatInterval = previousSwitch.RepeatInterval
+	}
+
+	// Chain actions and requirements are kept unless they are explicitly changed
+	if payload.OnTrigger == nil {
+		payload.OnTrigger = previousSwitch.OnTrigger
+	}
+
+	if payload.Requires == nil {
+		payload.Requires = previousSwitch.Requires
+	}
+
+	if !s.checkLinks(w, userID, id, payload) {
+		return
+	}
+
+	// Actions are kept unless they are explicitly changed
+	if payload.Actions == nil {
+		payload.Actions = previousSwitch.Actions
+	}
+
+	if !s.checkActions(w, r, payload) {
+		return
+	}
+
+	// Webhooks are kept unless they are explicitly changed. Their credentials are never returned,
+	// so webhooks sent without them keep the current ones for the same URL
+	if payload.Webhooks == nil {
+		payload.Webhooks = previousSwitch.Webhooks
+	} else if !keepWebhookSecrets(&payload, previousSwitch) {
+		s.sendError(w, http.StatusBadRequest, errWebhookSecret, nil)
+		return
+	}
+
+	// Duress settings are never returned, so keep them unless they are explicitly changed
+	if payload.DuressCode == nil {
+		payload.DuressCode = previousSwitch.DuressCode
+	} else {
+		err = hashDuressCode(&payload)
+		if err != nil {
+			s.sendError(w, http.StatusInternalServerError, "Failed to update switch", err)
+			return
+		}
+	}
+
+	if payload.DuressNotifiers == nil {
+		payload.DuressNotifiers = previousSwitch.DuressNotifiers
+	}
+
+	// Check-in tokens are never returned either
+	if payload.CheckInToken == nil {
+		payload.CheckInToken = previousSwitch.CheckInToken
+	} else {
+		hashCheckInToken(&payload)
+	}
+
+	if payload.AlertMatchers == nil {
+		payload.AlertMatchers = previousSwitch.AlertMatchers
+	}
+
+	if payload.RequirePasskey == nil {
+		payload.RequirePasskey = previousSwitch.RequirePasskey
+	}
+
+	if !s.checkRequirePasskey(w, payload) {
+		return
+	}
+
+	// Like changes to the passkeys themselves, no longer requiring one has to be confirmed with one
+	if requiresPasskey(previousSwitch) && !requiresPasskey(payload) && !s.confirmWithPasskey(w, userID, payload.Passkey) {
+		return
+	}
+	payload.Passkey = nil
+
 	// Set reminder status
-	reminderEnabled := payload.PushSubscription != nil && val.ReminderThresholdDuration != nil
+	reminderEnabled := val.ReminderThresholdDuration != nil && (payload.PushSubscription != nil || hasMemberNotifier(payload))
 	payload.ReminderEnabled = &reminderEnabled
 
+	// Sensitive changes to a protected switch wait for approval or a delay
+	if switches.IsProtected(previousSwitch) {
+		changes := sensitiveChanges(previousSwitch, payload)
+		if len(changes) > 0 {
+			s.requestUpdate(w, previousSwitch, changeSummary(changes), payload)
+			return
+		}
+	}
+
 	updatedSwitch, err := s.Store.Update(id, payload)
 	if err != nil {
-		s.sendError(w, http.StatusInternalServerError, "Failed to update switch", err)
+		s.sendSaveError(w, "Failed to update switch", err)
 		return
 	}
 
+	s.Events.Publish(api.EventTypeUpdated, updatedSwitch)
+
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(s.redact(updatedSwitch))
 }
@@ -200,7 +433,7 @@ func (s *Switch) DeleteHandleFunc(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = s.Store.GetByID(userID, id)
+	switchToDelete, err := s.Store.GetByID(userID, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			s.sendError(w, http.StatusNotFound, errSwitchNotFound, err)
@@ -210,12 +443,21 @@ func (s *Switch) DeleteHandleFunc(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if switches.IsProtected(switchToDelete) {
+		s.requestProtectedChange(w, switchToDelete, api.PendingChangeActionDelete, "Delete the switch", nil)
+		return
+	}
+
 	err = s.Store.Delete(userID, id)
 	if err != nil {
 		s.sendError(w, http.StatusInternalServerError, errFailedToDelete, err)
 		return
 	}
 
+	// A deleted switch has no status
+	switchToDelete.Status = nil
+	s.Events.Publish(api.EventTypeDeleted, switchToDelete)
+
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -231,7 +473,15 @@ func (s *Switch) ResetHandleFunc(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	switchToReset, err := s.Store.GetByID(userID, id)
+	req := api.CheckInRequest{}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.sendError(w, http.StatusBadRequest, errInvalidJSON, err)
+		return
+	}
+
+	switchToReset, err := s.getSwitchForCheckIn(userID, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			s.sendError(w, http.StatusNotFound, errSwitchNotFound, err)
@@ -241,28 +491,41 @@ func (s *Switch) ResetHandleFunc(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	duration, err := time.ParseDuration(switchToReset.CheckInInterval)
-	if err != nil {
-		s.sendError(w, http.StatusBadRequest, errTimeParse, err)
-		return
+	code := ""
+	if req.Code != nil {
+		code = *req.Code
 	}
 
-	// Update status to active
-	statusActive := api.SwitchStatusActive
-	switchToReset.Status = &statusActive
+	source := requestSource(r)
 
-	// Update TriggerAt time
-	newTriggerAt := time.Now().UTC().Add(duration).Unix()
-	switchToReset.TriggerAt = &newTriggerAt
+	if requiresPasskey(switchToReset) && req.Passkey != nil {
+		err = s.verifyCheckInPasskey(source.userID, id, req.Passkey)
+		if err != nil {
+			s.Logger.Warn("Failed passkey check-in", "id", id, "ip", clientIP(r), "error", err)
+			s.sendError(w, http.StatusForbidden, errInvalidPasskey, nil)
+			return
+		}
+		source.passkey = true
+	}
 
-	// Set default values to false
-	defaultOff := false
-	defaultStatus := api.SwitchStatusActive
-	switchToReset.Status = &defaultStatus
-	switchToReset.ReminderSent = &defaultOff
+	// A duress check-in must be indistinguishable from a normal one, so the switch
+	// is reset as usual and the notifications are sent in the background
+	duress := isDuressCode(switchToReset, code)
 
-	resetSwitch, err := s.Store.Update(id, switchToReset)
+	resetSwitch, err := s.checkIn(source, id, switchToReset)
 	if err != nil {
+		if errors.Is(err, errMissingPasskey) {
+			s.sendError(w, http.StatusForbidden, errPasskeyRequired, err)
+			return
+		}
+		if errors.Is(err, errInvalidInterval) {
+			s.sendError(w, http.StatusBadRequest, errTimeParse, err)
+			return
+		}
+		if errors.Is(err, errNotAMember) {
+			s.sendError(w, http.StatusForbidden, errNotMember, err)
+			return
+		}
 		if errors.Is(err, sql.ErrNoRows) {
 			s.sendError(w, http.StatusNotFound, errSwitchNotFound, err)
 			return
@@ -271,7 +534,17 @@ func (s *Switch) ResetHandleFunc(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if duress && s.Duress != nil {
+		go s.Duress(resetSwitch)
+	}
+
 	w.WriteHeader(http.StatusOK)
+
+	if switchOwner(resetSwitch) != userID {
+		_ = json.NewEncoder(w).Encode(s.memberView(resetSwitch))
+		return
+	}
+
 	_ = json.NewEncoder(w).Encode(s.redact(resetSwitch))
 }
 
@@ -295,10 +568,15 @@ func (s *Switch) DisableHandleFunc(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if switches.IsProtected(switchToDisable) {
+		s.requestProtectedChange(w, switchToDisable, api.PendingChangeActionDisable, "Disable the switch", nil)
+		return
+	}
+
 	statusDisabled := api.SwitchStatusDisabled
 	switchToDisable.Status = &statusDisabled
 
-	disabledSwitch, err := s.Store.Update(id, switchToDisable)
+	disabledSwitch, err := s.save(id, switchToDisable)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			s.sendError(w, http.StatusNotFound, errSwitchNotFound, err)
@@ -308,10 +586,23 @@ func (s *Switch) DisableHandleFunc(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.Events.Publish(api.EventTypeDisabled, disabledSwitch)
+
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(s.redact(disabledSwitch))
 }
 
+// save writes back a switch that was read from the store. Stored records hold encrypted
+// fields, so they are decrypted first to prevent Update from encrypting them a second time.
+func (s *Switch) save(id int, sw api.Switch) (api.Switch, error) {
+	err := s.Store.DecryptSwitch(&sw)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
+	return s.Store.Update(id, sw)
+}
+
 // sendError handles both the JSON response and logging of internal errors
 func (s *Switch) sendError(w http.ResponseWriter, code int, publicMsg string, internalErr error) {
 	if code >= http.StatusInternalServerError {
@@ -325,10 +616,41 @@ func (s *Switch) sendError(w http.ResponseWriter, code int, publicMsg string, in
 	})
 }
 
-// redact removes sensitive push subscription details before sending to the client.
+// redact removes sensitive push subscription, duress, check-in token, member notifier and webhook details before sending to the client.
 func (s *Switch) redact(sw api.Switch) api.Switch {
 	sw.PushSubscription = nil
 
+	markCheckedInMembers(&sw)
+	if sw.Members != nil {
+		members := make([]api.SwitchMember, len(*sw.Members))
+		for i, member := range *sw.Members {
+			member.Notifier = nil
+			members[i] = member
+		}
+		sw.Members = &members
+	}
+
+	if sw.TrustedContacts != nil {
+		contacts := make([]api.TrustedContact, len(*sw.TrustedContacts))
+		for i, contact := range *sw.TrustedContacts {
+			contact.Notifier = nil
+			contacts[i] = contact
+		}
+		sw.TrustedContacts = &contacts
+	}
+
+	redactWebhooks(&sw)
+
+	// Only reveal whether a duress code is configured
+	duressEnabled := sw.DuressCode != nil
+	sw.DuressEnabled = &duressEnabled
+	sw.DuressCode = nil
+	sw.DuressNotifiers = nil
+
+	checkInTokenEnabled := sw.CheckInToken != nil
+	sw.CheckInTokenEnabled = &checkInTokenEnabled
+	sw.CheckInToken = nil
+
 	return sw
 }
 
@@ -56,6 +56,24 @@ func TestPostHandleFunc(t *testing.T) {
 	// Wrap the handler
 	handlerToTest := mw(http.HandlerFunc(s.PostHandleFunc))
 
+	t.Run("requires trusted contacts to have a notifier", func(t *testing.T) {
+		body, _ := json.Marshal(api.Switch{
+			Message:         "Secret Message",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "24h",
+			TrustedContacts: &[]api.TrustedContact{{Name: "sam"}},
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/switch", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+
+		handlerToTest.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+	})
+
 	t.Run("successfully creates a switch with message", func(t *testing.T) {
 		payload := api.Switch{
 			Message:              "Secret Message",
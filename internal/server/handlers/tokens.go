@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+	"github.com/circa10a/dead-mans-switch/internal/server/secrets"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	errInvalidTokenID = "Invalid token ID"
+	errTokenNotFound  = "Token not found"
+	errTokenExpiry    = "Token expiry must be in the future"
+)
+
+var tokenValidator = validator.New()
+
+// TokensHandleFunc lists the personal access tokens of the user.
+func (s *Switch) TokensHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID := middleware.GetUserIDFromContext(r)
+
+	tokens, err := s.Store.GetAPITokens(userID)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(tokens)
+}
+
+// CreateTokenHandleFunc creates a personal access token for the user. The token is only returned here,
+// since only the hash of it is stored.
+func (s *Switch) CreateTokenHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID := middleware.GetUserIDFromContext(r)
+
+	payload, ok := s.decodeToken(w, r)
+	if !ok {
+		return
+	}
+
+	secret, _, err := secrets.NewToken()
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, "Failed to create token", err)
+		return
+	}
+	token := middleware.APITokenPrefix + secret
+
+	now := time.Now().Unix()
+	payload.CreatedAt = &now
+
+	created, err := s.Store.CreateAPIToken(userID, secrets.HashToken(token), payload)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	created.Token = &token
+
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(created)
+}
+
+// TokenHandleFunc returns a personal access token of the user.
+func (s *Switch) TokenHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	token, ok := s.lookupToken(w, r)
+	if !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(token)
+}
+
+// UpdateTokenHandleFunc changes the name, scopes and expiry of a personal access token of the user.
+func (s *Switch) UpdateTokenHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID := middleware.GetUserIDFromContext(r)
+
+	previous, ok := s.lookupToken(w, r)
+	if !ok {
+		return
+	}
+
+	payload, ok := s.decodeToken(w, r)
+	if !ok {
+		return
+	}
+
+	updated, err := s.Store.UpdateAPIToken(userID, *previous.Id, payload)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(updated)
+}
+
+// DeleteTokenHandleFunc revokes a personal access token of the user.
+func (s *Switch) DeleteTokenHandleFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID := middleware.GetUserIDFromContext(r)
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errInvalidTokenID, err)
+		return
+	}
+
+	err = s.Store.DeleteAPIToken(userID, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.sendError(w, http.StatusNotFound, errTokenNotFound, err)
+			return
+		}
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// lookupToken finds the token in the URL among the user's. It sends the error response itself and
+// reports whether the request can continue.
+func (s *Switch) lookupToken(w http.ResponseWriter, r *http.Request) (api.APIToken, bool) {
+	userID := middleware.GetUserIDFromContext(r)
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errInvalidTokenID, err)
+		return api.APIToken{}, false
+	}
+
+	token, err := s.Store.GetAPIToken(userID, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.sendError(w, http.StatusNotFound, errTokenNotFound, err)
+			return api.APIToken{}, false
+		}
+		s.sendError(w, http.StatusInternalServerError, errDatabaseError, err)
+		return api.APIToken{}, false
+	}
+
+	return token, true
+}
+
+// decodeToken reads and validates a token from the request body. It sends the error response itself and
+// reports whether the request can continue.
+func (s *Switch) decodeToken(w http.ResponseWriter, r *http.Request) (api.APIToken, bool) {
+	payload := api.APIToken{}
+
+	err := json.NewDecoder(r.Body).Decode(&payload)
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, errInvalidJSON, err)
+		return api.APIToken{}, false
+	}
+
+	err = tokenValidator.Struct(payload)
+	if err != nil {
+		fields := []string{}
+
+		var ve validator.ValidationErrors
+		if errors.As(err, &ve) {
+			for _, fe := range ve {
+				fields = append(fields, fmt.Sprintf("field '%s' failed on validation: %s", fe.Field(), fe.Tag()))
+			}
+		}
+
+		s.sendError(w, http.StatusBadRequest, "Validation failed: "+strings.Join(fields, ", "), err)
+		return api.APIToken{}, false
+	}
+
+	if payload.ExpiresAt != nil && *payload.ExpiresAt <= time.Now().Unix() {
+		s.sendError(w, http.StatusBadRequest, errTokenExpiry, nil)
+		return api.APIToken{}, false
+	}
+
+	// Read only fields are set by the server
+	payload.Id = nil
+	payload.CreatedAt = nil
+	payload.LastUsedAt = nil
+	payload.Token = nil
+
+	return payload, true
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+	"github.com/circa10a/dead-mans-switch/internal/server/secrets"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestTokens(t *testing.T) {
+	s, store := setupTestHandler(t)
+
+	r := chi.NewRouter()
+	r.Get("/api/v1/tokens", s.TokensHandleFunc)
+	r.Post("/api/v1/tokens", s.CreateTokenHandleFunc)
+	r.Get("/api/v1/tokens/{id}", s.TokenHandleFunc)
+	r.Put("/api/v1/tokens/{id}", s.UpdateTokenHandleFunc)
+	r.Delete("/api/v1/tokens/{id}", s.DeleteTokenHandleFunc)
+
+	do := func(method, path, userID string, body any) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			_ = json.NewEncoder(&buf).Encode(body)
+		}
+		req := httptest.NewRequest(method, path, &buf)
+		req = req.WithContext(middleware.WithUserID(req.Context(), userID))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	expiresAt := time.Now().Add(time.Hour).Unix()
+	created := api.APIToken{}
+
+	t.Run("tokens require a scope", func(t *testing.T) {
+		rec := do(http.MethodPost, "/api/v1/tokens", "admin", api.APIToken{Name: "ci", Scopes: []api.TokenScope{}})
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("unknown scopes are rejected", func(t *testing.T) {
+		rec := do(http.MethodPost, "/api/v1/tokens", "admin", api.APIToken{Name: "ci", Scopes: []api.TokenScope{"root"}})
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("expiry must be in the future", func(t *testing.T) {
+		past := time.Now().Add(-time.Hour).Unix()
+		rec := do(http.MethodPost, "/api/v1/tokens", "admin", api.APIToken{
+			Name:      "ci",
+			Scopes:    []api.TokenScope{api.TokenScopeRead},
+			ExpiresAt: &past,
+		})
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("create returns the token once", func(t *testing.T) {
+		rec := do(http.MethodPost, "/api/v1/tokens", "admin", api.APIToken{
+			Name:      "ci",
+			Scopes:    []api.TokenScope{api.TokenScopeCheckIn},
+			ExpiresAt: &expiresAt,
+		})
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		_ = json.NewDecoder(rec.Body).Decode(&created)
+		if created.Id == nil || created.CreatedAt == nil || created.Token == nil {
+			t.Fatalf("unexpected token in the response %+v", created)
+		}
+		if !strings.HasPrefix(*created.Token, middleware.APITokenPrefix) {
+			t.Errorf("expected the token to start with %q, got %q", middleware.APITokenPrefix, *created.Token)
+		}
+
+		// Only the hash of the token is stored
+		stored, userID, err := store.GetAPITokenByHash(secrets.HashToken(*created.Token))
+		if err != nil {
+			t.Fatalf("expected the token hash to be stored: %v", err)
+		}
+		if userID != "admin" || *stored.Id != *created.Id {
+			t.Errorf("unexpected stored token %+v for %q", stored, userID)
+		}
+	})
+
+	t.Run("list hides the token", func(t *testing.T) {
+		rec := do(http.MethodGet, "/api/v1/tokens", "admin", nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+
+		tokens := []api.APIToken{}
+		_ = json.NewDecoder(rec.Body).Decode(&tokens)
+		if len(tokens) != 1 || tokens[0].Token != nil || tokens[0].Name != "ci" {
+			t.Errorf("unexpected tokens %+v", tokens)
+		}
+	})
+
+	t.Run("update changes the scopes", func(t *testing.T) {
+		rec := do(http.MethodPut, fmt.Sprintf("/api/v1/tokens/%d", *created.Id), "admin", api.APIToken{
+			Name:   "ci",
+			Scopes: []api.TokenScope{api.TokenScopeRead, api.TokenScopeCheckIn},
+		})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		updated := api.APIToken{}
+		_ = json.NewDecoder(rec.Body).Decode(&updated)
+		if len(updated.Scopes) != 2 || updated.ExpiresAt != nil || updated.Token != nil {
+			t.Errorf("unexpected updated token %+v", updated)
+		}
+	})
+
+	t.Run("tokens of other users are not found", func(t *testing.T) {
+		rec := do(http.MethodGet, fmt.Sprintf("/api/v1/tokens/%d", *created.Id), "someone-else", nil)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", rec.Code)
+		}
+
+		rec = do(http.MethodDelete, fmt.Sprintf("/api/v1/tokens/%d", *created.Id), "someone-else", nil)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", rec.Code)
+		}
+	})
+
+	t.Run("delete revokes the token", func(t *testing.T) {
+		rec := do(http.MethodDelete, fmt.Sprintf("/api/v1/tokens/%d", *created.Id), "admin", nil)
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("expected 204, got %d", rec.Code)
+		}
+
+		rec = do(http.MethodGet, fmt.Sprintf("/api/v1/tokens/%d", *created.Id), "admin", nil)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", rec.Code)
+		}
+	})
+
+	t.Run("invalid ids are rejected", func(t *testing.T) {
+		rec := do(http.MethodGet, "/api/v1/tokens/abc", "admin", nil)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+const (
+	errWebhookSecret = "Webhooks require a signing secret"
+)
+
+// keepWebhookSecrets copies the current secret, headers and client certificate to updated webhooks with the
+// same URL that were sent without them, and reports whether every webhook ends up with a signing secret.
+func keepWebhookSecrets(payload *api.Switch, previous api.Switch) bool {
+	if payload.Webhooks == nil {
+		return true
+	}
+
+	current := map[string]api.Webhook{}
+	if previous.Webhooks != nil {
+		for _, hook := range *previous.Webhooks {
+			current[hook.Url] = hook
+		}
+	}
+
+	webhooks := make([]api.Webhook, len(*payload.Webhooks))
+	for i, hook := range *payload.Webhooks {
+		prev, ok := current[hook.Url]
+		if ok {
+			if hook.Secret == nil {
+				hook.Secret = prev.Secret
+			}
+			if hook.Headers == nil {
+				hook.Headers = prev.Headers
+			}
+			if hook.ClientCertificate == nil && hook.ClientKey == nil {
+				hook.ClientCertificate = prev.ClientCertificate
+				hook.ClientKey = prev.ClientKey
+			}
+		}
+		if hook.Secret == nil || *hook.Secret == "" {
+			return false
+		}
+		webhooks[i] = hook
+	}
+	payload.Webhooks = &webhooks
+
+	return true
+}
+
+// redactWebhooks removes webhook credentials, only revealing whether they are configured.
+func redactWebhooks(sw *api.Switch) {
+	if sw.Webhooks == nil {
+		return
+	}
+
+	webhooks := make([]api.Webhook, len(*sw.Webhooks))
+	for i, hook := range *sw.Webhooks {
+		signed := hook.Secret != nil
+		mutualTLS := hook.ClientCertificate != nil
+		hook.Signed = &signed
+		hook.MutualTls = &mutualTLS
+		hook.Secret = nil
+		hook.Headers = nil
+		hook.ClientCertificate = nil
+		hook.ClientKey = nil
+		webhooks[i] = hook
+	}
+	sw.Webhooks = &webhooks
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestSwitchWebhooks(t *testing.T) {
+	s, store := setupTestHandler(t)
+	mw := middleware.SwitchValidator(validator.New())
+
+	r := chi.NewRouter()
+	r.With(mw).Post("/api/v1/switch", s.PostHandleFunc)
+	r.With(mw).Put("/api/v1/switch/{id}", s.PutByIDHandleFunc)
+
+	do := func(method, path string, body any) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		_ = json.NewEncoder(&buf).Encode(body)
+		req := httptest.NewRequest(method, path, &buf)
+		req = req.WithContext(middleware.WithUserID(req.Context(), "admin"))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	payload := func(webhooks *[]api.Webhook) api.Switch {
+		return api.Switch{
+			Message:         "Webhooks",
+			Notifiers:       []string{"logger://"},
+			CheckInInterval: "24h",
+			Webhooks:        webhooks,
+		}
+	}
+
+	secret := "0123456789abcdef"
+	created := api.Switch{}
+
+	t.Run("webhooks require a secret", func(t *testing.T) {
+		rec := do(http.MethodPost, "/api/v1/switch", payload(&[]api.Webhook{{Url: "https://example.com/hook"}}))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("body templates must render to JSON", func(t *testing.T) {
+		rec := do(http.MethodPost, "/api/v1/switch", payload(&[]api.Webhook{{Url: "https://example.com/hook", Secret: &secret, Body: ptr("{{ .Message }}")}}))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("credentials are redacted", func(t *testing.T) {
+		rec := do(http.MethodPost, "/api/v1/switch", payload(&[]api.Webhook{{
+			Url:     "https://example.com/hook",
+			Secret:  &secret,
+			Headers: &map[string]string{"Authorization": "Bearer abc"},
+		}}))
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		_ = json.NewDecoder(rec.Body).Decode(&created)
+		hook := (*created.Webhooks)[0]
+		if hook.Secret != nil || hook.Headers != nil || !*hook.Signed || *hook.MutualTls {
+			t.Errorf("unexpected webhook in the response %+v", hook)
+		}
+	})
+
+	t.Run("credentials are kept for the same URL", func(t *testing.T) {
+		rec := do(http.MethodPut, fmt.Sprintf("/api/v1/switch/%d", *created.Id), payload(&[]api.Webhook{{Url: "https://example.com/hook", MaxAttempts: ptr(5)}}))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		stored, err := store.GetByID("admin", *created.Id)
+		if err != nil {
+			t.Fatalf("failed to get switch: %v", err)
+		}
+
+		hook := (*stored.Webhooks)[0]
+		if *hook.Secret != secret || (*hook.Headers)["Authorization"] != "Bearer abc" || *hook.MaxAttempts != 5 {
+			t.Errorf("expected credentials to be kept, got %+v", hook)
+		}
+	})
+
+	t.Run("a new URL needs its own secret", func(t *testing.T) {
+		rec := do(http.MethodPut, fmt.Sprintf("/api/v1/switch/%d", *created.Id), payload(&[]api.Webhook{{Url: "https://example.org/hook"}}))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,185 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+const (
+	// DefaultTimeout is how long an action can run when it doesn't set a timeout.
+	DefaultTimeout = 30 * time.Second
+	// maxOutput is how much of an action's output is kept.
+	maxOutput = 4 * 1024
+	// envPrefix prefixes the environment variables describing the switch.
+	envPrefix = "DEAD_MANS_SWITCH_"
+)
+
+// Action is a command the server is allowed to run on the host when a switch triggers. Actions are only
+// defined in the server configuration and switches refer to them by name.
+type Action struct {
+	// Command is the executable to run. It is not run through a shell.
+	Command string `mapstructure:"command"`
+	// Args are passed to the command as is.
+	Args []string `mapstructure:"args"`
+	// Timeout stops the command if it runs longer. Defaults to DefaultTimeout.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// Users are the users other than admins whose switches can run the action.
+	Users []string `mapstructure:"users"`
+}
+
+// Allows reports whether a user can add the action to their switches. Admins can add any action.
+func (a Action) Allows(userID string, admin bool) bool {
+	return admin || slices.Contains(a.Users, userID)
+}
+
+// Result is the outcome of running an action.
+type Result struct {
+	ExitCode int
+	Output   string
+	Err      error
+	Duration time.Duration
+}
+
+// Event describes the switch an action is run for. It is written to the command's standard input as JSON.
+type Event struct {
+	Action          string    `json:"action"`
+	SwitchID        int       `json:"switchId"`
+	UserID          string    `json:"userId"`
+	Message         string    `json:"message"`
+	Labels          []string  `json:"labels"`
+	CheckInInterval string    `json:"checkInInterval"`
+	LastCheckInAt   *int64    `json:"lastCheckInAt,omitempty"`
+	TriggeredAt     time.Time `json:"triggeredAt"`
+}
+
+// Validate makes sure every action has a command to run.
+func Validate(actions map[string]Action) error {
+	for name, action := range actions {
+		if strings.TrimSpace(name) == "" {
+			return errors.New("actions must have a name")
+		}
+
+		if action.Command == "" {
+			return fmt.Errorf("action %q is missing a command", name)
+		}
+
+		if action.Timeout < 0 {
+			return fmt.Errorf("action %q has a negative timeout", name)
+		}
+	}
+
+	return nil
+}
+
+// NewEvent describes a triggered switch for an action.
+func NewEvent(name string, sw api.Switch, now time.Time) Event {
+	event := Event{
+		Action:          name,
+		Message:         sw.Message,
+		Labels:          []string{},
+		CheckInInterval: sw.CheckInInterval,
+		LastCheckInAt:   sw.LastCheckInAt,
+		TriggeredAt:     now.UTC(),
+	}
+
+	if sw.Id != nil {
+		event.SwitchID = *sw.Id
+	}
+	if sw.UserId != nil {
+		event.UserID = *sw.UserId
+	}
+	if sw.Labels != nil {
+		event.Labels = *sw.Labels
+	}
+
+	return event
+}
+
+// Run runs an action for an event, passing the switch details through the environment and standard input.
+// Output beyond 4 KiB is dropped.
+func Run(ctx context.Context, action Action, event Event) Result {
+	timeout := action.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	input, err := json.Marshal(event)
+	if err != nil {
+		return Result{ExitCode: -1, Err: err}
+	}
+
+	output := &limitedBuffer{limit: maxOutput}
+
+	cmd := exec.CommandContext(ctx, action.Command, action.Args...)
+	cmd.Env = append(os.Environ(),
+		envPrefix+"ACTION="+event.Action,
+		envPrefix+"ID="+strconv.Itoa(event.SwitchID),
+		envPrefix+"USER_ID="+event.UserID,
+		envPrefix+"LABELS="+strings.Join(event.Labels, ","),
+		envPrefix+"TRIGGERED_AT="+strconv.FormatInt(event.TriggeredAt.Unix(), 10),
+	)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	// Don't wait on pipes held open by processes the command left behind
+	cmd.WaitDelay = time.Second
+
+	start := time.Now()
+	err = cmd.Run()
+
+	result := Result{
+		ExitCode: -1,
+		Output:   output.String(),
+		Duration: time.Since(start),
+	}
+	if cmd.ProcessState != nil {
+		result.ExitCode = cmd.ProcessState.ExitCode()
+	}
+
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		result.Err = fmt.Errorf("timed out after %s", timeout)
+	case err != nil:
+		result.Err = err
+	}
+
+	return result
+}
+
+// limitedBuffer keeps the first limit bytes written to it and discards the rest.
+type limitedBuffer struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	remaining := b.limit - b.buf.Len()
+	if remaining > 0 {
+		if len(p) > remaining {
+			b.buf.Write(p[:remaining])
+		} else {
+			b.buf.Write(p)
+		}
+	}
+
+	// Report everything as written so the command isn't stopped by a short write
+	return len(p), nil
+}
+
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
+}
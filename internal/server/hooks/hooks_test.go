@@ -0,0 +1,104 @@
+package hooks
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+func TestRun(t *testing.T) {
+	id := 7
+	userID := "admin"
+	event := NewEvent("wipe", api.Switch{
+		Id:              &id,
+		UserId:          &userID,
+		Message:         "goodbye",
+		CheckInInterval: "24h",
+		Labels:          &[]string{"servers", "keys"},
+	}, time.Now())
+
+	t.Run("passes the switch through the environment and stdin", func(t *testing.T) {
+		result := Run(context.Background(), Action{
+			Command: "/bin/sh",
+			Args:    []string{"-c", `echo "$DEAD_MANS_SWITCH_ACTION $DEAD_MANS_SWITCH_ID $DEAD_MANS_SWITCH_LABELS"; cat`},
+		}, event)
+
+		if result.Err != nil {
+			t.Fatalf("unexpected error: %v", result.Err)
+		}
+		if result.ExitCode != 0 {
+			t.Errorf("expected exit code 0, got %d", result.ExitCode)
+		}
+		if !strings.HasPrefix(result.Output, "wipe 7 servers,keys\n") {
+			t.Errorf("unexpected environment output %q", result.Output)
+		}
+		if !strings.Contains(result.Output, `"message":"goodbye"`) {
+			t.Errorf("expected the switch on stdin, got %q", result.Output)
+		}
+	})
+
+	t.Run("records the exit code of a failed command", func(t *testing.T) {
+		result := Run(context.Background(), Action{Command: "/bin/sh", Args: []string{"-c", "echo nope >&2; exit 3"}}, event)
+
+		if result.Err == nil {
+			t.Error("expected an error")
+		}
+		if result.ExitCode != 3 {
+			t.Errorf("expected exit code 3, got %d", result.ExitCode)
+		}
+		if result.Output != "nope\n" {
+			t.Errorf("expected stderr to be captured, got %q", result.Output)
+		}
+	})
+
+	t.Run("stops commands that time out", func(t *testing.T) {
+		result := Run(context.Background(), Action{Command: "/bin/sh", Args: []string{"-c", "sleep 5"}, Timeout: 100 * time.Millisecond}, event)
+
+		if result.Err == nil || !strings.Contains(result.Err.Error(), "timed out") {
+			t.Errorf("expected a timeout, got %v", result.Err)
+		}
+		if result.Duration > 3*time.Second {
+			t.Errorf("expected the command to be stopped, ran for %s", result.Duration)
+		}
+	})
+
+	t.Run("truncates long output", func(t *testing.T) {
+		result := Run(context.Background(), Action{Command: "/bin/sh", Args: []string{"-c", "head -c 10000 /dev/zero"}}, event)
+
+		if len(result.Output) != maxOutput {
+			t.Errorf("expected %d bytes of output, got %d", maxOutput, len(result.Output))
+		}
+	})
+
+	t.Run("reports missing commands", func(t *testing.T) {
+		result := Run(context.Background(), Action{Command: "/does/not/exist"}, event)
+
+		if result.Err == nil || result.ExitCode != -1 {
+			t.Errorf("expected an error and exit code -1, got %v %d", result.Err, result.ExitCode)
+		}
+	})
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		actions map[string]Action
+		wantErr bool
+	}{
+		{name: "valid", actions: map[string]Action{"wipe": {Command: "/usr/local/bin/wipe"}}},
+		{name: "missing command", actions: map[string]Action{"wipe": {}}, wantErr: true},
+		{name: "negative timeout", actions: map[string]Action{"wipe": {Command: "wipe", Timeout: -time.Second}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.actions)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+// Package metrics defines the application specific Prometheus metrics.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "dead_mans_switch"
+
+// CheckInMargin tracks how much time was left on a switch's countdown when it was checked in.
+var CheckInMargin = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Name:      "checkin_margin_seconds",
+	Help:      "Time remaining before a switch would have triggered when it was checked in.",
+	Buckets: []float64{
+		60,         // 1m
+		5 * 60,     // 5m
+		15 * 60,    // 15m
+		60 * 60,    // 1h
+		6 * 3600,   // 6h
+		12 * 3600,  // 12h
+		24 * 3600,  // 1d
+		72 * 3600,  // 3d
+		168 * 3600, // 7d
+	},
+}, []string{"method"})
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/secrets"
+)
+
+// APITokenPrefix starts every personal access token, telling them apart from JWTs.
+const APITokenPrefix = "dms_"
+
+const scopesKey contextKey = "scopes"
+
+var errTokenExpired = errors.New("token expired")
+
+// APITokenStore looks up personal access tokens by the hash of their secret.
+type APITokenStore interface {
+	GetAPITokenByHash(tokenHash string) (api.APIToken, string, error)
+	TouchAPIToken(id int, usedAt int64) error
+}
+
+// WithScopes returns a copy of ctx limited to the scopes of the API token that authenticated it.
+func WithScopes(ctx context.Context, scopes []api.TokenScope) context.Context {
+	return context.WithValue(ctx, scopesKey, scopes)
+}
+
+// HasScope reports whether the request is allowed what the scope grants. Only requests authenticated by
+// an API token are limited. write includes read and checkin, and admin includes every scope.
+func HasScope(r *http.Request, scope api.TokenScope) bool {
+	scopes, ok := r.Context().Value(scopesKey).([]api.TokenScope)
+	if !ok {
+		return true
+	}
+
+	for _, granted := range scopes {
+		switch {
+		case granted == scope, granted == api.TokenScopeAdmin:
+			return true
+		case granted == api.TokenScopeWrite && scope != api.TokenScopeAdmin:
+			return true
+		}
+	}
+
+	return false
+}
+
+// RequireScope rejects requests authenticated by an API token that lacks the scope.
+func RequireScope(scope api.TokenScope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !HasScope(r, scope) {
+				http.Error(w, fmt.Sprintf("Token is missing the %s scope", scope), http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// authenticateAPIToken returns the user and scopes of a personal access token and records its use.
+func (v *JWTValidator) authenticateAPIToken(token string) (string, []api.TokenScope, error) {
+	if v.APITokens == nil {
+		return "", nil, errors.New("API tokens are not supported")
+	}
+
+	apiToken, userID, err := v.APITokens.GetAPITokenByHash(secrets.HashToken(token))
+	if err != nil {
+		return "", nil, err
+	}
+
+	now := time.Now().Unix()
+	if apiToken.ExpiresAt != nil && *apiToken.ExpiresAt <= now {
+		return "", nil, errTokenExpired
+	}
+
+	// Last use is informational, so failing to record it doesn't fail the request
+	_ = v.APITokens.TouchAPIToken(*apiToken.Id, now)
+
+	return userID, apiToken.Scopes, nil
+}
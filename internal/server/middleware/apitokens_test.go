@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/secrets"
+)
+
+// fakeAPITokens is an APITokenStore holding tokens by the hash of their secret.
+type fakeAPITokens struct {
+	tokens  map[string]api.APIToken
+	touched []int
+}
+
+func (f *fakeAPITokens) GetAPITokenByHash(tokenHash string) (api.APIToken, string, error) {
+	token, ok := f.tokens[tokenHash]
+	if !ok {
+		return api.APIToken{}, "", sql.ErrNoRows
+	}
+	return token, "alice", nil
+}
+
+func (f *fakeAPITokens) TouchAPIToken(id int, _ int64) error {
+	f.touched = append(f.touched, id)
+	return nil
+}
+
+func TestJWTAuthAPITokens(t *testing.T) {
+	id, expiredID := 1, 2
+	expired := time.Now().Add(-time.Minute).Unix()
+
+	store := &fakeAPITokens{tokens: map[string]api.APIToken{
+		secrets.HashToken("dms_checkin"): {Id: &id, Scopes: []api.TokenScope{api.TokenScopeCheckIn}},
+		secrets.HashToken("dms_expired"): {Id: &expiredID, Scopes: []api.TokenScope{api.TokenScopeAdmin}, ExpiresAt: &expired},
+	}}
+
+	validator := &JWTValidator{Enabled: true, APITokens: store}
+
+	var gotUser string
+	var gotCheckIn, gotRead bool
+	handler := JWTAuth(validator)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUser = GetUserIDFromContext(r)
+		gotCheckIn = HasScope(r, api.TokenScopeCheckIn)
+		gotRead = HasScope(r, api.TokenScopeRead)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name           string
+		token          string
+		expectedStatus int
+	}{
+		{name: "valid token", token: "dms_checkin", expectedStatus: http.StatusOK},
+		{name: "expired token", token: "dms_expired", expectedStatus: http.StatusUnauthorized},
+		{name: "unknown token", token: "dms_unknown", expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+
+	if gotUser != "alice" || !gotCheckIn || gotRead {
+		t.Errorf("expected alice with only the checkin scope, got %s (checkin %v, read %v)", gotUser, gotCheckIn, gotRead)
+	}
+	if len(store.touched) != 1 || store.touched[0] != id {
+		t.Errorf("expected only the valid token's use to be recorded, got %v", store.touched)
+	}
+}
+
+func TestHasScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		scopes   []api.TokenScope
+		scope    api.TokenScope
+		expected bool
+	}{
+		{name: "same scope", scopes: []api.TokenScope{api.TokenScopeRead}, scope: api.TokenScopeRead, expected: true},
+		{name: "other scope", scopes: []api.TokenScope{api.TokenScopeRead}, scope: api.TokenScopeCheckIn, expected: false},
+		{name: "write includes read", scopes: []api.TokenScope{api.TokenScopeWrite}, scope: api.TokenScopeRead, expected: true},
+		{name: "write includes checkin", scopes: []api.TokenScope{api.TokenScopeWrite}, scope: api.TokenScopeCheckIn, expected: true},
+		{name: "write excludes admin", scopes: []api.TokenScope{api.TokenScopeWrite}, scope: api.TokenScopeAdmin, expected: false},
+		{name: "admin includes everything", scopes: []api.TokenScope{api.TokenScopeAdmin}, scope: api.TokenScopeWrite, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req = req.WithContext(WithScopes(req.Context(), tt.scopes))
+
+			if got := HasScope(req, tt.scope); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("requests without a token have every scope", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if !HasScope(req, api.TokenScopeAdmin) {
+			t.Error("expected every scope")
+		}
+	})
+}
+
+func TestRequireScope(t *testing.T) {
+	handler := RequireScope(api.TokenScopeWrite)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req = req.WithContext(WithScopes(req.Context(), []api.TokenScope{api.TokenScopeRead}))
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", rec.Code)
+	}
+}
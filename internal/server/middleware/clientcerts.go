@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+// Client certificate fields rules can match.
+const (
+	CertFieldCommonName = "cn"
+	CertFieldEmail      = "email"
+	CertFieldDNS        = "dns"
+	CertFieldURI        = "uri"
+)
+
+var (
+	certFields = []string{CertFieldCommonName, CertFieldEmail, CertFieldDNS, CertFieldURI}
+	certScopes = []api.TokenScope{api.TokenScopeRead, api.TokenScopeCheckIn, api.TokenScopeWrite, api.TokenScopeAdmin}
+
+	errNoCertRule = errors.New("no rule matches the client certificate")
+)
+
+// CertRule maps client certificates to a user ID. Rules listed under auth-client-cert-rules in the config file
+// are read into it.
+type CertRule struct {
+	// Field is the certificate field matched: the subject's common name (cn), or an email, dns or uri
+	// subject alternative name.
+	Field string `mapstructure:"field"`
+	// Match is a regular expression the whole field must match. Any value matches if empty.
+	Match string `mapstructure:"match"`
+	// User is the user ID, which can refer to capture groups of Match such as $1. Defaults to the field's value.
+	User string `mapstructure:"user"`
+	// Scopes limit what the certificate is allowed like those of an API token. Certificates aren't limited if empty.
+	Scopes []api.TokenScope `mapstructure:"scopes"`
+
+	pattern *regexp.Regexp
+}
+
+// ClientCertAuth signs in requests with a client certificate the TLS listener verified against the client CA,
+// so headless devices don't need a token.
+type ClientCertAuth struct {
+	rules []CertRule
+}
+
+// NewClientCertAuth returns a ClientCertAuth trying rules in order, the first match naming the user. Without
+// rules the subject's common name is the user ID.
+func NewClientCertAuth(rules []CertRule) (*ClientCertAuth, error) {
+	if len(rules) == 0 {
+		rules = []CertRule{{Field: CertFieldCommonName}}
+	}
+
+	compiled := make([]CertRule, 0, len(rules))
+	for i, rule := range rules {
+		if !slices.Contains(certFields, rule.Field) {
+			return nil, fmt.Errorf("client certificate rule %d has invalid field %q. Valid fields are: %v", i+1, rule.Field, certFields)
+		}
+
+		for _, scope := range rule.Scopes {
+			if !slices.Contains(certScopes, scope) {
+				return nil, fmt.Errorf("client certificate rule %d has invalid scope %q", i+1, scope)
+			}
+		}
+
+		match := rule.Match
+		if match == "" {
+			match = ".+"
+		}
+
+		pattern, err := regexp.Compile("^(?:" + match + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("client certificate rule %d has an invalid match: %w", i+1, err)
+		}
+		rule.pattern = pattern
+
+		compiled = append(compiled, rule)
+	}
+
+	return &ClientCertAuth{rules: compiled}, nil
+}
+
+// authenticate returns the user and scopes of the request's client certificate.
+func (c *ClientCertAuth) authenticate(r *http.Request) (string, []api.TokenScope, error) {
+	// Browsers send client certificates along with requests other sites make too
+	err := crossOrigin.Check(r)
+	if err != nil {
+		return "", nil, err
+	}
+
+	cert := r.TLS.VerifiedChains[0][0]
+
+	for _, rule := range c.rules {
+		for _, value := range certValues(cert, rule.Field) {
+			match := rule.pattern.FindStringSubmatchIndex(value)
+			if match == nil {
+				continue
+			}
+
+			userID := value
+			if rule.User != "" {
+				userID = string(rule.pattern.ExpandString(nil, rule.User, value, match))
+			}
+			if userID == "" {
+				continue
+			}
+
+			return userID, rule.Scopes, nil
+		}
+	}
+
+	return "", nil, errNoCertRule
+}
+
+// certValues returns the values of a certificate field.
+func certValues(cert *x509.Certificate, field string) []string {
+	switch field {
+	case CertFieldCommonName:
+		if cert.Subject.CommonName == "" {
+			return nil
+		}
+		return []string{cert.Subject.CommonName}
+	case CertFieldEmail:
+		return cert.EmailAddresses
+	case CertFieldDNS:
+		return cert.DNSNames
+	case CertFieldURI:
+		values := []string{}
+		for _, uri := range cert.URIs {
+			values = append(values, uri.String())
+		}
+		return values
+	}
+	return nil
+}
+
+// hasClientCert reports whether the request was sent with a client certificate the TLS listener verified.
+func hasClientCert(r *http.Request) bool {
+	return r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && len(r.TLS.VerifiedChains[0]) > 0
+}
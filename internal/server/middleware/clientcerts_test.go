@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/secrets"
+)
+
+func TestJWTAuthClientCerts(t *testing.T) {
+	clientCerts, err := NewClientCertAuth([]CertRule{
+		{Field: CertFieldURI, Match: `spiffe://home\.example\.com/device/(.+)`, User: "device:$1", Scopes: []api.TokenScope{api.TokenScopeCheckIn}},
+		{Field: CertFieldEmail, Match: `.+@example\.com`},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	validator := &JWTValidator{
+		Enabled:     true,
+		ClientCerts: clientCerts,
+		Sessions:    fakeSessions{secrets.HashToken("dmss_bob"): {Username: "bob"}},
+	}
+
+	var gotUser string
+	var gotScopes []api.TokenScope
+	handler := JWTAuth(validator)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUser = GetUserIDFromContext(r)
+		gotScopes, _ = r.Context().Value(scopesKey).([]api.TokenScope)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	device, _ := url.Parse("spiffe://home.example.com/device/garage-pi")
+
+	tests := []struct {
+		name           string
+		method         string
+		cert           *x509.Certificate
+		headers        map[string]string
+		expectedStatus int
+		expectedUser   string
+		expectedScopes []api.TokenScope
+	}{
+		{
+			name:           "rules map SANs to users with their scopes",
+			cert:           &x509.Certificate{URIs: []*url.URL{device}},
+			expectedStatus: http.StatusOK,
+			expectedUser:   "device:garage-pi",
+			expectedScopes: []api.TokenScope{api.TokenScopeCheckIn},
+		},
+		{
+			name:           "later rules are tried when earlier ones don't match",
+			cert:           &x509.Certificate{EmailAddresses: []string{"alice@other.org", "alice@example.com"}},
+			expectedStatus: http.StatusOK,
+			expectedUser:   "alice@example.com",
+		},
+		{
+			name:           "matches cover the whole value",
+			cert:           &x509.Certificate{EmailAddresses: []string{"alice@example.com.evil.org"}},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "certificates no rule maps are rejected",
+			cert:           &x509.Certificate{Subject: pkix.Name{CommonName: "alice"}},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "other sites can't make changes with the certificate",
+			method:         http.MethodPost,
+			cert:           &x509.Certificate{EmailAddresses: []string{"alice@example.com"}},
+			headers:        map[string]string{"Sec-Fetch-Site": "cross-site"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "the Authorization header takes precedence",
+			cert:           &x509.Certificate{EmailAddresses: []string{"alice@example.com"}},
+			headers:        map[string]string{"Authorization": "Bearer dmss_bob"},
+			expectedStatus: http.StatusOK,
+			expectedUser:   "bob",
+		},
+		{
+			name:           "requests without a certificate need a token",
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotUser, gotScopes = "", nil
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			req := httptest.NewRequest(method, "/", nil)
+			if tt.cert != nil {
+				req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{tt.cert}}}
+			}
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if gotUser != tt.expectedUser || !slices.Equal(gotScopes, tt.expectedScopes) {
+				t.Errorf("expected user %q with scopes %v, got %q with %v", tt.expectedUser, tt.expectedScopes, gotUser, gotScopes)
+			}
+		})
+	}
+
+	t.Run("the common name is the user without rules", func(t *testing.T) {
+		clientCerts, err := NewClientCertAuth(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "garage-pi"}}}}}
+
+		userID, _, err := clientCerts.authenticate(req)
+		if err != nil || userID != "garage-pi" {
+			t.Errorf("expected garage-pi, got %q: %v", userID, err)
+		}
+	})
+}
+
+func TestNewClientCertAuth(t *testing.T) {
+	tests := []struct {
+		name string
+		rule CertRule
+	}{
+		{name: "unknown field", rule: CertRule{Field: "serial"}},
+		{name: "invalid match", rule: CertRule{Field: CertFieldCommonName, Match: "("}},
+		{name: "unknown scope", rule: CertRule{Field: CertFieldCommonName, Scopes: []api.TokenScope{"delete"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClientCertAuth([]CertRule{tt.rule})
+			if err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultJWKSRefreshInterval is how often the issuer's keys are fetched again to pick up rotated keys.
+	DefaultJWKSRefreshInterval = time.Hour
+	// defaultJWKSMinRefreshInterval limits how often tokens signed by an unknown key fetch the issuer's keys,
+	// so tokens with made up key IDs can't flood the issuer.
+	defaultJWKSMinRefreshInterval = 30 * time.Second
+)
+
+// JWKSCache keeps the public keys of an OIDC issuer. Keys are fetched again in the background and when a
+// token is signed by a key that isn't known yet, which is how a rotated key first shows up.
+type JWKSCache struct {
+	issuerURL          string
+	refreshInterval    time.Duration
+	minRefreshInterval time.Duration
+	logger             *slog.Logger
+
+	mu   sync.RWMutex
+	keys map[string]PublicKey
+
+	// refreshMu serializes fetches so concurrent requests for an unknown key share one fetch
+	refreshMu   sync.Mutex
+	lastAttempt time.Time
+}
+
+// NewJWKSCache returns a cache of the issuer's keys. Keys are fetched with Refresh and kept up to date by Run.
+func NewJWKSCache(issuerURL string, refreshInterval time.Duration, logger *slog.Logger) *JWKSCache {
+	if refreshInterval <= 0 {
+		refreshInterval = DefaultJWKSRefreshInterval
+	}
+
+	return &JWKSCache{
+		issuerURL:          issuerURL,
+		refreshInterval:    refreshInterval,
+		minRefreshInterval: defaultJWKSMinRefreshInterval,
+		logger:             logger,
+		keys:               map[string]PublicKey{},
+	}
+}
+
+// PublicKey returns the key with the given key ID, fetching the issuer's keys again if it isn't known.
+func (c *JWKSCache) PublicKey(kid string) (PublicKey, error) {
+	key, ok := c.key(kid)
+	if ok {
+		return key, nil
+	}
+
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	// Another request may have fetched the key while this one waited
+	key, ok = c.key(kid)
+	if ok {
+		return key, nil
+	}
+
+	if time.Since(c.lastAttempt) < c.minRefreshInterval {
+		return PublicKey{}, fmt.Errorf("public key not found for kid: %s", kid)
+	}
+
+	err := c.refresh()
+	if err != nil {
+		c.logger.Warn("Failed to refresh issuer keys", "kid", kid, "error", err)
+	}
+
+	key, ok = c.key(kid)
+	if !ok {
+		return PublicKey{}, fmt.Errorf("public key not found for kid: %s", kid)
+	}
+
+	return key, nil
+}
+
+// Refresh fetches the issuer's keys. Keys the issuer no longer publishes are dropped, and the current keys
+// are kept if the issuer can't be reached.
+func (c *JWKSCache) Refresh() error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	return c.refresh()
+}
+
+// Run refreshes the issuer's keys until ctx is done. Until keys have been fetched it retries more often, so
+// a server started while the issuer was down starts accepting tokens soon after it's back.
+func (c *JWKSCache) Run(ctx context.Context) {
+	timer := time.NewTimer(c.nextRefresh())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			err := c.Refresh()
+			if err != nil {
+				c.logger.Warn("Failed to refresh issuer keys", "error", err)
+			}
+			timer.Reset(c.nextRefresh())
+		}
+	}
+}
+
+// refresh fetches the issuer's keys. The caller must hold refreshMu.
+func (c *JWKSCache) refresh() error {
+	c.lastAttempt = time.Now()
+
+	keys, err := FetchPublicKeys(c.issuerURL)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.keys = keys
+	c.mu.Unlock()
+
+	return nil
+}
+
+// nextRefresh returns how long to wait before fetching the issuer's keys again.
+func (c *JWKSCache) nextRefresh() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if len(c.keys) == 0 {
+		return c.minRefreshInterval
+	}
+	return c.refreshInterval
+}
+
+// key returns a cached key.
+func (c *JWKSCache) key(kid string) (PublicKey, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	key, ok := c.keys[kid]
+	return key, ok
+}
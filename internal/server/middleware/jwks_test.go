@@ -0,0 +1,188 @@
+package middleware
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testIssuer serves an OIDC discovery document and a JWKS that can be swapped out to rotate keys.
+type testIssuer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	keys    []jsonWebKey
+	down    bool
+	fetches atomic.Int32
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+
+	issuer := &testIssuer{}
+	issuer.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		issuer.mu.Lock()
+		defer issuer.mu.Unlock()
+
+		if issuer.down {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/.well-known/openid-configuration" {
+			_ = json.NewEncoder(w).Encode(map[string]string{"jwks_uri": "http://" + r.Host + "/jwks"})
+			return
+		}
+
+		issuer.fetches.Add(1)
+		_ = json.NewEncoder(w).Encode(jsonWebKeySet{Keys: issuer.keys})
+	}))
+	t.Cleanup(issuer.Close)
+
+	return issuer
+}
+
+// setKeys replaces the keys the issuer publishes with new keys with the given IDs.
+func (i *testIssuer) setKeys(t *testing.T, kids ...string) {
+	t.Helper()
+
+	keys := []jsonWebKey{}
+	for _, kid := range kids {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, ecJWK(kid, &key.PublicKey))
+	}
+
+	i.mu.Lock()
+	i.keys = keys
+	i.mu.Unlock()
+}
+
+func (i *testIssuer) setDown(down bool) {
+	i.mu.Lock()
+	i.down = down
+	i.mu.Unlock()
+}
+
+func TestJWKSCache(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("Rotated keys are fetched when a token uses them", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		issuer.setKeys(t, "key-1")
+
+		cache := NewJWKSCache(issuer.URL, time.Hour, logger)
+		cache.minRefreshInterval = 0
+
+		err := cache.Refresh()
+		if err != nil {
+			t.Fatalf("failed to fetch keys: %v", err)
+		}
+
+		issuer.setKeys(t, "key-2")
+
+		_, err = cache.PublicKey("key-2")
+		if err != nil {
+			t.Fatalf("expected the rotated key to be fetched: %v", err)
+		}
+
+		_, err = cache.PublicKey("key-1")
+		if err == nil {
+			t.Error("expected the retired key to be dropped")
+		}
+	})
+
+	t.Run("Unknown keys only fetch the issuer's keys once per interval", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		issuer.setKeys(t, "key-1")
+
+		cache := NewJWKSCache(issuer.URL, time.Hour, logger)
+		err := cache.Refresh()
+		if err != nil {
+			t.Fatalf("failed to fetch keys: %v", err)
+		}
+
+		for range 10 {
+			_, err = cache.PublicKey("made-up")
+			if err == nil {
+				t.Fatal("expected an unknown key to be rejected")
+			}
+		}
+
+		if fetches := issuer.fetches.Load(); fetches != 1 {
+			t.Errorf("expected 1 fetch, got %d", fetches)
+		}
+
+		_, err = cache.PublicKey("key-1")
+		if err != nil {
+			t.Errorf("expected known keys to keep working: %v", err)
+		}
+	})
+
+	t.Run("Keys are kept while the issuer is down", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		issuer.setKeys(t, "key-1")
+
+		cache := NewJWKSCache(issuer.URL, time.Hour, logger)
+		err := cache.Refresh()
+		if err != nil {
+			t.Fatalf("failed to fetch keys: %v", err)
+		}
+
+		issuer.setDown(true)
+
+		err = cache.Refresh()
+		if err == nil {
+			t.Fatal("expected the refresh to fail")
+		}
+
+		_, err = cache.PublicKey("key-1")
+		if err != nil {
+			t.Errorf("expected cached keys to keep working: %v", err)
+		}
+	})
+
+	t.Run("Keys are fetched in the background once the issuer is back", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		issuer.setKeys(t, "key-1")
+		issuer.setDown(true)
+
+		cache := NewJWKSCache(issuer.URL, time.Hour, logger)
+		cache.minRefreshInterval = 10 * time.Millisecond
+
+		err := cache.Refresh()
+		if err == nil {
+			t.Fatal("expected the first fetch to fail")
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go cache.Run(ctx)
+
+		issuer.setDown(false)
+
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			_, ok := cache.key("key-1")
+			if ok {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("expected keys to be fetched in the background")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+}
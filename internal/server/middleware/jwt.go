@@ -2,6 +2,10 @@ package middleware
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rsa"
 	"encoding/base64"
 	"encoding/json"
@@ -9,8 +13,11 @@ import (
 	"math/big"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/circa10a/dead-mans-switch/api"
 	"github.com/circa10a/dead-mans-switch/internal/server/database"
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -19,6 +26,12 @@ const (
 	userIDKey contextKey = "user_id"
 )
 
+// supportedAlgorithms are the JWT signing algorithms accepted from an issuer.
+var supportedAlgorithms = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// jwksClient fetches issuer keys. Keys can be fetched while a request waits, so it gives up rather than hang.
+var jwksClient = &http.Client{Timeout: 10 * time.Second}
+
 // GetUserIDFromContext retrieves the user ID from the request context
 func GetUserIDFromContext(r *http.Request) string {
 	userID := r.Context().Value(userIDKey)
@@ -28,12 +41,158 @@ func GetUserIDFromContext(r *http.Request) string {
 	return database.AdminUser
 }
 
+// WithUserID returns a copy of ctx carrying the authenticated user ID
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
 // JWTValidator holds configuration for JWT validation
 type JWTValidator struct {
-	Audience   string
-	Enabled    bool
-	IssuerURL  string
-	PublicKeys map[string]*rsa.PublicKey
+	Enabled bool
+	// Issuers are the OIDC providers whose tokens are trusted.
+	Issuers []Issuer
+	// APITokens authenticates personal access tokens sent instead of a JWT.
+	APITokens APITokenStore
+	// Sessions authenticates the sessions of local user accounts. The session cookie is only read when it is set.
+	Sessions SessionStore
+	// Roles looks up the roles admins assigned to users. Users are plain users if it isn't set.
+	Roles RoleStore
+	// Proxy trusts the user named by a reverse proxy. Personal access tokens are still accepted from anywhere.
+	Proxy *ProxyAuth
+	// ClientCerts signs in requests sent with a verified client certificate and no Authorization header.
+	ClientCerts *ClientCertAuth
+}
+
+// Issuer is an OIDC provider whose tokens are trusted. Issuers listed under auth-issuers in the config file
+// are read into it.
+type Issuer struct {
+	// Name labels the issuer in the UI's sign in picker and namespaces its user IDs as "<name>:<user>", so
+	// users of different issuers can't collide. Issuers without a name use plain user IDs, which can't
+	// contain ':' while another issuer is named.
+	Name string `mapstructure:"name"`
+	// URL is the issuer tokens must be issued by.
+	URL string `mapstructure:"url"`
+	// Audiences are the audience claims accepted from the issuer. Any audience is accepted if empty.
+	Audiences []string `mapstructure:"audiences"`
+	// ClientID is the client the UI signs in with. Defaults to the first audience.
+	ClientID string `mapstructure:"client-id"`
+	// UserClaim is the claim used as the user ID. Defaults to sub, falling back to email and name.
+	UserClaim string `mapstructure:"user-claim"`
+	// RolesClaim is the claim holding the user's roles or groups. Defaults to groups.
+	RolesClaim string `mapstructure:"roles-claim"`
+	// AdminRoles are the roles in RolesClaim that make a user an admin.
+	AdminRoles []string `mapstructure:"admin-roles"`
+	// Keys looks up the keys the issuer signs tokens with.
+	Keys KeySource `mapstructure:"-"`
+}
+
+// ValidateIssuers checks that every issuer has a URL and that tokens and user IDs can't be mistaken for
+// another issuer's.
+func ValidateIssuers(issuers []Issuer) error {
+	urls := map[string]bool{}
+	names := map[string]bool{}
+
+	for _, issuer := range issuers {
+		if issuer.URL == "" {
+			return fmt.Errorf("issuer %q has no URL", issuer.Name)
+		}
+
+		_, err := url.ParseRequestURI(issuer.URL)
+		if err != nil {
+			return fmt.Errorf("issuer %q has an invalid URL: %w", issuer.Name, err)
+		}
+
+		normalizedURL := strings.TrimSuffix(issuer.URL, "/")
+		if urls[normalizedURL] {
+			return fmt.Errorf("issuer %s is configured more than once", issuer.URL)
+		}
+		urls[normalizedURL] = true
+
+		if strings.Contains(issuer.Name, ":") {
+			return fmt.Errorf("issuer name %q cannot contain ':'", issuer.Name)
+		}
+
+		// Users of unnamed issuers aren't namespaced, so only one issuer can go without a name
+		if names[issuer.Name] {
+			if issuer.Name == "" {
+				return fmt.Errorf("issuer %s needs a name since only one issuer can be unnamed", issuer.URL)
+			}
+			return fmt.Errorf("issuer name %q is used more than once", issuer.Name)
+		}
+		names[issuer.Name] = true
+	}
+
+	return nil
+}
+
+// defaultUserClaims are the claims tried in order for a user ID when an issuer doesn't name one.
+var defaultUserClaims = []string{"sub", "email", "name"}
+
+// LoginClientID returns the client the UI signs in to the issuer with.
+func (i Issuer) LoginClientID() string {
+	if i.ClientID == "" && len(i.Audiences) > 0 {
+		return i.Audiences[0]
+	}
+	return i.ClientID
+}
+
+// hasAudience reports whether a token's audiences include one the issuer accepts.
+func (i Issuer) hasAudience(audiences []string) bool {
+	if len(i.Audiences) == 0 {
+		return true
+	}
+
+	for _, aud := range audiences {
+		if slices.Contains(i.Audiences, aud) {
+			return true
+		}
+	}
+	return false
+}
+
+// userID returns the namespaced user ID of a token's claims, or "" if the claims don't identify a user.
+func (i Issuer) userID(claims jwt.MapClaims) string {
+	claimNames := defaultUserClaims
+	if i.UserClaim != "" {
+		claimNames = []string{i.UserClaim}
+	}
+
+	for _, name := range claimNames {
+		value, ok := claims[name].(string)
+		if !ok || value == "" {
+			continue
+		}
+
+		if i.Name == "" {
+			return value
+		}
+		return i.Name + ":" + value
+	}
+
+	return ""
+}
+
+// KeySource looks up the public keys tokens are signed with by their key ID.
+type KeySource interface {
+	PublicKey(kid string) (PublicKey, error)
+}
+
+// PublicKey is a key tokens are signed with and the algorithm its JWK restricts it to, if any.
+type PublicKey struct {
+	Key crypto.PublicKey
+	Alg string
+}
+
+// StaticKeys is a fixed set of public keys by key ID.
+type StaticKeys map[string]crypto.PublicKey
+
+// PublicKey returns the key with the given key ID.
+func (k StaticKeys) PublicKey(kid string) (PublicKey, error) {
+	key, ok := k[kid]
+	if !ok {
+		return PublicKey{}, fmt.Errorf("public key not found for kid: %s", kid)
+	}
+	return PublicKey{Key: key}, nil
 }
 
 // jsonWebKeySet represents a JWKS response
@@ -48,24 +207,64 @@ type jsonWebKey struct {
 	Use string `json:"use"`
 	N   string `json:"n"`
 	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
 	Alg string `json:"alg"`
 }
 
-// customClaims represents JWT claims from Authentik
-type customClaims struct {
-	Sub   string `json:"sub"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
-	jwt.RegisteredClaims
-}
-
-// JWTAuth is a middleware that validates JWT tokens from Authentik
+// JWTAuth is a middleware that validates JWT tokens from Authentik, personal access tokens, local sessions,
+// client certificates, or the user named by a trusted reverse proxy
 func JWTAuth(validator *JWTValidator) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if !validator.Enabled {
-				// Auth disabled - set default user ID
-				ctx := context.WithValue(r.Context(), userIDKey, database.AdminUser)
+				// Auth disabled - set default user ID, who administers the server
+				ctx := WithRole(WithUserID(r.Context(), database.AdminUser), api.RoleAdmin)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			// Local sessions come from the Authorization header or the UI's session cookie
+			if validator.Sessions != nil {
+				if sessionToken := SessionToken(r); sessionToken != "" {
+					userID, err := validator.authenticateSession(sessionToken)
+					if err != nil {
+						http.Error(w, "Invalid session", http.StatusUnauthorized)
+						return
+					}
+
+					ctx := WithRole(WithUserID(r.Context(), userID), validator.role(userID, false))
+					next.ServeHTTP(w, r.WithContext(ctx))
+					return
+				}
+			}
+
+			// Headless devices sign in with the client certificate the TLS listener verified
+			if validator.ClientCerts != nil && r.Header.Get("Authorization") == "" && hasClientCert(r) {
+				userID, scopes, err := validator.ClientCerts.authenticate(r)
+				if err != nil {
+					http.Error(w, "Invalid client certificate", http.StatusUnauthorized)
+					return
+				}
+
+				ctx := WithRole(WithUserID(r.Context(), userID), validator.role(userID, false))
+				if len(scopes) > 0 {
+					ctx = WithScopes(ctx, scopes)
+				}
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			// A trusted reverse proxy already signed the user in
+			if validator.Proxy != nil && !hasAPIToken(r) {
+				userID, proxyAdmin, err := validator.Proxy.authenticate(r)
+				if err != nil {
+					http.Error(w, "Invalid proxy authentication", http.StatusUnauthorized)
+					return
+				}
+
+				ctx := WithRole(WithUserID(r.Context(), userID), validator.role(userID, proxyAdmin))
 				next.ServeHTTP(w, r.WithContext(ctx))
 				return
 			}
@@ -85,26 +284,35 @@ func JWTAuth(validator *JWTValidator) func(http.Handler) http.Handler {
 
 			tokenString := parts[1]
 
-			// Parse and validate token
-			token, err := jwt.ParseWithClaims(tokenString, &customClaims{}, func(token *jwt.Token) (interface{}, error) {
-				// Verify signing method
-				if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-					return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			// Personal access tokens carry their own user and scopes
+			if strings.HasPrefix(tokenString, APITokenPrefix) {
+				userID, scopes, err := validator.authenticateAPIToken(tokenString)
+				if err != nil {
+					http.Error(w, "Invalid token", http.StatusUnauthorized)
+					return
 				}
 
-				kid, ok := token.Header["kid"].(string)
-				if !ok {
-					return nil, fmt.Errorf("missing kid in token header")
-				}
+				ctx := WithScopes(WithRole(WithUserID(r.Context(), userID), validator.role(userID, false)), scopes)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
 
-				// Get public key
-				publicKey, ok := validator.PublicKeys[kid]
-				if !ok {
-					return nil, fmt.Errorf("public key not found for kid: %s", kid)
-				}
+			// The issuer picks the keys the token is verified with, so it is read before verifying the token
+			unverified, _, err := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
+			if err != nil {
+				http.Error(w, "Invalid token", http.StatusUnauthorized)
+				return
+			}
+
+			issuerURL, _ := unverified.Claims.GetIssuer()
+			issuer, ok := validator.issuer(issuerURL)
+			if !ok {
+				http.Error(w, "Invalid issuer", http.StatusUnauthorized)
+				return
+			}
 
-				return publicKey, nil
-			})
+			// Parse and validate token
+			token, err := jwt.ParseWithClaims(tokenString, jwt.MapClaims{}, issuer.keyFunc, jwt.WithValidMethods(supportedAlgorithms))
 
 			if err != nil {
 				http.Error(w, "Invalid token", http.StatusUnauthorized)
@@ -117,58 +325,111 @@ func JWTAuth(validator *JWTValidator) func(http.Handler) http.Handler {
 			}
 
 			// Validate claims
-			claims, ok := token.Claims.(*customClaims)
+			claims, ok := token.Claims.(jwt.MapClaims)
 			if !ok {
 				http.Error(w, "Invalid token claims", http.StatusUnauthorized)
 				return
 			}
 
-			// Verify issuer (normalize trailing slashes for cross-provider compatibility)
-			if strings.TrimSuffix(claims.Issuer, "/") != strings.TrimSuffix(validator.IssuerURL, "/") {
-				http.Error(w, "Invalid issuer", http.StatusUnauthorized)
-				return
-			}
-
 			// Verify audience if configured
-			if validator.Audience != "" {
-				hasAudience := false
-				for _, aud := range claims.Audience {
-					if aud == validator.Audience {
-						hasAudience = true
-						break
-					}
-				}
-				if !hasAudience {
-					http.Error(w, "Invalid audience", http.StatusUnauthorized)
-					return
-				}
+			audiences, err := claims.GetAudience()
+			if err != nil || !issuer.hasAudience(audiences) {
+				http.Error(w, "Invalid audience", http.StatusUnauthorized)
+				return
 			}
 
-			// Extract user ID from subject claim (or email/username if available)
-			userID := claims.Sub
-			if userID == "" && claims.Email != "" {
-				userID = claims.Email
-			}
-			if userID == "" && claims.Name != "" {
-				userID = claims.Name
-			}
+			userID := issuer.userID(claims)
 			if userID == "" {
 				http.Error(w, "No user identifier in token", http.StatusUnauthorized)
 				return
 			}
-			ctx := context.WithValue(r.Context(), userIDKey, userID)
+
+			// A plain user ID that looks namespaced could pass for the user of a named issuer
+			if issuer.Name == "" && strings.Contains(userID, ":") && validator.hasNamedIssuer() {
+				http.Error(w, "Invalid user identifier in token", http.StatusUnauthorized)
+				return
+			}
+			ctx := WithRole(WithUserID(r.Context(), userID), validator.role(userID, issuer.grantsAdmin(claims)))
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+// issuer returns the trusted issuer with the given URL. Trailing slashes are ignored for cross-provider
+// compatibility.
+func (v *JWTValidator) issuer(issuerURL string) (Issuer, bool) {
+	if issuerURL == "" {
+		return Issuer{}, false
+	}
+
+	for _, issuer := range v.Issuers {
+		if strings.TrimSuffix(issuer.URL, "/") == strings.TrimSuffix(issuerURL, "/") {
+			return issuer, true
+		}
+	}
+	return Issuer{}, false
+}
+
+// hasNamedIssuer reports whether any trusted issuer namespaces its user IDs.
+func (v *JWTValidator) hasNamedIssuer() bool {
+	return slices.ContainsFunc(v.Issuers, func(issuer Issuer) bool {
+		return issuer.Name != ""
+	})
+}
+
+// keyFunc returns the issuer key a token is signed with after checking the token's algorithm suits the key.
+func (i Issuer) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, ok := token.Header["kid"].(string)
+	if !ok {
+		return nil, fmt.Errorf("missing kid in token header")
+	}
+
+	if i.Keys == nil {
+		return nil, fmt.Errorf("public key not found for kid: %s", kid)
+	}
+
+	publicKey, err := i.Keys.PublicKey(kid)
+	if err != nil {
+		return nil, err
+	}
+
+	if publicKey.Alg != "" && publicKey.Alg != token.Method.Alg() {
+		return nil, fmt.Errorf("token algorithm %s doesn't match key algorithm %s", token.Method.Alg(), publicKey.Alg)
+	}
+
+	if !methodMatchesKey(token.Method, publicKey.Key) {
+		return nil, fmt.Errorf("unexpected signing method %s for kid: %s", token.Method.Alg(), kid)
+	}
+
+	return publicKey.Key, nil
+}
+
+// methodMatchesKey reports whether a token's signing method can be verified with a key, so a token can't pick
+// an algorithm its key wasn't made for.
+func methodMatchesKey(method jwt.SigningMethod, key crypto.PublicKey) bool {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		switch method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+			return true
+		}
+	case *ecdsa.PublicKey:
+		m, ok := method.(*jwt.SigningMethodECDSA)
+		return ok && m.CurveBits == k.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		_, ok := method.(*jwt.SigningMethodEd25519)
+		return ok
+	}
+	return false
+}
+
 // oidcDiscovery represents the OpenID Connect discovery document
 type oidcDiscovery struct {
 	JWKSURI string `json:"jwks_uri"`
 }
 
 // FetchPublicKeys fetches JWKS from the issuer's OIDC discovery endpoint
-func FetchPublicKeys(issuerURL string) (map[string]*rsa.PublicKey, error) {
+func FetchPublicKeys(issuerURL string) (map[string]PublicKey, error) {
 	// Use OIDC discovery to find the JWKS URI
 	discoveryURL := strings.TrimSuffix(issuerURL, "/") + "/.well-known/openid-configuration"
 
@@ -182,7 +443,7 @@ func FetchPublicKeys(issuerURL string) (map[string]*rsa.PublicKey, error) {
 		return nil, fmt.Errorf("failed to create OIDC discovery request: %w", err)
 	}
 
-	discoveryResp, err := http.DefaultClient.Do(discoveryReq)
+	discoveryResp, err := jwksClient.Do(discoveryReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch OIDC discovery: %w", err)
 	}
@@ -212,7 +473,7 @@ func FetchPublicKeys(issuerURL string) (map[string]*rsa.PublicKey, error) {
 		return nil, fmt.Errorf("failed to create JWKS request: %w", err)
 	}
 
-	resp, err := http.DefaultClient.Do(jwksReq)
+	resp, err := jwksClient.Do(jwksReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
 	}
@@ -228,28 +489,43 @@ func FetchPublicKeys(issuerURL string) (map[string]*rsa.PublicKey, error) {
 		return nil, fmt.Errorf("failed to decode JWKS: %w", err)
 	}
 
-	publicKeys := make(map[string]*rsa.PublicKey)
+	publicKeys := make(map[string]PublicKey)
 
 	for _, key := range jwks.Keys {
-		if key.Kty != "RSA" {
+		// Keys only meant for encryption don't sign tokens
+		if key.Use == "enc" {
 			continue
 		}
 
-		publicKey, err := jwkToRSAPublicKey(key)
+		publicKey, err := jwkToPublicKey(key)
 		if err != nil {
 			continue
 		}
 
-		publicKeys[key.Kid] = publicKey
+		publicKeys[key.Kid] = PublicKey{Key: publicKey, Alg: key.Alg}
 	}
 
 	if len(publicKeys) == 0 {
-		return nil, fmt.Errorf("no valid RSA keys found in JWKS")
+		return nil, fmt.Errorf("no valid signing keys found in JWKS")
 	}
 
 	return publicKeys, nil
 }
 
+// jwkToPublicKey converts a JWK to an RSA, EC or Ed25519 public key
+func jwkToPublicKey(jwk jsonWebKey) (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		return jwkToRSAPublicKey(jwk)
+	case "EC":
+		return jwkToECPublicKey(jwk)
+	case "OKP":
+		return jwkToEd25519PublicKey(jwk)
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", jwk.Kty)
+	}
+}
+
 // jwkToRSAPublicKey converts a JWK to an RSA public key
 func jwkToRSAPublicKey(jwk jsonWebKey) (*rsa.PublicKey, error) {
 	if jwk.Kty != "RSA" {
@@ -280,6 +556,68 @@ func jwkToRSAPublicKey(jwk jsonWebKey) (*rsa.PublicKey, error) {
 	}, nil
 }
 
+// jwkToECPublicKey converts a JWK to an ECDSA public key
+func jwkToECPublicKey(jwk jsonWebKey) (*ecdsa.PublicKey, error) {
+	if jwk.Kty != "EC" {
+		return nil, fmt.Errorf("unsupported key type: %s", jwk.Kty)
+	}
+
+	var curve elliptic.Curve
+	switch jwk.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve: %s", jwk.Crv)
+	}
+
+	xBytes, err := decodeBase64URL(jwk.X)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode x coordinate: %w", err)
+	}
+
+	yBytes, err := decodeBase64URL(jwk.Y)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode y coordinate: %w", err)
+	}
+
+	size := (curve.Params().BitSize + 7) / 8
+	if len(xBytes) != size || len(yBytes) != size {
+		return nil, fmt.Errorf("invalid coordinate length for curve %s", jwk.Crv)
+	}
+
+	// Parsing the uncompressed point checks it is on the curve
+	point := append([]byte{4}, xBytes...)
+	point = append(point, yBytes...)
+
+	return ecdsa.ParseUncompressedPublicKey(curve, point)
+}
+
+// jwkToEd25519PublicKey converts a JWK to an Ed25519 public key
+func jwkToEd25519PublicKey(jwk jsonWebKey) (ed25519.PublicKey, error) {
+	if jwk.Kty != "OKP" {
+		return nil, fmt.Errorf("unsupported key type: %s", jwk.Kty)
+	}
+
+	if jwk.Crv != "Ed25519" {
+		return nil, fmt.Errorf("unsupported curve: %s", jwk.Crv)
+	}
+
+	xBytes, err := decodeBase64URL(jwk.X)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode public key: %w", err)
+	}
+
+	if len(xBytes) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid Ed25519 public key length %d", len(xBytes))
+	}
+
+	return ed25519.PublicKey(xBytes), nil
+}
+
 // decodeBase64URL decodes a base64url-encoded string
 func decodeBase64URL(s string) ([]byte, error) {
 	// Add padding if necessary
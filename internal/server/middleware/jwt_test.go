@@ -1,9 +1,15 @@
 package middleware
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -13,6 +19,14 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// customClaims are the claims test tokens are issued with.
+type customClaims struct {
+	Sub   string `json:"sub"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	jwt.RegisteredClaims
+}
+
 func TestJWTAuth(t *testing.T) {
 	// Generate RSA key pair for testing
 	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
@@ -24,7 +38,7 @@ func TestJWTAuth(t *testing.T) {
 	issuerURL := "https://authentik.example.com/application/o/oauth2/"
 	audience := "dead-mans-switch"
 
-	publicKeys := map[string]*rsa.PublicKey{
+	publicKeys := StaticKeys{
 		"test-key": publicKey,
 	}
 
@@ -246,10 +260,8 @@ func TestJWTAuth(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			v := &JWTValidator{
-				Enabled:    tt.enabled,
-				IssuerURL:  issuerURL,
-				Audience:   audience,
-				PublicKeys: publicKeys,
+				Enabled: tt.enabled,
+				Issuers: []Issuer{{URL: issuerURL, Audiences: []string{audience}, Keys: publicKeys}},
 			}
 
 			nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -289,16 +301,14 @@ func TestJWTAuthWithoutAudience(t *testing.T) {
 
 	issuerURL := "https://authentik.example.com/application/o/oauth2/"
 
-	publicKeys := map[string]*rsa.PublicKey{
+	publicKeys := StaticKeys{
 		"test-key": publicKey,
 	}
 
 	// Without audience validation
 	validator := &JWTValidator{
-		Enabled:    true,
-		IssuerURL:  issuerURL,
-		Audience:   "", // Empty audience
-		PublicKeys: publicKeys,
+		Enabled: true,
+		Issuers: []Issuer{{URL: issuerURL, Keys: publicKeys}}, // No audiences
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodRS256, &customClaims{
@@ -334,6 +344,232 @@ func TestJWTAuthWithoutAudience(t *testing.T) {
 	}
 }
 
+func TestJWTAuthMultipleIssuers(t *testing.T) {
+	companyKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	familyKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	validator := &JWTValidator{
+		Enabled: true,
+		Issuers: []Issuer{
+			{URL: "https://company.example.com", Audiences: []string{"dms"}, Keys: StaticKeys{"key": &companyKey.PublicKey}},
+			{
+				Name:      "family",
+				URL:       "https://family.example.com/",
+				Audiences: []string{"dms-ui", "dms-cli"},
+				UserClaim: "email",
+				Keys:      StaticKeys{"key": &familyKey.PublicKey},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		method         jwt.SigningMethod
+		signingKey     crypto.PrivateKey
+		claims         customClaims
+		expectedStatus int
+		expectedUser   string
+	}{
+		{
+			name:       "unnamed issuer keeps plain user IDs",
+			method:     jwt.SigningMethodRS256,
+			signingKey: companyKey,
+			claims: customClaims{Sub: "alice", RegisteredClaims: jwt.RegisteredClaims{
+				Issuer: "https://company.example.com/", Audience: jwt.ClaimStrings{"dms"},
+			}},
+			expectedStatus: http.StatusOK,
+			expectedUser:   "alice",
+		},
+		{
+			name:       "unnamed issuer can't pass for a user of a named issuer",
+			method:     jwt.SigningMethodRS256,
+			signingKey: companyKey,
+			claims: customClaims{Sub: "family:alice@family.example.com", RegisteredClaims: jwt.RegisteredClaims{
+				Issuer: "https://company.example.com", Audience: jwt.ClaimStrings{"dms"},
+			}},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "named issuer namespaces its user claim",
+			method:     jwt.SigningMethodES256,
+			signingKey: familyKey,
+			claims: customClaims{Sub: "123", Email: "alice@family.example.com", RegisteredClaims: jwt.RegisteredClaims{
+				Issuer: "https://family.example.com", Audience: jwt.ClaimStrings{"dms-cli"},
+			}},
+			expectedStatus: http.StatusOK,
+			expectedUser:   "family:alice@family.example.com",
+		},
+		{
+			name:       "audiences are checked per issuer",
+			method:     jwt.SigningMethodES256,
+			signingKey: familyKey,
+			claims: customClaims{Email: "alice@family.example.com", RegisteredClaims: jwt.RegisteredClaims{
+				Issuer: "https://family.example.com", Audience: jwt.ClaimStrings{"dms"},
+			}},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "keys of one issuer don't verify another issuer's tokens",
+			method:     jwt.SigningMethodES256,
+			signingKey: familyKey,
+			claims: customClaims{Sub: "alice", RegisteredClaims: jwt.RegisteredClaims{
+				Issuer: "https://company.example.com", Audience: jwt.ClaimStrings{"dms"},
+			}},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "missing user claim",
+			method:     jwt.SigningMethodES256,
+			signingKey: familyKey,
+			claims: customClaims{Sub: "123", RegisteredClaims: jwt.RegisteredClaims{
+				Issuer: "https://family.example.com", Audience: jwt.ClaimStrings{"dms-ui"},
+			}},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "untrusted issuer",
+			method:     jwt.SigningMethodRS256,
+			signingKey: companyKey,
+			claims: customClaims{Sub: "alice", RegisteredClaims: jwt.RegisteredClaims{
+				Issuer: "https://evil.example.com", Audience: jwt.ClaimStrings{"dms"},
+			}},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(time.Hour))
+			token := jwt.NewWithClaims(tt.method, &tt.claims)
+			token.Header["kid"] = "key"
+
+			tokenString, err := token.SignedString(tt.signingKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var gotUser string
+			handler := JWTAuth(validator)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotUser = GetUserIDFromContext(r)
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest("GET", "/", nil)
+			req.Header.Set("Authorization", "Bearer "+tokenString)
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("unexpected status code: expected %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if gotUser != tt.expectedUser {
+				t.Errorf("expected user %q, got %q", tt.expectedUser, gotUser)
+			}
+		})
+	}
+}
+
+func TestJWTAuthSigningAlgorithms(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecP384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPublicKey, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuerURL := "https://idp.example.com"
+
+	keys := keySourceFunc(func(kid string) (PublicKey, error) {
+		switch kid {
+		case "rsa":
+			return PublicKey{Key: &rsaKey.PublicKey}, nil
+		case "rsa-rs512":
+			return PublicKey{Key: &rsaKey.PublicKey, Alg: "RS512"}, nil
+		case "ec":
+			return PublicKey{Key: &ecKey.PublicKey}, nil
+		case "ec-p384":
+			return PublicKey{Key: &ecP384Key.PublicKey}, nil
+		case "ed25519":
+			return PublicKey{Key: edPublicKey}, nil
+		}
+		return PublicKey{}, errors.New("unknown kid")
+	})
+
+	tests := []struct {
+		name           string
+		method         jwt.SigningMethod
+		kid            string
+		signingKey     crypto.PrivateKey
+		expectedStatus int
+	}{
+		{name: "RS256", method: jwt.SigningMethodRS256, kid: "rsa", signingKey: rsaKey, expectedStatus: http.StatusOK},
+		{name: "PS256", method: jwt.SigningMethodPS256, kid: "rsa", signingKey: rsaKey, expectedStatus: http.StatusOK},
+		{name: "ES256", method: jwt.SigningMethodES256, kid: "ec", signingKey: ecKey, expectedStatus: http.StatusOK},
+		{name: "ES384", method: jwt.SigningMethodES384, kid: "ec-p384", signingKey: ecP384Key, expectedStatus: http.StatusOK},
+		{name: "EdDSA", method: jwt.SigningMethodEdDSA, kid: "ed25519", signingKey: edKey, expectedStatus: http.StatusOK},
+		{name: "algorithm not allowed by the key's JWK", method: jwt.SigningMethodRS256, kid: "rsa-rs512", signingKey: rsaKey, expectedStatus: http.StatusUnauthorized},
+		{name: "EC algorithm with an RSA key", method: jwt.SigningMethodES256, kid: "rsa", signingKey: ecKey, expectedStatus: http.StatusUnauthorized},
+		{name: "ES384 token for a P-256 key", method: jwt.SigningMethodES384, kid: "ec", signingKey: ecP384Key, expectedStatus: http.StatusUnauthorized},
+		{name: "HMAC", method: jwt.SigningMethodHS256, kid: "rsa", signingKey: []byte("secret"), expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := jwt.NewWithClaims(tt.method, &customClaims{
+				Sub: "user123",
+				RegisteredClaims: jwt.RegisteredClaims{
+					Issuer:    issuerURL,
+					ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+				},
+			})
+			token.Header["kid"] = tt.kid
+
+			tokenString, err := token.SignedString(tt.signingKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			handler := JWTAuth(&JWTValidator{Enabled: true, Issuers: []Issuer{{URL: issuerURL, Keys: keys}}})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest("GET", "/", nil)
+			req.Header.Set("Authorization", "Bearer "+tokenString)
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("unexpected status code: expected %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+// keySourceFunc adapts a function to a KeySource.
+type keySourceFunc func(kid string) (PublicKey, error)
+
+func (f keySourceFunc) PublicKey(kid string) (PublicKey, error) {
+	return f(kid)
+}
+
 func TestFetchPublicKeys(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -372,6 +608,43 @@ func TestFetchPublicKeys(t *testing.T) {
 			},
 			expectedError: true, // Will fail due to invalid key values, but tests the flow
 		},
+		{
+			name: "EC and OKP keys",
+			mockHandler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/.well-known/openid-configuration" {
+					w.Header().Set("Content-Type", "application/json")
+					_ = json.NewEncoder(w).Encode(map[string]string{
+						"jwks_uri": "http://" + r.Host + "/jwks",
+					})
+					return
+				}
+				ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				edKey, _, _ := ed25519.GenerateKey(rand.Reader)
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(jsonWebKeySet{
+					Keys: []jsonWebKey{ecJWK("ec", &ecKey.PublicKey), ed25519JWK("ed", edKey)},
+				})
+			},
+			expectedKeyNum: 2,
+		},
+		{
+			name: "encryption keys are skipped",
+			mockHandler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/.well-known/openid-configuration" {
+					w.Header().Set("Content-Type", "application/json")
+					_ = json.NewEncoder(w).Encode(map[string]string{
+						"jwks_uri": "http://" + r.Host + "/jwks",
+					})
+					return
+				}
+				ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				key := ecJWK("enc", &ecKey.PublicKey)
+				key.Use = "enc"
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(jsonWebKeySet{Keys: []jsonWebKey{key}})
+			},
+			expectedError: true,
+		},
 		{
 			name: "non-200 status on discovery",
 			mockHandler: func(w http.ResponseWriter, r *http.Request) {
@@ -433,13 +706,16 @@ func TestFetchPublicKeys(t *testing.T) {
 			server := httptest.NewServer(tt.mockHandler)
 			defer server.Close()
 
-			_, err := FetchPublicKeys(server.URL)
+			keys, err := FetchPublicKeys(server.URL)
 			if tt.expectedError && err == nil {
 				t.Error("expected error, got nil")
 			}
 			if !tt.expectedError && err != nil {
 				t.Errorf("unexpected error: %v", err)
 			}
+			if !tt.expectedError && len(keys) != tt.expectedKeyNum {
+				t.Errorf("expected %d keys, got %d", tt.expectedKeyNum, len(keys))
+			}
 		})
 	}
 }
@@ -536,3 +812,80 @@ func TestJWKToRSAPublicKey(t *testing.T) {
 		})
 	}
 }
+
+func TestJWKToPublicKey(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	offCurve := ecJWK("ec", &ecKey.PublicKey)
+	offCurve.Y = offCurve.X
+
+	tests := []struct {
+		name        string
+		jwk         jsonWebKey
+		expectedKey crypto.PublicKey
+	}{
+		{name: "EC key", jwk: ecJWK("ec", &ecKey.PublicKey), expectedKey: &ecKey.PublicKey},
+		{name: "Ed25519 key", jwk: ed25519JWK("ed", edKey), expectedKey: edKey},
+		{name: "unsupported key type", jwk: jsonWebKey{Kty: "oct"}},
+		{name: "unsupported EC curve", jwk: jsonWebKey{Kty: "EC", Crv: "secp256k1", X: "AQAB", Y: "AQAB"}},
+		{name: "EC point off the curve", jwk: offCurve},
+		{name: "short EC coordinates", jwk: jsonWebKey{Kty: "EC", Crv: "P-256", X: "AQAB", Y: "AQAB"}},
+		{name: "unsupported OKP curve", jwk: jsonWebKey{Kty: "OKP", Crv: "X25519", X: ed25519JWK("ed", edKey).X}},
+		{name: "short Ed25519 key", jwk: jsonWebKey{Kty: "OKP", Crv: "Ed25519", X: "AQAB"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := jwkToPublicKey(tt.jwk)
+			if tt.expectedKey == nil {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			equal, ok := key.(interface{ Equal(crypto.PublicKey) bool })
+			if !ok || !equal.Equal(tt.expectedKey) {
+				t.Errorf("unexpected key %v", key)
+			}
+		})
+	}
+}
+
+// ecJWK returns the JWK of an ECDSA public key.
+func ecJWK(kid string, key *ecdsa.PublicKey) jsonWebKey {
+	point, _ := key.Bytes()
+	size := (len(point) - 1) / 2
+
+	return jsonWebKey{
+		Kty: "EC",
+		Kid: kid,
+		Use: "sig",
+		Crv: key.Curve.Params().Name,
+		X:   base64.RawURLEncoding.EncodeToString(point[1 : 1+size]),
+		Y:   base64.RawURLEncoding.EncodeToString(point[1+size:]),
+	}
+}
+
+// ed25519JWK returns the JWK of an Ed25519 public key.
+func ed25519JWK(kid string, key ed25519.PublicKey) jsonWebKey {
+	return jsonWebKey{
+		Kty: "OKP",
+		Kid: kid,
+		Use: "sig",
+		Crv: "Ed25519",
+		X:   base64.RawURLEncoding.EncodeToString(key),
+		Alg: "EdDSA",
+	}
+}
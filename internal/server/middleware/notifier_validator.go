@@ -38,7 +38,26 @@ func NotifierValidator(next http.Handler) http.Handler {
 
 		// Validate with Shoutrrr
 		serviceRouter := router.ServiceRouter{}
-		for _, url := range payload.Notifiers {
+		notifiers := payload.Notifiers
+		if payload.DuressNotifiers != nil {
+			notifiers = append(notifiers, *payload.DuressNotifiers...)
+		}
+		if payload.Members != nil {
+			for _, member := range *payload.Members {
+				if member.Notifier != nil {
+					notifiers = append(notifiers, *member.Notifier)
+				}
+			}
+		}
+		if payload.TrustedContacts != nil {
+			for _, contact := range *payload.TrustedContacts {
+				if contact.Notifier != nil {
+					notifiers = append(notifiers, *contact.Notifier)
+				}
+			}
+		}
+
+		for _, url := range notifiers {
 			_, err = serviceRouter.Locate(url)
 			if err != nil {
 				w.Header().Set("Content-Type", "application/json")
@@ -53,6 +53,33 @@ func TestNotifierValidator(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 		},
+		{
+			name:   "invalid duress notifier",
+			method: http.MethodPost,
+			payload: api.Switch{
+				Notifiers:       []string{"logger://"},
+				DuressNotifiers: &[]string{"myscheme://bad-url"},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "invalid member notifier",
+			method: http.MethodPost,
+			payload: api.Switch{
+				Notifiers: []string{"logger://"},
+				Members:   &[]api.SwitchMember{{UserId: "alice", Notifier: ptr("myscheme://bad-url")}},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "invalid trusted contact notifier",
+			method: http.MethodPost,
+			payload: api.Switch{
+				Notifiers:       []string{"logger://"},
+				TrustedContacts: &[]api.TrustedContact{{Name: "sam", Notifier: ptr("myscheme://bad-url")}},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name:   "empty notifier list",
 			method: http.MethodPost,
@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strings"
+)
+
+// DefaultUserHeader is the header a reverse proxy names the signed in user in unless configured otherwise.
+const DefaultUserHeader = "Remote-User"
+
+var (
+	errUntrustedProxy = errors.New("request did not come from a trusted proxy")
+	errMissingUser    = errors.New("proxy did not name a user")
+
+	// crossOrigin rejects requests other sites make in the user's browser, since the proxy signs those in too
+	crossOrigin = http.NewCrossOriginProtection()
+)
+
+// ProxyAuth trusts the user a reverse proxy in front of the server already signed in, such as Tailscale
+// Serve, an Authentik outpost or any forward auth proxy. The identity headers are only read from requests
+// sent by a trusted proxy, since anyone else could set them.
+type ProxyAuth struct {
+	// UserHeader names the user, such as Tailscale-User-Login, X-authentik-username or Remote-User.
+	UserHeader string
+	// GroupsHeader lists the user's groups, separated by commas or pipes. Groups aren't read if it is empty.
+	GroupsHeader string
+	// AdminGroups are the groups in GroupsHeader that make a user an admin.
+	AdminGroups []string
+	// TrustedProxies are the addresses requests with identity headers are accepted from.
+	TrustedProxies []netip.Prefix
+}
+
+// ParseTrustedProxies parses CIDRs or single IP addresses of trusted proxies.
+func ParseTrustedProxies(values []string) ([]netip.Prefix, error) {
+	prefixes := []netip.Prefix{}
+
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+
+		if !strings.Contains(value, "/") {
+			addr, err := netip.ParseAddr(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", value, err)
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", value, err)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+
+	return prefixes, nil
+}
+
+// authenticate returns the user the proxy signed in and whether the proxy made them an admin.
+func (p *ProxyAuth) authenticate(r *http.Request) (string, bool, error) {
+	if !p.trusted(r.RemoteAddr) {
+		return "", false, errUntrustedProxy
+	}
+
+	userID := strings.TrimSpace(r.Header.Get(p.UserHeader))
+	if userID == "" {
+		return "", false, errMissingUser
+	}
+
+	err := crossOrigin.Check(r)
+	if err != nil {
+		return "", false, err
+	}
+
+	return userID, p.grantsAdmin(r), nil
+}
+
+// trusted reports whether a request's remote address belongs to a trusted proxy. The connection's address
+// is used rather than X-Forwarded-For, which the client controls.
+func (p *ProxyAuth) trusted(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+
+	for _, prefix := range p.TrustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// grantsAdmin reports whether the proxy lists one of the admin groups for the user.
+func (p *ProxyAuth) grantsAdmin(r *http.Request) bool {
+	if p.GroupsHeader == "" || len(p.AdminGroups) == 0 {
+		return false
+	}
+
+	groups := strings.FieldsFunc(r.Header.Get(p.GroupsHeader), func(c rune) bool {
+		return c == ',' || c == '|'
+	})
+
+	for _, group := range groups {
+		if slices.Contains(p.AdminGroups, strings.TrimSpace(group)) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAPIToken reports whether a request sends a personal access token as its bearer token.
+func hasAPIToken(r *http.Request) bool {
+	parts := strings.SplitN(r.Header.Get("Authorization"), " ", 2)
+	return len(parts) == 2 && strings.EqualFold(parts[0], "bearer") && strings.HasPrefix(parts[1], APITokenPrefix)
+}
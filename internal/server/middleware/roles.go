@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/database"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const roleKey contextKey = "role"
+
+// defaultRolesClaim is the claim an issuer's roles are read from when it doesn't name one.
+const defaultRolesClaim = "groups"
+
+// RoleStore looks up the roles admins assigned to users.
+type RoleStore interface {
+	GetUserSettings(userID string) (database.UserSettings, error)
+}
+
+// WithRole returns a copy of ctx carrying the role of the authenticated user.
+func WithRole(ctx context.Context, role api.Role) context.Context {
+	return context.WithValue(ctx, roleKey, role)
+}
+
+// IsAdmin reports whether the request was made by an admin.
+func IsAdmin(r *http.Request) bool {
+	role, _ := r.Context().Value(roleKey).(api.Role)
+	return role == api.RoleAdmin
+}
+
+// RequireAdmin rejects requests made by users who aren't admins.
+func RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !IsAdmin(r) {
+			http.Error(w, "Admin role required", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// role returns the role of a user. Users are admins if their issuer says so or an admin assigned them the
+// role, so an assigned role can't take away what the issuer grants.
+func (v *JWTValidator) role(userID string, issuerAdmin bool) api.Role {
+	if issuerAdmin {
+		return api.RoleAdmin
+	}
+
+	if v.Roles == nil {
+		return api.RoleUser
+	}
+
+	// Failing to look up the role shouldn't fail the request, but it mustn't grant admin either
+	settings, err := v.Roles.GetUserSettings(userID)
+	if err != nil || settings.Role != api.RoleAdmin {
+		return api.RoleUser
+	}
+
+	return api.RoleAdmin
+}
+
+// grantsAdmin reports whether a token's roles claim holds one of the issuer's admin roles. The claim can be
+// a single role or a list of them.
+func (i Issuer) grantsAdmin(claims jwt.MapClaims) bool {
+	if len(i.AdminRoles) == 0 {
+		return false
+	}
+
+	claimName := i.RolesClaim
+	if claimName == "" {
+		claimName = defaultRolesClaim
+	}
+
+	switch roles := claims[claimName].(type) {
+	case string:
+		return slices.Contains(i.AdminRoles, roles)
+	case []any:
+		for _, role := range roles {
+			name, ok := role.(string)
+			if ok && slices.Contains(i.AdminRoles, name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,173 @@
+package middleware
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/database"
+	"github.com/circa10a/dead-mans-switch/internal/server/secrets"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// fakeRoles is a RoleStore holding the roles assigned to users.
+type fakeRoles map[string]api.Role
+
+func (f fakeRoles) GetUserSettings(userID string) (database.UserSettings, error) {
+	if userID == "broken" {
+		return database.UserSettings{}, errors.New("database is locked")
+	}
+	return database.UserSettings{UserID: userID, Role: f[userID]}, nil
+}
+
+func TestJWTAuthRoles(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	validator := &JWTValidator{
+		Enabled: true,
+		Issuers: []Issuer{
+			{URL: "https://company.example.com", AdminRoles: []string{"dms-admins"}, Keys: StaticKeys{"key": &key.PublicKey}},
+			{Name: "family", URL: "https://family.example.com", RolesClaim: "role", AdminRoles: []string{"admin"}, Keys: StaticKeys{"key": &key.PublicKey}},
+		},
+		Sessions: fakeSessions{
+			secrets.HashToken("dmss_carol"): {Username: "carol"},
+			secrets.HashToken("dmss_dave"):  {Username: "dave"},
+		},
+		Roles: fakeRoles{"carol": api.RoleAdmin, "bob": api.RoleUser},
+	}
+
+	var gotAdmin bool
+	handler := JWTAuth(validator)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAdmin = IsAdmin(r)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	sign := func(claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+		token.Header["kid"] = "key"
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return "Bearer " + signed
+	}
+
+	tests := []struct {
+		name          string
+		header        string
+		expectedAdmin bool
+	}{
+		{
+			name:          "groups claim grants admin",
+			header:        sign(jwt.MapClaims{"iss": "https://company.example.com", "sub": "alice", "groups": []string{"staff", "dms-admins"}}),
+			expectedAdmin: true,
+		},
+		{
+			name:   "other groups don't",
+			header: sign(jwt.MapClaims{"iss": "https://company.example.com", "sub": "alice", "groups": []string{"staff"}}),
+		},
+		{
+			name:          "issuers name their roles claim, which can be a single role",
+			header:        sign(jwt.MapClaims{"iss": "https://family.example.com", "sub": "alice", "role": "admin"}),
+			expectedAdmin: true,
+		},
+		{
+			name:   "roles are only read from the issuer's claim",
+			header: sign(jwt.MapClaims{"iss": "https://family.example.com", "sub": "alice", "groups": []string{"admin"}}),
+		},
+		{
+			name:          "an assigned user role doesn't take away the issuer's admin role",
+			header:        sign(jwt.MapClaims{"iss": "https://company.example.com", "sub": "bob", "groups": "dms-admins"}),
+			expectedAdmin: true,
+		},
+		{
+			name:          "assigned roles apply to issuer users",
+			header:        sign(jwt.MapClaims{"iss": "https://company.example.com", "sub": "carol"}),
+			expectedAdmin: true,
+		},
+		{
+			name:   "failing to look up a role doesn't grant admin",
+			header: sign(jwt.MapClaims{"iss": "https://company.example.com", "sub": "broken"}),
+		},
+		{
+			name:          "assigned roles apply to local sessions",
+			header:        "Bearer dmss_carol",
+			expectedAdmin: true,
+		},
+		{
+			name:   "local users are plain users by default",
+			header: "Bearer dmss_dave",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotAdmin = false
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", tt.header)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+			}
+			if gotAdmin != tt.expectedAdmin {
+				t.Errorf("expected admin %v, got %v", tt.expectedAdmin, gotAdmin)
+			}
+		})
+	}
+
+	t.Run("the default user administers servers without auth", func(t *testing.T) {
+		handler := JWTAuth(&JWTValidator{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotAdmin = IsAdmin(r)
+		}))
+
+		gotAdmin = false
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if !gotAdmin {
+			t.Error("expected the default user to be an admin")
+		}
+	})
+}
+
+func TestRequireAdmin(t *testing.T) {
+	handler := RequireAdmin(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name           string
+		role           api.Role
+		expectedStatus int
+	}{
+		{name: "admins are let through", role: api.RoleAdmin, expectedStatus: http.StatusOK},
+		{name: "users are rejected", role: api.RoleUser, expectedStatus: http.StatusForbidden},
+		{name: "requests without a role are rejected", expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.role != "" {
+				req = req.WithContext(WithRole(req.Context(), tt.role))
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected %d, got %d", tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
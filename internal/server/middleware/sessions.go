@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/internal/server/database"
+	"github.com/circa10a/dead-mans-switch/internal/server/secrets"
+)
+
+const (
+	// SessionTokenPrefix starts every local session token, telling them apart from JWTs and API tokens.
+	SessionTokenPrefix = "dmss_"
+	// SessionCookie is the cookie the UI's session token is kept in.
+	SessionCookie = "dms_session"
+)
+
+// SessionStore looks up the sessions of local user accounts by the hash of their token.
+type SessionStore interface {
+	GetSession(tokenHash string, now int64) (database.Session, error)
+}
+
+// SessionToken returns the local session token of a request, sent as a bearer token by the CLI or as a
+// cookie by the UI. An Authorization header takes precedence over the cookie.
+func SessionToken(r *http.Request) string {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader != "" {
+		parts := strings.SplitN(authHeader, " ", 2)
+		if len(parts) == 2 && strings.EqualFold(parts[0], "bearer") && strings.HasPrefix(parts[1], SessionTokenPrefix) {
+			return parts[1]
+		}
+		return ""
+	}
+
+	cookie, err := r.Cookie(SessionCookie)
+	if err != nil || !strings.HasPrefix(cookie.Value, SessionTokenPrefix) {
+		return ""
+	}
+
+	return cookie.Value
+}
+
+// authenticateSession returns the user a local session token is signed in as.
+func (v *JWTValidator) authenticateSession(token string) (string, error) {
+	if v.Sessions == nil {
+		return "", errors.New("local sessions are not supported")
+	}
+
+	session, err := v.Sessions.GetSession(secrets.HashToken(token), time.Now().Unix())
+	if err != nil {
+		return "", err
+	}
+
+	return session.Username, nil
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/database"
+	"github.com/circa10a/dead-mans-switch/internal/server/secrets"
+)
+
+// fakeSessions is a SessionStore holding sessions by the hash of their token.
+type fakeSessions map[string]database.Session
+
+func (f fakeSessions) GetSession(tokenHash string, _ int64) (database.Session, error) {
+	session, ok := f[tokenHash]
+	if !ok {
+		return database.Session{}, sql.ErrNoRows
+	}
+	return session, nil
+}
+
+func TestJWTAuthSessions(t *testing.T) {
+	validator := &JWTValidator{
+		Enabled:   true,
+		APITokens: &fakeAPITokens{tokens: map[string]api.APIToken{}},
+		Sessions: fakeSessions{
+			secrets.HashToken("dmss_valid"): {Username: "bob"},
+		},
+	}
+
+	var gotUser string
+	handler := JWTAuth(validator)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUser = GetUserIDFromContext(r)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name           string
+		header         string
+		cookie         string
+		expectedStatus int
+	}{
+		{name: "bearer session", header: "Bearer dmss_valid", expectedStatus: http.StatusOK},
+		{name: "session cookie", cookie: "dmss_valid", expectedStatus: http.StatusOK},
+		{name: "unknown session", header: "Bearer dmss_unknown", expectedStatus: http.StatusUnauthorized},
+		{name: "unknown session cookie", cookie: "dmss_unknown", expectedStatus: http.StatusUnauthorized},
+		{name: "header takes precedence over the cookie", header: "Bearer dms_unknown", cookie: "dmss_valid", expectedStatus: http.StatusUnauthorized},
+		{name: "cookies without the prefix are ignored", cookie: "valid", expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotUser = ""
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: SessionCookie, Value: tt.cookie})
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected %d, got %d", tt.expectedStatus, rec.Code)
+			}
+			if tt.expectedStatus == http.StatusOK && gotUser != "bob" {
+				t.Errorf("expected user bob, got %q", gotUser)
+			}
+		})
+	}
+
+	t.Run("cookies are ignored without local sessions", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(&http.Cookie{Name: SessionCookie, Value: "dmss_valid"})
+
+		rec := httptest.NewRecorder()
+		JWTAuth(&JWTValidator{Enabled: true})(handler).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+)
+
+// Streaming lifts the server's read and write timeouts for requests to the given paths, so long-lived
+// responses such as server-sent events aren't cut off. It must wrap any middleware whose response writer
+// can't be unwrapped, since the deadlines are set on the connection through the writer it receives.
+func Streaming(next http.Handler, paths ...string) http.Handler {
+	streams := map[string]bool{}
+	for _, path := range paths {
+		streams[path] = true
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if streams[r.URL.Path] {
+			rc := http.NewResponseController(w)
+			_ = rc.SetReadDeadline(time.Time{})
+			_ = rc.SetWriteDeadline(time.Time{})
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
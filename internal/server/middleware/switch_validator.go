@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/webhooks"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -81,6 +82,22 @@ func SwitchValidator(v *validator.Validate) func(http.Handler) http.Handler {
 				reminderThresholdDuration = &d
 			}
 
+			for field, value := range map[string]*string{
+				"changeDelay":        payload.ChangeDelay,
+				"verificationWindow": payload.VerificationWindow,
+				"maxPostpone":        payload.MaxPostpone,
+				"repeatInterval":     payload.RepeatInterval,
+			} {
+				if value == nil || *value == "" {
+					continue
+				}
+				d, err := time.ParseDuration(*value)
+				if err != nil || d <= 0 {
+					sendJSONError(w, http.StatusBadRequest, field+" must be a positive duration (e.g., 24h, 72h)")
+					return
+				}
+			}
+
 			err = v.Struct(payload)
 			if err != nil {
 				errMsgs := []string{}
@@ -100,6 +117,27 @@ func SwitchValidator(v *validator.Validate) func(http.Handler) http.Handler {
 				return
 			}
 
+			if payload.Quorum != nil {
+				members := 0
+				if payload.Members != nil {
+					members = len(*payload.Members)
+				}
+				if *payload.Quorum > members {
+					sendJSONError(w, http.StatusBadRequest, "quorum must not be greater than the number of members")
+					return
+				}
+			}
+
+			if payload.Webhooks != nil {
+				for _, hook := range *payload.Webhooks {
+					err = webhooks.Validate(hook)
+					if err != nil {
+						sendJSONError(w, http.StatusBadRequest, err.Error())
+						return
+					}
+				}
+			}
+
 			validatedData := ValidatedSwitch{
 				Payload:                   payload,
 				CheckInIntervalDuration:   checkInIntervalDuration,
@@ -98,6 +98,120 @@ func TestSwitchValidator(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 		},
+		{
+			name: "Failure - Quorum greater than members",
+			payload: map[string]interface{}{
+				"message":         "test message",
+				"checkInInterval": "24h",
+				"notifiers":       []string{"discord://token"},
+				"members":         []map[string]string{{"userId": "alice"}, {"userId": "bob"}},
+				"quorum":          3,
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Failure - Duplicate members",
+			payload: map[string]interface{}{
+				"message":         "test message",
+				"checkInInterval": "24h",
+				"notifiers":       []string{"discord://token"},
+				"members":         []map[string]string{{"userId": "alice"}, {"userId": "alice"}},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Failure - Invalid verificationWindow",
+			payload: map[string]interface{}{
+				"message":            "test message",
+				"checkInInterval":    "24h",
+				"notifiers":          []string{"discord://token"},
+				"trustedContacts":    []map[string]string{{"name": "sam", "notifier": "logger://"}},
+				"verificationWindow": "-1h",
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Failure - Invalid changeDelay",
+			payload: map[string]interface{}{
+				"message":         "test message",
+				"checkInInterval": "24h",
+				"notifiers":       []string{"discord://token"},
+				"protected":       true,
+				"changeDelay":     "soon",
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Failure - Invalid repeatInterval",
+			payload: map[string]interface{}{
+				"message":         "test message",
+				"checkInInterval": "24h",
+				"notifiers":       []string{"discord://token"},
+				"repeatInterval":  "0h",
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Failure - Invalid maxTriggers",
+			payload: map[string]interface{}{
+				"message":         "test message",
+				"checkInInterval": "24h",
+				"notifiers":       []string{"discord://token"},
+				"rearm":           true,
+				"maxTriggers":     0,
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Failure - Invalid chain action",
+			payload: map[string]interface{}{
+				"message":         "test message",
+				"checkInInterval": "24h",
+				"notifiers":       []string{"discord://token"},
+				"onTrigger":       []map[string]interface{}{{"switchId": 2, "action": "explode"}},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Failure - Invalid webhook URL",
+			payload: map[string]interface{}{
+				"message":         "test message",
+				"checkInInterval": "24h",
+				"notifiers":       []string{"discord://token"},
+				"webhooks":        []map[string]interface{}{{"url": "ftp://example.com", "secret": "0123456789abcdef"}},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Failure - Invalid webhook body template",
+			payload: map[string]interface{}{
+				"message":         "test message",
+				"checkInInterval": "24h",
+				"notifiers":       []string{"discord://token"},
+				"webhooks":        []map[string]interface{}{{"url": "https://example.com", "secret": "0123456789abcdef", "body": "{{ .Nope }}"}},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Failure - Duplicate approvers",
+			payload: map[string]interface{}{
+				"message":         "test message",
+				"checkInInterval": "24h",
+				"notifiers":       []string{"discord://token"},
+				"approvers":       []string{"bob", "bob"},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Failure - Duplicate trusted contacts",
+			payload: map[string]interface{}{
+				"message":         "test message",
+				"checkInInterval": "24h",
+				"notifiers":       []string{"discord://token"},
+				"trustedContacts": []map[string]string{{"name": "sam"}, {"name": "sam"}},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name:           "Failure - Malformed JSON",
 			payload:        `{"message": "incomplete"...`,
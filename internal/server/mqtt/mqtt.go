@@ -0,0 +1,289 @@
+// Package mqtt bridges switches to an MQTT broker, so home automation systems can follow and check in to them.
+package mqtt
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/events"
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
+)
+
+const (
+	// checkInTopic receives the check-in tokens of switches, relative to the topic prefix.
+	checkInTopic = "checkin"
+	// statusTopic holds whether the server is connected, relative to the topic prefix.
+	statusTopic   = "status"
+	statusOnline  = "online"
+	statusOffline = "offline"
+	// publishBuffer is how many events can wait for the bridge before new ones are dropped.
+	publishBuffer = 1000
+	// keepAlive is how often, in seconds, the connection is checked when idle.
+	keepAlive = 30
+	// disconnectTimeout bounds how long shutting down waits for the broker.
+	disconnectTimeout = 5 * time.Second
+	// retainOnlyNew asks the broker not to send retained messages when subscribing, so a token left
+	// retained on the check-in topic doesn't check in on every reconnect.
+	retainOnlyNew = 2
+)
+
+// Config holds the broker connection settings.
+type Config struct {
+	// BrokerURL is the address of the broker, such as mqtt://localhost:1883 or mqtts://broker:8883.
+	BrokerURL string
+	ClientID  string
+	Username  string
+	Password  string
+	// TopicPrefix is prepended to every topic the bridge publishes or subscribes to.
+	TopicPrefix string
+	// CACertificate is the path to a PEM bundle used to verify the broker instead of the system roots.
+	CACertificate string
+	// ClientCertificate and ClientKey are the paths to a PEM certificate and key for mutual TLS.
+	ClientCertificate string
+	ClientKey         string
+}
+
+// CheckInFunc checks in to the switch with the given check-in token.
+type CheckInFunc func(token string) error
+
+// Bridge publishes switch lifecycle events to a broker and checks in to switches whose token is sent to it.
+type Bridge struct {
+	config  autopaho.ClientConfig
+	prefix  string
+	bus     *events.Bus
+	checkIn CheckInFunc
+	logger  *slog.Logger
+}
+
+// New returns a bridge for the broker in cfg. Connecting is left to Start.
+func New(cfg Config, bus *events.Bus, checkIn CheckInFunc, logger *slog.Logger) (*Bridge, error) {
+	brokerURL, err := url.Parse(cfg.BrokerURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MQTT broker URL: %w", err)
+	}
+	if brokerURL.Host == "" {
+		return nil, fmt.Errorf("invalid MQTT broker URL %q: missing host", cfg.BrokerURL)
+	}
+
+	tlsConfig, err := loadTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Bridge{
+		prefix:  strings.TrimSuffix(cfg.TopicPrefix, "/"),
+		bus:     bus,
+		checkIn: checkIn,
+		logger:  logger.With("component", "mqtt"),
+	}
+
+	b.config = autopaho.ClientConfig{
+		ServerUrls:      []*url.URL{brokerURL},
+		TlsCfg:          tlsConfig,
+		KeepAlive:       keepAlive,
+		ConnectUsername: cfg.Username,
+		ConnectPassword: []byte(cfg.Password),
+		// The broker marks the server offline if the connection drops without a disconnect
+		WillMessage: &paho.WillMessage{
+			Topic:   b.topic(statusTopic),
+			Payload: []byte(statusOffline),
+			QoS:     1,
+			Retain:  true,
+		},
+		OnConnectError: func(err error) {
+			b.logger.Error("Failed to connect to MQTT broker", "error", err)
+		},
+		ClientConfig: paho.ClientConfig{
+			ClientID: cfg.ClientID,
+			OnPublishReceived: []func(paho.PublishReceived) (bool, error){
+				b.received,
+			},
+			OnClientError: func(err error) {
+				b.logger.Error("MQTT client error", "error", err)
+			},
+		},
+	}
+
+	return b, nil
+}
+
+// Start connects to the broker and publishes events until the context is cancelled, then marks the
+// server offline and disconnects. The connection is re-established whenever it drops, and events
+// published in the meantime are queued until it is.
+func (b *Bridge) Start(ctx context.Context) error {
+	ch, stop := b.bus.Subscribe(publishBuffer)
+	defer stop()
+
+	cfg := b.config
+	cfg.OnConnectionUp = func(cm *autopaho.ConnectionManager, _ *paho.Connack) {
+		// Connection callbacks must not block
+		go b.connected(ctx, cm)
+	}
+
+	// The connection outlives ctx so the offline status can still be sent after it is cancelled
+	cm, err := autopaho.NewConnection(context.WithoutCancel(ctx), cfg)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			b.disconnect(cm)
+			return nil
+		case event := <-ch:
+			b.publish(ctx, cm, event)
+		}
+	}
+}
+
+// connected marks the server online and subscribes to check-ins after each connection to the broker.
+func (b *Bridge) connected(ctx context.Context, cm *autopaho.ConnectionManager) {
+	b.logger.Info("Connected to MQTT broker")
+
+	_, err := cm.Publish(ctx, &paho.Publish{
+		Topic:   b.topic(statusTopic),
+		Payload: []byte(statusOnline),
+		QoS:     1,
+		Retain:  true,
+	})
+	if err != nil {
+		b.logger.Error("Failed to publish MQTT status", "error", err)
+	}
+
+	_, err = cm.Subscribe(ctx, &paho.Subscribe{
+		Subscriptions: []paho.SubscribeOptions{
+			{Topic: b.topic(checkInTopic), QoS: 1, RetainHandling: retainOnlyNew},
+		},
+	})
+	if err != nil {
+		b.logger.Error("Failed to subscribe to MQTT check-ins", "error", err, "topic", b.topic(checkInTopic))
+	}
+}
+
+// disconnect marks the server offline and closes the connection.
+func (b *Bridge) disconnect(cm *autopaho.ConnectionManager) {
+	ctx, cancel := context.WithTimeout(context.Background(), disconnectTimeout)
+	defer cancel()
+
+	_, err := cm.Publish(ctx, &paho.Publish{
+		Topic:   b.topic(statusTopic),
+		Payload: []byte(statusOffline),
+		QoS:     1,
+		Retain:  true,
+	})
+	if err != nil && !errors.Is(err, autopaho.ConnectionDownError) {
+		b.logger.Error("Failed to publish MQTT status", "error", err)
+	}
+
+	err = cm.Disconnect(ctx)
+	if err != nil {
+		b.logger.Error("Failed to disconnect from MQTT broker", "error", err)
+	}
+}
+
+// publish sends an event to the switch's event topic and retains it on its state topic, so new
+// subscribers see the current status of every switch. The state of a deleted switch is cleared.
+func (b *Bridge) publish(ctx context.Context, cm *autopaho.ConnectionManager, event api.Event) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		b.logger.Error("Failed to encode event", "error", err, "event", event.Id)
+		return
+	}
+
+	base := b.topic(topicSegment(event.UserId), strconv.Itoa(event.SwitchId))
+
+	state := payload
+	if event.Type == api.EventTypeDeleted {
+		// An empty retained message removes the retained one
+		state = []byte{}
+	}
+
+	for _, msg := range []*paho.Publish{
+		{Topic: base + "/event", Payload: payload, QoS: 1},
+		{Topic: base + "/state", Payload: state, QoS: 1, Retain: true},
+	} {
+		err = cm.PublishViaQueue(ctx, &autopaho.QueuePublish{Publish: msg})
+		if err != nil {
+			b.logger.Error("Failed to publish event", "error", err, "event", event.Id, "topic", msg.Topic)
+		}
+	}
+}
+
+// received checks in to the switch whose token was sent to the check-in topic.
+func (b *Bridge) received(pr paho.PublishReceived) (bool, error) {
+	if pr.Packet.Topic != b.topic(checkInTopic) {
+		return false, nil
+	}
+
+	token := strings.TrimSpace(string(pr.Packet.Payload))
+	if token == "" {
+		return true, nil
+	}
+
+	err := b.checkIn(token)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		b.logger.Warn("Check-in with an unknown token")
+	case err != nil:
+		b.logger.Error("Failed to check in", "error", err)
+	}
+
+	return true, nil
+}
+
+// topic joins segments onto the topic prefix.
+func (b *Bridge) topic(segments ...string) string {
+	if b.prefix == "" {
+		return strings.Join(segments, "/")
+	}
+	return b.prefix + "/" + strings.Join(segments, "/")
+}
+
+// topicSegment replaces the characters that separate topic levels or act as wildcards, such as in user IDs.
+func topicSegment(s string) string {
+	return strings.NewReplacer("/", "_", "+", "_", "#", "_").Replace(s)
+}
+
+// loadTLSConfig reads the CA bundle and client certificate used to connect to the broker, if configured.
+func loadTLSConfig(cfg Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if cfg.CACertificate != "" {
+		pem, err := os.ReadFile(cfg.CACertificate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read MQTT CA certificate: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in MQTT CA certificate %s", cfg.CACertificate)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.ClientCertificate != "" || cfg.ClientKey != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.ClientCertificate, cfg.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load MQTT client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
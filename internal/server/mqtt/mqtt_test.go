@@ -0,0 +1,221 @@
+package mqtt
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/events"
+	broker "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+	"github.com/mochi-mqtt/server/v2/packets"
+)
+
+// startBroker runs an embedded broker that only accepts the given credentials and returns its address.
+func startBroker(t *testing.T, username, password string) (*broker.Server, string) {
+	t.Helper()
+
+	srv := broker.New(&broker.Options{InlineClient: true, Logger: slog.New(slog.NewTextHandler(io.Discard, nil))})
+
+	err := srv.AddHook(new(auth.Hook), &auth.Options{
+		Ledger: &auth.Ledger{
+			Auth: auth.AuthRules{{Username: auth.RString(username), Password: auth.RString(password), Allow: true}},
+			ACL:  auth.ACLRules{{}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to add auth hook: %v", err)
+	}
+
+	tcp := listeners.NewTCP(listeners.Config{ID: "test", Address: "127.0.0.1:0"})
+	err = srv.AddListener(tcp)
+	if err != nil {
+		t.Fatalf("failed to add listener: %v", err)
+	}
+
+	go func() { _ = srv.Serve() }()
+	t.Cleanup(func() { _ = srv.Close() })
+
+	return srv, "mqtt://" + tcp.Address()
+}
+
+// retained returns the payload retained on a topic, or nil if there is none.
+func retained(srv *broker.Server, topic string) []byte {
+	messages := srv.Topics.Messages(topic)
+	if len(messages) == 0 {
+		return nil
+	}
+	return messages[0].Payload
+}
+
+// waitFor polls until condition holds or fails the test after a few seconds.
+func waitFor(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestBridge(t *testing.T) {
+	srv, brokerURL := startBroker(t, "dms", "secret")
+
+	eventMessages := make(chan packets.Packet, 10)
+	err := srv.Subscribe("dms/+/+/event", 1, func(_ *broker.Client, _ packets.Subscription, pk packets.Packet) {
+		eventMessages <- pk
+	})
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+
+	tokens := make(chan string, 10)
+	checkIn := func(token string) error {
+		tokens <- token
+		if token != "valid-token" {
+			return sql.ErrNoRows
+		}
+		return nil
+	}
+
+	bus := events.NewBus()
+	bridge, err := New(Config{
+		BrokerURL:   brokerURL,
+		ClientID:    "dead-mans-switch-test",
+		Username:    "dms",
+		Password:    "secret",
+		TopicPrefix: "dms/",
+	}, bus, checkIn, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("failed to create bridge: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- bridge.Start(ctx) }()
+
+	waitFor(t, "the online status", func() bool {
+		return string(retained(srv, "dms/status")) == statusOnline
+	})
+
+	t.Run("events are published and retained as state", func(t *testing.T) {
+		id, userID := 7, "auth0|a/b"
+		status := api.SwitchStatusTriggered
+		bus.Publish(api.EventTypeTriggered, api.Switch{Id: &id, UserId: &userID, Status: &status})
+
+		select {
+		case pk := <-eventMessages:
+			if pk.TopicName != "dms/auth0|a_b/7/event" {
+				t.Errorf("unexpected topic %q", pk.TopicName)
+			}
+			event := api.Event{}
+			err := json.Unmarshal(pk.Payload, &event)
+			if err != nil || event.Type != api.EventTypeTriggered || event.SwitchId != id {
+				t.Errorf("unexpected event %s: %v", pk.Payload, err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected the event to be published")
+		}
+
+		waitFor(t, "the retained state", func() bool {
+			event := api.Event{}
+			return json.Unmarshal(retained(srv, "dms/auth0|a_b/7/state"), &event) == nil && event.Type == api.EventTypeTriggered
+		})
+
+		bus.Publish(api.EventTypeDeleted, api.Switch{Id: &id, UserId: &userID})
+
+		waitFor(t, "the state to be cleared", func() bool {
+			return retained(srv, "dms/auth0|a_b/7/state") == nil
+		})
+	})
+
+	t.Run("tokens sent to the check-in topic check in", func(t *testing.T) {
+		waitFor(t, "the check-in subscription", func() bool {
+			return len(srv.Topics.Subscribers("dms/checkin").Subscriptions) > 0
+		})
+
+		for _, payload := range []string{"unknown-token", "", " valid-token\n"} {
+			err := srv.Publish("dms/checkin", []byte(payload), false, 1)
+			if err != nil {
+				t.Fatalf("failed to publish: %v", err)
+			}
+		}
+
+		for _, expected := range []string{"unknown-token", "valid-token"} {
+			select {
+			case token := <-tokens:
+				if token != expected {
+					t.Errorf("expected token %q, got %q", expected, token)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("expected a check-in with %q", expected)
+			}
+		}
+	})
+
+	t.Run("stopping marks the server offline", func(t *testing.T) {
+		cancel()
+
+		select {
+		case err := <-stopped:
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected the bridge to stop")
+		}
+
+		if status := string(retained(srv, "dms/status")); status != statusOffline {
+			t.Errorf("expected the offline status, got %q", status)
+		}
+	})
+}
+
+func TestNew(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{name: "missing host", cfg: Config{BrokerURL: "localhost"}},
+		{name: "invalid URL", cfg: Config{BrokerURL: "mqtt://%zz"}},
+		{name: "missing CA certificate", cfg: Config{BrokerURL: "mqtts://localhost:8883", CACertificate: "/does/not/exist.pem"}},
+		{name: "client certificate without key", cfg: Config{BrokerURL: "mqtts://localhost:8883", ClientCertificate: "/does/not/exist.pem"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg, events.NewBus(), nil, logger)
+			if err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestTopicSegment(t *testing.T) {
+	tests := map[string]string{
+		"admin":          "admin",
+		"auth0|123":      "auth0|123",
+		"a/b":            "a_b",
+		"wild+card#user": "wild_card_user",
+	}
+
+	for in, expected := range tests {
+		if got := topicSegment(in); got != expected {
+			t.Errorf("topicSegment(%q) = %q, expected %q", in, got, expected)
+		}
+	}
+}
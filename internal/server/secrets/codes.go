@@ -0,0 +1,103 @@
+package secrets
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Argon2id parameters based on the OWASP recommendations.
+const (
+	argonMemory  = 19 * 1024
+	argonTime    = 2
+	argonThreads = 1
+	argonKeyLen  = 32
+	argonSaltLen = 16
+)
+
+var errInvalidHash = errors.New("invalid code hash format")
+
+// dummyHash is verified against when a code has no stored hash so both paths take the same time.
+var dummyHash = sync.OnceValue(func() string {
+	hash, _ := HashCode("dead-mans-switch")
+	return hash
+})
+
+// HashCode hashes a secret code, such as a duress code or password, with argon2id and returns it in PHC string format.
+func HashCode(code string) (string, error) {
+	salt := make([]byte, argonSaltLen)
+
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(code), salt, argonTime, argonMemory, argonThreads, argonKeyLen)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		argonMemory,
+		argonTime,
+		argonThreads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// VerifyCode reports whether code matches the hash produced by HashCode.
+// An empty hash is still compared against a dummy hash so callers can't be timed
+// to learn whether a code is configured.
+func VerifyCode(code, hash string) bool {
+	configured := hash != ""
+	if !configured {
+		hash = dummyHash()
+	}
+
+	salt, key, memory, iterations, threads, err := decodeHash(hash)
+	if err != nil {
+		return false
+	}
+
+	candidate := argon2.IDKey([]byte(code), salt, iterations, memory, threads, uint32(len(key)))
+
+	return subtle.ConstantTimeCompare(candidate, key) == 1 && configured
+}
+
+// decodeHash parses a PHC formatted argon2id hash.
+func decodeHash(hash string) ([]byte, []byte, uint32, uint32, uint8, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return nil, nil, 0, 0, 0, errInvalidHash
+	}
+
+	var version int
+	_, err := fmt.Sscanf(parts[2], "v=%d", &version)
+	if err != nil || version != argon2.Version {
+		return nil, nil, 0, 0, 0, errInvalidHash
+	}
+
+	var memory, iterations uint32
+	var threads uint8
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &threads)
+	if err != nil {
+		return nil, nil, 0, 0, 0, errInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, nil, 0, 0, 0, errInvalidHash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return nil, nil, 0, 0, 0, errInvalidHash
+	}
+
+	return salt, key, memory, iterations, threads, nil
+}
@@ -0,0 +1,61 @@
+package secrets
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHashCode(t *testing.T) {
+	t.Run("produces a salted argon2id hash", func(t *testing.T) {
+		first, err := HashCode("1234")
+		if err != nil {
+			t.Fatalf("failed to hash code: %v", err)
+		}
+
+		second, err := HashCode("1234")
+		if err != nil {
+			t.Fatalf("failed to hash code: %v", err)
+		}
+
+		if !strings.HasPrefix(first, "$argon2id$") {
+			t.Errorf("expected argon2id PHC string, got %q", first)
+		}
+		if first == second {
+			t.Error("expected hashes of the same code to differ by salt")
+		}
+	})
+}
+
+func TestVerifyCode(t *testing.T) {
+	hash, err := HashCode("correct horse")
+	if err != nil {
+		t.Fatalf("failed to hash code: %v", err)
+	}
+
+	t.Run("accepts the matching code", func(t *testing.T) {
+		if !VerifyCode("correct horse", hash) {
+			t.Error("expected code to match")
+		}
+	})
+
+	t.Run("rejects a different code", func(t *testing.T) {
+		if VerifyCode("battery staple", hash) {
+			t.Error("expected code not to match")
+		}
+	})
+
+	t.Run("never matches when no hash is configured", func(t *testing.T) {
+		if VerifyCode("dead-mans-switch", "") {
+			t.Error("expected empty hash to never match")
+		}
+		if VerifyCode("", "") {
+			t.Error("expected empty hash to never match")
+		}
+	})
+
+	t.Run("rejects malformed hashes", func(t *testing.T) {
+		if VerifyCode("correct horse", "$argon2id$bogus") {
+			t.Error("expected malformed hash not to match")
+		}
+	})
+}
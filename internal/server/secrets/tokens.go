@@ -0,0 +1,32 @@
+package secrets
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+const tokenSize = 32
+
+// NewToken returns a random URL safe token along with the hash to store in its place.
+func NewToken() (string, string, error) {
+	b := make([]byte, tokenSize)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	return token, HashToken(token), nil
+}
+
+// HashToken hashes a token returned by NewToken so it can be looked up without storing it.
+// Tokens are random, so a fast hash is sufficient unlike user chosen codes.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,25 @@
+package secrets
+
+import "testing"
+
+func TestNewToken(t *testing.T) {
+	first, firstHash, err := NewToken()
+	if err != nil {
+		t.Fatalf("failed to create token: %v", err)
+	}
+
+	second, _, err := NewToken()
+	if err != nil {
+		t.Fatalf("failed to create token: %v", err)
+	}
+
+	if first == second {
+		t.Error("expected tokens to be unique")
+	}
+	if firstHash == first {
+		t.Error("expected hash to differ from token")
+	}
+	if HashToken(first) != firstHash {
+		t.Error("expected HashToken to match the hash returned with the token")
+	}
+}
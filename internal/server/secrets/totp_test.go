@@ -0,0 +1,79 @@
+package secrets
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestVerifyTOTP(t *testing.T) {
+	// RFC 6238 test vector secret, "12345678901234567890" in base32
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	now := time.Unix(59, 0)
+
+	t.Run("accepts the code for the current step", func(t *testing.T) {
+		step, ok := VerifyTOTP(secret, "287082", now, 0)
+		if !ok || step != 1 {
+			t.Errorf("expected the code to match step 1, got %d %v", step, ok)
+		}
+	})
+
+	t.Run("allows a step of clock drift", func(t *testing.T) {
+		_, ok := VerifyTOTP(secret, "287082", now.Add(30*time.Second), 0)
+		if !ok {
+			t.Error("expected the previous step's code to match")
+		}
+
+		_, ok = VerifyTOTP(secret, "287082", now.Add(90*time.Second), 0)
+		if ok {
+			t.Error("expected a code from three steps ago to be rejected")
+		}
+	})
+
+	t.Run("rejects used codes", func(t *testing.T) {
+		_, ok := VerifyTOTP(secret, "287082", now, 1)
+		if ok {
+			t.Error("expected a code from the last used step to be rejected")
+		}
+	})
+
+	t.Run("rejects wrong codes", func(t *testing.T) {
+		for _, code := range []string{"000000", "28708", "2870820", ""} {
+			_, ok := VerifyTOTP(secret, code, now, 0)
+			if ok {
+				t.Errorf("expected %q to be rejected", code)
+			}
+		}
+	})
+
+	t.Run("new secrets round trip", func(t *testing.T) {
+		secret, err := NewTOTPSecret()
+		if err != nil {
+			t.Fatalf("failed to create secret: %v", err)
+		}
+
+		key, err := totpEncoding.DecodeString(secret)
+		if err != nil {
+			t.Fatalf("failed to decode secret: %v", err)
+		}
+
+		_, ok := VerifyTOTP(secret, totpCode(key, now.Unix()/totpPeriod), now, 0)
+		if !ok {
+			t.Error("expected the generated code to match")
+		}
+	})
+}
+
+func TestTOTPURL(t *testing.T) {
+	u, err := url.Parse(TOTPURL("Dead Man's Switch", "alice", "ABC"))
+	if err != nil {
+		t.Fatalf("failed to parse URL: %v", err)
+	}
+
+	if u.Scheme != "otpauth" || u.Host != "totp" || u.Path != "/Dead Man's Switch:alice" {
+		t.Errorf("unexpected URL %q", u)
+	}
+	if u.Query().Get("secret") != "ABC" || u.Query().Get("issuer") != "Dead Man's Switch" {
+		t.Errorf("unexpected parameters %q", u.RawQuery)
+	}
+}
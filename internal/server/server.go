@@ -2,12 +2,15 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"embed"
 	"errors"
 	"fmt"
 	"io/fs"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"slices"
@@ -23,16 +26,23 @@ import (
 	"github.com/caddyserver/certmagic"
 	"github.com/circa10a/dead-mans-switch/api"
 	"github.com/circa10a/dead-mans-switch/internal/server/database"
+	"github.com/circa10a/dead-mans-switch/internal/server/events"
 	"github.com/circa10a/dead-mans-switch/internal/server/handlers"
+	"github.com/circa10a/dead-mans-switch/internal/server/hooks"
 	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+	"github.com/circa10a/dead-mans-switch/internal/server/mqtt"
 	"github.com/circa10a/dead-mans-switch/internal/server/secrets"
+	"github.com/circa10a/dead-mans-switch/internal/server/smtp"
+	"github.com/go-webauthn/webauthn/webauthn"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 const (
-	defaultLogLevel        = "info"
-	defaultWorkerInterval  = 1 * time.Minute
-	defaultWorkerBatchSize = 1000
+	defaultLogLevel         = "info"
+	defaultMaxPauseDuration = 30 * 24 * time.Hour
+	defaultSessionDuration  = 7 * 24 * time.Hour
+	defaultWorkerInterval   = 1 * time.Minute
+	defaultWorkerBatchSize  = 1000
 )
 
 //go:embed web/*
@@ -50,33 +60,52 @@ type Server struct {
 
 	ctx            context.Context
 	cancel         context.CancelFunc
+	clientCAs      *x509.CertPool
 	mux            http.Handler
 	logger         *slog.Logger
 	middlewares    []func(http.Handler) http.Handler
 	vapidPublicKey string
 	worker         *worker
+	dispatcher     *dispatcher
 }
 
 // Config holds configuration for creating a Server.
 type Config struct {
-	AuthEnabled       bool
-	AuthIssuerURL     string
-	AuthAudience      string
-	AutoTLS           bool
-	ContactEmail      string
-	DemoMode          bool
-	DemoResetInterval time.Duration
-	Domains           []string
-	LogFormat         string
-	LogLevel          string
-	Metrics           bool
-	Port              int
-	DataDir           string
-	TLSCert           string
-	TLSKey            string
-	Validation        bool
-	WorkerBatchSize   int
-	WorkerInterval    time.Duration
+	Actions                 map[string]hooks.Action
+	AuthAdminRoles          []string
+	AuthEnabled             bool
+	AuthGroupsHeader        string
+	AuthIssuerURL           string
+	AuthAudience            string
+	AuthClientCA            string
+	AuthClientCertRules     []middleware.CertRule
+	AuthIssuers             []middleware.Issuer
+	AuthJWKSRefreshInterval time.Duration
+	AuthMode                api.AuthMode
+	AuthRolesClaim          string
+	AuthTrustedProxies      []string
+	AuthUserHeader          string
+	AutoTLS                 bool
+	ContactEmail            string
+	DemoMode                bool
+	DemoResetInterval       time.Duration
+	Domains                 []string
+	ExternalURL             string
+	LogFormat               string
+	LogLevel                string
+	MaxPauseDuration        time.Duration
+	MaxSwitchesPerUser      int
+	Metrics                 bool
+	MQTT                    mqtt.Config
+	Port                    int
+	SessionDuration         time.Duration
+	SMTP                    smtp.Config
+	DataDir                 string
+	TLSCert                 string
+	TLSKey                  string
+	Validation              bool
+	WorkerBatchSize         int
+	WorkerInterval          time.Duration
 }
 
 // New returns a new server configured from cfg.
@@ -93,6 +122,46 @@ func New(cfg *Config) (*Server, error) {
 		server.LogLevel = defaultLogLevel
 	}
 
+	if server.MaxPauseDuration == 0 {
+		server.MaxPauseDuration = defaultMaxPauseDuration
+	}
+
+	// AuthEnabled predates auth modes and means signing in with an OIDC provider
+	if server.AuthMode == "" {
+		server.AuthMode = api.AuthModeNone
+		if server.AuthEnabled {
+			server.AuthMode = api.AuthModeOIDC
+		}
+	}
+	server.AuthEnabled = server.AuthMode != api.AuthModeNone
+
+	if server.SessionDuration == 0 {
+		server.SessionDuration = defaultSessionDuration
+	}
+
+	if server.AuthJWKSRefreshInterval == 0 {
+		server.AuthJWKSRefreshInterval = middleware.DefaultJWKSRefreshInterval
+	}
+
+	if server.AuthUserHeader == "" {
+		server.AuthUserHeader = middleware.DefaultUserHeader
+	}
+
+	if server.ExternalURL == "" {
+		server.ExternalURL = fmt.Sprintf("http://localhost:%d", server.Port)
+		if server.AutoTLS && len(server.Domains) > 0 {
+			server.ExternalURL = "https://" + server.Domains[0]
+		}
+	}
+
+	// Email check-in addresses default to the server's own domain
+	if server.SMTP.Listen != "" && server.SMTP.Domain == "" {
+		externalURL, err := url.Parse(server.ExternalURL)
+		if err == nil {
+			server.SMTP.Domain = externalURL.Hostname()
+		}
+	}
+
 	if server.WorkerBatchSize == 0 {
 		server.WorkerBatchSize = defaultWorkerBatchSize
 	}
@@ -178,6 +247,21 @@ func New(cfg *Config) (*Server, error) {
 		}
 	}
 
+	// Lifecycle events of switches, sent to the webhooks users subscribe
+	bus := events.NewBus()
+	server.dispatcher = &dispatcher{
+		store:  db,
+		bus:    bus,
+		logger: server.logger,
+	}
+	go server.dispatcher.start(server.ctx)
+
+	// Reminders sent by email can be replied to when email check-ins are accepted
+	replyDomain := ""
+	if server.SMTP.Listen != "" {
+		replyDomain = server.SMTP.Domain
+	}
+
 	// Worker
 	server.worker = &worker{
 		store:           db,
@@ -185,6 +269,11 @@ func New(cfg *Config) (*Server, error) {
 		batchSize:       server.WorkerBatchSize,
 		logger:          server.logger,
 		subscriberEmail: server.ContactEmail,
+		externalURL:     server.ExternalURL,
+		actions:         server.Actions,
+		events:          bus,
+		replyDomain:     replyDomain,
+		hooks:           newHookPool(server.ctx, maxConcurrentHooks),
 		// worker validates the sub claim
 		vapidPublicKey: server.vapidPublicKey,
 		// worker signs the push
@@ -200,25 +289,72 @@ func New(cfg *Config) (*Server, error) {
 
 	// JWT Authentication
 	var jwtValidator *middleware.JWTValidator
-	if server.AuthEnabled {
-		var err error
-		publicKeys, err := middleware.FetchPublicKeys(server.AuthIssuerURL)
+	switch server.AuthMode {
+	case api.AuthModeOIDC:
+		issuers := server.oidcIssuers()
+		for i, issuer := range issuers {
+			// An issuer that is briefly down shouldn't stop the server, its tokens are rejected until its keys are fetched
+			keys := middleware.NewJWKSCache(issuer.URL, server.AuthJWKSRefreshInterval, server.logger.With("component", "jwks", "issuer", issuer.URL))
+			err := keys.Refresh()
+			if err != nil {
+				server.logger.Warn("Failed to fetch public keys from issuer, retrying in the background", "issuer", issuer.URL, "error", err)
+			}
+			go keys.Run(server.ctx)
+
+			issuers[i].Keys = keys
+		}
+
+		jwtValidator = &middleware.JWTValidator{
+			Enabled:   true,
+			Issuers:   issuers,
+			APITokens: db,
+			Roles:     db,
+		}
+	case api.AuthModeLocal:
+		// Local user accounts sign in with the server itself, so there is no issuer to trust
+		jwtValidator = &middleware.JWTValidator{
+			Enabled:   true,
+			APITokens: db,
+			Sessions:  db,
+			Roles:     db,
+		}
+	case api.AuthModeHeader:
+		// The proxy in front of the server signs users in, API tokens still work for clients that bypass it
+		trustedProxies, err := middleware.ParseTrustedProxies(server.AuthTrustedProxies)
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch public keys from issuer: %w", err)
+			return nil, err
 		}
 
 		jwtValidator = &middleware.JWTValidator{
-			Enabled:    true,
-			IssuerURL:  server.AuthIssuerURL,
-			Audience:   server.AuthAudience,
-			PublicKeys: publicKeys,
+			Enabled:   true,
+			APITokens: db,
+			Roles:     db,
+			Proxy: &middleware.ProxyAuth{
+				UserHeader:     server.AuthUserHeader,
+				GroupsHeader:   server.AuthGroupsHeader,
+				AdminGroups:    server.AuthAdminRoles,
+				TrustedProxies: trustedProxies,
+			},
 		}
-	} else {
+	default:
 		jwtValidator = &middleware.JWTValidator{
 			Enabled: false,
 		}
 	}
 
+	// Client certificates sign in besides the auth mode's own method, for devices without a browser
+	if server.AuthClientCA != "" {
+		server.clientCAs, err = loadClientCAs(server.AuthClientCA)
+		if err != nil {
+			return nil, err
+		}
+
+		jwtValidator.ClientCerts, err = middleware.NewClientCertAuth(server.AuthClientCertRules)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Default middlewares
 	server.mux = middleware.Logging(server.logger, server.mux)
 	server.mux = middleware.SecurityHeaders(server.mux)
@@ -228,14 +364,29 @@ func New(cfg *Config) (*Server, error) {
 		server.mux = mw(server.mux)
 	}
 
+	// The event stream stays open past the server's write timeout
+	server.mux = middleware.Streaming(server.mux, "/api/v1/events")
+
 	// Routes
-	// Auth configuration endpoint (unauthenticated so UI can discover OIDC settings)
+	// Auth configuration endpoint (unauthenticated so UI can discover how to sign in)
 	authCfg := api.AuthConfig{
 		Enabled: server.AuthEnabled,
-	}
-	if server.AuthEnabled {
-		authCfg.Audience = &server.AuthAudience
-		authCfg.IssuerUrl = &server.AuthIssuerURL
+		Mode:    server.AuthMode,
+	}
+	if server.AuthMode == api.AuthModeOIDC {
+		issuers := []api.AuthIssuer{}
+		for _, issuer := range server.oidcIssuers() {
+			issuers = append(issuers, api.AuthIssuer{
+				Name:      issuer.Name,
+				IssuerUrl: issuer.URL,
+				ClientId:  issuer.LoginClientID(),
+			})
+		}
+		authCfg.Issuers = &issuers
+
+		// Older clients only know a single issuer
+		authCfg.Audience = &issuers[0].ClientId
+		authCfg.IssuerUrl = &issuers[0].IssuerUrl
 	}
 
 	// Health check
@@ -246,8 +397,61 @@ func New(cfg *Config) (*Server, error) {
 
 	// Switches
 	switchHandler := &handlers.Switch{
-		Store:  db,
-		Logger: server.logger,
+		Store:            db,
+		Logger:           server.logger,
+		MaxPauseDuration: server.MaxPauseDuration,
+		Duress:           server.worker.processDuress,
+		ChangeRequested:  server.worker.notifyChangeRequested,
+		Actions:          server.Actions,
+		Events:           bus,
+		SessionDuration:  server.SessionDuration,
+		MaxSwitches:      server.MaxSwitchesPerUser,
+		// Browsers only keep secure cookies for sites served over HTTPS
+		SecureCookies: strings.HasPrefix(server.ExternalURL, "https://"),
+	}
+
+	// Passkeys belong to local user accounts and are bound to the address the UI is served from
+	if server.AuthMode == api.AuthModeLocal {
+		switchHandler.WebAuthn, err = newWebAuthn(server.ExternalURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure passkeys: %w", err)
+		}
+	}
+
+	// MQTT bridge, publishing events to a broker and checking in with the tokens sent to it
+	if server.MQTT.BrokerURL != "" {
+		bridge, err := mqtt.New(server.MQTT, bus, func(token string) error {
+			_, err := switchHandler.CheckInWithToken(token, api.CheckInMethodMQTT)
+			return err
+		}, server.logger)
+		if err != nil {
+			return nil, err
+		}
+
+		go func() {
+			err := bridge.Start(server.ctx)
+			if err != nil {
+				server.logger.Error("MQTT bridge stopped", "error", err)
+			}
+		}()
+	}
+
+	// SMTP listener, checking in with the tokens in emails sent to it
+	if server.SMTP.Listen != "" {
+		mailServer, err := smtp.New(server.SMTP, func(token string) error {
+			_, err := switchHandler.CheckInByEmail(token)
+			return err
+		}, server.logger)
+		if err != nil {
+			return nil, err
+		}
+
+		go func() {
+			err := mailServer.Start(server.ctx)
+			if err != nil {
+				server.logger.Error("SMTP listener stopped", "error", err)
+			}
+		}()
 	}
 
 	validator := validator.New()
@@ -269,33 +473,124 @@ func New(cfg *Config) (*Server, error) {
 		// Unauthenticated routes
 		r.Group(func(r chi.Router) {
 			r.Get("/auth/config", handlers.AuthConfigHandler(authCfg))
+
+			// Local user accounts sign in with a password or a passkey
+			if server.AuthMode == api.AuthModeLocal {
+				r.Post("/auth/login", switchHandler.LoginHandleFunc)
+				r.Post("/auth/login/passkey", switchHandler.PasskeyLoginHandleFunc)
+				r.Post("/auth/login/passkey/challenge", switchHandler.PasskeyLoginChallengeHandleFunc)
+			}
+
+			// Trusted contacts are authenticated by the single use token they were sent
+			r.Get("/contact/{token}", switchHandler.ContactHandleFunc)
+			r.Post("/contact/{token}/postpone", switchHandler.ContactPostponeHandleFunc)
+			r.Post("/contact/{token}/confirm", switchHandler.ContactConfirmHandleFunc)
+
+			// Alertmanager is authenticated by the check-in token of the switch it keeps alive
+			r.Post("/integrations/alertmanager/{token}", switchHandler.AlertmanagerHandleFunc)
 		})
 
 		// Apply JWT auth middleware to authenticated routes
 		r.Group(func(r chi.Router) {
 			r.Use(middleware.JWTAuth(jwtValidator))
 
-			// Group routes that require the validation middlewares
+			// Reading routes, allowed for API tokens with the read scope
 			r.Group(func(r chi.Router) {
-				r.Use(middleware.SwitchValidator(validator))
-				r.Use(middleware.NotifierValidator)
+				r.Use(middleware.RequireScope(api.TokenScopeRead))
+
+				r.Get("/changes", switchHandler.ChangesHandleFunc)
+				r.Get("/events", switchHandler.EventsHandleFunc)
+				r.Get("/switch", switchHandler.GetHandleFunc)
+				r.Get("/switch/{id}", switchHandler.GetByIDHandleFunc)
+				r.Get("/switch/{id}/audit", switchHandler.AuditHandleFunc)
+				r.Get("/switch/{id}/deliveries", switchHandler.DeliveriesHandleFunc)
+				r.Get("/switch/{id}/checkins", switchHandler.CheckInsHandleFunc)
+				r.Get("/webhooks", switchHandler.SubscriptionsHandleFunc)
+				r.Get("/webhooks/{id}", switchHandler.SubscriptionHandleFunc)
+				r.Get("/webhooks/{id}/deliveries", switchHandler.SubscriptionDeliveriesHandleFunc)
+
+				// VAPID key for push notifications
+				r.Get("/vapid", func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "text/plain")
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte(server.vapidPublicKey))
+				})
+			})
+
+			// Check-in routes, allowed for API tokens with the checkin scope
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RequireScope(api.TokenScopeCheckIn))
 
-				r.Post("/switch", switchHandler.PostHandleFunc)
-				r.Put("/switch/{id}", switchHandler.PutByIDHandleFunc)
+				r.Post("/checkin", switchHandler.CheckInAllHandleFunc)
+				r.Post("/switch/{id}/reset", switchHandler.ResetHandleFunc)
+				r.Post("/switch/{id}/reset/challenge", switchHandler.ResetChallengeHandleFunc)
 			})
 
-			// Standard routes (No body validation needed)
-			r.Get("/switch", switchHandler.GetHandleFunc)
-			r.Get("/switch/{id}", switchHandler.GetByIDHandleFunc)
-			r.Delete("/switch/{id}", switchHandler.DeleteHandleFunc)
-			r.Post("/switch/{id}/reset", switchHandler.ResetHandleFunc)
-			r.Post("/switch/{id}/disable", switchHandler.DisableHandleFunc)
-
-			// VAPID key for push notifications
-			r.Get("/vapid", func(w http.ResponseWriter, r *http.Request) {
-				w.Header().Set("Content-Type", "text/plain")
-				w.WriteHeader(http.StatusOK)
-				_, _ = w.Write([]byte(server.vapidPublicKey))
+			// Changing routes, allowed for API tokens with the write scope
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RequireScope(api.TokenScopeWrite))
+
+				// Group routes that require the validation middlewares
+				r.Group(func(r chi.Router) {
+					r.Use(middleware.SwitchValidator(validator))
+					r.Use(middleware.NotifierValidator)
+
+					r.Post("/switch", switchHandler.PostHandleFunc)
+					r.Put("/switch/{id}", switchHandler.PutByIDHandleFunc)
+				})
+
+				// Standard routes (No body validation needed)
+				r.Post("/changes/{id}/approve", switchHandler.ApproveChangeHandleFunc)
+				r.Post("/changes/{id}/cancel", switchHandler.CancelChangeHandleFunc)
+				r.Post("/switch/pause", switchHandler.PauseAllHandleFunc)
+				r.Post("/switch/resume", switchHandler.ResumeAllHandleFunc)
+				r.Delete("/switch/{id}", switchHandler.DeleteHandleFunc)
+				r.Post("/switch/{id}/disable", switchHandler.DisableHandleFunc)
+				r.Post("/switch/{id}/pause", switchHandler.PauseHandleFunc)
+				r.Post("/switch/{id}/resume", switchHandler.ResumeHandleFunc)
+				r.Post("/webhooks", switchHandler.CreateSubscriptionHandleFunc)
+				r.Put("/webhooks/{id}", switchHandler.UpdateSubscriptionHandleFunc)
+				r.Delete("/webhooks/{id}", switchHandler.DeleteSubscriptionHandleFunc)
+			})
+
+			// API token management, allowed for API tokens with the admin scope
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RequireScope(api.TokenScopeAdmin))
+
+				r.Get("/tokens", switchHandler.TokensHandleFunc)
+				r.Post("/tokens", switchHandler.CreateTokenHandleFunc)
+				r.Get("/tokens/{id}", switchHandler.TokenHandleFunc)
+				r.Put("/tokens/{id}", switchHandler.UpdateTokenHandleFunc)
+				r.Delete("/tokens/{id}", switchHandler.DeleteTokenHandleFunc)
+
+				// Local user accounts
+				if server.AuthMode == api.AuthModeLocal {
+					r.Post("/auth/logout", switchHandler.LogoutHandleFunc)
+					r.Get("/auth/me", switchHandler.AccountHandleFunc)
+					r.Post("/auth/password", switchHandler.ChangePasswordHandleFunc)
+					r.Post("/auth/totp", switchHandler.SetupTOTPHandleFunc)
+					r.Post("/auth/totp/enable", switchHandler.EnableTOTPHandleFunc)
+					r.Post("/auth/totp/disable", switchHandler.DisableTOTPHandleFunc)
+					r.Get("/auth/passkeys", switchHandler.PasskeysHandleFunc)
+					r.Post("/auth/passkeys", switchHandler.CreatePasskeyHandleFunc)
+					r.Post("/auth/passkeys/challenge", switchHandler.PasskeyChallengeHandleFunc)
+					r.Post("/auth/passkeys/confirm/challenge", switchHandler.PasskeyConfirmChallengeHandleFunc)
+					r.Delete("/auth/passkeys/{passkeyId}", switchHandler.DeletePasskeyHandleFunc)
+				}
+			})
+
+			// Administration of every user's switches, allowed for admins and their API tokens with the admin scope
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RequireScope(api.TokenScopeAdmin))
+				r.Use(middleware.RequireAdmin)
+
+				r.Get("/admin/switches", switchHandler.AdminSwitchesHandleFunc)
+				r.Post("/admin/switches/{id}/disable", switchHandler.AdminDisableHandleFunc)
+				r.Get("/admin/stats", switchHandler.AdminStatsHandleFunc)
+				r.Get("/admin/users", switchHandler.AdminUsersHandleFunc)
+				r.Put("/admin/users/{userId}/role", switchHandler.AdminRoleHandleFunc)
+				r.Put("/admin/users/{userId}/quota", switchHandler.AdminQuotaHandleFunc)
+				r.Delete("/admin/users/{userId}/quota", switchHandler.AdminDeleteQuotaHandleFunc)
 			})
 		})
 	})
@@ -328,6 +623,9 @@ func (s *Server) Start() error {
 		log.Info("Starting server on :80 and :443")
 		certmagic.DefaultACME.Agreed = true
 		certmagic.DefaultACME.Email = s.ContactEmail
+		if s.clientCAs != nil {
+			return s.startAutoTLS()
+		}
 		return certmagic.HTTPS(s.Domains, s.mux)
 	}
 
@@ -347,6 +645,7 @@ func (s *Server) Start() error {
 
 	// If custom cert and key provided, listen on specified server port via https
 	if s.TLSCert != "" && s.TLSKey != "" {
+		httpServer.TLSConfig = s.withClientCerts(&tls.Config{})
 		return httpServer.ListenAndServeTLS(s.TLSCert, s.TLSKey)
 	}
 
@@ -382,6 +681,65 @@ func (s *Server) validate() error {
 		return errors.New("TLS key is missing TLS certificate")
 	}
 
+	validAuthModes := []api.AuthMode{api.AuthModeNone, api.AuthModeOIDC, api.AuthModeLocal, api.AuthModeHeader}
+	if s.AuthMode != "" && !slices.Contains(validAuthModes, s.AuthMode) {
+		return fmt.Errorf("invalid auth mode. Valid auth modes are: %v", validAuthModes)
+	}
+
+	if s.AuthMode == api.AuthModeOIDC && len(s.oidcIssuers()) == 0 {
+		return errors.New("OIDC auth mode requires an issuer URL")
+	}
+
+	err := middleware.ValidateIssuers(s.oidcIssuers())
+	if err != nil {
+		return err
+	}
+
+	if s.AuthMode == api.AuthModeHeader {
+		// Without trusted proxies anyone could claim to be any user by sending the header
+		if len(s.AuthTrustedProxies) == 0 {
+			return errors.New("header auth mode requires at least one trusted proxy")
+		}
+
+		_, err = middleware.ParseTrustedProxies(s.AuthTrustedProxies)
+		if err != nil {
+			return err
+		}
+
+		if strings.TrimSpace(s.AuthUserHeader) == "" {
+			return errors.New("header auth mode requires a user header")
+		}
+	}
+
+	if s.AuthClientCA != "" {
+		// Client certificates are only sent over a TLS listener of the server itself
+		if !s.AutoTLS && s.TLSCert == "" {
+			return errors.New("client certificate auth requires AutoTLS or a TLS certificate")
+		}
+
+		if s.AuthMode == "" || s.AuthMode == api.AuthModeNone {
+			return errors.New("client certificate auth requires an auth mode other than none")
+		}
+
+		_, err = middleware.NewClientCertAuth(s.AuthClientCertRules)
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.MaxSwitchesPerUser < 0 {
+		return errors.New("max switches per user cannot be negative")
+	}
+
+	if s.AuthMode == api.AuthModeLocal && s.DemoMode {
+		return errors.New("local auth mode cannot be used in demo mode since its accounts are reset")
+	}
+
+	err = hooks.Validate(s.Actions)
+	if err != nil {
+		return err
+	}
+
 	validLogFormats := []string{"json", "text", ""}
 	if !slices.Contains(validLogFormats, s.LogFormat) {
 		return fmt.Errorf("invalid log format. Valid log formats are: %v", validLogFormats)
@@ -411,3 +769,34 @@ func getLogFormatter(logformat string) log.Formatter {
 	// Default to text formatter if not specified or invalid
 	return log.TextFormatter
 }
+
+// oidcIssuers returns the OIDC providers whose tokens are trusted. The issuer set with AuthIssuerURL comes
+// first and has no name, so users who signed in before more issuers were added keep their user IDs.
+func (s *Server) oidcIssuers() []middleware.Issuer {
+	issuers := []middleware.Issuer{}
+
+	if s.AuthIssuerURL != "" {
+		issuer := middleware.Issuer{URL: s.AuthIssuerURL, RolesClaim: s.AuthRolesClaim, AdminRoles: s.AuthAdminRoles}
+		if s.AuthAudience != "" {
+			issuer.Audiences = []string{s.AuthAudience}
+		}
+		issuers = append(issuers, issuer)
+	}
+
+	return append(issuers, s.AuthIssuers...)
+}
+
+// newWebAuthn configures passkeys for the site at externalURL. Passkeys only work for the domain they were
+// registered on, so changing the external URL invalidates them.
+func newWebAuthn(externalURL string) (*webauthn.WebAuthn, error) {
+	u, err := url.Parse(externalURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return webauthn.New(&webauthn.Config{
+		RPID:          u.Hostname(),
+		RPDisplayName: "Dead Man's Switch",
+		RPOrigins:     []string{u.Scheme + "://" + u.Host},
+	})
+}
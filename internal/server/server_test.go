@@ -2,12 +2,16 @@ package server
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/charmbracelet/log"
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/hooks"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
 )
 
 func TestValidate(t *testing.T) {
@@ -108,6 +112,209 @@ func TestValidate(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		{
+			name: "action without a command",
+			server: &Server{
+				Config: Config{
+					Validation: true,
+					Actions:    map[string]hooks.Action{"wipe": {}},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid auth mode",
+			server: &Server{
+				Config: Config{
+					Validation: true,
+					AuthMode:   "saml",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "OIDC auth mode without an issuer",
+			server: &Server{
+				Config: Config{
+					Validation: true,
+					AuthMode:   api.AuthModeOIDC,
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "OIDC auth mode with only configured issuers",
+			server: &Server{
+				Config: Config{
+					Validation:  true,
+					AuthMode:    api.AuthModeOIDC,
+					AuthIssuers: []middleware.Issuer{{Name: "family", URL: "https://family.example.com"}},
+				},
+			},
+		},
+		{
+			name: "two unnamed issuers",
+			server: &Server{
+				Config: Config{
+					Validation:    true,
+					AuthMode:      api.AuthModeOIDC,
+					AuthIssuerURL: "https://company.example.com",
+					AuthIssuers:   []middleware.Issuer{{URL: "https://family.example.com"}},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "issuer configured twice",
+			server: &Server{
+				Config: Config{
+					Validation:    true,
+					AuthMode:      api.AuthModeOIDC,
+					AuthIssuerURL: "https://company.example.com/",
+					AuthIssuers:   []middleware.Issuer{{Name: "company", URL: "https://company.example.com"}},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "duplicate issuer names",
+			server: &Server{
+				Config: Config{
+					Validation: true,
+					AuthMode:   api.AuthModeOIDC,
+					AuthIssuers: []middleware.Issuer{
+						{Name: "family", URL: "https://family.example.com"},
+						{Name: "family", URL: "https://other.example.com"},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "issuer name with a colon",
+			server: &Server{
+				Config: Config{
+					Validation:  true,
+					AuthMode:    api.AuthModeOIDC,
+					AuthIssuers: []middleware.Issuer{{Name: "a:b", URL: "https://family.example.com"}},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "negative switch quota",
+			server: &Server{
+				Config: Config{
+					Validation:         true,
+					MaxSwitchesPerUser: -1,
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "local auth mode in demo mode",
+			server: &Server{
+				Config: Config{
+					Validation: true,
+					AuthMode:   api.AuthModeLocal,
+					DemoMode:   true,
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "valid local auth mode",
+			server: &Server{
+				Config: Config{
+					Validation: true,
+					AuthMode:   api.AuthModeLocal,
+				},
+			},
+		},
+		{
+			name: "header auth mode without trusted proxies",
+			server: &Server{
+				Config: Config{
+					Validation:     true,
+					AuthMode:       api.AuthModeHeader,
+					AuthUserHeader: "Remote-User",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "header auth mode with an invalid trusted proxy",
+			server: &Server{
+				Config: Config{
+					Validation:         true,
+					AuthMode:           api.AuthModeHeader,
+					AuthTrustedProxies: []string{"proxy.internal"},
+					AuthUserHeader:     "Remote-User",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "valid header auth mode",
+			server: &Server{
+				Config: Config{
+					Validation:         true,
+					AuthMode:           api.AuthModeHeader,
+					AuthTrustedProxies: []string{"127.0.0.1", "100.64.0.0/10"},
+					AuthUserHeader:     "Tailscale-User-Login",
+				},
+			},
+		},
+		{
+			name: "client CA without TLS",
+			server: &Server{
+				Config: Config{
+					Validation:   true,
+					AuthMode:     api.AuthModeLocal,
+					AuthClientCA: "ca.pem",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "client CA without auth",
+			server: &Server{
+				Config: Config{
+					Validation:   true,
+					AuthClientCA: "ca.pem",
+					TLSCert:      "cert.pem",
+					TLSKey:       "key.pem",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "client certificate rule with an unknown field",
+			server: &Server{
+				Config: Config{
+					Validation:          true,
+					AuthMode:            api.AuthModeLocal,
+					AuthClientCA:        "ca.pem",
+					AuthClientCertRules: []middleware.CertRule{{Field: "serial"}},
+					TLSCert:             "cert.pem",
+					TLSKey:              "key.pem",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "valid client certificate auth",
+			server: &Server{
+				Config: Config{
+					Validation:          true,
+					AuthMode:            api.AuthModeLocal,
+					AuthClientCA:        "ca.pem",
+					AuthClientCertRules: []middleware.CertRule{{Field: middleware.CertFieldEmail}},
+					AutoTLS:             true,
+					Domains:             []string{"switch.example.com"},
+				},
+			},
+		},
 		{
 			name: "validation disabled skips all checks",
 			server: &Server{
@@ -440,6 +647,107 @@ func TestServerConfigOpts(t *testing.T) {
 		}
 	})
 
+	t.Run("AuthMode", func(t *testing.T) {
+		v := api.AuthModeLocal
+		cfg := &Config{
+			AuthMode:   v,
+			DataDir:    tmpDir,
+			Validation: true,
+		}
+
+		s, err := New(cfg)
+		if err != nil {
+			t.Fatalf("received unexpected err: %s", err.Error())
+		}
+
+		if s.AuthMode != v || !s.AuthEnabled {
+			t.Errorf(outputStr, s.AuthMode, v)
+		}
+
+		// Local accounts sign in with the server itself, and the API needs a session
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/switch", nil)
+		w := httptest.NewRecorder()
+		s.mux.ServeHTTP(w, req)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf(outputStr, w.Code, http.StatusUnauthorized)
+		}
+
+		req = httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader(`{"username":"alice","password":"wrong"}`))
+		w = httptest.NewRecorder()
+		s.mux.ServeHTTP(w, req)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf(outputStr, w.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("AuthModeHeader", func(t *testing.T) {
+		cfg := &Config{
+			AuthMode:           api.AuthModeHeader,
+			AuthTrustedProxies: []string{"192.0.2.1"},
+			DataDir:            tmpDir,
+			Validation:         true,
+		}
+
+		s, err := New(cfg)
+		if err != nil {
+			t.Fatalf("received unexpected err: %s", err.Error())
+		}
+
+		if s.AuthUserHeader != middleware.DefaultUserHeader {
+			t.Errorf(outputStr, s.AuthUserHeader, middleware.DefaultUserHeader)
+		}
+
+		// httptest requests come from 192.0.2.1, the trusted proxy
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/switch", nil)
+		req.Header.Set("Remote-User", "alice")
+		w := httptest.NewRecorder()
+		s.mux.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Errorf(outputStr, w.Code, http.StatusOK)
+		}
+
+		req = httptest.NewRequest(http.MethodGet, "/api/v1/switch", nil)
+		req.RemoteAddr = "198.51.100.7:1234"
+		req.Header.Set("Remote-User", "alice")
+		w = httptest.NewRecorder()
+		s.mux.ServeHTTP(w, req)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf(outputStr, w.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("AuthEnabled", func(t *testing.T) {
+		cfg := &Config{
+			DataDir:    tmpDir,
+			Validation: false,
+		}
+
+		s, err := New(cfg)
+		if err != nil {
+			t.Fatalf("received unexpected err: %s", err.Error())
+		}
+
+		// Without auth there are no local accounts to sign in to
+		if s.AuthMode != api.AuthModeNone {
+			t.Errorf(outputStr, s.AuthMode, api.AuthModeNone)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader(`{}`))
+		w := httptest.NewRecorder()
+		s.mux.ServeHTTP(w, req)
+		if w.Code == http.StatusOK || w.Code == http.StatusUnauthorized {
+			t.Errorf("expected no login route, got %d", w.Code)
+		}
+
+		// The default user administers a server without auth
+		req = httptest.NewRequest(http.MethodGet, "/api/v1/admin/stats", nil)
+		w = httptest.NewRecorder()
+		s.mux.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Errorf(outputStr, w.Code, http.StatusOK)
+		}
+	})
+
 	t.Run("Validation", func(t *testing.T) {
 		v := true
 		cfg := &Config{
@@ -0,0 +1,338 @@
+// Package smtp runs a small SMTP listener so replying to a reminder email, or writing to a switch's
+// check-in address, counts as a check-in.
+package smtp
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	gosmtp "github.com/emersion/go-smtp"
+)
+
+const (
+	// localPart is the local part of the check-in address. A token can follow it as checkin+<token>.
+	localPart = "checkin"
+	// maxMessageBytes bounds the size of accepted messages, which only need to hold a reply.
+	maxMessageBytes = 1 << 20
+	// maxRecipients bounds how many check-in addresses a single message can be sent to.
+	maxRecipients = 10
+	// timeout bounds how long a client can take to send a command or its data.
+	timeout = 30 * time.Second
+)
+
+// Config holds the listener settings.
+type Config struct {
+	// Listen is the address to accept mail on, such as :2525.
+	Listen string
+	// Domain is the domain of the check-in addresses, such as dms.example.com for checkin@dms.example.com.
+	Domain string
+	// AllowedSenders are the addresses, or @domain suffixes, that may check in. Any sender is allowed if empty.
+	AllowedSenders []string
+}
+
+// CheckInFunc checks in with a token from an email.
+type CheckInFunc func(token string) error
+
+// Server accepts mail for the check-in addresses and checks in with the tokens it carries.
+type Server struct {
+	cfg     Config
+	tokens  *regexp.Regexp
+	checkIn CheckInFunc
+	logger  *slog.Logger
+}
+
+// New returns a server for the addresses in cfg. Listening is left to Start.
+func New(cfg Config, checkIn CheckInFunc, logger *slog.Logger) (*Server, error) {
+	if cfg.Domain == "" {
+		return nil, errors.New("SMTP domain is required")
+	}
+
+	return &Server{
+		cfg: cfg,
+		// Replies usually quote the reminder, which includes the check-in address with its token
+		tokens:  regexp.MustCompile(`(?i)` + localPart + `\+([A-Za-z0-9._~-]+)@` + regexp.QuoteMeta(cfg.Domain)),
+		checkIn: checkIn,
+		logger:  logger.With("component", "smtp"),
+	}, nil
+}
+
+// Start listens on the configured address and accepts mail until the context is cancelled.
+func (s *Server) Start(ctx context.Context) error {
+	l, err := net.Listen("tcp", s.cfg.Listen)
+	if err != nil {
+		return fmt.Errorf("failed to listen for SMTP: %w", err)
+	}
+
+	s.logger.Info("Accepting email check-ins", "address", l.Addr().String(), "domain", s.cfg.Domain)
+
+	return s.Serve(ctx, l)
+}
+
+// Serve accepts mail on l until the context is cancelled.
+func (s *Server) Serve(ctx context.Context, l net.Listener) error {
+	srv := gosmtp.NewServer(s)
+	srv.Domain = s.cfg.Domain
+	srv.MaxMessageBytes = maxMessageBytes
+	srv.MaxRecipients = maxRecipients
+	srv.ReadTimeout = timeout
+	srv.WriteTimeout = timeout
+	srv.ErrorLog = slog.NewLogLogger(s.logger.Handler(), slog.LevelError)
+
+	go func() {
+		<-ctx.Done()
+		_ = srv.Close()
+	}()
+
+	err := srv.Serve(l)
+	if err != nil && !errors.Is(err, gosmtp.ErrServerClosed) && ctx.Err() == nil {
+		return err
+	}
+
+	return nil
+}
+
+// NewSession implements gosmtp.Backend.
+func (s *Server) NewSession(_ *gosmtp.Conn) (gosmtp.Session, error) {
+	return &session{server: s}, nil
+}
+
+// allowed reports whether an address may check in.
+func (s *Server) allowed(address string) bool {
+	if len(s.cfg.AllowedSenders) == 0 {
+		return true
+	}
+
+	address = strings.ToLower(address)
+	for _, sender := range s.cfg.AllowedSenders {
+		sender = strings.ToLower(sender)
+		if strings.HasPrefix(sender, "@") {
+			if strings.HasSuffix(address, sender) {
+				return true
+			}
+		} else if address == sender {
+			return true
+		}
+	}
+
+	return false
+}
+
+// recipientToken returns the token of a check-in address, which is empty for the plain address, and
+// whether the address is a check-in address at all.
+func (s *Server) recipientToken(address string) (string, bool) {
+	local, domain, found := strings.Cut(address, "@")
+	if !found || !strings.EqualFold(domain, s.cfg.Domain) {
+		return "", false
+	}
+
+	if strings.EqualFold(local, localPart) {
+		return "", true
+	}
+
+	prefix := localPart + "+"
+	if len(local) > len(prefix) && strings.EqualFold(local[:len(prefix)], prefix) {
+		return local[len(prefix):], true
+	}
+
+	return "", false
+}
+
+var (
+	errSenderNotAllowed = &gosmtp.SMTPError{Code: 550, EnhancedCode: gosmtp.EnhancedCode{5, 7, 1}, Message: "Sender not allowed"}
+	errUnknownRecipient = &gosmtp.SMTPError{Code: 550, EnhancedCode: gosmtp.EnhancedCode{5, 1, 1}, Message: "No such check-in address"}
+	errNotAuthenticated = &gosmtp.SMTPError{Code: 550, EnhancedCode: gosmtp.EnhancedCode{5, 7, 26}, Message: "Message failed DKIM and SPF checks"}
+	errMissingToken     = &gosmtp.SMTPError{Code: 554, EnhancedCode: gosmtp.EnhancedCode{5, 6, 0}, Message: "No check-in token found"}
+	errUnknownToken     = &gosmtp.SMTPError{Code: 550, EnhancedCode: gosmtp.EnhancedCode{5, 7, 1}, Message: "Unknown or expired check-in token"}
+	errCheckInFailed    = &gosmtp.SMTPError{Code: 451, EnhancedCode: gosmtp.EnhancedCode{4, 3, 0}, Message: "Failed to check in, try again later"}
+)
+
+// session is a single SMTP transaction.
+type session struct {
+	server *Server
+	tokens []string
+}
+
+// Mail implements gosmtp.Session.
+func (s *session) Mail(from string, _ *gosmtp.MailOptions) error {
+	if from == "" || !s.server.allowed(from) {
+		return errSenderNotAllowed
+	}
+	return nil
+}
+
+// Rcpt implements gosmtp.Session.
+func (s *session) Rcpt(to string, _ *gosmtp.RcptOptions) error {
+	token, ok := s.server.recipientToken(to)
+	if !ok {
+		return errUnknownRecipient
+	}
+	if token != "" {
+		s.tokens = append(s.tokens, token)
+	}
+	return nil
+}
+
+// Data implements gosmtp.Session. The message is checked in with every token in its recipients or body.
+func (s *session) Data(r io.Reader) error {
+	// Drain whatever isn't read so the client gets a reply
+	defer func() { _, _ = io.Copy(io.Discard, r) }()
+
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return &gosmtp.SMTPError{Code: 554, EnhancedCode: gosmtp.EnhancedCode{5, 6, 0}, Message: "Malformed message"}
+	}
+
+	from, err := mail.ParseAddress(msg.Header.Get("From"))
+	if err != nil || !s.server.allowed(from.Address) {
+		return errSenderNotAllowed
+	}
+
+	if !verifyAuthentication(msg.Header) {
+		s.server.logger.Warn("Rejected email check-in that failed DKIM and SPF", "from", from.Address)
+		return errNotAuthenticated
+	}
+
+	text, err := messageText(msg.Header, msg.Body)
+	if err != nil {
+		s.server.logger.Warn("Failed to read email check-in", "error", err, "from", from.Address)
+	}
+
+	tokens := s.tokens
+	for _, match := range s.server.tokens.FindAllStringSubmatch(text, -1) {
+		tokens = append(tokens, match[1])
+	}
+
+	if len(tokens) == 0 {
+		return errMissingToken
+	}
+
+	checkedIn := false
+	seen := map[string]bool{}
+	for _, token := range tokens {
+		if seen[token] {
+			continue
+		}
+		seen[token] = true
+
+		err := s.server.checkIn(token)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			continue
+		case err != nil:
+			s.server.logger.Error("Failed to check in by email", "error", err, "from", from.Address)
+			return errCheckInFailed
+		}
+		checkedIn = true
+	}
+
+	if !checkedIn {
+		s.server.logger.Warn("Email check-in with an unknown token", "from", from.Address)
+		return errUnknownToken
+	}
+
+	s.server.logger.Info("Checked in by email", "from", from.Address)
+	return nil
+}
+
+// Reset implements gosmtp.Session.
+func (s *session) Reset() {
+	s.tokens = nil
+}
+
+// Logout implements gosmtp.Session.
+func (s *session) Logout() error {
+	return nil
+}
+
+// verifyAuthentication checks the DKIM and SPF results added by an upstream mail server. A message is
+// rejected only if a check failed and none passed, so messages without results are accepted.
+func verifyAuthentication(header mail.Header) bool {
+	passed, failed := false, false
+
+	record := func(result string) {
+		switch strings.ToLower(result) {
+		case "pass":
+			passed = true
+		case "fail", "softfail", "permerror":
+			failed = true
+		}
+	}
+
+	for _, results := range header["Authentication-Results"] {
+		for _, clause := range strings.Split(results, ";") {
+			method, result, found := strings.Cut(strings.TrimSpace(clause), "=")
+			if !found {
+				continue
+			}
+			method = strings.ToLower(strings.TrimSpace(method))
+			if method != "dkim" && method != "spf" {
+				continue
+			}
+			if fields := strings.Fields(result); len(fields) > 0 {
+				record(fields[0])
+			}
+		}
+	}
+
+	for _, spf := range header["Received-Spf"] {
+		if fields := strings.Fields(spf); len(fields) > 0 {
+			record(fields[0])
+		}
+	}
+
+	return passed || !failed
+}
+
+// messageText returns the decoded text of a message, including every part of a multipart message.
+func messageText(header mail.Header, body io.Reader) (string, error) {
+	return partText(header.Get("Content-Type"), header.Get("Content-Transfer-Encoding"), body)
+}
+
+// partText decodes a message part, recursing into multipart parts.
+func partText(contentType, encoding string, body io.Reader) (string, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err == nil && strings.HasPrefix(mediaType, "multipart/") {
+		text := strings.Builder{}
+		parts := multipart.NewReader(body, params["boundary"])
+		for {
+			// NextPart decodes quoted-printable parts itself and removes their encoding header
+			part, err := parts.NextPart()
+			if errors.Is(err, io.EOF) {
+				return text.String(), nil
+			}
+			if err != nil {
+				return text.String(), err
+			}
+
+			partBody, err := partText(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part)
+			text.WriteString(partBody)
+			text.WriteString("\n")
+			if err != nil {
+				return text.String(), err
+			}
+		}
+	}
+
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	}
+
+	b, err := io.ReadAll(body)
+	return string(b), err
+}
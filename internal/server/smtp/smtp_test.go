@@ -0,0 +1,199 @@
+package smtp
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"log/slog"
+	"net"
+	"net/mail"
+	netsmtp "net/smtp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// startServer runs a server on a local port and returns its address and the tokens it checked in with.
+func startServer(t *testing.T, cfg Config) (string, chan string) {
+	t.Helper()
+
+	tokens := make(chan string, 10)
+	checkIn := func(token string) error {
+		if token != "valid-token" && token != "reply-token" {
+			return sql.ErrNoRows
+		}
+		tokens <- token
+		return nil
+	}
+
+	srv, err := New(cfg, checkIn, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+	go func() { stopped <- srv.Serve(ctx, l) }()
+
+	t.Cleanup(func() {
+		cancel()
+		select {
+		case err := <-stopped:
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Error("expected the server to stop")
+		}
+	})
+
+	return l.Addr().String(), tokens
+}
+
+func TestServer(t *testing.T) {
+	addr, tokens := startServer(t, Config{
+		Domain:         "dms.example.com",
+		AllowedSenders: []string{"alice@example.com", "@family.example.com"},
+	})
+
+	send := func(from, to, msg string) error {
+		return netsmtp.SendMail(addr, nil, from, []string{to}, []byte(strings.ReplaceAll(msg, "\n", "\r\n")))
+	}
+
+	expectCheckIn := func(t *testing.T, expected string) {
+		t.Helper()
+		select {
+		case token := <-tokens:
+			if token != expected {
+				t.Errorf("expected a check-in with %q, got %q", expected, token)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected a check-in with %q", expected)
+		}
+	}
+
+	t.Run("check-in addresses check in", func(t *testing.T) {
+		err := send("alice@example.com", "checkin+valid-token@dms.example.com",
+			"From: Alice <alice@example.com>\nSubject: still here\n\nHi\n")
+		if err != nil {
+			t.Fatalf("failed to send: %v", err)
+		}
+		expectCheckIn(t, "valid-token")
+	})
+
+	t.Run("replies check in with the quoted address", func(t *testing.T) {
+		err := send("bob@family.example.com", "Checkin@DMS.example.com",
+			"From: bob@family.example.com\nSubject: Re: Reminder\nContent-Type: text/plain\nContent-Transfer-Encoding: quoted-printable\n\n"+
+				"I'm fine.\n\n> To check in, reply to this email or write to checkin+reply-=\ntoken@dms.example.com.\n")
+		if err != nil {
+			t.Fatalf("failed to send: %v", err)
+		}
+		expectCheckIn(t, "reply-token")
+	})
+
+	t.Run("multipart replies check in", func(t *testing.T) {
+		err := send("alice@example.com", "checkin@dms.example.com",
+			"From: alice@example.com\nMIME-Version: 1.0\nContent-Type: multipart/alternative; boundary=b\n\n"+
+				"--b\nContent-Type: text/plain\nContent-Transfer-Encoding: base64\n\n"+
+				"Y2hlY2tpbityZXBseS10b2tlbkBkbXMuZXhhbXBsZS5jb20=\n--b--\n")
+		if err != nil {
+			t.Fatalf("failed to send: %v", err)
+		}
+		expectCheckIn(t, "reply-token")
+	})
+
+	rejected := []struct {
+		name string
+		from string
+		to   string
+		msg  string
+	}{
+		{
+			name: "senders must be allowed",
+			from: "mallory@example.com",
+			to:   "checkin+valid-token@dms.example.com",
+			msg:  "From: mallory@example.com\n\nHi\n",
+		},
+		{
+			name: "the From header must be allowed",
+			from: "alice@example.com",
+			to:   "checkin+valid-token@dms.example.com",
+			msg:  "From: mallory@example.com\n\nHi\n",
+		},
+		{
+			name: "other addresses are rejected",
+			from: "alice@example.com",
+			to:   "postmaster@dms.example.com",
+			msg:  "From: alice@example.com\n\nHi\n",
+		},
+		{
+			name: "messages failing DKIM and SPF are rejected",
+			from: "alice@example.com",
+			to:   "checkin+valid-token@dms.example.com",
+			msg:  "From: alice@example.com\nAuthentication-Results: mx.example.com; dkim=fail; spf=softfail\n\nHi\n",
+		},
+		{
+			name: "messages without a token are rejected",
+			from: "alice@example.com",
+			to:   "checkin@dms.example.com",
+			msg:  "From: alice@example.com\n\nHi\n",
+		},
+		{
+			name: "unknown tokens are rejected",
+			from: "alice@example.com",
+			to:   "checkin+unknown-token@dms.example.com",
+			msg:  "From: alice@example.com\n\nHi\n",
+		},
+	}
+
+	for _, tt := range rejected {
+		t.Run(tt.name, func(t *testing.T) {
+			err := send(tt.from, tt.to, tt.msg)
+			if err == nil {
+				t.Fatal("expected the message to be rejected")
+			}
+			select {
+			case token := <-tokens:
+				t.Errorf("unexpected check-in with %q", token)
+			default:
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(Config{Listen: ":2525"}, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err == nil {
+		t.Error("expected an error without a domain")
+	}
+}
+
+func TestVerifyAuthentication(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   mail.Header
+		expected bool
+	}{
+		{name: "no results", header: mail.Header{}, expected: true},
+		{name: "dkim pass", header: mail.Header{"Authentication-Results": {"mx.example.com; dkim=pass header.d=example.com"}}, expected: true},
+		{name: "spf fail", header: mail.Header{"Authentication-Results": {"mx.example.com; spf=fail smtp.mailfrom=example.com"}}, expected: false},
+		{name: "dkim pass with spf fail", header: mail.Header{"Authentication-Results": {"mx.example.com; dkim=pass; spf=fail"}}, expected: true},
+		{name: "dkim none", header: mail.Header{"Authentication-Results": {"mx.example.com; dkim=none"}}, expected: true},
+		{name: "dmarc is ignored", header: mail.Header{"Authentication-Results": {"mx.example.com; dmarc=fail"}}, expected: true},
+		{name: "received-spf softfail", header: mail.Header{"Received-Spf": {"SoftFail (mx.example.com: domain does not designate sender)"}}, expected: false},
+		{name: "received-spf pass", header: mail.Header{"Received-Spf": {"Pass (mx.example.com: domain designates sender)"}}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := verifyAuthentication(tt.header); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package switches
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/database"
+)
+
+// Expired reports whether a switch has run out of time, whether or not it has fired yet.
+func Expired(sw api.Switch, now time.Time) bool {
+	if sw.Status == nil {
+		return false
+	}
+
+	switch *sw.Status {
+	case api.SwitchStatusFailed, api.SwitchStatusMissing, api.SwitchStatusTriggered, api.SwitchStatusVerifying, api.SwitchStatusWaiting:
+		return true
+	case api.SwitchStatusActive:
+		return sw.TriggerAt != nil && *sw.TriggerAt <= now.Unix()
+	default:
+		return false
+	}
+}
+
+// RequirementsMet reports whether every switch a switch requires has expired. Required switches that
+// no longer exist are ignored.
+func RequirementsMet(store database.Store, sw api.Switch, now time.Time) (bool, error) {
+	if sw.Requires == nil {
+		return true, nil
+	}
+
+	owner := database.AdminUser
+	if sw.UserId != nil {
+		owner = *sw.UserId
+	}
+
+	for _, id := range *sw.Requires {
+		required, err := store.GetByID(owner, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			return false, err
+		}
+
+		if !Expired(required, now) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// Arm starts a fresh countdown on a switch that isn't already counting down.
+func Arm(sw *api.Switch, now time.Time) error {
+	statusActive := api.SwitchStatusActive
+	sw.Status = &statusActive
+
+	sw.PausedAt = nil
+	sw.PausedUntil = nil
+	sw.ResumePolicy = nil
+
+	return NextQuorumInterval(sw, now)
+}
@@ -0,0 +1,59 @@
+package switches
+
+import (
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/database"
+)
+
+func TestRequirementsMet(t *testing.T) {
+	store, err := database.NewSQLiteStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+
+	err = store.Init()
+	if err != nil {
+		t.Fatalf("failed to init db: %v", err)
+	}
+
+	now := time.Now()
+	future := now.Add(time.Hour).Unix()
+	statusActive := api.SwitchStatusActive
+	statusTriggered := api.SwitchStatusTriggered
+
+	running, err := store.Create(api.Switch{Message: "running", Notifiers: []string{"logger://"}, CheckInInterval: "1h", Status: &statusActive, TriggerAt: &future})
+	if err != nil {
+		t.Fatalf("failed to seed switch: %v", err)
+	}
+
+	fired, err := store.Create(api.Switch{Message: "fired", Notifiers: []string{"logger://"}, CheckInInterval: "1h", Status: &statusTriggered})
+	if err != nil {
+		t.Fatalf("failed to seed switch: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		requires *[]int
+		expected bool
+	}{
+		{name: "no requirements", requires: nil, expected: true},
+		{name: "required switch fired", requires: &[]int{*fired.Id}, expected: true},
+		{name: "required switch still running", requires: &[]int{*fired.Id, *running.Id}, expected: false},
+		{name: "deleted switches are ignored", requires: &[]int{9999}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			met, err := RequirementsMet(store, api.Switch{Requires: tt.requires}, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if met != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, met)
+			}
+		})
+	}
+}
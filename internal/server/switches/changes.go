@@ -0,0 +1,162 @@
+package switches
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/database"
+	"github.com/circa10a/dead-mans-switch/internal/server/events"
+)
+
+// defaultChangeDelay is how long changes to a protected switch without approvers wait before they apply.
+const defaultChangeDelay = 72 * time.Hour
+
+var errUnknownChangeAction = errors.New("unknown change action")
+
+// IsProtected reports whether sensitive changes to a switch wait for approval or a delay.
+func IsProtected(sw api.Switch) bool {
+	return sw.Protected != nil && *sw.Protected
+}
+
+// ChangeDelay returns how long a change to a protected switch without approvers waits before it applies.
+func ChangeDelay(sw api.Switch) time.Duration {
+	return durationOrDefault(sw.ChangeDelay, defaultChangeDelay)
+}
+
+// ApplyChange makes a pending change to a protected switch take effect.
+func ApplyChange(store database.Store, change database.PendingChange) error {
+	sw, err := store.GetByID(change.RequestedBy, change.SwitchId)
+	if err != nil {
+		return err
+	}
+
+	err = store.DecryptSwitch(&sw)
+	if err != nil {
+		return err
+	}
+
+	switch change.Action {
+	case api.PendingChangeActionDelete:
+		return store.Delete(change.RequestedBy, change.SwitchId)
+	case api.PendingChangeActionDisable:
+		statusDisabled := api.SwitchStatusDisabled
+		sw.Status = &statusDisabled
+	case api.PendingChangeActionPause:
+		paused, err := pendingPause(change, &sw)
+		if err != nil || !paused {
+			return err
+		}
+	case api.PendingChangeActionUpdate:
+		sw, err = pendingUpdate(store, change, sw)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: %s", errUnknownChangeAction, change.Action)
+	}
+
+	_, err = store.Update(change.SwitchId, sw)
+	return err
+}
+
+// PublishChange publishes the lifecycle event of an applied change to a switch.
+func PublishChange(bus *events.Bus, change database.PendingChange) {
+	sw := api.Switch{Id: &change.SwitchId, UserId: &change.RequestedBy}
+
+	switch change.Action {
+	case api.PendingChangeActionDelete:
+		bus.Publish(api.EventTypeDeleted, sw)
+	case api.PendingChangeActionDisable:
+		statusDisabled := api.SwitchStatusDisabled
+		sw.Status = &statusDisabled
+		bus.Publish(api.EventTypeDisabled, sw)
+	case api.PendingChangeActionPause:
+		statusPaused := api.SwitchStatusPaused
+		sw.Status = &statusPaused
+		bus.Publish(api.EventTypePaused, sw)
+	case api.PendingChangeActionUpdate:
+		bus.Publish(api.EventTypeUpdated, sw)
+	}
+}
+
+// pendingPause pauses a switch as requested by a pending pause, reporting whether it was paused. A switch
+// that stopped running or a pause that ended while the change waited leaves nothing to pause.
+func pendingPause(change database.PendingChange, sw *api.Switch) (bool, error) {
+	if change.Payload == nil {
+		return false, errors.New("pending pause has no payload")
+	}
+
+	req := api.PauseRequest{}
+
+	err := json.Unmarshal([]byte(*change.Payload), &req)
+	if err != nil {
+		return false, err
+	}
+
+	if req.Until == nil {
+		return false, errors.New("pending pause has no end")
+	}
+
+	now := time.Now()
+	until := time.Unix(*req.Until, 0)
+
+	if !Pausable(*sw) || !until.After(now) {
+		return false, nil
+	}
+
+	policy := api.SwitchResumePolicyPreserve
+	if req.ResumePolicy != nil && *req.ResumePolicy == api.PauseRequestResumePolicyReset {
+		policy = api.SwitchResumePolicyReset
+	}
+
+	Pause(sw, now, until, policy)
+
+	return true, nil
+}
+
+// pendingUpdate returns the switch a pending update writes. The runtime state of the switch, such as its
+// countdown and pause, is kept from the current switch since it may have changed while the update waited.
+func pendingUpdate(store database.Store, change database.PendingChange, current api.Switch) (api.Switch, error) {
+	if change.Payload == nil {
+		return api.Switch{}, errors.New("pending update has no payload")
+	}
+
+	updated := api.Switch{}
+
+	err := json.Unmarshal([]byte(*change.Payload), &updated)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
+	err = store.DecryptSwitch(&updated)
+	if err != nil {
+		return api.Switch{}, err
+	}
+
+	updated.TriggerAt = current.TriggerAt
+	updated.LastCheckInAt = current.LastCheckInAt
+	updated.ReminderSent = current.ReminderSent
+	updated.PausedAt = current.PausedAt
+	updated.PausedUntil = current.PausedUntil
+	updated.ResumePolicy = current.ResumePolicy
+	updated.FailureReason = current.FailureReason
+	updated.TriggerCount = current.TriggerCount
+
+	if updated.Status == nil || *updated.Status != api.SwitchStatusDisabled {
+		updated.Status = current.Status
+	}
+
+	if updated.CheckInInterval != current.CheckInInterval {
+		duration, err := time.ParseDuration(updated.CheckInInterval)
+		if err != nil {
+			return api.Switch{}, fmt.Errorf("%w: %w", ErrInvalidInterval, err)
+		}
+		triggerAt := time.Now().Add(duration).Unix()
+		updated.TriggerAt = &triggerAt
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,42 @@
+package switches
+
+import (
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+// Trusted contact defaults used when a switch doesn't set its own
+const (
+	defaultVerificationWindow = 24 * time.Hour
+	defaultMaxPostpone        = 72 * time.Hour
+)
+
+// HasTrustedContacts reports whether a switch is verified by trusted contacts before it is released.
+func HasTrustedContacts(sw api.Switch) bool {
+	return sw.TrustedContacts != nil && len(*sw.TrustedContacts) > 0
+}
+
+// VerificationWindow returns how long trusted contacts have to respond before an expired switch is released.
+func VerificationWindow(sw api.Switch) time.Duration {
+	return durationOrDefault(sw.VerificationWindow, defaultVerificationWindow)
+}
+
+// MaxPostpone returns the longest a trusted contact can postpone the release of a switch.
+func MaxPostpone(sw api.Switch) time.Duration {
+	return durationOrDefault(sw.MaxPostpone, defaultMaxPostpone)
+}
+
+// durationOrDefault parses an optional duration, falling back when it is unset or invalid.
+func durationOrDefault(value *string, fallback time.Duration) time.Duration {
+	if value == nil || *value == "" {
+		return fallback
+	}
+
+	d, err := time.ParseDuration(*value)
+	if err != nil || d <= 0 {
+		return fallback
+	}
+
+	return d
+}
@@ -0,0 +1,61 @@
+package switches
+
+import (
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+// Pause marks a switch as paused until the given time. Pausing an already paused switch
+// keeps the original pause start so the preserved remaining time is not lost.
+func Pause(sw *api.Switch, now, until time.Time, policy api.SwitchResumePolicy) {
+	if sw.Status == nil || *sw.Status != api.SwitchStatusPaused || sw.PausedAt == nil {
+		pausedAt := now.Unix()
+		sw.PausedAt = &pausedAt
+	}
+
+	pausedUntil := until.Unix()
+	sw.PausedUntil = &pausedUntil
+	sw.ResumePolicy = &policy
+
+	statusPaused := api.SwitchStatusPaused
+	sw.Status = &statusPaused
+}
+
+// Pausable reports whether a switch is in a state that can be paused.
+func Pausable(sw api.Switch) bool {
+	return sw.Status != nil && (*sw.Status == api.SwitchStatusActive || *sw.Status == api.SwitchStatusPaused)
+}
+
+// Resume re-arms a paused switch according to its resume policy.
+func Resume(sw *api.Switch, now time.Time) error {
+	var triggerAt int64
+
+	if sw.ResumePolicy != nil && *sw.ResumePolicy == api.SwitchResumePolicyReset {
+		duration, err := time.ParseDuration(sw.CheckInInterval)
+		if err != nil {
+			return err
+		}
+		triggerAt = now.Add(duration).Unix()
+	} else {
+		// Preserve the time that was left on the countdown when the pause started
+		var remaining int64
+		if sw.TriggerAt != nil && sw.PausedAt != nil {
+			remaining = max(*sw.TriggerAt-*sw.PausedAt, 0)
+		}
+		triggerAt = now.Unix() + remaining
+	}
+
+	sw.TriggerAt = &triggerAt
+	sw.PausedAt = nil
+	sw.PausedUntil = nil
+	sw.ResumePolicy = nil
+
+	reminderSent := false
+	sw.ReminderSent = &reminderSent
+
+	statusActive := api.SwitchStatusActive
+	sw.Status = &statusActive
+
+	return nil
+}
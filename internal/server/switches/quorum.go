@@ -0,0 +1,92 @@
+// Package switches holds the state transitions of switches shared by the API handlers and the background worker,
+// such as pausing, re-arming, quorums and applying approved changes.
+package switches
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+// ErrInvalidInterval is returned when a switch's check-in interval can't be parsed.
+var ErrInvalidInterval = errors.New("invalid check-in interval")
+
+// IsQuorumSwitch reports whether a switch is kept armed by its members' check-ins rather than its owner's.
+func IsQuorumSwitch(sw api.Switch) bool {
+	return sw.Members != nil && len(*sw.Members) > 0
+}
+
+// QuorumMet reports whether enough members checked in during the interval ending at the switch's trigger time.
+func QuorumMet(sw api.Switch) (bool, error) {
+	pending, err := PendingMembers(sw)
+	if err != nil {
+		return false, err
+	}
+
+	checkedIn := len(*sw.Members) - len(pending)
+
+	return checkedIn >= quorumSize(sw), nil
+}
+
+// PendingMembers returns the members who haven't checked in during the current interval.
+func PendingMembers(sw api.Switch) ([]api.SwitchMember, error) {
+	if !IsQuorumSwitch(sw) {
+		return nil, nil
+	}
+
+	start, err := IntervalStart(sw)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := []api.SwitchMember{}
+	for _, member := range *sw.Members {
+		if member.LastCheckInAt == nil || *member.LastCheckInAt < start {
+			pending = append(pending, member)
+		}
+	}
+
+	return pending, nil
+}
+
+// NextQuorumInterval re-arms a quorum switch whose members met the quorum, or any switch that re-arms after
+// triggering, starting a new interval from now.
+func NextQuorumInterval(sw *api.Switch, now time.Time) error {
+	duration, err := time.ParseDuration(sw.CheckInInterval)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidInterval, err)
+	}
+
+	triggerAt := now.Add(duration).Unix()
+	sw.TriggerAt = &triggerAt
+
+	reminderSent := false
+	sw.ReminderSent = &reminderSent
+
+	return nil
+}
+
+// IntervalStart returns the Unix time at which the switch's current check-in interval began.
+func IntervalStart(sw api.Switch) (int64, error) {
+	if sw.TriggerAt == nil {
+		return 0, errors.New("triggerAt should not be nil")
+	}
+
+	duration, err := time.ParseDuration(sw.CheckInInterval)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidInterval, err)
+	}
+
+	return *sw.TriggerAt - int64(duration.Seconds()), nil
+}
+
+// quorumSize returns how many members must check in each interval, defaulting to every member.
+func quorumSize(sw api.Switch) int {
+	if sw.Quorum != nil {
+		return *sw.Quorum
+	}
+
+	return len(*sw.Members)
+}
@@ -0,0 +1,64 @@
+package switches
+
+import (
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+func TestQuorumMet(t *testing.T) {
+	now := time.Now()
+	triggerAt := now.Add(time.Hour).Unix()
+	inInterval := now.Add(-time.Hour).Unix()
+	beforeInterval := now.Add(-48 * time.Hour).Unix()
+
+	members := []api.SwitchMember{
+		{UserId: "alice", LastCheckInAt: &inInterval},
+		{UserId: "bob", LastCheckInAt: &beforeInterval},
+		{UserId: "carol"},
+	}
+
+	tests := []struct {
+		name     string
+		quorum   *int
+		expected bool
+	}{
+		{name: "defaults to every member", quorum: nil, expected: false},
+		{name: "one of three", quorum: ptr(1), expected: true},
+		{name: "two of three", quorum: ptr(2), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sw := api.Switch{
+				CheckInInterval: "24h",
+				TriggerAt:       &triggerAt,
+				Members:         &members,
+				Quorum:          tt.quorum,
+			}
+
+			met, err := QuorumMet(sw)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if met != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, met)
+			}
+		})
+	}
+
+	t.Run("pending members are those without a check-in this interval", func(t *testing.T) {
+		pending, err := PendingMembers(api.Switch{CheckInInterval: "24h", TriggerAt: &triggerAt, Members: &members})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(pending) != 2 || pending[0].UserId != "bob" || pending[1].UserId != "carol" {
+			t.Errorf("expected bob and carol to be pending, got %+v", pending)
+		}
+	})
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/caddyserver/certmagic"
+)
+
+// loadClientCAs reads the PEM bundle of CAs client certificates are verified against.
+func loadClientCAs(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in client CA %s", path)
+	}
+
+	return pool, nil
+}
+
+// withClientCerts makes a TLS listener request client certificates and verify those sent against the client
+// CA. Clients without a certificate are still accepted, since browsers sign in with a token instead.
+func (s *Server) withClientCerts(tlsConfig *tls.Config) *tls.Config {
+	if s.clientCAs != nil {
+		tlsConfig.ClientCAs = s.clientCAs
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	return tlsConfig
+}
+
+// startAutoTLS serves HTTPS with certificates from Let's Encrypt like certmagic.HTTPS, with a TLS config
+// that requests client certificates.
+func (s *Server) startAutoTLS() error {
+	magic := certmagic.NewDefault()
+
+	err := magic.ManageSync(s.ctx, s.Domains)
+	if err != nil {
+		return err
+	}
+
+	tlsConfig := s.withClientCerts(magic.TLSConfig())
+	tlsConfig.NextProtos = append([]string{"h2", "http/1.1"}, tlsConfig.NextProtos...)
+
+	httpsListener, err := tls.Listen("tcp", fmt.Sprintf(":%d", certmagic.HTTPSPort), tlsConfig)
+	if err != nil {
+		return err
+	}
+	defer httpsListener.Close()
+
+	// Port 80 answers HTTP challenges and redirects everything else to HTTPS
+	redirect := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host = r.Host
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+	})
+
+	var httpHandler http.Handler = redirect
+	if acme, ok := magic.Issuers[0].(*certmagic.ACMEIssuer); ok {
+		httpHandler = acme.HTTPChallengeHandler(redirect)
+	}
+
+	httpServer := &http.Server{
+		Addr:              fmt.Sprintf(":%d", certmagic.HTTPPort),
+		Handler:           httpHandler,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       5 * time.Second,
+		WriteTimeout:      5 * time.Second,
+		IdleTimeout:       5 * time.Second,
+	}
+
+	go func() {
+		err := httpServer.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("HTTP challenge server stopped", "error", err)
+		}
+	}()
+	defer httpServer.Close()
+
+	httpsServer := &http.Server{
+		Handler:           s.mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      2 * time.Minute,
+		IdleTimeout:       5 * time.Minute,
+	}
+
+	return httpsServer.Serve(httpsListener)
+}
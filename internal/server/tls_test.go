@@ -0,0 +1,160 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+	"github.com/circa10a/dead-mans-switch/internal/server/middleware"
+)
+
+// newTestCA returns a self-signed CA and writes its certificate to a PEM file.
+func newTestCA(t *testing.T, dir string) (*x509.Certificate, *ecdsa.PrivateKey, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Devices CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "ca.pem")
+	err = os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert, key, path
+}
+
+// newTestClientCert returns a client certificate for the common name signed by the CA.
+func newTestClientCert(t *testing.T, ca *x509.Certificate, caKey *ecdsa.PrivateKey, commonName string) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestClientCertAuth(t *testing.T) {
+	tmpDir := t.TempDir()
+	ca, caKey, caPath := newTestCA(t, tmpDir)
+
+	s, err := New(&Config{
+		AuthMode:     api.AuthModeLocal,
+		AuthClientCA: caPath,
+		AuthClientCertRules: []middleware.CertRule{
+			{Field: middleware.CertFieldCommonName, Match: "device-(.+)", User: "$1", Scopes: []api.TokenScope{api.TokenScopeRead}},
+		},
+		DataDir:    tmpDir,
+		Validation: false,
+	})
+	if err != nil {
+		t.Fatalf("received unexpected err: %s", err.Error())
+	}
+
+	ts := httptest.NewUnstartedServer(s.mux)
+	ts.TLS = s.withClientCerts(&tls.Config{})
+	ts.StartTLS()
+	defer ts.Close()
+
+	get := func(certs ...tls.Certificate) (int, error) {
+		transport := ts.Client().Transport.(*http.Transport).Clone()
+		transport.TLSClientConfig.Certificates = certs
+
+		resp, err := (&http.Client{Transport: transport}).Get(ts.URL + "/api/v1/switch")
+		if err != nil {
+			return 0, err
+		}
+		defer resp.Body.Close()
+
+		return resp.StatusCode, nil
+	}
+
+	t.Run("devices sign in with a certificate from the CA", func(t *testing.T) {
+		code, err := get(newTestClientCert(t, ca, caKey, "device-garage-pi"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != http.StatusOK {
+			t.Errorf("expected 200, got %d", code)
+		}
+	})
+
+	t.Run("certificates no rule maps are rejected", func(t *testing.T) {
+		code, err := get(newTestClientCert(t, ca, caKey, "laptop"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", code)
+		}
+	})
+
+	t.Run("clients without a certificate still connect", func(t *testing.T) {
+		code, err := get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", code)
+		}
+	})
+
+	t.Run("certificates from other CAs fail the handshake", func(t *testing.T) {
+		otherCA, otherKey, _ := newTestCA(t, t.TempDir())
+
+		_, err := get(newTestClientCert(t, otherCA, otherKey, "device-garage-pi"))
+		if err == nil {
+			t.Error("expected the handshake to fail")
+		}
+	})
+}
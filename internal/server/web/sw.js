@@ -49,7 +49,7 @@ self.addEventListener('notificationclick', (event) => {
             // but we call it to move status from 'active' back to a full timer
             fetch(`/api/v1/switch/${switchId}/reset`, {
                 method: 'POST',
-                headers: { 'Content-Type': 'application/json' }
+                headers: { 'Content-Type': 'application/json', 'X-Dead-Mans-Switch-Client': 'push' }
                 // Note: We don't send the pushSubscription here because
                 // the SW doesn't have easy access to it, and the server
                 // should retain the existing one if not provided.
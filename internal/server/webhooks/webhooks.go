@@ -0,0 +1,360 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/circa10a/dead-mans-switch/api"
+)
+
+const (
+	// SignatureHeader carries the timestamp and HMAC-SHA256 signature of a request, as t=<unix>,v1=<hex>.
+	SignatureHeader = "X-Dead-Mans-Switch-Signature"
+	// EventTriggered is the event sent when a switch triggers.
+	EventTriggered = "switch.triggered"
+	// DefaultMaxAttempts is how many times a webhook is attempted when it doesn't set a limit.
+	DefaultMaxAttempts = 3
+	// DefaultRetryBackoff is the wait before the first retry when a webhook doesn't set one.
+	DefaultRetryBackoff = 2 * time.Second
+	// maxRetryBackoff caps the wait between attempts.
+	maxRetryBackoff = time.Minute
+	// requestTimeout bounds each attempt.
+	requestTimeout = 10 * time.Second
+)
+
+var (
+	errInvalidSignature = errors.New("invalid webhook signature")
+	errExpiredSignature = errors.New("webhook signature timestamp is outside the tolerance")
+)
+
+// sleep waits between attempts. Tests replace it to avoid waiting.
+var sleep = func(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Data is what a webhook body template is rendered with.
+type Data struct {
+	Event           string    `json:"event"`
+	SwitchID        int       `json:"switchId"`
+	UserID          string    `json:"userId"`
+	Message         string    `json:"message"`
+	Labels          []string  `json:"labels"`
+	CheckInInterval string    `json:"checkInInterval"`
+	LastCheckInAt   *int64    `json:"lastCheckInAt,omitempty"`
+	TriggeredAt     time.Time `json:"triggeredAt"`
+}
+
+// Result is the outcome of delivering a webhook.
+type Result struct {
+	Attempts   int
+	StatusCode int
+	Err        error
+	Duration   time.Duration
+}
+
+// NewData describes a switch event for a webhook body.
+func NewData(event string, sw api.Switch, now time.Time) Data {
+	data := Data{
+		Event:           event,
+		Message:         sw.Message,
+		Labels:          []string{},
+		CheckInInterval: sw.CheckInInterval,
+		LastCheckInAt:   sw.LastCheckInAt,
+		TriggeredAt:     now.UTC(),
+	}
+
+	if sw.Id != nil {
+		data.SwitchID = *sw.Id
+	}
+	if sw.UserId != nil {
+		data.UserID = *sw.UserId
+	}
+	if sw.Labels != nil {
+		data.Labels = *sw.Labels
+	}
+
+	return data
+}
+
+// Validate checks that a webhook's body template renders to JSON, its retry backoff is a positive duration
+// and its client certificate and key belong together.
+func Validate(hook api.Webhook) error {
+	if hook.Body != nil && *hook.Body != "" {
+		_, err := Render(*hook.Body, NewData(EventTriggered, api.Switch{Message: "test"}, time.Now()))
+		if err != nil {
+			return err
+		}
+	}
+
+	if hook.RetryBackoff != nil && *hook.RetryBackoff != "" {
+		d, err := time.ParseDuration(*hook.RetryBackoff)
+		if err != nil || d <= 0 {
+			return errors.New("webhook retryBackoff must be a positive duration (e.g., 2s, 1m)")
+		}
+	}
+
+	if (hook.ClientCertificate == nil) != (hook.ClientKey == nil) {
+		return errors.New("webhook clientCertificate and clientKey must be set together")
+	}
+
+	if hook.ClientCertificate != nil {
+		_, err := tls.X509KeyPair([]byte(*hook.ClientCertificate), []byte(*hook.ClientKey))
+		if err != nil {
+			return fmt.Errorf("invalid webhook client certificate: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Render renders a body template and makes sure the result is JSON. An empty template renders the data itself.
+func Render(body string, data Data) ([]byte, error) {
+	if body == "" {
+		return json.Marshal(data)
+	}
+
+	tmpl, err := template.New("webhook").Funcs(template.FuncMap{
+		"json": func(v any) (string, error) {
+			out, err := json.Marshal(v)
+			return string(out), err
+		},
+	}).Option("missingkey=error").Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook body template: %w", err)
+	}
+
+	var buf bytes.Buffer
+
+	err = tmpl.Execute(&buf, data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook body template: %w", err)
+	}
+
+	if !json.Valid(buf.Bytes()) {
+		return nil, errors.New("webhook body template must render to JSON")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Sign returns the signature header value for a request body sent at the given time.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", ts, signature(secret, ts, body))
+}
+
+// Verify checks a signature header against a request body. Requests signed longer than tolerance before now,
+// or after it, are rejected to prevent replays.
+func Verify(secret, header string, body []byte, tolerance time.Duration, now time.Time) error {
+	var ts string
+	signatures := []string{}
+
+	for part := range strings.SplitSeq(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return errInvalidSignature
+	}
+
+	age := now.Sub(time.Unix(unix, 0))
+	if age > tolerance || age < -tolerance {
+		return errExpiredSignature
+	}
+
+	expected := signature(secret, ts, body)
+	for _, sig := range signatures {
+		if hmac.Equal([]byte(sig), []byte(expected)) {
+			return nil
+		}
+	}
+
+	return errInvalidSignature
+}
+
+// signature computes the hex HMAC-SHA256 of the timestamp and body, joined by a dot.
+func signature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Target identifies a webhook in delivery logs without the credentials or query string its URL may contain.
+func Target(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+
+	return u.String()
+}
+
+// Deliver renders a webhook's body template and sends it.
+func Deliver(ctx context.Context, hook api.Webhook, data Data) Result {
+	body, err := Render(deref(hook.Body), data)
+	if err != nil {
+		return Result{Err: err}
+	}
+
+	return Send(ctx, hook, body)
+}
+
+// Send sends a signed request with the given body, retrying network errors, rate limits and server errors
+// with an exponential backoff until it succeeds or runs out of attempts.
+func Send(ctx context.Context, hook api.Webhook, body []byte) Result {
+	start := time.Now()
+	result := Result{}
+
+	client, err := newClient(hook)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	maxAttempts := DefaultMaxAttempts
+	if hook.MaxAttempts != nil {
+		maxAttempts = *hook.MaxAttempts
+	}
+
+	backoff := DefaultRetryBackoff
+	if hook.RetryBackoff != nil && *hook.RetryBackoff != "" {
+		backoff, err = time.ParseDuration(*hook.RetryBackoff)
+		if err != nil {
+			result.Err = err
+			return result
+		}
+	}
+
+	for result.Attempts < maxAttempts {
+		if result.Attempts > 0 {
+			err = sleep(ctx, backoff)
+			if err != nil {
+				break
+			}
+			backoff = min(backoff*2, maxRetryBackoff)
+		}
+
+		result.Attempts++
+
+		var retry bool
+		result.StatusCode, retry, result.Err = send(ctx, client, hook, body)
+		if result.Err == nil || !retry {
+			break
+		}
+	}
+
+	result.Duration = time.Since(start)
+
+	return result
+}
+
+// send makes a single attempt and reports whether a failure is worth retrying.
+func send(ctx context.Context, client *http.Client, hook api.Webhook, body []byte) (int, bool, error) {
+	method := http.MethodPost
+	if hook.Method != nil {
+		method = string(*hook.Method)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, hook.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, false, err
+	}
+
+	if hook.Headers != nil {
+		for key, value := range *hook.Headers {
+			req.Header.Set(key, value)
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "dead-mans-switch")
+	if hook.Secret != nil {
+		req.Header.Set(SignatureHeader, Sign(*hook.Secret, time.Now(), body))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, true, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.StatusCode, false, nil
+	}
+
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+
+	return resp.StatusCode, retry, fmt.Errorf("webhook responded with %s", resp.Status)
+}
+
+// newClient returns an HTTP client that presents the webhook's client certificate, if it has one.
+func newClient(hook api.Webhook) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if hook.ClientCertificate != nil && hook.ClientKey != nil {
+		cert, err := tls.X509KeyPair([]byte(*hook.ClientCertificate), []byte(*hook.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook client certificate: %w", err)
+		}
+
+		transport.TLSClientConfig = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		}
+	}
+
+	return &http.Client{
+		Transport: transport,
+		// Redirects could send the signed body somewhere the owner didn't choose
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}, nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -8,14 +9,24 @@ import (
 	"io"
 	"log/slog"
 	"strings"
+	"sync"
+	"text/template"
 	"time"
 
 	"github.com/SherClockHolmes/webpush-go"
 	"github.com/circa10a/dead-mans-switch/api"
 	"github.com/circa10a/dead-mans-switch/internal/server/database"
+	"github.com/circa10a/dead-mans-switch/internal/server/events"
+	"github.com/circa10a/dead-mans-switch/internal/server/hooks"
+	"github.com/circa10a/dead-mans-switch/internal/server/secrets"
+	"github.com/circa10a/dead-mans-switch/internal/server/switches"
+	"github.com/circa10a/dead-mans-switch/internal/server/webhooks"
 	"github.com/nicholas-fedor/shoutrrr"
 )
 
+// maxConcurrentHooks is how many switches can run their actions or send their webhooks at once.
+const maxConcurrentHooks = 8
+
 // worker periodically processes expired switches and sends notifications.
 type worker struct {
 	store           database.Store
@@ -25,6 +36,47 @@ type worker struct {
 	subscriberEmail string
 	vapidPrivateKey string
 	vapidPublicKey  string
+	// externalURL is the base URL trusted contacts are linked to.
+	externalURL string
+	// actions are the commands switches can run on the host when they trigger, by name.
+	actions map[string]hooks.Action
+	// events receives the lifecycle events of switches the worker processes.
+	events *events.Bus
+	// replyDomain is the domain of the email check-in addresses, if the server accepts email check-ins.
+	replyDomain string
+	// hooks runs the actions and webhooks of triggered switches off the sweep.
+	hooks *hookPool
+}
+
+// hookPool runs the actions and webhooks of triggered switches in the background, so a slow action or a
+// retrying webhook doesn't hold up the sweep. At most size of them run at once and the rest wait their turn.
+type hookPool struct {
+	ctx   context.Context
+	slots chan struct{}
+	wg    sync.WaitGroup
+}
+
+// newHookPool returns a pool whose actions and webhooks are cancelled along with ctx.
+func newHookPool(ctx context.Context, size int) *hookPool {
+	return &hookPool{
+		ctx:   ctx,
+		slots: make(chan struct{}, size),
+	}
+}
+
+// run starts fn once a slot is free without waiting for it.
+func (p *hookPool) run(fn func(ctx context.Context)) {
+	p.wg.Go(func() {
+		p.slots <- struct{}{}
+		defer func() { <-p.slots }()
+
+		fn(p.ctx)
+	})
+}
+
+// wait blocks until everything started in the pool has finished.
+func (p *hookPool) wait() {
+	p.wg.Wait()
 }
 
 // start begins the worker's processing loop.
@@ -38,6 +90,7 @@ func (w *worker) start(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			w.logger.Info("Stopping notification worker")
+			w.hooks.wait()
 			return
 		case <-ticker.C:
 			w.logger.Debug("Checking for expired switches")
@@ -49,6 +102,38 @@ func (w *worker) start(ctx context.Context) {
 
 // Sweep processes expired switches in batches.
 func (w *worker) sweep() {
+	// Delayed changes to protected switches
+	changes, err := w.store.GetDueChanges(w.batchSize)
+	if err != nil {
+		w.logger.Error("Failed to fetch due changes", "error", err)
+		return
+	}
+
+	w.logger.Debug("Fetched due changes", "count", len(changes))
+
+	for _, change := range changes {
+		err = w.processChange(change)
+		if err != nil {
+			w.logger.Error("Could not apply pending change", "error", err, "id", change.Id)
+		}
+	}
+
+	// Paused switches
+	resumable, err := w.store.GetResumable(w.batchSize)
+	if err != nil {
+		w.logger.Error("Failed to fetch resumable switches", "error", err)
+		return
+	}
+
+	w.logger.Debug("Fetched resumable switches", "count", len(resumable))
+
+	for _, sw := range resumable {
+		err = w.processResume(sw)
+		if err != nil {
+			w.logger.Error("Could not resume paused switch", "error", err, "id", sw.Id)
+		}
+	}
+
 	// Reminders
 	reminders, err := w.store.GetEligibleReminders(w.batchSize)
 	if err != nil {
@@ -74,8 +159,15 @@ func (w *worker) sweep() {
 
 	w.logger.Debug("Fetched expired switches", "count", len(expired))
 
+	// Switches already handled through a chain earlier in the sweep are skipped
+	processed := map[int]bool{}
+
 	for _, sw := range expired {
-		err = w.processExpiredSwitch(sw)
+		if processed[*sw.Id] {
+			continue
+		}
+
+		err = w.processExpiredSwitch(sw, processed)
 		if err != nil {
 			w.logger.Error("Could not process expired switch", "error", err, "id", sw.Id)
 		}
@@ -83,7 +175,75 @@ func (w *worker) sweep() {
 }
 
 // processExpiredSwitch sends notifications for expired switches.
-func (w *worker) processExpiredSwitch(sw api.Switch) error {
+// A quorum switch whose members met the quorum is re-armed for another interval instead, a switch
+// with trusted contacts is only released once their verification window ends, and a switch that
+// requires other switches waits until they have expired too. processed tracks the switches handled
+// in the current sweep so chains are followed at most once.
+func (w *worker) processExpiredSwitch(sw api.Switch, processed map[int]bool) error {
+	processed[*sw.Id] = true
+
+	if sw.Status != nil && *sw.Status == api.SwitchStatusMissing {
+		return w.processMissing(sw)
+	}
+
+	verifying := sw.Status != nil && *sw.Status == api.SwitchStatusVerifying
+
+	if switches.IsQuorumSwitch(sw) && !verifying {
+		met, err := switches.QuorumMet(sw)
+		if err != nil {
+			return err
+		}
+
+		if met {
+			w.logger.Debug("Quorum met, starting next interval", "id", *sw.Id)
+
+			// Meeting the quorum counts as a check-in
+			sw.TriggerCount = nil
+
+			err = switches.NextQuorumInterval(&sw, time.Now())
+			if err != nil {
+				return err
+			}
+
+			_, err = w.store.Update(*sw.Id, sw)
+			return err
+		}
+
+		w.logger.Info("Quorum not met", "id", *sw.Id)
+	}
+
+	if !verifying {
+		met, err := switches.RequirementsMet(w.store, sw, time.Now())
+		if err != nil {
+			return err
+		}
+
+		if !met {
+			return w.wait(sw, processed)
+		}
+	}
+
+	if switches.HasTrustedContacts(sw) && !verifying {
+		err := w.startVerification(sw)
+		if err != nil {
+			return err
+		}
+
+		w.processDependents(sw, processed)
+		return nil
+	}
+
+	if verifying {
+		w.logger.Info("Verification window ended, releasing switch", "id", *sw.Id)
+
+		err := w.store.DeleteContactTokens(*sw.Id)
+		if err != nil {
+			return err
+		}
+
+		w.audit(sw, api.AuditActionReleased, "")
+	}
+
 	w.logger.Info("Switch expired, sending final notifications", "id", *sw.Id)
 
 	// Send External Notifiers (Shoutrrr)
@@ -103,6 +263,8 @@ func (w *worker) processExpiredSwitch(sw api.Switch) error {
 				return err
 			}
 
+			w.events.Publish(api.EventTypeFailed, sw)
+
 			err = w.sendWebPush(sw, "Failed to trigger switch", failureMsg)
 			if err != nil {
 				return err
@@ -120,6 +282,52 @@ func (w *worker) processExpiredSwitch(sw api.Switch) error {
 		}
 	}
 
+	w.startHooks(sw, true)
+
+	// Published before the switch is re-armed or deleted, which still counts as triggering
+	statusTriggered := api.SwitchStatusTriggered
+	triggered := sw
+	triggered.Status = &statusTriggered
+	w.events.Publish(api.EventTypeTriggered, triggered)
+
+	err := w.completeTrigger(sw)
+	if err != nil {
+		return err
+	}
+
+	// Chained switches and switches waiting on this one are handled in the same sweep
+	w.processChain(sw, processed)
+	w.processDependents(sw, processed)
+
+	return nil
+}
+
+// completeTrigger moves a switch on after its notifications were sent. It is re-armed, deleted, or marked
+// as triggered, or as missing when it repeats still missing alerts.
+func (w *worker) completeTrigger(sw api.Switch) error {
+	now := time.Now()
+
+	triggerCount := 1
+	if sw.TriggerCount != nil {
+		triggerCount = *sw.TriggerCount + 1
+	}
+	sw.TriggerCount = &triggerCount
+
+	if shouldRearm(sw) {
+		w.logger.Info("Re-arming switch after triggering", "id", *sw.Id, "triggers", triggerCount)
+
+		statusActive := api.SwitchStatusActive
+		sw.Status = &statusActive
+
+		err := switches.NextQuorumInterval(&sw, now)
+		if err != nil {
+			return err
+		}
+
+		_, err = w.store.Update(*sw.Id, sw)
+		return err
+	}
+
 	if *sw.DeleteAfterTriggered {
 		w.logger.Debug("Auto-deleting switch after triggering", "id", *sw.Id)
 
@@ -131,17 +339,486 @@ func (w *worker) processExpiredSwitch(sw api.Switch) error {
 		if err != nil {
 			return err
 		}
+
+		sw.Status = nil
+		w.events.Publish(api.EventTypeDeleted, sw)
 		return nil
 	}
 
-	w.logger.Debug("Marking switch as triggered", "id", *sw.Id)
 	statusTriggered := api.SwitchStatusTriggered
 	sw.Status = &statusTriggered
+
+	// Keep alerting until someone checks in
+	repeat, ok := repeatInterval(sw)
+	if ok {
+		w.logger.Debug("Marking switch as missing", "id", *sw.Id, "repeat", repeat.String())
+
+		statusMissing := api.SwitchStatusMissing
+		sw.Status = &statusMissing
+
+		nextAlertAt := now.Add(repeat).Unix()
+		sw.TriggerAt = &nextAlertAt
+	} else {
+		w.logger.Debug("Marking switch as triggered", "id", *sw.Id)
+	}
+
+	_, err := w.store.Update(*sw.Id, sw)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// wait holds an expired switch until the switches it requires have expired too. Waiting switches are
+// processed again when one of the switches they require expires.
+func (w *worker) wait(sw api.Switch, processed map[int]bool) error {
+	if sw.Status != nil && *sw.Status == api.SwitchStatusWaiting {
+		return nil
+	}
+
+	w.logger.Info("Switch expired, waiting for the switches it requires", "id", *sw.Id)
+
+	statusWaiting := api.SwitchStatusWaiting
+	sw.Status = &statusWaiting
+
+	_, err := w.store.Update(*sw.Id, sw)
+	if err != nil {
+		return err
+	}
+
+	// A waiting switch counts as expired for the switches that require it
+	w.processDependents(sw, processed)
+
+	return nil
+}
+
+// processChain arms or triggers the switches a triggered switch is chained to. Triggering a switch expires
+// it immediately, as if its countdown ran out.
+func (w *worker) processChain(sw api.Switch, processed map[int]bool) {
+	if sw.OnTrigger == nil {
+		return
+	}
+
+	owner := database.AdminUser
+	if sw.UserId != nil {
+		owner = *sw.UserId
+	}
+
+	now := time.Now()
+
+	for _, action := range *sw.OnTrigger {
+		if processed[action.SwitchId] {
+			continue
+		}
+
+		target, err := w.store.GetByID(owner, action.SwitchId)
+		if err != nil {
+			w.logger.Error("Could not load chained switch", "id", *sw.Id, "target", action.SwitchId, "error", err)
+			continue
+		}
+
+		err = w.store.DecryptSwitch(&target)
+		if err != nil {
+			w.logger.Error("Could not load chained switch", "id", *sw.Id, "target", action.SwitchId, "error", err)
+			continue
+		}
+
+		status := api.SwitchStatusActive
+		if target.Status != nil {
+			status = *target.Status
+		}
+
+		switch action.Action {
+		case api.ChainActionArm:
+			// Only switches that aren't counting down or being handled are armed
+			if status != api.SwitchStatusDisabled && status != api.SwitchStatusFailed &&
+				status != api.SwitchStatusPaused && status != api.SwitchStatusTriggered {
+				continue
+			}
+
+			w.logger.Info("Arming chained switch", "id", *sw.Id, "target", action.SwitchId)
+
+			err = switches.Arm(&target, now)
+			if err == nil {
+				_, err = w.store.Update(action.SwitchId, target)
+			}
+		case api.ChainActionTrigger:
+			// Disabled switches and switches that already ran out of time are left alone
+			if status == api.SwitchStatusDisabled || switches.Expired(target, now) {
+				continue
+			}
+
+			w.logger.Info("Triggering chained switch", "id", *sw.Id, "target", action.SwitchId)
+
+			statusActive := api.SwitchStatusActive
+			target.Status = &statusActive
+
+			triggerAt := now.Unix()
+			target.TriggerAt = &triggerAt
+
+			err = w.processExpiredSwitch(target, processed)
+		}
+		if err != nil {
+			w.logger.Error("Could not process chained switch", "id", *sw.Id, "target", action.SwitchId, "error", err)
+		}
+	}
+}
+
+// processDependents processes the waiting switches that require a switch which has just expired.
+func (w *worker) processDependents(sw api.Switch, processed map[int]bool) {
+	dependents, err := w.store.GetWaitingDependents(*sw.Id)
+	if err != nil {
+		w.logger.Error("Failed to fetch waiting switches", "id", *sw.Id, "error", err)
+		return
+	}
+
+	for _, dependent := range dependents {
+		if processed[*dependent.Id] {
+			continue
+		}
+
+		err = w.processExpiredSwitch(dependent, processed)
+		if err != nil {
+			w.logger.Error("Could not process waiting switch", "id", *dependent.Id, "error", err)
+		}
+	}
+}
+
+// processMissing sends a still missing alert for a triggered switch that hasn't been checked in since,
+// and schedules the next one. Failed alerts are retried at the next repeat rather than failing the switch.
+func (w *worker) processMissing(sw api.Switch) error {
+	now := time.Now()
+
+	repeat, ok := repeatInterval(sw)
+	if !ok {
+		// The repeat interval was removed, so the switch simply stays triggered
+		statusTriggered := api.SwitchStatusTriggered
+		sw.Status = &statusTriggered
+
+		_, err := w.store.Update(*sw.Id, sw)
+		return err
+	}
+
+	w.logger.Info("Switch still missing, sending alerts", "id", *sw.Id)
+
+	message := "Still missing: " + w.renderMessage(sw, now)
+	err := w.sendMessage(sw.Notifiers, message)
+	if err != nil {
+		w.logger.Error("Failed to send still missing alerts", "id", *sw.Id, "error", err)
+	}
+
+	nextAlertAt := now.Add(repeat).Unix()
+	sw.TriggerAt = &nextAlertAt
+
+	_, err = w.store.Update(*sw.Id, sw)
+	return err
+}
+
+// shouldRearm reports whether a switch that just triggered starts a fresh interval, which it does until
+// it has triggered maxTriggers times in a row.
+func shouldRearm(sw api.Switch) bool {
+	if sw.Rearm == nil || !*sw.Rearm {
+		return false
+	}
+
+	return sw.MaxTriggers == nil || sw.TriggerCount == nil || *sw.TriggerCount < *sw.MaxTriggers
+}
+
+// repeatInterval returns how often a triggered switch sends still missing alerts, if it does.
+func repeatInterval(sw api.Switch) (time.Duration, bool) {
+	if sw.RepeatInterval == nil || *sw.RepeatInterval == "" {
+		return 0, false
+	}
+
+	repeat, err := time.ParseDuration(*sw.RepeatInterval)
+	if err != nil || repeat <= 0 {
+		return 0, false
+	}
+
+	return repeat, true
+}
+
+// startVerification asks a switch's trusted contacts to verify its release. Each contact gets a single use
+// link to postpone or confirm the release, which otherwise happens when the verification window ends.
+func (w *worker) startVerification(sw api.Switch) error {
+	now := time.Now()
+	contacts := *sw.TrustedContacts
+
+	statusVerifying := api.SwitchStatusVerifying
+	sw.Status = &statusVerifying
+
+	releaseAt := now.Add(switches.VerificationWindow(sw)).Unix()
+	sw.TriggerAt = &releaseAt
+
 	_, err := w.store.Update(*sw.Id, sw)
 	if err != nil {
 		return err
 	}
 
+	w.logger.Info("Switch expired, asking trusted contacts to verify", "id", *sw.Id, "contacts", len(contacts))
+
+	owner := database.AdminUser
+	if sw.UserId != nil {
+		owner = *sw.UserId
+	}
+
+	// Links stay valid for as long as every contact could keep postponing the release
+	expiresAt := now.Add(switches.VerificationWindow(sw) + time.Duration(len(contacts))*switches.MaxPostpone(sw)).Unix()
+
+	var errs []error
+
+	for _, contact := range contacts {
+		token, hash, err := secrets.NewToken()
+		if err != nil {
+			return err
+		}
+
+		err = w.store.CreateContactToken(database.ContactToken{
+			TokenHash:   hash,
+			SwitchID:    *sw.Id,
+			UserID:      owner,
+			ContactName: contact.Name,
+			ExpiresAt:   expiresAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		message := fmt.Sprintf("%s's dead man's switch has expired and will be released at %s unless it is postponed. Verify it here: %s/contact.html#%s",
+			owner, time.Unix(releaseAt, 0).UTC().Format(time.RFC1123), strings.TrimSuffix(w.externalURL, "/"), token)
+
+		err = shoutrrr.Send(*contact.Notifier, message)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("verification request failed for contact %s: %w", contact.Name, err))
+		}
+	}
+
+	w.audit(sw, api.AuditActionVerificationStarted, fmt.Sprintf("Asked %d trusted contacts to verify", len(contacts)))
+
+	// A contact who can't be reached doesn't stop the others from verifying
+	err = errors.Join(errs...)
+	if err != nil {
+		w.logger.Error("Failed to reach trusted contacts", "id", *sw.Id, "error", err)
+	}
+
+	return nil
+}
+
+// audit records a system event in a switch's audit trail.
+func (w *worker) audit(sw api.Switch, action api.AuditEventAction, detail string) {
+	owner := database.AdminUser
+	if sw.UserId != nil {
+		owner = *sw.UserId
+	}
+
+	event := api.AuditEvent{
+		SwitchId:  *sw.Id,
+		CreatedAt: time.Now().Unix(),
+		Actor:     "system",
+		Action:    action,
+	}
+	if detail != "" {
+		event.Detail = &detail
+	}
+
+	_, err := w.store.CreateAuditEvent(owner, event)
+	if err != nil {
+		w.logger.Error("Failed to record audit event", "error", err, "id", *sw.Id, "action", action)
+	}
+}
+
+// processChange applies a change to a protected switch once its delay has passed without it being cancelled.
+// A change that fails to apply is set back to pending so the next sweep tries it again.
+func (w *worker) processChange(change database.PendingChange) error {
+	err := w.store.ResolvePendingChange(*change.Id, api.PendingChangeStatusApplied, "system", time.Now().Unix())
+	if err != nil {
+		return err
+	}
+
+	w.logger.Info("Applying delayed change", "id", change.SwitchId, "change", *change.Id, "action", change.Action)
+
+	err = switches.ApplyChange(w.store, change)
+	if err != nil {
+		return errors.Join(err, w.store.ReopenPendingChange(*change.Id))
+	}
+
+	switches.PublishChange(w.events, change)
+
+	// A deleted switch takes its audit trail with it
+	if change.Action != api.PendingChangeActionDelete {
+		w.audit(api.Switch{Id: &change.SwitchId, UserId: &change.RequestedBy}, api.AuditActionChangeApplied, change.Summary)
+	}
+
+	return nil
+}
+
+// notifyChangeRequested warns the recipients of a protected switch that a change to it was requested,
+// giving them until the change applies to raise the alarm.
+func (w *worker) notifyChangeRequested(sw api.Switch, change api.PendingChange) {
+	message := fmt.Sprintf("A change to a dead man's switch of %s was requested: %s. It applies at %s unless it is cancelled.",
+		change.RequestedBy, change.Summary, time.Unix(*change.ApplyAt, 0).UTC().Format(time.RFC1123))
+
+	for _, notifier := range sw.Notifiers {
+		err := shoutrrr.Send(notifier, message)
+		if err != nil {
+			w.logger.Error("Failed to notify recipient of requested change", "id", *sw.Id, "error", err)
+		}
+	}
+}
+
+// processDuress silently fires a switch that was checked in with its duress code. The switch record is
+// left untouched and the owner is not sent a push so nothing reveals the duress check-in.
+func (w *worker) processDuress(sw api.Switch) {
+	err := w.store.DecryptSwitch(&sw)
+	if err != nil {
+		w.logger.Error("Could not process switch", "error", err, "id", sw.Id)
+		return
+	}
+
+	if sw.DuressNotifiers != nil && len(*sw.DuressNotifiers) > 0 {
+		sw.Notifiers = *sw.DuressNotifiers
+	}
+
+	err = w.sendNotifiers(sw)
+	if err != nil {
+		w.logger.Error("Failed to send notifications", "id", *sw.Id, "error", err)
+	}
+
+	// The owner can see deliveries, so a duress run leaves no record of them
+	w.startHooks(sw, false)
+}
+
+// startHooks runs the actions and webhooks of a triggered switch in the background, recording each result
+// as a delivery when record is set.
+func (w *worker) startHooks(sw api.Switch, record bool) {
+	if sw.Actions != nil {
+		w.hooks.run(func(ctx context.Context) {
+			w.runActions(ctx, sw, record)
+		})
+	}
+
+	if sw.Webhooks != nil {
+		w.hooks.run(func(ctx context.Context) {
+			w.runWebhooks(ctx, sw, record)
+		})
+	}
+}
+
+// runActions runs the host actions of a triggered switch one after another, recording each result as a
+// delivery when record is set. Failed actions don't stop the others or fail the switch.
+func (w *worker) runActions(ctx context.Context, sw api.Switch, record bool) {
+	owner := database.AdminUser
+	if sw.UserId != nil {
+		owner = *sw.UserId
+	}
+
+	for _, name := range *sw.Actions {
+		delivery := api.Delivery{
+			SwitchId: *sw.Id,
+			Kind:     api.DeliveryKindAction,
+			Target:   name,
+		}
+
+		action, ok := w.actions[name]
+		if ok {
+			w.logger.Info("Running action", "id", *sw.Id, "action", name)
+
+			result := hooks.Run(ctx, action, hooks.NewEvent(name, sw, time.Now()))
+
+			duration := result.Duration.Milliseconds()
+			delivery.Success = result.Err == nil
+			delivery.ExitCode = &result.ExitCode
+			delivery.Output = &result.Output
+			delivery.Duration = &duration
+			if result.Err != nil {
+				reason := result.Err.Error()
+				delivery.Error = &reason
+			}
+		} else {
+			// The action was removed from the server configuration after the switch was saved
+			reason := "action is not configured on the server"
+			delivery.Error = &reason
+		}
+
+		if !delivery.Success {
+			w.logger.Error("Action failed", "id", *sw.Id, "action", name, "error", *delivery.Error)
+		}
+
+		if !record {
+			continue
+		}
+
+		delivery.CreatedAt = time.Now().Unix()
+
+		_, err := w.store.CreateDelivery(owner, delivery)
+		if err != nil {
+			w.logger.Error("Failed to record delivery", "id", *sw.Id, "action", name, "error", err)
+		}
+	}
+}
+
+// runWebhooks sends the webhooks of a triggered switch one after another, recording each result as a
+// delivery when record is set. Failed webhooks don't stop the others or fail the switch.
+func (w *worker) runWebhooks(ctx context.Context, sw api.Switch, record bool) {
+	owner := database.AdminUser
+	if sw.UserId != nil {
+		owner = *sw.UserId
+	}
+
+	for _, hook := range *sw.Webhooks {
+		target := webhooks.Target(hook.Url)
+		w.logger.Info("Sending webhook", "id", *sw.Id, "webhook", target)
+
+		result := webhooks.Deliver(ctx, hook, webhooks.NewData(webhooks.EventTriggered, sw, time.Now()))
+
+		duration := result.Duration.Milliseconds()
+		delivery := api.Delivery{
+			SwitchId: *sw.Id,
+			Kind:     api.DeliveryKindWebhook,
+			Target:   target,
+			Success:  result.Err == nil,
+			Attempts: &result.Attempts,
+			Duration: &duration,
+		}
+		if result.StatusCode != 0 {
+			delivery.StatusCode = &result.StatusCode
+		}
+		if result.Err != nil {
+			reason := result.Err.Error()
+			delivery.Error = &reason
+			w.logger.Error("Webhook failed", "id", *sw.Id, "webhook", target, "attempts", result.Attempts, "error", reason)
+		}
+
+		if !record {
+			continue
+		}
+
+		delivery.CreatedAt = time.Now().Unix()
+
+		_, err := w.store.CreateDelivery(owner, delivery)
+		if err != nil {
+			w.logger.Error("Failed to record delivery", "id", *sw.Id, "webhook", target, "error", err)
+		}
+	}
+}
+
+// processResume re-arms a switch whose pause has ended.
+func (w *worker) processResume(sw api.Switch) error {
+	w.logger.Info("Pause ended, resuming switch", "id", *sw.Id)
+
+	err := switches.Resume(&sw, time.Now())
+	if err != nil {
+		return err
+	}
+
+	resumed, err := w.store.Update(*sw.Id, sw)
+	if err != nil {
+		return err
+	}
+
+	w.events.Publish(api.EventTypeResumed, resumed)
+
 	return nil
 }
 
@@ -184,7 +861,11 @@ func (w *worker) processReminder(sw api.Switch) error {
 		title := "Expiring Soon"
 		body := fmt.Sprintf("Your switch will trigger in %s. Time to check in.", remainingStr)
 
-		err := w.sendWebPush(sw, title, body)
+		if switches.IsQuorumSwitch(sw) {
+			err = w.sendMemberReminders(sw, title, body)
+		} else {
+			err = w.sendWebPush(sw, title, body)
+		}
 		if err != nil {
 			return err
 		}
@@ -195,24 +876,97 @@ func (w *worker) processReminder(sw api.Switch) error {
 		sw.ReminderSent = &v
 
 		_, reminderSentErr := w.store.Update(*sw.Id, sw)
-		return reminderSentErr
+		if reminderSentErr != nil {
+			return reminderSentErr
+		}
+
+		w.events.Publish(api.EventTypeReminderSent, sw)
+		return nil
 	}
 
 	return nil
 }
 
+// sendMemberReminders reminds the members of a quorum switch who haven't checked in yet. The owner is
+// reminded through their push subscription when they are one of those members.
+func (w *worker) sendMemberReminders(sw api.Switch, title, body string) error {
+	pending, err := switches.PendingMembers(sw)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, member := range pending {
+		if sw.UserId != nil && member.UserId == *sw.UserId {
+			err := w.sendWebPush(sw, title, body)
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		if member.Notifier == nil || *member.Notifier == "" {
+			continue
+		}
+
+		w.logger.Debug("Sending member reminder", "id", *sw.Id, "member", member.UserId)
+
+		instructions, err := w.replyInstructions(sw, member.UserId, *member.Notifier)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to create reply token for member %s: %w", member.UserId, err))
+		}
+
+		err = shoutrrr.Send(*member.Notifier, fmt.Sprintf("%s: %s%s", title, body, instructions))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("reminder failed for member %s: %w", member.UserId, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// replyInstructions tells a member reminded by email how to check in by replying, with a token that
+// checks in as them until the switch triggers. Other notifiers get no instructions.
+func (w *worker) replyInstructions(sw api.Switch, userID, notifier string) (string, error) {
+	if w.replyDomain == "" || !strings.HasPrefix(notifier, "smtp://") || sw.TriggerAt == nil {
+		return "", nil
+	}
+
+	token, hash, err := secrets.NewToken()
+	if err != nil {
+		return "", err
+	}
+
+	err = w.store.CreateReplyToken(database.ReplyToken{
+		TokenHash: hash,
+		SwitchID:  *sw.Id,
+		UserID:    userID,
+		ExpiresAt: *sw.TriggerAt,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("\n\nTo check in, reply to this email or write to checkin+%s@%s.", token, w.replyDomain), nil
+}
+
 // sendNotifiers triggers configured notifiers
 func (w *worker) sendNotifiers(sw api.Switch) error {
+	return w.sendMessage(sw.Notifiers, w.renderMessage(sw, time.Now()))
+}
+
+// sendMessage delivers a message to each notifier URL, collecting every failure.
+func (w *worker) sendMessage(urls []string, message string) error {
 	var errs []error
 
-	for _, url := range sw.Notifiers {
+	for _, url := range urls {
 		sender, err := shoutrrr.CreateSender(url)
 		if err != nil {
 			errs = append(errs, fmt.Errorf("failed to create sender for %s: %w", url, err))
 			continue
 		}
 
-		sendErrs := sender.Send(sw.Message, nil)
+		sendErrs := sender.Send(message, nil)
 		for _, sendErr := range sendErrs {
 			if sendErr != nil {
 				errs = append(errs, fmt.Errorf("delivery failed for %s: %w", url, sendErr))
@@ -227,6 +981,47 @@ func (w *worker) sendNotifiers(sw api.Switch) error {
 	return nil
 }
 
+// messageData is the data available to templated switch messages.
+type messageData struct {
+	// LastCheckInAt is when the switch was last checked in, formatted as RFC3339.
+	LastCheckInAt string
+	// TimeSinceLastCheckIn is how long ago the switch was last checked in, e.g. 49h30m0s.
+	TimeSinceLastCheckIn string
+}
+
+// renderMessage executes the switch message as a template so it can reference check-in details.
+// Messages that fail to render are sent unmodified.
+func (w *worker) renderMessage(sw api.Switch, now time.Time) string {
+	if !strings.Contains(sw.Message, "{{") {
+		return sw.Message
+	}
+
+	data := messageData{
+		LastCheckInAt:        "never",
+		TimeSinceLastCheckIn: "unknown",
+	}
+	if sw.LastCheckInAt != nil {
+		last := time.Unix(*sw.LastCheckInAt, 0)
+		data.LastCheckInAt = last.UTC().Format(time.RFC3339)
+		data.TimeSinceLastCheckIn = now.Sub(last).Round(time.Minute).String()
+	}
+
+	tmpl, err := template.New("message").Option("missingkey=error").Parse(sw.Message)
+	if err != nil {
+		w.logger.Debug("Message is not a valid template, sending as is", "id", sw.Id, "error", err)
+		return sw.Message
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
+	if err != nil {
+		w.logger.Debug("Failed to render message template, sending as is", "id", sw.Id, "error", err)
+		return sw.Message
+	}
+
+	return buf.String()
+}
+
 // sendWebPush sends a web push notification.
 // Modified to accept title and body to support both Reminders and Expirations.
 func (w *worker) sendWebPush(sw api.Switch, title, body string) error {